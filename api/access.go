@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/clarenous/go-capsule/api/auth"
+)
+
+// methodScopes are the token scopes of the methods, the methods not listed
+// need the admin scope
+var methodScopes = map[string]string{
+	"/api.APIService/GetBestBlock":          auth.ScopeRead,
+	"/api.APIService/GetBlock":              auth.ScopeRead,
+	"/api.APIService/GetBlockHeader":        auth.ScopeRead,
+	"/api.APIService/GetBlockFilter":        auth.ScopeRead,
+	"/api.APIService/GetBlockVerboseV0":     auth.ScopeRead,
+	"/api.APIService/GetBlockVerboseV1":     auth.ScopeRead,
+	"/api.APIService/ListBlocks":            auth.ScopeRead,
+	"/api.APIService/GetTransaction":        auth.ScopeRead,
+	"/api.APIService/GetTransactions":       auth.ScopeRead,
+	"/api.APIService/GetEvidence":           auth.ScopeRead,
+	"/api.APIService/GetEvidences":          auth.ScopeRead,
+	"/api.APIService/TestMempoolAccept":     auth.ScopeRead,
+	"/api.APIService/DecodeRawTransaction":  auth.ScopeRead,
+	"/api.APIService/DecodeRawBlock":        auth.ScopeRead,
+	"/api.APIService/GetPeers":              auth.ScopeRead,
+	"/api.APIService/GetNetTotals":          auth.ScopeRead,
+	"/api.APIService/GetClientStatus":       auth.ScopeRead,
+	"/api.APIService/SubscribeBlocks":       auth.ScopeRead,
+	"/api.APIService/SubscribeTransactions": auth.ScopeRead,
+	"/api.APIService/SubscribeEvidences":    auth.ScopeRead,
+
+	"/api.APIService/GetWalletStatus":       auth.ScopeWallet,
+	"/api.APIService/GetWalletAddresses":    auth.ScopeWallet,
+	"/api.APIService/GetWalletBalance":      auth.ScopeWallet,
+	"/api.APIService/GetWalletTransactions": auth.ScopeWallet,
+	"/api.APIService/GetWalletEvidences":    auth.ScopeWallet,
+	"/api.APIService/CreateAddress":         auth.ScopeWallet,
+	"/api.APIService/CreateTransaction":     auth.ScopeWallet,
+	"/api.APIService/SendTransaction":       auth.ScopeWallet,
+	"/api.APIService/SubmitRawTransaction":  auth.ScopeWallet,
+
+	"/api.APIService/GetWork":          auth.ScopeMining,
+	"/api.APIService/SubmitWork":       auth.ScopeMining,
+	"/api.APIService/StartMining":      auth.ScopeMining,
+	"/api.APIService/StopMining":       auth.ScopeMining,
+	"/api.APIService/SetMiningWorkers": auth.ScopeMining,
+	"/api.APIService/GetMiningStats":   auth.ScopeMining,
+
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": auth.ScopeRead,
+}
+
+// unaryInterceptor checks the access token before the mode of the method,
+// the errors are returned as the grpc status
+func (a *API) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.authorize(ctx, info.FullMethod); err != nil {
+		return nil, toStatus(err)
+	}
+	resp, err := a.lightModeInterceptor(ctx, req, info, handler)
+	return resp, toStatus(err)
+}
+
+// streamInterceptor checks the access token before the mode of the method,
+// the errors are returned as the grpc status
+func (a *API) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+		return toStatus(err)
+	}
+	return toStatus(a.lightModeStreamInterceptor(srv, ss, info, handler))
+}
+
+// authorize checks the token in the authorization metadata is granted the
+// scope of the method, the gateway forwards the http header as the metadata.
+func (a *API) authorize(ctx context.Context, method string) error {
+	if a.tokens == nil {
+		return nil
+	}
+
+	var credential string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md["authorization"]) > 0 {
+		credential = parseAuthorization(md["authorization"][0])
+	}
+	token, err := a.tokens.Authenticate(credential)
+	if err != nil {
+		return ErrUnauthenticated
+	}
+
+	scope, ok := methodScopes[method]
+	if !ok {
+		scope = auth.ScopeAdmin
+	}
+	if !token.HasScope(scope) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// authHandler rejects the http requests without a valid token, the scopes
+// are checked by the grpc server except for the websockets, which browsers
+// can't give a header, so they may pass the token in the query.
+func (a *API) authHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.tokens == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		isWebsocket := strings.HasPrefix(r.URL.Path, wsPathPrefix)
+		credential := parseAuthorization(r.Header.Get("Authorization"))
+		if credential == "" && isWebsocket {
+			credential = r.URL.Query().Get("token")
+		}
+
+		token, err := a.tokens.Authenticate(credential)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Basic realm="capsule"`)
+			writeHTTPError(w, r, ErrUnauthenticated)
+			return
+		}
+		if isWebsocket && !token.HasScope(auth.ScopeRead) {
+			writeHTTPError(w, r, ErrPermissionDenied)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parseAuthorization returns the credential of the bearer token, or of the
+// basic auth with the token name as user and the secret as password.
+func parseAuthorization(value string) string {
+	i := strings.IndexByte(value, ' ')
+	if i < 0 {
+		return ""
+	}
+
+	switch scheme, param := value[:i], strings.TrimSpace(value[i+1:]); {
+	case strings.EqualFold(scheme, "Bearer"):
+		return param
+	case strings.EqualFold(scheme, "Basic"):
+		credential, err := base64.StdEncoding.DecodeString(param)
+		if err != nil {
+			return ""
+		}
+		return string(credential)
+	}
+	return ""
+}
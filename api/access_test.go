@@ -0,0 +1,236 @@
+package api
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/clarenous/go-capsule/api/auth"
+	cfg "github.com/clarenous/go-capsule/config"
+	"github.com/clarenous/go-capsule/event"
+	"github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+func newTestTokens(t *testing.T) (*auth.Store, func()) {
+	dir, err := ioutil.TempDir("", "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return auth.NewStore(filepath.Join(dir, "tokens.json")), func() { os.RemoveAll(dir) }
+}
+
+func TestAuthorize(t *testing.T) {
+	tokens, cleanup := newTestTokens(t)
+	defer cleanup()
+
+	reader, err := tokens.Create("reader", []string{auth.ScopeRead})
+	if err != nil {
+		t.Fatal(err)
+	}
+	miner, err := tokens.Create("miner", []string{auth.ScopeMining})
+	if err != nil {
+		t.Fatal(err)
+	}
+	basicMiner := "Basic " + base64.StdEncoding.EncodeToString([]byte(miner))
+
+	cases := []struct {
+		authorization string
+		method        string
+		want          error
+	}{
+		{authorization: "Bearer " + reader, method: "/api.APIService/GetBestBlock"},
+		{authorization: "bearer " + reader, method: "/api.APIService/SubscribeBlocks"},
+		{authorization: "Bearer " + reader, method: "/api.APIService/GetWork", want: ErrPermissionDenied},
+		{authorization: "Bearer " + reader, method: "/api.APIService/BanPeer", want: ErrPermissionDenied},
+		{authorization: basicMiner, method: "/api.APIService/GetWork"},
+		{authorization: basicMiner, method: "/api.APIService/GetBlock", want: ErrPermissionDenied},
+		{authorization: reader, method: "/api.APIService/GetBestBlock", want: ErrUnauthenticated},
+		{method: "/api.APIService/GetBestBlock", want: ErrUnauthenticated},
+	}
+
+	a := &API{tokens: tokens}
+	for i, c := range cases {
+		ctx := context.Background()
+		if c.authorization != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", c.authorization))
+		}
+		if err := a.authorize(ctx, c.method); err != c.want {
+			t.Errorf("case %d: got err %v, want %v", i, err, c.want)
+		}
+	}
+
+	// everyone is accepted when the authentication is disabled
+	if err := (&API{}).authorize(context.Background(), "/api.APIService/BanPeer"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuthHandler(t *testing.T) {
+	tokens, cleanup := newTestTokens(t)
+	defer cleanup()
+
+	reader, err := tokens.Create("reader", []string{auth.ScopeRead})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wallet, err := tokens.Create("wallet", []string{auth.ScopeWallet})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := &API{tokens: tokens}
+	handler := a.authHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	cases := []struct {
+		url           string
+		authorization string
+		want          int
+	}{
+		{url: "/v1/blocks/best", authorization: "Bearer " + reader, want: http.StatusOK},
+		{url: "/v1/blocks/best", want: http.StatusUnauthorized},
+		{url: "/v1/blocks/best?token=" + reader, want: http.StatusUnauthorized},
+		{url: wsPathPrefix + "blocks?token=" + reader, want: http.StatusOK},
+		{url: wsPathPrefix + "blocks", authorization: "Bearer " + wallet, want: http.StatusForbidden},
+		{url: wsPathPrefix + "blocks?token=wallet:00", want: http.StatusUnauthorized},
+	}
+
+	for i, c := range cases {
+		r := httptest.NewRequest(http.MethodGet, c.url, nil)
+		if c.authorization != "" {
+			r.Header.Set("Authorization", c.authorization)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != c.want {
+			t.Errorf("case %d: got status %d, want %d", i, w.Code, c.want)
+		}
+	}
+}
+
+// writeTestCert writes the self signed certificate and key of a host other
+// than the loopback, the gateway pins the certificate of its grpc server.
+func writeTestCert(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "capsule.example"},
+		DNSNames:     []string{"capsule.example"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func TestTLSGateway(t *testing.T) {
+	dir, err := ioutil.TempDir("", "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := cfg.DefaultConfig().SetRoot(dir)
+	config.ApiAddress = freeAddress(t)
+	config.GRPCAddress = "127.0.0.1:0"
+	config.Auth.TLSCertFile, config.Auth.TLSKeyFile = writeTestCert(t, dir)
+	credential, err := auth.NewStore(config.TokenFile()).Create("reader", []string{auth.ScopeRead})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dispatcher := event.NewDispatcher()
+	defer dispatcher.Stop()
+	a := NewAPI(nil, nil, nil, nil, dispatcher, config)
+	if err := a.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer a.Stop()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	url := "https://" + config.ApiAddress + "/v1/subscribe/blocks"
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("got status %s without token", resp.Status)
+	}
+
+	// the gateway replies the header with the first notification
+	block := types.MockBlock()
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			dispatcher.Post(protocol.BlockConnectedEvent{Block: block})
+			select {
+			case <-stop:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}()
+
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Authorization", "Bearer "+credential)
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(line, block.Hash().String()) {
+		t.Fatalf("got stream line %s", line)
+	}
+}
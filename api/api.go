@@ -1,94 +1,247 @@
 package api
 
 import (
-	"flag"
-	"fmt"
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/clarenous/go-capsule/api/auth"
+	cfg "github.com/clarenous/go-capsule/config"
+	"github.com/clarenous/go-capsule/event"
+	"github.com/clarenous/go-capsule/light"
 	"github.com/clarenous/go-capsule/mining/cpuminer"
+	"github.com/clarenous/go-capsule/mining/miningpool"
 	"github.com/clarenous/go-capsule/netsync"
 	"github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/protocol/types"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 	"net"
 	"net/http"
-	"strconv"
+	"strings"
+	"time"
 )
 
-const (
-	defaultGRPCPort   = 8867
-	defaultHTTPPort   = 8868
-	maxMsgSize        = 1024 * 1024 * 64
-	GRPCListenAddress = "127.0.0.1"
-)
+const maxMsgSize = 1024 * 1024 * 64
+
+// lightMethods are the methods served by the header chain in light mode
+var lightMethods = map[string]bool{
+	"/api.APIService/GetBestBlock":    true,
+	"/api.APIService/GetBlockHeader":  true,
+	"/api.APIService/GetTransaction":  true,
+	"/api.APIService/GetTransactions": true,
+	"/api.APIService/GetEvidence":     true,
+	"/api.APIService/GetEvidences":    true,
+
+	"/api.APIService/DecodeRawTransaction": true,
+	"/api.APIService/DecodeRawBlock":       true,
+}
+
+var jsonMarshaler = &runtime.JSONPb{OrigName: true, EmitDefaults: true}
+
+// chainReader is the chain behind the methods served in both modes
+type chainReader interface {
+	BestBlockHeader() *types.BlockHeader
+	GetHeaderByHash(*types.Hash) (*types.BlockHeader, error)
+	GetHeaderByHeight(uint64) (*types.BlockHeader, error)
+	GetTransaction(*types.Hash) (*types.Tx, error)
+	GetEvidence(*types.Hash) (*types.Evidence, *types.Tx, int, error)
+}
+
+// syncReader is the sync and the network state reported by the client status
+type syncReader interface {
+	BestPeer() *netsync.PeerInfo
+	IsCaughtUp() bool
+	IsListening() bool
+	PeerCounts() (outbound, inbound int)
+}
 
 type API struct {
+	UnimplementedAPIServiceServer
+
 	server      *grpc.Server
+	reader      chainReader
+	syncer      syncReader
 	Chain       *protocol.Chain
+	LightChain  *light.Chain
 	Miner       *cpuminer.CPUMiner
+	MiningPool  *miningpool.MiningPool
 	SyncManager *netsync.SyncManager
+
+	eventDispatcher *event.Dispatcher
+	config          *cfg.Config
+	tokens          *auth.Store
+	tlsCert         *tls.Certificate
+	gateway         *http.Server
+	numWebsockets   int32 // atomic
+	startTime       time.Time
 }
 
-func NewAPI(chain *protocol.Chain, miner *cpuminer.CPUMiner, syncManager *netsync.SyncManager) *API {
-	api := &API{
-		Chain:       chain,
-		Miner:       miner,
-		SyncManager: syncManager,
+func NewAPI(chain *protocol.Chain, miner *cpuminer.CPUMiner, miningPool *miningpool.MiningPool, syncManager *netsync.SyncManager, dispatcher *event.Dispatcher, config *cfg.Config) *API {
+	return &API{
+		reader:          chain,
+		syncer:          syncManager,
+		Chain:           chain,
+		Miner:           miner,
+		MiningPool:      miningPool,
+		SyncManager:     syncManager,
+		eventDispatcher: dispatcher,
+		config:          config,
+		tokens:          newTokenStore(config),
 	}
-	api.initServer()
-	return api
 }
 
-func (a *API) initServer() {
+// NewLightAPI returns the api of the light client, only the methods backed by
+// the headers and the proven txs are served.
+func NewLightAPI(chain *light.Chain, config *cfg.Config) *API {
+	return &API{
+		reader:     chain,
+		LightChain: chain,
+		config:     config,
+		tokens:     newTokenStore(config),
+	}
+}
+
+// newTokenStore returns nil when the authentication is disabled
+func newTokenStore(config *cfg.Config) *auth.Store {
+	if config.Auth.Disable {
+		return nil
+	}
+	return auth.NewStore(config.TokenFile())
+}
+
+// lightModeInterceptor rejects the methods needing the full chain in light mode
+func (a *API) lightModeInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if a.LightChain != nil && !lightMethods[info.FullMethod] {
+		return nil, ErrLightMode
+	}
+	return handler(ctx, req)
+}
+
+// lightModeStreamInterceptor rejects the subscriptions in light mode, there
+// are no chain events without the full chain
+func (a *API) lightModeStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if a.LightChain != nil && strings.HasPrefix(info.FullMethod, "/api.APIService/") && !lightMethods[info.FullMethod] {
+		return ErrLightMode
+	}
+	return handler(srv, ss)
+}
+
+func (a *API) initServer() error {
 	// set the size for receive Msg
 	opts := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(maxMsgSize),
 		grpc.MaxSendMsgSize(maxMsgSize),
+		grpc.UnaryInterceptor(a.unaryInterceptor),
+		grpc.StreamInterceptor(a.streamInterceptor),
 	}
+
+	if certFile, keyFile := a.config.TLSFiles(); certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		a.tlsCert = &cert
+		opts = append(opts, grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}})))
+	}
+
 	a.server = grpc.NewServer(opts...)
 	RegisterAPIServiceServer(a.server, a)
 	reflection.Register(a.server)
+	return nil
 }
 
-func (a *API) runGateway() {
-	var run = func() error {
-		ctx := context.Background()
-		ctx, cancel := context.WithCancel(ctx)
-		defer cancel()
-
-		mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard,
-			&runtime.JSONPb{OrigName: true, EmitDefaults: true}))
-		opts := []grpc.DialOption{grpc.WithInsecure(), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMsgSize))}
-		echoEndpoint := flag.String("echo_endpoint", ":"+strconv.Itoa(defaultGRPCPort), "endpoint of Service")
-		err := RegisterAPIServiceHandlerFromEndpoint(ctx, mux, *echoEndpoint, opts)
-		if err != nil {
-			return err
-		}
-		httpPort := fmt.Sprintf("%s%d", ":", defaultHTTPPort)
-		return http.ListenAndServe(httpPort, mux)
+// gatewayDialOption returns the credentials of the gateway to its own grpc
+// server, the certificate is pinned as the server name may not match.
+func (a *API) gatewayDialOption() grpc.DialOption {
+	if a.tlsCert == nil {
+		return grpc.WithInsecure()
+	}
+
+	pinned := a.tlsCert.Certificate[0]
+	return grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], pinned) {
+				return ErrGatewayCertificate
+			}
+			return nil
+		},
+	}))
+}
+
+func (a *API) runGateway(ctx context.Context, listener net.Listener, grpcAddress string) error {
+	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, jsonMarshaler))
+	opts := []grpc.DialOption{a.gatewayDialOption(), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMsgSize))}
+	if err := RegisterAPIServiceHandlerFromEndpoint(ctx, gwmux, dialAddress(grpcAddress), opts); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", gwmux)
+	mux.HandleFunc(wsPathPrefix, a.handleWebsocket)
+	a.gateway = &http.Server{Handler: a.authHandler(mux)}
+	if a.tlsCert != nil {
+		a.gateway.TLSConfig = &tls.Config{Certificates: []tls.Certificate{*a.tlsCert}}
+		listener = tls.NewListener(listener, a.gateway.TLSConfig)
 	}
 
 	go func() {
-		log.WithFields(log.Fields{"port_grpc": defaultGRPCPort, "port_http": defaultHTTPPort}).Info("starting gateway on port")
-		if err := run(); err != nil {
-			log.Error("fail on runGateway", err)
+		if err := a.gateway.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.WithFields(log.Fields{"err": err}).Error("fail on runGateway")
 		}
 	}()
+	return nil
+}
+
+// dialAddress returns the loopback address of the listen address on all the
+// interfaces
+func dialAddress(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		return net.JoinHostPort("127.0.0.1", port)
+	}
+	return address
 }
 
 func (a *API) Start() error {
-	address := fmt.Sprintf("%s%s%d", GRPCListenAddress, ":", defaultGRPCPort)
-	listen, err := net.Listen("tcp", address)
+	if err := a.initServer(); err != nil {
+		return err
+	}
+	a.startTime = time.Now()
+
+	grpcListener, err := net.Listen("tcp", a.config.GRPCAddress)
+	if err != nil {
+		return err
+	}
+	httpListener, err := net.Listen("tcp", a.config.ApiAddress)
 	if err != nil {
+		grpcListener.Close()
 		return err
 	}
-	go a.server.Serve(listen)
-	a.runGateway()
 
+	go a.server.Serve(grpcListener)
+	if err := a.runGateway(context.Background(), httpListener, grpcListener.Addr().String()); err != nil {
+		a.server.Stop()
+		httpListener.Close()
+		return err
+	}
+
+	log.WithFields(log.Fields{"grpc_addr": a.config.GRPCAddress, "api_addr": a.config.ApiAddress, "tls": a.tlsCert != nil, "auth": a.tokens != nil}).Info("starting api server")
 	return nil
 }
 
 func (a *API) Stop() {
-	a.server.Stop()
+	if a.gateway != nil {
+		a.gateway.Close()
+	}
+	if a.server != nil {
+		a.server.Stop()
+	}
 }
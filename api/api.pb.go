@@ -1,39 +1,19 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
 // source: api.proto
 
-/*
-Package api is a generated protocol buffer package.
-
-It is generated from these files:
-	api.proto
-
-It has these top-level messages:
-	GetBestBlockResponse
-	Proof
-	GetBlockRequest
-	GetBlockResponse
-	GetBlockHeaderRequest
-	GetBlockHeaderResponse
-	GetBlockVerboseRequest
-	GetBlockVerboseV0Response
-	GetBlockVerboseV1Response
-	Tx
-	Evidence
-	GetTransactionRequest
-	GetTransactionResponse
-	GetEvidenceRequest
-	GetEvidenceResponse
-*/
 package api
 
-import proto "github.com/gogo/protobuf/proto"
-import fmt "fmt"
-import math "math"
-import _ "google.golang.org/genproto/googleapis/api/annotations"
-import google_protobuf1 "github.com/golang/protobuf/ptypes/empty"
-
-import context "golang.org/x/net/context"
-import grpc "google.golang.org/grpc"
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -46,15 +26,133 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+type BlockNotification_Type int32
+
+const (
+	BlockNotification_CONNECTED    BlockNotification_Type = 0
+	BlockNotification_DISCONNECTED BlockNotification_Type = 1
+)
+
+var BlockNotification_Type_name = map[int32]string{
+	0: "CONNECTED",
+	1: "DISCONNECTED",
+}
+
+var BlockNotification_Type_value = map[string]int32{
+	"CONNECTED":    0,
+	"DISCONNECTED": 1,
+}
+
+func (x BlockNotification_Type) String() string {
+	return proto.EnumName(BlockNotification_Type_name, int32(x))
+}
+
+func (BlockNotification_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16, 0}
+}
+
+type TxNotification_Type int32
+
+const (
+	TxNotification_ADDED   TxNotification_Type = 0
+	TxNotification_REMOVED TxNotification_Type = 1
+)
+
+var TxNotification_Type_name = map[int32]string{
+	0: "ADDED",
+	1: "REMOVED",
+}
+
+var TxNotification_Type_value = map[string]int32{
+	"ADDED":   0,
+	"REMOVED": 1,
+}
+
+func (x TxNotification_Type) String() string {
+	return proto.EnumName(TxNotification_Type_name, int32(x))
+}
+
+func (TxNotification_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17, 0}
+}
+
+type PageRequest struct {
+	PageSize             uint32   `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PageRequest) Reset()         { *m = PageRequest{} }
+func (m *PageRequest) String() string { return proto.CompactTextString(m) }
+func (*PageRequest) ProtoMessage()    {}
+func (*PageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{0}
+}
+func (m *PageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PageRequest.Unmarshal(m, b)
+}
+func (m *PageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PageRequest.Marshal(b, m, deterministic)
+}
+func (m *PageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PageRequest.Merge(m, src)
+}
+func (m *PageRequest) XXX_Size() int {
+	return xxx_messageInfo_PageRequest.Size(m)
+}
+func (m *PageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PageRequest proto.InternalMessageInfo
+
+func (m *PageRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *PageRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
 type GetBestBlockResponse struct {
-	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Hash   string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash                 string   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBestBlockResponse) Reset()         { *m = GetBestBlockResponse{} }
+func (m *GetBestBlockResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBestBlockResponse) ProtoMessage()    {}
+func (*GetBestBlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+}
+func (m *GetBestBlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBestBlockResponse.Unmarshal(m, b)
+}
+func (m *GetBestBlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBestBlockResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBestBlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBestBlockResponse.Merge(m, src)
+}
+func (m *GetBestBlockResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBestBlockResponse.Size(m)
+}
+func (m *GetBestBlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBestBlockResponse.DiscardUnknown(m)
 }
 
-func (m *GetBestBlockResponse) Reset()                    { *m = GetBestBlockResponse{} }
-func (m *GetBestBlockResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetBestBlockResponse) ProtoMessage()               {}
-func (*GetBestBlockResponse) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{0} }
+var xxx_messageInfo_GetBestBlockResponse proto.InternalMessageInfo
 
 func (m *GetBestBlockResponse) GetHeight() uint64 {
 	if m != nil {
@@ -71,14 +169,43 @@ func (m *GetBestBlockResponse) GetHash() string {
 }
 
 type Proof struct {
+	// the proof of work fields
 	Target uint64 `protobuf:"varint,1,opt,name=target,proto3" json:"target,omitempty"`
 	Nonce  uint64 `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// the consensus engine of the proof, "pow" or "poa"
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// the proof of authority fields
+	Signer               uint32   `protobuf:"varint,4,opt,name=signer,proto3" json:"signer,omitempty"`
+	InTurn               bool     `protobuf:"varint,5,opt,name=in_turn,json=inTurn,proto3" json:"in_turn,omitempty"`
+	Signature            string   `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Proof) Reset()                    { *m = Proof{} }
-func (m *Proof) String() string            { return proto.CompactTextString(m) }
-func (*Proof) ProtoMessage()               {}
-func (*Proof) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{1} }
+func (m *Proof) Reset()         { *m = Proof{} }
+func (m *Proof) String() string { return proto.CompactTextString(m) }
+func (*Proof) ProtoMessage()    {}
+func (*Proof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
+}
+func (m *Proof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Proof.Unmarshal(m, b)
+}
+func (m *Proof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Proof.Marshal(b, m, deterministic)
+}
+func (m *Proof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Proof.Merge(m, src)
+}
+func (m *Proof) XXX_Size() int {
+	return xxx_messageInfo_Proof.Size(m)
+}
+func (m *Proof) XXX_DiscardUnknown() {
+	xxx_messageInfo_Proof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Proof proto.InternalMessageInfo
 
 func (m *Proof) GetTarget() uint64 {
 	if m != nil {
@@ -94,14 +221,64 @@ func (m *Proof) GetNonce() uint64 {
 	return 0
 }
 
+func (m *Proof) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Proof) GetSigner() uint32 {
+	if m != nil {
+		return m.Signer
+	}
+	return 0
+}
+
+func (m *Proof) GetInTurn() bool {
+	if m != nil {
+		return m.InTurn
+	}
+	return false
+}
+
+func (m *Proof) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
 type GetBlockRequest struct {
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockRequest) Reset()         { *m = GetBlockRequest{} }
+func (m *GetBlockRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockRequest) ProtoMessage()    {}
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
+}
+func (m *GetBlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockRequest.Unmarshal(m, b)
+}
+func (m *GetBlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockRequest.Merge(m, src)
+}
+func (m *GetBlockRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockRequest.Size(m)
+}
+func (m *GetBlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockRequest.DiscardUnknown(m)
 }
 
-func (m *GetBlockRequest) Reset()                    { *m = GetBlockRequest{} }
-func (m *GetBlockRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetBlockRequest) ProtoMessage()               {}
-func (*GetBlockRequest) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{2} }
+var xxx_messageInfo_GetBlockRequest proto.InternalMessageInfo
 
 func (m *GetBlockRequest) GetId() string {
 	if m != nil {
@@ -111,23 +288,45 @@ func (m *GetBlockRequest) GetId() string {
 }
 
 type GetBlockResponse struct {
-	Hash            string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	ChainId         string   `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	Version         uint64   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
-	Height          uint64   `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
-	Timestamp       uint64   `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Previous        string   `protobuf:"bytes,6,opt,name=previous,proto3" json:"previous,omitempty"`
-	TransactionRoot string   `protobuf:"bytes,7,opt,name=transaction_root,json=transactionRoot,proto3" json:"transaction_root,omitempty"`
-	WitnessRoot     string   `protobuf:"bytes,8,opt,name=witness_root,json=witnessRoot,proto3" json:"witness_root,omitempty"`
-	Proof           *Proof   `protobuf:"bytes,9,opt,name=proof" json:"proof,omitempty"`
-	Transactions    []string `protobuf:"bytes,10,rep,name=transactions" json:"transactions,omitempty"`
-	Evidences       []string `protobuf:"bytes,11,rep,name=evidences" json:"evidences,omitempty"`
-}
-
-func (m *GetBlockResponse) Reset()                    { *m = GetBlockResponse{} }
-func (m *GetBlockResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetBlockResponse) ProtoMessage()               {}
-func (*GetBlockResponse) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{3} }
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	ChainId              string   `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Version              uint64   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Height               uint64   `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp            uint64   `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Previous             string   `protobuf:"bytes,6,opt,name=previous,proto3" json:"previous,omitempty"`
+	TransactionRoot      string   `protobuf:"bytes,7,opt,name=transaction_root,json=transactionRoot,proto3" json:"transaction_root,omitempty"`
+	WitnessRoot          string   `protobuf:"bytes,8,opt,name=witness_root,json=witnessRoot,proto3" json:"witness_root,omitempty"`
+	Proof                *Proof   `protobuf:"bytes,9,opt,name=proof,proto3" json:"proof,omitempty"`
+	Transactions         []string `protobuf:"bytes,10,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Evidences            []string `protobuf:"bytes,11,rep,name=evidences,proto3" json:"evidences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockResponse) Reset()         { *m = GetBlockResponse{} }
+func (m *GetBlockResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockResponse) ProtoMessage()    {}
+func (*GetBlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
+}
+func (m *GetBlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockResponse.Unmarshal(m, b)
+}
+func (m *GetBlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockResponse.Merge(m, src)
+}
+func (m *GetBlockResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockResponse.Size(m)
+}
+func (m *GetBlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockResponse proto.InternalMessageInfo
 
 func (m *GetBlockResponse) GetHash() string {
 	if m != nil {
@@ -207,13 +406,35 @@ func (m *GetBlockResponse) GetEvidences() []string {
 }
 
 type GetBlockHeaderRequest struct {
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockHeaderRequest) Reset()         { *m = GetBlockHeaderRequest{} }
+func (m *GetBlockHeaderRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockHeaderRequest) ProtoMessage()    {}
+func (*GetBlockHeaderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
+}
+func (m *GetBlockHeaderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockHeaderRequest.Unmarshal(m, b)
+}
+func (m *GetBlockHeaderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockHeaderRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockHeaderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockHeaderRequest.Merge(m, src)
+}
+func (m *GetBlockHeaderRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockHeaderRequest.Size(m)
+}
+func (m *GetBlockHeaderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockHeaderRequest.DiscardUnknown(m)
 }
 
-func (m *GetBlockHeaderRequest) Reset()                    { *m = GetBlockHeaderRequest{} }
-func (m *GetBlockHeaderRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetBlockHeaderRequest) ProtoMessage()               {}
-func (*GetBlockHeaderRequest) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{4} }
+var xxx_messageInfo_GetBlockHeaderRequest proto.InternalMessageInfo
 
 func (m *GetBlockHeaderRequest) GetId() string {
 	if m != nil {
@@ -223,21 +444,43 @@ func (m *GetBlockHeaderRequest) GetId() string {
 }
 
 type GetBlockHeaderResponse struct {
-	Hash            string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	ChainId         string `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	Version         uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
-	Height          uint64 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
-	Timestamp       uint64 `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Previous        string `protobuf:"bytes,6,opt,name=previous,proto3" json:"previous,omitempty"`
-	TransactionRoot string `protobuf:"bytes,7,opt,name=transaction_root,json=transactionRoot,proto3" json:"transaction_root,omitempty"`
-	WitnessRoot     string `protobuf:"bytes,8,opt,name=witness_root,json=witnessRoot,proto3" json:"witness_root,omitempty"`
-	Proof           *Proof `protobuf:"bytes,9,opt,name=proof" json:"proof,omitempty"`
-}
-
-func (m *GetBlockHeaderResponse) Reset()                    { *m = GetBlockHeaderResponse{} }
-func (m *GetBlockHeaderResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetBlockHeaderResponse) ProtoMessage()               {}
-func (*GetBlockHeaderResponse) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{5} }
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	ChainId              string   `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Version              uint64   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Height               uint64   `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp            uint64   `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Previous             string   `protobuf:"bytes,6,opt,name=previous,proto3" json:"previous,omitempty"`
+	TransactionRoot      string   `protobuf:"bytes,7,opt,name=transaction_root,json=transactionRoot,proto3" json:"transaction_root,omitempty"`
+	WitnessRoot          string   `protobuf:"bytes,8,opt,name=witness_root,json=witnessRoot,proto3" json:"witness_root,omitempty"`
+	Proof                *Proof   `protobuf:"bytes,9,opt,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockHeaderResponse) Reset()         { *m = GetBlockHeaderResponse{} }
+func (m *GetBlockHeaderResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockHeaderResponse) ProtoMessage()    {}
+func (*GetBlockHeaderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
+}
+func (m *GetBlockHeaderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockHeaderResponse.Unmarshal(m, b)
+}
+func (m *GetBlockHeaderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockHeaderResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlockHeaderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockHeaderResponse.Merge(m, src)
+}
+func (m *GetBlockHeaderResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockHeaderResponse.Size(m)
+}
+func (m *GetBlockHeaderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockHeaderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockHeaderResponse proto.InternalMessageInfo
 
 func (m *GetBlockHeaderResponse) GetHash() string {
 	if m != nil {
@@ -302,14 +545,136 @@ func (m *GetBlockHeaderResponse) GetProof() *Proof {
 	return nil
 }
 
+type GetBlockFilterRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockFilterRequest) Reset()         { *m = GetBlockFilterRequest{} }
+func (m *GetBlockFilterRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockFilterRequest) ProtoMessage()    {}
+func (*GetBlockFilterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
+}
+func (m *GetBlockFilterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockFilterRequest.Unmarshal(m, b)
+}
+func (m *GetBlockFilterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockFilterRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockFilterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockFilterRequest.Merge(m, src)
+}
+func (m *GetBlockFilterRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockFilterRequest.Size(m)
+}
+func (m *GetBlockFilterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockFilterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockFilterRequest proto.InternalMessageInfo
+
+func (m *GetBlockFilterRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetBlockFilterResponse struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Filter               string   `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	FilterHeader         string   `protobuf:"bytes,4,opt,name=filter_header,json=filterHeader,proto3" json:"filter_header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockFilterResponse) Reset()         { *m = GetBlockFilterResponse{} }
+func (m *GetBlockFilterResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockFilterResponse) ProtoMessage()    {}
+func (*GetBlockFilterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
+}
+func (m *GetBlockFilterResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockFilterResponse.Unmarshal(m, b)
+}
+func (m *GetBlockFilterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockFilterResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlockFilterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockFilterResponse.Merge(m, src)
+}
+func (m *GetBlockFilterResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockFilterResponse.Size(m)
+}
+func (m *GetBlockFilterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockFilterResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockFilterResponse proto.InternalMessageInfo
+
+func (m *GetBlockFilterResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *GetBlockFilterResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetBlockFilterResponse) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *GetBlockFilterResponse) GetFilterHeader() string {
+	if m != nil {
+		return m.FilterHeader
+	}
+	return ""
+}
+
 type GetBlockVerboseRequest struct {
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockVerboseRequest) Reset()         { *m = GetBlockVerboseRequest{} }
+func (m *GetBlockVerboseRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockVerboseRequest) ProtoMessage()    {}
+func (*GetBlockVerboseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
+}
+func (m *GetBlockVerboseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockVerboseRequest.Unmarshal(m, b)
+}
+func (m *GetBlockVerboseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockVerboseRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockVerboseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockVerboseRequest.Merge(m, src)
+}
+func (m *GetBlockVerboseRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockVerboseRequest.Size(m)
+}
+func (m *GetBlockVerboseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockVerboseRequest.DiscardUnknown(m)
 }
 
-func (m *GetBlockVerboseRequest) Reset()                    { *m = GetBlockVerboseRequest{} }
-func (m *GetBlockVerboseRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetBlockVerboseRequest) ProtoMessage()               {}
-func (*GetBlockVerboseRequest) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{6} }
+var xxx_messageInfo_GetBlockVerboseRequest proto.InternalMessageInfo
 
 func (m *GetBlockVerboseRequest) GetId() string {
 	if m != nil {
@@ -319,22 +684,44 @@ func (m *GetBlockVerboseRequest) GetId() string {
 }
 
 type GetBlockVerboseV0Response struct {
-	Hash            string                                   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	ChainId         string                                   `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	Version         uint64                                   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
-	Height          uint64                                   `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
-	Timestamp       uint64                                   `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Previous        string                                   `protobuf:"bytes,6,opt,name=previous,proto3" json:"previous,omitempty"`
-	TransactionRoot string                                   `protobuf:"bytes,7,opt,name=transaction_root,json=transactionRoot,proto3" json:"transaction_root,omitempty"`
-	WitnessRoot     string                                   `protobuf:"bytes,8,opt,name=witness_root,json=witnessRoot,proto3" json:"witness_root,omitempty"`
-	Proof           *Proof                                   `protobuf:"bytes,9,opt,name=proof" json:"proof,omitempty"`
-	Transactions    []*GetBlockVerboseV0Response_Transaction `protobuf:"bytes,10,rep,name=transactions" json:"transactions,omitempty"`
-}
-
-func (m *GetBlockVerboseV0Response) Reset()                    { *m = GetBlockVerboseV0Response{} }
-func (m *GetBlockVerboseV0Response) String() string            { return proto.CompactTextString(m) }
-func (*GetBlockVerboseV0Response) ProtoMessage()               {}
-func (*GetBlockVerboseV0Response) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{7} }
+	Hash                 string                                   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	ChainId              string                                   `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Version              uint64                                   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Height               uint64                                   `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp            uint64                                   `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Previous             string                                   `protobuf:"bytes,6,opt,name=previous,proto3" json:"previous,omitempty"`
+	TransactionRoot      string                                   `protobuf:"bytes,7,opt,name=transaction_root,json=transactionRoot,proto3" json:"transaction_root,omitempty"`
+	WitnessRoot          string                                   `protobuf:"bytes,8,opt,name=witness_root,json=witnessRoot,proto3" json:"witness_root,omitempty"`
+	Proof                *Proof                                   `protobuf:"bytes,9,opt,name=proof,proto3" json:"proof,omitempty"`
+	Transactions         []*GetBlockVerboseV0Response_Transaction `protobuf:"bytes,10,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
+	XXX_unrecognized     []byte                                   `json:"-"`
+	XXX_sizecache        int32                                    `json:"-"`
+}
+
+func (m *GetBlockVerboseV0Response) Reset()         { *m = GetBlockVerboseV0Response{} }
+func (m *GetBlockVerboseV0Response) String() string { return proto.CompactTextString(m) }
+func (*GetBlockVerboseV0Response) ProtoMessage()    {}
+func (*GetBlockVerboseV0Response) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+}
+func (m *GetBlockVerboseV0Response) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockVerboseV0Response.Unmarshal(m, b)
+}
+func (m *GetBlockVerboseV0Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockVerboseV0Response.Marshal(b, m, deterministic)
+}
+func (m *GetBlockVerboseV0Response) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockVerboseV0Response.Merge(m, src)
+}
+func (m *GetBlockVerboseV0Response) XXX_Size() int {
+	return xxx_messageInfo_GetBlockVerboseV0Response.Size(m)
+}
+func (m *GetBlockVerboseV0Response) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockVerboseV0Response.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockVerboseV0Response proto.InternalMessageInfo
 
 func (m *GetBlockVerboseV0Response) GetHash() string {
 	if m != nil {
@@ -407,16 +794,36 @@ func (m *GetBlockVerboseV0Response) GetTransactions() []*GetBlockVerboseV0Respon
 }
 
 type GetBlockVerboseV0Response_Transaction struct {
-	Txid      string   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
-	Evidences []string `protobuf:"bytes,2,rep,name=evidences" json:"evidences,omitempty"`
+	Txid                 string   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Evidences            []string `protobuf:"bytes,2,rep,name=evidences,proto3" json:"evidences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetBlockVerboseV0Response_Transaction) Reset()         { *m = GetBlockVerboseV0Response_Transaction{} }
 func (m *GetBlockVerboseV0Response_Transaction) String() string { return proto.CompactTextString(m) }
 func (*GetBlockVerboseV0Response_Transaction) ProtoMessage()    {}
 func (*GetBlockVerboseV0Response_Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptorApi, []int{7, 0}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{10, 0}
+}
+func (m *GetBlockVerboseV0Response_Transaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockVerboseV0Response_Transaction.Unmarshal(m, b)
+}
+func (m *GetBlockVerboseV0Response_Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockVerboseV0Response_Transaction.Marshal(b, m, deterministic)
 }
+func (m *GetBlockVerboseV0Response_Transaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockVerboseV0Response_Transaction.Merge(m, src)
+}
+func (m *GetBlockVerboseV0Response_Transaction) XXX_Size() int {
+	return xxx_messageInfo_GetBlockVerboseV0Response_Transaction.Size(m)
+}
+func (m *GetBlockVerboseV0Response_Transaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockVerboseV0Response_Transaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockVerboseV0Response_Transaction proto.InternalMessageInfo
 
 func (m *GetBlockVerboseV0Response_Transaction) GetTxid() string {
 	if m != nil {
@@ -433,22 +840,44 @@ func (m *GetBlockVerboseV0Response_Transaction) GetEvidences() []string {
 }
 
 type GetBlockVerboseV1Response struct {
-	Hash            string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	ChainId         string `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	Version         uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
-	Height          uint64 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
-	Timestamp       uint64 `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Previous        string `protobuf:"bytes,6,opt,name=previous,proto3" json:"previous,omitempty"`
-	TransactionRoot string `protobuf:"bytes,7,opt,name=transaction_root,json=transactionRoot,proto3" json:"transaction_root,omitempty"`
-	WitnessRoot     string `protobuf:"bytes,8,opt,name=witness_root,json=witnessRoot,proto3" json:"witness_root,omitempty"`
-	Proof           *Proof `protobuf:"bytes,9,opt,name=proof" json:"proof,omitempty"`
-	Transactions    []*Tx  `protobuf:"bytes,10,rep,name=transactions" json:"transactions,omitempty"`
-}
-
-func (m *GetBlockVerboseV1Response) Reset()                    { *m = GetBlockVerboseV1Response{} }
-func (m *GetBlockVerboseV1Response) String() string            { return proto.CompactTextString(m) }
-func (*GetBlockVerboseV1Response) ProtoMessage()               {}
-func (*GetBlockVerboseV1Response) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{8} }
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	ChainId              string   `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Version              uint64   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Height               uint64   `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp            uint64   `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Previous             string   `protobuf:"bytes,6,opt,name=previous,proto3" json:"previous,omitempty"`
+	TransactionRoot      string   `protobuf:"bytes,7,opt,name=transaction_root,json=transactionRoot,proto3" json:"transaction_root,omitempty"`
+	WitnessRoot          string   `protobuf:"bytes,8,opt,name=witness_root,json=witnessRoot,proto3" json:"witness_root,omitempty"`
+	Proof                *Proof   `protobuf:"bytes,9,opt,name=proof,proto3" json:"proof,omitempty"`
+	Transactions         []*Tx    `protobuf:"bytes,10,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockVerboseV1Response) Reset()         { *m = GetBlockVerboseV1Response{} }
+func (m *GetBlockVerboseV1Response) String() string { return proto.CompactTextString(m) }
+func (*GetBlockVerboseV1Response) ProtoMessage()    {}
+func (*GetBlockVerboseV1Response) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+}
+func (m *GetBlockVerboseV1Response) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockVerboseV1Response.Unmarshal(m, b)
+}
+func (m *GetBlockVerboseV1Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockVerboseV1Response.Marshal(b, m, deterministic)
+}
+func (m *GetBlockVerboseV1Response) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockVerboseV1Response.Merge(m, src)
+}
+func (m *GetBlockVerboseV1Response) XXX_Size() int {
+	return xxx_messageInfo_GetBlockVerboseV1Response.Size(m)
+}
+func (m *GetBlockVerboseV1Response) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockVerboseV1Response.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockVerboseV1Response proto.InternalMessageInfo
 
 func (m *GetBlockVerboseV1Response) GetHash() string {
 	if m != nil {
@@ -520,137 +949,357 @@ func (m *GetBlockVerboseV1Response) GetTransactions() []*Tx {
 	return nil
 }
 
-type Tx struct {
-	Txid      string      `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
-	Version   uint64      `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
-	Inputs    []*Tx_TxIn  `protobuf:"bytes,3,rep,name=inputs" json:"inputs,omitempty"`
-	Outputs   []*Tx_TxOut `protobuf:"bytes,4,rep,name=outputs" json:"outputs,omitempty"`
-	Evidences []*Evidence `protobuf:"bytes,5,rep,name=evidences" json:"evidences,omitempty"`
-	LockTime  uint64      `protobuf:"varint,6,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+type ListBlocksRequest struct {
+	FromHeight           uint64   `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	ToHeight             uint64   `protobuf:"varint,2,opt,name=to_height,json=toHeight,proto3" json:"to_height,omitempty"`
+	Verbosity            uint32   `protobuf:"varint,3,opt,name=verbosity,proto3" json:"verbosity,omitempty"`
+	PageSize             uint32   `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBlocksRequest) Reset()         { *m = ListBlocksRequest{} }
+func (m *ListBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*ListBlocksRequest) ProtoMessage()    {}
+func (*ListBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+}
+func (m *ListBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlocksRequest.Unmarshal(m, b)
+}
+func (m *ListBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlocksRequest.Marshal(b, m, deterministic)
+}
+func (m *ListBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlocksRequest.Merge(m, src)
+}
+func (m *ListBlocksRequest) XXX_Size() int {
+	return xxx_messageInfo_ListBlocksRequest.Size(m)
+}
+func (m *ListBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlocksRequest.DiscardUnknown(m)
 }
 
-func (m *Tx) Reset()                    { *m = Tx{} }
-func (m *Tx) String() string            { return proto.CompactTextString(m) }
-func (*Tx) ProtoMessage()               {}
-func (*Tx) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{9} }
+var xxx_messageInfo_ListBlocksRequest proto.InternalMessageInfo
 
-func (m *Tx) GetTxid() string {
+func (m *ListBlocksRequest) GetFromHeight() uint64 {
 	if m != nil {
-		return m.Txid
+		return m.FromHeight
 	}
-	return ""
+	return 0
 }
 
-func (m *Tx) GetVersion() uint64 {
+func (m *ListBlocksRequest) GetToHeight() uint64 {
 	if m != nil {
-		return m.Version
+		return m.ToHeight
 	}
 	return 0
 }
 
-func (m *Tx) GetInputs() []*Tx_TxIn {
+func (m *ListBlocksRequest) GetVerbosity() uint32 {
 	if m != nil {
-		return m.Inputs
+		return m.Verbosity
 	}
-	return nil
+	return 0
 }
 
-func (m *Tx) GetOutputs() []*Tx_TxOut {
+func (m *ListBlocksRequest) GetPageSize() uint32 {
 	if m != nil {
-		return m.Outputs
+		return m.PageSize
 	}
-	return nil
+	return 0
 }
 
-func (m *Tx) GetEvidences() []*Evidence {
+func (m *ListBlocksRequest) GetPageToken() string {
 	if m != nil {
-		return m.Evidences
+		return m.PageToken
 	}
-	return nil
+	return ""
 }
 
-func (m *Tx) GetLockTime() uint64 {
-	if m != nil {
-		return m.LockTime
-	}
-	return 0
+type ListBlocksResponse struct {
+	Blocks               []*GetBlockResponse          `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	BlocksVerbose_0      []*GetBlockVerboseV0Response `protobuf:"bytes,2,rep,name=blocks_verbose_0,json=blocksVerbose0,proto3" json:"blocks_verbose_0,omitempty"`
+	BlocksVerbose_1      []*GetBlockVerboseV1Response `protobuf:"bytes,3,rep,name=blocks_verbose_1,json=blocksVerbose1,proto3" json:"blocks_verbose_1,omitempty"`
+	NextPageToken        string                       `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
 }
 
-type Tx_TxIn struct {
-	ValueSource  *Tx_TxIn_ValueSource `protobuf:"bytes,1,opt,name=value_source,json=valueSource" json:"value_source,omitempty"`
-	RedeemScript string               `protobuf:"bytes,2,opt,name=redeem_script,json=redeemScript,proto3" json:"redeem_script,omitempty"`
-	UnlockScript string               `protobuf:"bytes,3,opt,name=unlock_script,json=unlockScript,proto3" json:"unlock_script,omitempty"`
-	Sequence     uint64               `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+func (m *ListBlocksResponse) Reset()         { *m = ListBlocksResponse{} }
+func (m *ListBlocksResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBlocksResponse) ProtoMessage()    {}
+func (*ListBlocksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
+}
+func (m *ListBlocksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlocksResponse.Unmarshal(m, b)
+}
+func (m *ListBlocksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlocksResponse.Marshal(b, m, deterministic)
+}
+func (m *ListBlocksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlocksResponse.Merge(m, src)
+}
+func (m *ListBlocksResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBlocksResponse.Size(m)
+}
+func (m *ListBlocksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlocksResponse.DiscardUnknown(m)
 }
 
-func (m *Tx_TxIn) Reset()                    { *m = Tx_TxIn{} }
-func (m *Tx_TxIn) String() string            { return proto.CompactTextString(m) }
-func (*Tx_TxIn) ProtoMessage()               {}
-func (*Tx_TxIn) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{9, 0} }
+var xxx_messageInfo_ListBlocksResponse proto.InternalMessageInfo
 
-func (m *Tx_TxIn) GetValueSource() *Tx_TxIn_ValueSource {
+func (m *ListBlocksResponse) GetBlocks() []*GetBlockResponse {
 	if m != nil {
-		return m.ValueSource
+		return m.Blocks
 	}
 	return nil
 }
 
-func (m *Tx_TxIn) GetRedeemScript() string {
+func (m *ListBlocksResponse) GetBlocksVerbose_0() []*GetBlockVerboseV0Response {
 	if m != nil {
-		return m.RedeemScript
+		return m.BlocksVerbose_0
 	}
-	return ""
+	return nil
 }
 
-func (m *Tx_TxIn) GetUnlockScript() string {
+func (m *ListBlocksResponse) GetBlocksVerbose_1() []*GetBlockVerboseV1Response {
 	if m != nil {
-		return m.UnlockScript
+		return m.BlocksVerbose_1
 	}
-	return ""
+	return nil
 }
 
-func (m *Tx_TxIn) GetSequence() uint64 {
+func (m *ListBlocksResponse) GetNextPageToken() string {
 	if m != nil {
-		return m.Sequence
+		return m.NextPageToken
 	}
-	return 0
+	return ""
 }
 
-type Tx_TxIn_ValueSource struct {
-	Txid  string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
-	Index uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+type Tx struct {
+	Txid                 string      `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Version              uint64      `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Inputs               []*Tx_TxIn  `protobuf:"bytes,3,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs              []*Tx_TxOut `protobuf:"bytes,4,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	Evidences            []*Evidence `protobuf:"bytes,5,rep,name=evidences,proto3" json:"evidences,omitempty"`
+	LockTime             uint64      `protobuf:"varint,6,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Tx) Reset()         { *m = Tx{} }
+func (m *Tx) String() string { return proto.CompactTextString(m) }
+func (*Tx) ProtoMessage()    {}
+func (*Tx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+}
+func (m *Tx) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tx.Unmarshal(m, b)
+}
+func (m *Tx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tx.Marshal(b, m, deterministic)
+}
+func (m *Tx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tx.Merge(m, src)
+}
+func (m *Tx) XXX_Size() int {
+	return xxx_messageInfo_Tx.Size(m)
+}
+func (m *Tx) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tx.DiscardUnknown(m)
 }
 
-func (m *Tx_TxIn_ValueSource) Reset()                    { *m = Tx_TxIn_ValueSource{} }
-func (m *Tx_TxIn_ValueSource) String() string            { return proto.CompactTextString(m) }
-func (*Tx_TxIn_ValueSource) ProtoMessage()               {}
-func (*Tx_TxIn_ValueSource) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{9, 0, 0} }
+var xxx_messageInfo_Tx proto.InternalMessageInfo
 
-func (m *Tx_TxIn_ValueSource) GetTxid() string {
+func (m *Tx) GetTxid() string {
 	if m != nil {
 		return m.Txid
 	}
 	return ""
 }
 
-func (m *Tx_TxIn_ValueSource) GetIndex() uint64 {
+func (m *Tx) GetVersion() uint64 {
 	if m != nil {
-		return m.Index
+		return m.Version
 	}
 	return 0
 }
 
-type Tx_TxOut struct {
-	Value      uint64 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
-	ScriptHash string `protobuf:"bytes,2,opt,name=script_hash,json=scriptHash,proto3" json:"script_hash,omitempty"`
-}
-
-func (m *Tx_TxOut) Reset()                    { *m = Tx_TxOut{} }
-func (m *Tx_TxOut) String() string            { return proto.CompactTextString(m) }
-func (*Tx_TxOut) ProtoMessage()               {}
-func (*Tx_TxOut) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{9, 1} }
-
-func (m *Tx_TxOut) GetValue() uint64 {
+func (m *Tx) GetInputs() []*Tx_TxIn {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *Tx) GetOutputs() []*Tx_TxOut {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *Tx) GetEvidences() []*Evidence {
+	if m != nil {
+		return m.Evidences
+	}
+	return nil
+}
+
+func (m *Tx) GetLockTime() uint64 {
+	if m != nil {
+		return m.LockTime
+	}
+	return 0
+}
+
+type Tx_TxIn struct {
+	ValueSource          *Tx_TxIn_ValueSource `protobuf:"bytes,1,opt,name=value_source,json=valueSource,proto3" json:"value_source,omitempty"`
+	RedeemScript         string               `protobuf:"bytes,2,opt,name=redeem_script,json=redeemScript,proto3" json:"redeem_script,omitempty"`
+	UnlockScript         string               `protobuf:"bytes,3,opt,name=unlock_script,json=unlockScript,proto3" json:"unlock_script,omitempty"`
+	Sequence             uint64               `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Tx_TxIn) Reset()         { *m = Tx_TxIn{} }
+func (m *Tx_TxIn) String() string { return proto.CompactTextString(m) }
+func (*Tx_TxIn) ProtoMessage()    {}
+func (*Tx_TxIn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14, 0}
+}
+func (m *Tx_TxIn) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tx_TxIn.Unmarshal(m, b)
+}
+func (m *Tx_TxIn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tx_TxIn.Marshal(b, m, deterministic)
+}
+func (m *Tx_TxIn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tx_TxIn.Merge(m, src)
+}
+func (m *Tx_TxIn) XXX_Size() int {
+	return xxx_messageInfo_Tx_TxIn.Size(m)
+}
+func (m *Tx_TxIn) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tx_TxIn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tx_TxIn proto.InternalMessageInfo
+
+func (m *Tx_TxIn) GetValueSource() *Tx_TxIn_ValueSource {
+	if m != nil {
+		return m.ValueSource
+	}
+	return nil
+}
+
+func (m *Tx_TxIn) GetRedeemScript() string {
+	if m != nil {
+		return m.RedeemScript
+	}
+	return ""
+}
+
+func (m *Tx_TxIn) GetUnlockScript() string {
+	if m != nil {
+		return m.UnlockScript
+	}
+	return ""
+}
+
+func (m *Tx_TxIn) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+type Tx_TxIn_ValueSource struct {
+	Txid                 string   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Index                uint64   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Tx_TxIn_ValueSource) Reset()         { *m = Tx_TxIn_ValueSource{} }
+func (m *Tx_TxIn_ValueSource) String() string { return proto.CompactTextString(m) }
+func (*Tx_TxIn_ValueSource) ProtoMessage()    {}
+func (*Tx_TxIn_ValueSource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14, 0, 0}
+}
+func (m *Tx_TxIn_ValueSource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tx_TxIn_ValueSource.Unmarshal(m, b)
+}
+func (m *Tx_TxIn_ValueSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tx_TxIn_ValueSource.Marshal(b, m, deterministic)
+}
+func (m *Tx_TxIn_ValueSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tx_TxIn_ValueSource.Merge(m, src)
+}
+func (m *Tx_TxIn_ValueSource) XXX_Size() int {
+	return xxx_messageInfo_Tx_TxIn_ValueSource.Size(m)
+}
+func (m *Tx_TxIn_ValueSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tx_TxIn_ValueSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tx_TxIn_ValueSource proto.InternalMessageInfo
+
+func (m *Tx_TxIn_ValueSource) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *Tx_TxIn_ValueSource) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type Tx_TxOut struct {
+	Value                uint64   `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	ScriptHash           string   `protobuf:"bytes,2,opt,name=script_hash,json=scriptHash,proto3" json:"script_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Tx_TxOut) Reset()         { *m = Tx_TxOut{} }
+func (m *Tx_TxOut) String() string { return proto.CompactTextString(m) }
+func (*Tx_TxOut) ProtoMessage()    {}
+func (*Tx_TxOut) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14, 1}
+}
+func (m *Tx_TxOut) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tx_TxOut.Unmarshal(m, b)
+}
+func (m *Tx_TxOut) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tx_TxOut.Marshal(b, m, deterministic)
+}
+func (m *Tx_TxOut) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tx_TxOut.Merge(m, src)
+}
+func (m *Tx_TxOut) XXX_Size() int {
+	return xxx_messageInfo_Tx_TxOut.Size(m)
+}
+func (m *Tx_TxOut) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tx_TxOut.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tx_TxOut proto.InternalMessageInfo
+
+func (m *Tx_TxOut) GetValue() uint64 {
 	if m != nil {
 		return m.Value
 	}
@@ -665,16 +1314,38 @@ func (m *Tx_TxOut) GetScriptHash() string {
 }
 
 type Evidence struct {
-	Evid        string `protobuf:"bytes,1,opt,name=evid,proto3" json:"evid,omitempty"`
-	Digest      string `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
-	Source      string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
-	ValidScript string `protobuf:"bytes,4,opt,name=valid_script,json=validScript,proto3" json:"valid_script,omitempty"`
+	Evid                 string   `protobuf:"bytes,1,opt,name=evid,proto3" json:"evid,omitempty"`
+	Digest               string   `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+	Source               string   `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	ValidScript          string   `protobuf:"bytes,4,opt,name=valid_script,json=validScript,proto3" json:"valid_script,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Evidence) Reset()                    { *m = Evidence{} }
-func (m *Evidence) String() string            { return proto.CompactTextString(m) }
-func (*Evidence) ProtoMessage()               {}
-func (*Evidence) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{10} }
+func (m *Evidence) Reset()         { *m = Evidence{} }
+func (m *Evidence) String() string { return proto.CompactTextString(m) }
+func (*Evidence) ProtoMessage()    {}
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+}
+func (m *Evidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Evidence.Unmarshal(m, b)
+}
+func (m *Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Evidence.Marshal(b, m, deterministic)
+}
+func (m *Evidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Evidence.Merge(m, src)
+}
+func (m *Evidence) XXX_Size() int {
+	return xxx_messageInfo_Evidence.Size(m)
+}
+func (m *Evidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Evidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Evidence proto.InternalMessageInfo
 
 func (m *Evidence) GetEvid() string {
 	if m != nil {
@@ -704,233 +1375,629 @@ func (m *Evidence) GetValidScript() string {
 	return ""
 }
 
-type GetTransactionRequest struct {
-	Txid string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+type BlockNotification struct {
+	Type                 BlockNotification_Type `protobuf:"varint,1,opt,name=type,proto3,enum=api.BlockNotification_Type" json:"type,omitempty"`
+	Hash                 string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height               uint64                 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Previous             string                 `protobuf:"bytes,4,opt,name=previous,proto3" json:"previous,omitempty"`
+	Timestamp            uint64                 `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Transactions         []string               `protobuf:"bytes,6,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func (m *GetTransactionRequest) Reset()                    { *m = GetTransactionRequest{} }
-func (m *GetTransactionRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetTransactionRequest) ProtoMessage()               {}
-func (*GetTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{11} }
+func (m *BlockNotification) Reset()         { *m = BlockNotification{} }
+func (m *BlockNotification) String() string { return proto.CompactTextString(m) }
+func (*BlockNotification) ProtoMessage()    {}
+func (*BlockNotification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+}
+func (m *BlockNotification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockNotification.Unmarshal(m, b)
+}
+func (m *BlockNotification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockNotification.Marshal(b, m, deterministic)
+}
+func (m *BlockNotification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockNotification.Merge(m, src)
+}
+func (m *BlockNotification) XXX_Size() int {
+	return xxx_messageInfo_BlockNotification.Size(m)
+}
+func (m *BlockNotification) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockNotification.DiscardUnknown(m)
+}
 
-func (m *GetTransactionRequest) GetTxid() string {
+var xxx_messageInfo_BlockNotification proto.InternalMessageInfo
+
+func (m *BlockNotification) GetType() BlockNotification_Type {
 	if m != nil {
-		return m.Txid
+		return m.Type
 	}
-	return ""
-}
-
-type GetTransactionResponse struct {
-	Txid      string                          `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
-	Version   uint64                          `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
-	Inputs    []*GetTransactionResponse_TxIn  `protobuf:"bytes,3,rep,name=inputs" json:"inputs,omitempty"`
-	Outputs   []*GetTransactionResponse_TxOut `protobuf:"bytes,4,rep,name=outputs" json:"outputs,omitempty"`
-	Evidences []*Evidence                     `protobuf:"bytes,5,rep,name=evidences" json:"evidences,omitempty"`
-	LockTime  uint64                          `protobuf:"varint,6,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+	return BlockNotification_CONNECTED
 }
 
-func (m *GetTransactionResponse) Reset()                    { *m = GetTransactionResponse{} }
-func (m *GetTransactionResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetTransactionResponse) ProtoMessage()               {}
-func (*GetTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{12} }
-
-func (m *GetTransactionResponse) GetTxid() string {
+func (m *BlockNotification) GetHash() string {
 	if m != nil {
-		return m.Txid
+		return m.Hash
 	}
 	return ""
 }
 
-func (m *GetTransactionResponse) GetVersion() uint64 {
+func (m *BlockNotification) GetHeight() uint64 {
 	if m != nil {
-		return m.Version
+		return m.Height
 	}
 	return 0
 }
 
-func (m *GetTransactionResponse) GetInputs() []*GetTransactionResponse_TxIn {
+func (m *BlockNotification) GetPrevious() string {
 	if m != nil {
-		return m.Inputs
+		return m.Previous
 	}
-	return nil
+	return ""
 }
 
-func (m *GetTransactionResponse) GetOutputs() []*GetTransactionResponse_TxOut {
+func (m *BlockNotification) GetTimestamp() uint64 {
 	if m != nil {
-		return m.Outputs
+		return m.Timestamp
 	}
-	return nil
+	return 0
 }
 
-func (m *GetTransactionResponse) GetEvidences() []*Evidence {
+func (m *BlockNotification) GetTransactions() []string {
 	if m != nil {
-		return m.Evidences
+		return m.Transactions
 	}
 	return nil
 }
 
-func (m *GetTransactionResponse) GetLockTime() uint64 {
-	if m != nil {
-		return m.LockTime
-	}
-	return 0
+type TxNotification struct {
+	Type                 TxNotification_Type `protobuf:"varint,1,opt,name=type,proto3,enum=api.TxNotification_Type" json:"type,omitempty"`
+	Tx                   *Tx                 `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
-type GetTransactionResponse_TxIn struct {
-	ValueSource  *GetTransactionResponse_TxIn_ValueSource `protobuf:"bytes,1,opt,name=value_source,json=valueSource" json:"value_source,omitempty"`
-	RedeemScript string                                   `protobuf:"bytes,2,opt,name=redeem_script,json=redeemScript,proto3" json:"redeem_script,omitempty"`
-	UnlockScript string                                   `protobuf:"bytes,3,opt,name=unlock_script,json=unlockScript,proto3" json:"unlock_script,omitempty"`
-	Sequence     uint64                                   `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+func (m *TxNotification) Reset()         { *m = TxNotification{} }
+func (m *TxNotification) String() string { return proto.CompactTextString(m) }
+func (*TxNotification) ProtoMessage()    {}
+func (*TxNotification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+}
+func (m *TxNotification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxNotification.Unmarshal(m, b)
+}
+func (m *TxNotification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxNotification.Marshal(b, m, deterministic)
+}
+func (m *TxNotification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxNotification.Merge(m, src)
+}
+func (m *TxNotification) XXX_Size() int {
+	return xxx_messageInfo_TxNotification.Size(m)
+}
+func (m *TxNotification) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxNotification.DiscardUnknown(m)
 }
 
-func (m *GetTransactionResponse_TxIn) Reset()         { *m = GetTransactionResponse_TxIn{} }
-func (m *GetTransactionResponse_TxIn) String() string { return proto.CompactTextString(m) }
-func (*GetTransactionResponse_TxIn) ProtoMessage()    {}
-func (*GetTransactionResponse_TxIn) Descriptor() ([]byte, []int) {
-	return fileDescriptorApi, []int{12, 0}
+var xxx_messageInfo_TxNotification proto.InternalMessageInfo
+
+func (m *TxNotification) GetType() TxNotification_Type {
+	if m != nil {
+		return m.Type
+	}
+	return TxNotification_ADDED
 }
 
-func (m *GetTransactionResponse_TxIn) GetValueSource() *GetTransactionResponse_TxIn_ValueSource {
+func (m *TxNotification) GetTx() *Tx {
 	if m != nil {
-		return m.ValueSource
+		return m.Tx
 	}
 	return nil
 }
 
-func (m *GetTransactionResponse_TxIn) GetRedeemScript() string {
+type SubscribeEvidencesRequest struct {
+	Digest               string   `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Mempool              bool     `protobuf:"varint,3,opt,name=mempool,proto3" json:"mempool,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeEvidencesRequest) Reset()         { *m = SubscribeEvidencesRequest{} }
+func (m *SubscribeEvidencesRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeEvidencesRequest) ProtoMessage()    {}
+func (*SubscribeEvidencesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+}
+func (m *SubscribeEvidencesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeEvidencesRequest.Unmarshal(m, b)
+}
+func (m *SubscribeEvidencesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeEvidencesRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeEvidencesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeEvidencesRequest.Merge(m, src)
+}
+func (m *SubscribeEvidencesRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeEvidencesRequest.Size(m)
+}
+func (m *SubscribeEvidencesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeEvidencesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeEvidencesRequest proto.InternalMessageInfo
+
+func (m *SubscribeEvidencesRequest) GetDigest() string {
 	if m != nil {
-		return m.RedeemScript
+		return m.Digest
 	}
 	return ""
 }
 
-func (m *GetTransactionResponse_TxIn) GetUnlockScript() string {
+func (m *SubscribeEvidencesRequest) GetSource() string {
 	if m != nil {
-		return m.UnlockScript
+		return m.Source
 	}
 	return ""
 }
 
-func (m *GetTransactionResponse_TxIn) GetSequence() uint64 {
+func (m *SubscribeEvidencesRequest) GetMempool() bool {
 	if m != nil {
-		return m.Sequence
+		return m.Mempool
 	}
-	return 0
+	return false
 }
 
-type GetTransactionResponse_TxIn_ValueSource struct {
-	Txid  string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
-	Index uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+type EvidenceNotification struct {
+	Txid                 string    `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Index                uint64    `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	BlockHash            string    `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Height               uint64    `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Evidence             *Evidence `protobuf:"bytes,5,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *GetTransactionResponse_TxIn_ValueSource) Reset() {
-	*m = GetTransactionResponse_TxIn_ValueSource{}
+func (m *EvidenceNotification) Reset()         { *m = EvidenceNotification{} }
+func (m *EvidenceNotification) String() string { return proto.CompactTextString(m) }
+func (*EvidenceNotification) ProtoMessage()    {}
+func (*EvidenceNotification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
 }
-func (m *GetTransactionResponse_TxIn_ValueSource) String() string { return proto.CompactTextString(m) }
-func (*GetTransactionResponse_TxIn_ValueSource) ProtoMessage()    {}
-func (*GetTransactionResponse_TxIn_ValueSource) Descriptor() ([]byte, []int) {
-	return fileDescriptorApi, []int{12, 0, 0}
+func (m *EvidenceNotification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvidenceNotification.Unmarshal(m, b)
+}
+func (m *EvidenceNotification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvidenceNotification.Marshal(b, m, deterministic)
+}
+func (m *EvidenceNotification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvidenceNotification.Merge(m, src)
+}
+func (m *EvidenceNotification) XXX_Size() int {
+	return xxx_messageInfo_EvidenceNotification.Size(m)
+}
+func (m *EvidenceNotification) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvidenceNotification.DiscardUnknown(m)
 }
 
-func (m *GetTransactionResponse_TxIn_ValueSource) GetTxid() string {
+var xxx_messageInfo_EvidenceNotification proto.InternalMessageInfo
+
+func (m *EvidenceNotification) GetTxid() string {
 	if m != nil {
 		return m.Txid
 	}
 	return ""
 }
 
-func (m *GetTransactionResponse_TxIn_ValueSource) GetIndex() uint64 {
+func (m *EvidenceNotification) GetIndex() uint64 {
 	if m != nil {
 		return m.Index
 	}
 	return 0
 }
 
-type GetTransactionResponse_TxOut struct {
-	Value      uint64 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
-	ScriptHash string `protobuf:"bytes,2,opt,name=script_hash,json=scriptHash,proto3" json:"script_hash,omitempty"`
-}
-
-func (m *GetTransactionResponse_TxOut) Reset()         { *m = GetTransactionResponse_TxOut{} }
-func (m *GetTransactionResponse_TxOut) String() string { return proto.CompactTextString(m) }
-func (*GetTransactionResponse_TxOut) ProtoMessage()    {}
-func (*GetTransactionResponse_TxOut) Descriptor() ([]byte, []int) {
-	return fileDescriptorApi, []int{12, 1}
+func (m *EvidenceNotification) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
 }
 
-func (m *GetTransactionResponse_TxOut) GetValue() uint64 {
+func (m *EvidenceNotification) GetHeight() uint64 {
 	if m != nil {
-		return m.Value
+		return m.Height
 	}
 	return 0
 }
 
-func (m *GetTransactionResponse_TxOut) GetScriptHash() string {
+func (m *EvidenceNotification) GetEvidence() *Evidence {
 	if m != nil {
-		return m.ScriptHash
+		return m.Evidence
 	}
-	return ""
+	return nil
 }
 
-type GetEvidenceRequest struct {
-	Evid string `protobuf:"bytes,1,opt,name=evid,proto3" json:"evid,omitempty"`
+type GetTransactionRequest struct {
+	Txid                 string   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetEvidenceRequest) Reset()                    { *m = GetEvidenceRequest{} }
-func (m *GetEvidenceRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetEvidenceRequest) ProtoMessage()               {}
-func (*GetEvidenceRequest) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{13} }
+func (m *GetTransactionRequest) Reset()         { *m = GetTransactionRequest{} }
+func (m *GetTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionRequest) ProtoMessage()    {}
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+}
+func (m *GetTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionRequest.Unmarshal(m, b)
+}
+func (m *GetTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionRequest.Merge(m, src)
+}
+func (m *GetTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionRequest.Size(m)
+}
+func (m *GetTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionRequest.DiscardUnknown(m)
+}
 
-func (m *GetEvidenceRequest) GetEvid() string {
+var xxx_messageInfo_GetTransactionRequest proto.InternalMessageInfo
+
+func (m *GetTransactionRequest) GetTxid() string {
 	if m != nil {
-		return m.Evid
+		return m.Txid
 	}
 	return ""
 }
 
-type GetEvidenceResponse struct {
-	Txid        string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
-	Index       uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
-	Evid        string `protobuf:"bytes,3,opt,name=evid,proto3" json:"evid,omitempty"`
-	Digest      string `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
-	Source      string `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
-	ValidScript string `protobuf:"bytes,6,opt,name=valid_script,json=validScript,proto3" json:"valid_script,omitempty"`
+type GetTransactionResponse struct {
+	Txid                 string                          `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Version              uint64                          `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Inputs               []*GetTransactionResponse_TxIn  `protobuf:"bytes,3,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs              []*GetTransactionResponse_TxOut `protobuf:"bytes,4,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	Evidences            []*Evidence                     `protobuf:"bytes,5,rep,name=evidences,proto3" json:"evidences,omitempty"`
+	LockTime             uint64                          `protobuf:"varint,6,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *GetTransactionResponse) Reset()         { *m = GetTransactionResponse{} }
+func (m *GetTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionResponse) ProtoMessage()    {}
+func (*GetTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
+}
+func (m *GetTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionResponse.Unmarshal(m, b)
+}
+func (m *GetTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionResponse.Merge(m, src)
+}
+func (m *GetTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionResponse.Size(m)
+}
+func (m *GetTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionResponse.DiscardUnknown(m)
 }
 
-func (m *GetEvidenceResponse) Reset()                    { *m = GetEvidenceResponse{} }
-func (m *GetEvidenceResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetEvidenceResponse) ProtoMessage()               {}
-func (*GetEvidenceResponse) Descriptor() ([]byte, []int) { return fileDescriptorApi, []int{14} }
+var xxx_messageInfo_GetTransactionResponse proto.InternalMessageInfo
 
-func (m *GetEvidenceResponse) GetTxid() string {
+func (m *GetTransactionResponse) GetTxid() string {
 	if m != nil {
 		return m.Txid
 	}
 	return ""
 }
 
-func (m *GetEvidenceResponse) GetIndex() uint64 {
+func (m *GetTransactionResponse) GetVersion() uint64 {
 	if m != nil {
-		return m.Index
+		return m.Version
 	}
 	return 0
 }
 
-func (m *GetEvidenceResponse) GetEvid() string {
+func (m *GetTransactionResponse) GetInputs() []*GetTransactionResponse_TxIn {
 	if m != nil {
-		return m.Evid
+		return m.Inputs
 	}
-	return ""
+	return nil
 }
 
-func (m *GetEvidenceResponse) GetDigest() string {
+func (m *GetTransactionResponse) GetOutputs() []*GetTransactionResponse_TxOut {
 	if m != nil {
-		return m.Digest
+		return m.Outputs
 	}
-	return ""
+	return nil
 }
 
-func (m *GetEvidenceResponse) GetSource() string {
+func (m *GetTransactionResponse) GetEvidences() []*Evidence {
+	if m != nil {
+		return m.Evidences
+	}
+	return nil
+}
+
+func (m *GetTransactionResponse) GetLockTime() uint64 {
+	if m != nil {
+		return m.LockTime
+	}
+	return 0
+}
+
+type GetTransactionResponse_TxIn struct {
+	ValueSource          *GetTransactionResponse_TxIn_ValueSource `protobuf:"bytes,1,opt,name=value_source,json=valueSource,proto3" json:"value_source,omitempty"`
+	RedeemScript         string                                   `protobuf:"bytes,2,opt,name=redeem_script,json=redeemScript,proto3" json:"redeem_script,omitempty"`
+	UnlockScript         string                                   `protobuf:"bytes,3,opt,name=unlock_script,json=unlockScript,proto3" json:"unlock_script,omitempty"`
+	Sequence             uint64                                   `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
+	XXX_unrecognized     []byte                                   `json:"-"`
+	XXX_sizecache        int32                                    `json:"-"`
+}
+
+func (m *GetTransactionResponse_TxIn) Reset()         { *m = GetTransactionResponse_TxIn{} }
+func (m *GetTransactionResponse_TxIn) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionResponse_TxIn) ProtoMessage()    {}
+func (*GetTransactionResponse_TxIn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21, 0}
+}
+func (m *GetTransactionResponse_TxIn) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionResponse_TxIn.Unmarshal(m, b)
+}
+func (m *GetTransactionResponse_TxIn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionResponse_TxIn.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionResponse_TxIn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionResponse_TxIn.Merge(m, src)
+}
+func (m *GetTransactionResponse_TxIn) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionResponse_TxIn.Size(m)
+}
+func (m *GetTransactionResponse_TxIn) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionResponse_TxIn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionResponse_TxIn proto.InternalMessageInfo
+
+func (m *GetTransactionResponse_TxIn) GetValueSource() *GetTransactionResponse_TxIn_ValueSource {
+	if m != nil {
+		return m.ValueSource
+	}
+	return nil
+}
+
+func (m *GetTransactionResponse_TxIn) GetRedeemScript() string {
+	if m != nil {
+		return m.RedeemScript
+	}
+	return ""
+}
+
+func (m *GetTransactionResponse_TxIn) GetUnlockScript() string {
+	if m != nil {
+		return m.UnlockScript
+	}
+	return ""
+}
+
+func (m *GetTransactionResponse_TxIn) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+type GetTransactionResponse_TxIn_ValueSource struct {
+	Txid                 string   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Index                uint64   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTransactionResponse_TxIn_ValueSource) Reset() {
+	*m = GetTransactionResponse_TxIn_ValueSource{}
+}
+func (m *GetTransactionResponse_TxIn_ValueSource) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionResponse_TxIn_ValueSource) ProtoMessage()    {}
+func (*GetTransactionResponse_TxIn_ValueSource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21, 0, 0}
+}
+func (m *GetTransactionResponse_TxIn_ValueSource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionResponse_TxIn_ValueSource.Unmarshal(m, b)
+}
+func (m *GetTransactionResponse_TxIn_ValueSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionResponse_TxIn_ValueSource.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionResponse_TxIn_ValueSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionResponse_TxIn_ValueSource.Merge(m, src)
+}
+func (m *GetTransactionResponse_TxIn_ValueSource) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionResponse_TxIn_ValueSource.Size(m)
+}
+func (m *GetTransactionResponse_TxIn_ValueSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionResponse_TxIn_ValueSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionResponse_TxIn_ValueSource proto.InternalMessageInfo
+
+func (m *GetTransactionResponse_TxIn_ValueSource) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *GetTransactionResponse_TxIn_ValueSource) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type GetTransactionResponse_TxOut struct {
+	Value                uint64   `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	ScriptHash           string   `protobuf:"bytes,2,opt,name=script_hash,json=scriptHash,proto3" json:"script_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTransactionResponse_TxOut) Reset()         { *m = GetTransactionResponse_TxOut{} }
+func (m *GetTransactionResponse_TxOut) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionResponse_TxOut) ProtoMessage()    {}
+func (*GetTransactionResponse_TxOut) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21, 1}
+}
+func (m *GetTransactionResponse_TxOut) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionResponse_TxOut.Unmarshal(m, b)
+}
+func (m *GetTransactionResponse_TxOut) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionResponse_TxOut.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionResponse_TxOut) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionResponse_TxOut.Merge(m, src)
+}
+func (m *GetTransactionResponse_TxOut) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionResponse_TxOut.Size(m)
+}
+func (m *GetTransactionResponse_TxOut) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionResponse_TxOut.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionResponse_TxOut proto.InternalMessageInfo
+
+func (m *GetTransactionResponse_TxOut) GetValue() uint64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *GetTransactionResponse_TxOut) GetScriptHash() string {
+	if m != nil {
+		return m.ScriptHash
+	}
+	return ""
+}
+
+type GetEvidenceRequest struct {
+	Evid                 string   `protobuf:"bytes,1,opt,name=evid,proto3" json:"evid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEvidenceRequest) Reset()         { *m = GetEvidenceRequest{} }
+func (m *GetEvidenceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEvidenceRequest) ProtoMessage()    {}
+func (*GetEvidenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
+}
+func (m *GetEvidenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEvidenceRequest.Unmarshal(m, b)
+}
+func (m *GetEvidenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEvidenceRequest.Marshal(b, m, deterministic)
+}
+func (m *GetEvidenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEvidenceRequest.Merge(m, src)
+}
+func (m *GetEvidenceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEvidenceRequest.Size(m)
+}
+func (m *GetEvidenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEvidenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEvidenceRequest proto.InternalMessageInfo
+
+func (m *GetEvidenceRequest) GetEvid() string {
+	if m != nil {
+		return m.Evid
+	}
+	return ""
+}
+
+type GetEvidenceResponse struct {
+	Txid                 string   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Index                uint64   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Evid                 string   `protobuf:"bytes,3,opt,name=evid,proto3" json:"evid,omitempty"`
+	Digest               string   `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
+	Source               string   `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	ValidScript          string   `protobuf:"bytes,6,opt,name=valid_script,json=validScript,proto3" json:"valid_script,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEvidenceResponse) Reset()         { *m = GetEvidenceResponse{} }
+func (m *GetEvidenceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetEvidenceResponse) ProtoMessage()    {}
+func (*GetEvidenceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+}
+func (m *GetEvidenceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEvidenceResponse.Unmarshal(m, b)
+}
+func (m *GetEvidenceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEvidenceResponse.Marshal(b, m, deterministic)
+}
+func (m *GetEvidenceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEvidenceResponse.Merge(m, src)
+}
+func (m *GetEvidenceResponse) XXX_Size() int {
+	return xxx_messageInfo_GetEvidenceResponse.Size(m)
+}
+func (m *GetEvidenceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEvidenceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEvidenceResponse proto.InternalMessageInfo
+
+func (m *GetEvidenceResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *GetEvidenceResponse) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *GetEvidenceResponse) GetEvid() string {
+	if m != nil {
+		return m.Evid
+	}
+	return ""
+}
+
+func (m *GetEvidenceResponse) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *GetEvidenceResponse) GetSource() string {
 	if m != nil {
 		return m.Source
 	}
@@ -944,264 +2011,3808 @@ func (m *GetEvidenceResponse) GetValidScript() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*GetBestBlockResponse)(nil), "api.GetBestBlockResponse")
-	proto.RegisterType((*Proof)(nil), "api.Proof")
-	proto.RegisterType((*GetBlockRequest)(nil), "api.GetBlockRequest")
-	proto.RegisterType((*GetBlockResponse)(nil), "api.GetBlockResponse")
-	proto.RegisterType((*GetBlockHeaderRequest)(nil), "api.GetBlockHeaderRequest")
-	proto.RegisterType((*GetBlockHeaderResponse)(nil), "api.GetBlockHeaderResponse")
-	proto.RegisterType((*GetBlockVerboseRequest)(nil), "api.GetBlockVerboseRequest")
-	proto.RegisterType((*GetBlockVerboseV0Response)(nil), "api.GetBlockVerboseV0Response")
-	proto.RegisterType((*GetBlockVerboseV0Response_Transaction)(nil), "api.GetBlockVerboseV0Response.Transaction")
-	proto.RegisterType((*GetBlockVerboseV1Response)(nil), "api.GetBlockVerboseV1Response")
-	proto.RegisterType((*Tx)(nil), "api.Tx")
-	proto.RegisterType((*Tx_TxIn)(nil), "api.Tx.TxIn")
-	proto.RegisterType((*Tx_TxIn_ValueSource)(nil), "api.Tx.TxIn.ValueSource")
-	proto.RegisterType((*Tx_TxOut)(nil), "api.Tx.TxOut")
-	proto.RegisterType((*Evidence)(nil), "api.Evidence")
-	proto.RegisterType((*GetTransactionRequest)(nil), "api.GetTransactionRequest")
-	proto.RegisterType((*GetTransactionResponse)(nil), "api.GetTransactionResponse")
-	proto.RegisterType((*GetTransactionResponse_TxIn)(nil), "api.GetTransactionResponse.TxIn")
-	proto.RegisterType((*GetTransactionResponse_TxIn_ValueSource)(nil), "api.GetTransactionResponse.TxIn.ValueSource")
-	proto.RegisterType((*GetTransactionResponse_TxOut)(nil), "api.GetTransactionResponse.TxOut")
-	proto.RegisterType((*GetEvidenceRequest)(nil), "api.GetEvidenceRequest")
-	proto.RegisterType((*GetEvidenceResponse)(nil), "api.GetEvidenceResponse")
+type RawTransactionRequest struct {
+	RawTx                string   `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *RawTransactionRequest) Reset()         { *m = RawTransactionRequest{} }
+func (m *RawTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*RawTransactionRequest) ProtoMessage()    {}
+func (*RawTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+}
+func (m *RawTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RawTransactionRequest.Unmarshal(m, b)
+}
+func (m *RawTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RawTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *RawTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RawTransactionRequest.Merge(m, src)
+}
+func (m *RawTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_RawTransactionRequest.Size(m)
+}
+func (m *RawTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RawTransactionRequest.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_RawTransactionRequest proto.InternalMessageInfo
 
-// Client API for APIService service
+func (m *RawTransactionRequest) GetRawTx() string {
+	if m != nil {
+		return m.RawTx
+	}
+	return ""
+}
 
-type APIServiceClient interface {
-	GetBestBlock(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*GetBestBlockResponse, error)
-	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
-	GetBlockHeader(ctx context.Context, in *GetBlockHeaderRequest, opts ...grpc.CallOption) (*GetBlockHeaderResponse, error)
-	GetBlockVerboseV0(ctx context.Context, in *GetBlockVerboseRequest, opts ...grpc.CallOption) (*GetBlockVerboseV0Response, error)
-	GetBlockVerboseV1(ctx context.Context, in *GetBlockVerboseRequest, opts ...grpc.CallOption) (*GetBlockVerboseV1Response, error)
-	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
-	GetEvidence(ctx context.Context, in *GetEvidenceRequest, opts ...grpc.CallOption) (*GetEvidenceResponse, error)
+type RawBlockRequest struct {
+	RawBlock             string   `protobuf:"bytes,1,opt,name=raw_block,json=rawBlock,proto3" json:"raw_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type aPIServiceClient struct {
-	cc *grpc.ClientConn
+func (m *RawBlockRequest) Reset()         { *m = RawBlockRequest{} }
+func (m *RawBlockRequest) String() string { return proto.CompactTextString(m) }
+func (*RawBlockRequest) ProtoMessage()    {}
+func (*RawBlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+}
+func (m *RawBlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RawBlockRequest.Unmarshal(m, b)
+}
+func (m *RawBlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RawBlockRequest.Marshal(b, m, deterministic)
+}
+func (m *RawBlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RawBlockRequest.Merge(m, src)
+}
+func (m *RawBlockRequest) XXX_Size() int {
+	return xxx_messageInfo_RawBlockRequest.Size(m)
+}
+func (m *RawBlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RawBlockRequest.DiscardUnknown(m)
 }
 
-func NewAPIServiceClient(cc *grpc.ClientConn) APIServiceClient {
-	return &aPIServiceClient{cc}
+var xxx_messageInfo_RawBlockRequest proto.InternalMessageInfo
+
+func (m *RawBlockRequest) GetRawBlock() string {
+	if m != nil {
+		return m.RawBlock
+	}
+	return ""
+}
+
+type SubmitRawTransactionResponse struct {
+	Txid                 string   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Orphan               bool     `protobuf:"varint,2,opt,name=orphan,proto3" json:"orphan,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitRawTransactionResponse) Reset()         { *m = SubmitRawTransactionResponse{} }
+func (m *SubmitRawTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitRawTransactionResponse) ProtoMessage()    {}
+func (*SubmitRawTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+}
+func (m *SubmitRawTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitRawTransactionResponse.Unmarshal(m, b)
+}
+func (m *SubmitRawTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitRawTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *SubmitRawTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitRawTransactionResponse.Merge(m, src)
+}
+func (m *SubmitRawTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_SubmitRawTransactionResponse.Size(m)
+}
+func (m *SubmitRawTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitRawTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitRawTransactionResponse proto.InternalMessageInfo
+
+func (m *SubmitRawTransactionResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *SubmitRawTransactionResponse) GetOrphan() bool {
+	if m != nil {
+		return m.Orphan
+	}
+	return false
+}
+
+type TestMempoolAcceptResponse struct {
+	Txid                 string       `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Allowed              bool         `protobuf:"varint,2,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Orphan               bool         `protobuf:"varint,3,opt,name=orphan,proto3" json:"orphan,omitempty"`
+	RejectReason         string       `protobuf:"bytes,4,opt,name=reject_reason,json=rejectReason,proto3" json:"reject_reason,omitempty"`
+	RejectDetail         *ErrorDetail `protobuf:"bytes,5,opt,name=reject_detail,json=rejectDetail,proto3" json:"reject_detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *TestMempoolAcceptResponse) Reset()         { *m = TestMempoolAcceptResponse{} }
+func (m *TestMempoolAcceptResponse) String() string { return proto.CompactTextString(m) }
+func (*TestMempoolAcceptResponse) ProtoMessage()    {}
+func (*TestMempoolAcceptResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
+}
+func (m *TestMempoolAcceptResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestMempoolAcceptResponse.Unmarshal(m, b)
+}
+func (m *TestMempoolAcceptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestMempoolAcceptResponse.Marshal(b, m, deterministic)
+}
+func (m *TestMempoolAcceptResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestMempoolAcceptResponse.Merge(m, src)
+}
+func (m *TestMempoolAcceptResponse) XXX_Size() int {
+	return xxx_messageInfo_TestMempoolAcceptResponse.Size(m)
+}
+func (m *TestMempoolAcceptResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestMempoolAcceptResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestMempoolAcceptResponse proto.InternalMessageInfo
+
+func (m *TestMempoolAcceptResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *TestMempoolAcceptResponse) GetAllowed() bool {
+	if m != nil {
+		return m.Allowed
+	}
+	return false
+}
+
+func (m *TestMempoolAcceptResponse) GetOrphan() bool {
+	if m != nil {
+		return m.Orphan
+	}
+	return false
+}
+
+func (m *TestMempoolAcceptResponse) GetRejectReason() string {
+	if m != nil {
+		return m.RejectReason
+	}
+	return ""
+}
+
+func (m *TestMempoolAcceptResponse) GetRejectDetail() *ErrorDetail {
+	if m != nil {
+		return m.RejectDetail
+	}
+	return nil
+}
+
+type GetTransactionsRequest struct {
+	Txids                []string `protobuf:"bytes,1,rep,name=txids,proto3" json:"txids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTransactionsRequest) Reset()         { *m = GetTransactionsRequest{} }
+func (m *GetTransactionsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionsRequest) ProtoMessage()    {}
+func (*GetTransactionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
+}
+func (m *GetTransactionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionsRequest.Unmarshal(m, b)
+}
+func (m *GetTransactionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionsRequest.Merge(m, src)
+}
+func (m *GetTransactionsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionsRequest.Size(m)
+}
+func (m *GetTransactionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionsRequest proto.InternalMessageInfo
+
+func (m *GetTransactionsRequest) GetTxids() []string {
+	if m != nil {
+		return m.Txids
+	}
+	return nil
+}
+
+type GetTransactionsResponse struct {
+	Transactions         []*GetTransactionResponse `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *GetTransactionsResponse) Reset()         { *m = GetTransactionsResponse{} }
+func (m *GetTransactionsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionsResponse) ProtoMessage()    {}
+func (*GetTransactionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
+}
+func (m *GetTransactionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionsResponse.Unmarshal(m, b)
+}
+func (m *GetTransactionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionsResponse.Merge(m, src)
+}
+func (m *GetTransactionsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionsResponse.Size(m)
+}
+func (m *GetTransactionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionsResponse proto.InternalMessageInfo
+
+func (m *GetTransactionsResponse) GetTransactions() []*GetTransactionResponse {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+type GetEvidencesRequest struct {
+	Evids                []string `protobuf:"bytes,1,rep,name=evids,proto3" json:"evids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEvidencesRequest) Reset()         { *m = GetEvidencesRequest{} }
+func (m *GetEvidencesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEvidencesRequest) ProtoMessage()    {}
+func (*GetEvidencesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
+}
+func (m *GetEvidencesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEvidencesRequest.Unmarshal(m, b)
+}
+func (m *GetEvidencesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEvidencesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetEvidencesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEvidencesRequest.Merge(m, src)
+}
+func (m *GetEvidencesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEvidencesRequest.Size(m)
+}
+func (m *GetEvidencesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEvidencesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEvidencesRequest proto.InternalMessageInfo
+
+func (m *GetEvidencesRequest) GetEvids() []string {
+	if m != nil {
+		return m.Evids
+	}
+	return nil
+}
+
+type GetEvidencesResponse struct {
+	Evidences            []*GetEvidenceResponse `protobuf:"bytes,1,rep,name=evidences,proto3" json:"evidences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetEvidencesResponse) Reset()         { *m = GetEvidencesResponse{} }
+func (m *GetEvidencesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetEvidencesResponse) ProtoMessage()    {}
+func (*GetEvidencesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
+}
+func (m *GetEvidencesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEvidencesResponse.Unmarshal(m, b)
+}
+func (m *GetEvidencesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEvidencesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetEvidencesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEvidencesResponse.Merge(m, src)
+}
+func (m *GetEvidencesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetEvidencesResponse.Size(m)
+}
+func (m *GetEvidencesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEvidencesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEvidencesResponse proto.InternalMessageInfo
+
+func (m *GetEvidencesResponse) GetEvidences() []*GetEvidenceResponse {
+	if m != nil {
+		return m.Evidences
+	}
+	return nil
+}
+
+type GetWorkResponse struct {
+	BlockHeader          string   `protobuf:"bytes,1,opt,name=block_header,json=blockHeader,proto3" json:"block_header,omitempty"`
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Target               uint64   `protobuf:"varint,3,opt,name=target,proto3" json:"target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWorkResponse) Reset()         { *m = GetWorkResponse{} }
+func (m *GetWorkResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWorkResponse) ProtoMessage()    {}
+func (*GetWorkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
+}
+func (m *GetWorkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWorkResponse.Unmarshal(m, b)
+}
+func (m *GetWorkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWorkResponse.Marshal(b, m, deterministic)
+}
+func (m *GetWorkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWorkResponse.Merge(m, src)
+}
+func (m *GetWorkResponse) XXX_Size() int {
+	return xxx_messageInfo_GetWorkResponse.Size(m)
+}
+func (m *GetWorkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWorkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWorkResponse proto.InternalMessageInfo
+
+func (m *GetWorkResponse) GetBlockHeader() string {
+	if m != nil {
+		return m.BlockHeader
+	}
+	return ""
+}
+
+func (m *GetWorkResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetWorkResponse) GetTarget() uint64 {
+	if m != nil {
+		return m.Target
+	}
+	return 0
+}
+
+type SubmitWorkRequest struct {
+	BlockHeader          string   `protobuf:"bytes,1,opt,name=block_header,json=blockHeader,proto3" json:"block_header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitWorkRequest) Reset()         { *m = SubmitWorkRequest{} }
+func (m *SubmitWorkRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitWorkRequest) ProtoMessage()    {}
+func (*SubmitWorkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
+}
+func (m *SubmitWorkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitWorkRequest.Unmarshal(m, b)
+}
+func (m *SubmitWorkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitWorkRequest.Marshal(b, m, deterministic)
+}
+func (m *SubmitWorkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitWorkRequest.Merge(m, src)
+}
+func (m *SubmitWorkRequest) XXX_Size() int {
+	return xxx_messageInfo_SubmitWorkRequest.Size(m)
+}
+func (m *SubmitWorkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitWorkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitWorkRequest proto.InternalMessageInfo
+
+func (m *SubmitWorkRequest) GetBlockHeader() string {
+	if m != nil {
+		return m.BlockHeader
+	}
+	return ""
+}
+
+type SubmitWorkResponse struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitWorkResponse) Reset()         { *m = SubmitWorkResponse{} }
+func (m *SubmitWorkResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitWorkResponse) ProtoMessage()    {}
+func (*SubmitWorkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
+}
+func (m *SubmitWorkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitWorkResponse.Unmarshal(m, b)
+}
+func (m *SubmitWorkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitWorkResponse.Marshal(b, m, deterministic)
+}
+func (m *SubmitWorkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitWorkResponse.Merge(m, src)
+}
+func (m *SubmitWorkResponse) XXX_Size() int {
+	return xxx_messageInfo_SubmitWorkResponse.Size(m)
+}
+func (m *SubmitWorkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitWorkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitWorkResponse proto.InternalMessageInfo
+
+func (m *SubmitWorkResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *SubmitWorkResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type SetMiningWorkersRequest struct {
+	NumWorkers           int32    `protobuf:"varint,1,opt,name=num_workers,json=numWorkers,proto3" json:"num_workers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetMiningWorkersRequest) Reset()         { *m = SetMiningWorkersRequest{} }
+func (m *SetMiningWorkersRequest) String() string { return proto.CompactTextString(m) }
+func (*SetMiningWorkersRequest) ProtoMessage()    {}
+func (*SetMiningWorkersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
+}
+func (m *SetMiningWorkersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMiningWorkersRequest.Unmarshal(m, b)
+}
+func (m *SetMiningWorkersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMiningWorkersRequest.Marshal(b, m, deterministic)
+}
+func (m *SetMiningWorkersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMiningWorkersRequest.Merge(m, src)
+}
+func (m *SetMiningWorkersRequest) XXX_Size() int {
+	return xxx_messageInfo_SetMiningWorkersRequest.Size(m)
+}
+func (m *SetMiningWorkersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMiningWorkersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetMiningWorkersRequest proto.InternalMessageInfo
+
+func (m *SetMiningWorkersRequest) GetNumWorkers() int32 {
+	if m != nil {
+		return m.NumWorkers
+	}
+	return 0
+}
+
+type MiningStatusResponse struct {
+	Mining               bool     `protobuf:"varint,1,opt,name=mining,proto3" json:"mining,omitempty"`
+	NumWorkers           int32    `protobuf:"varint,2,opt,name=num_workers,json=numWorkers,proto3" json:"num_workers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MiningStatusResponse) Reset()         { *m = MiningStatusResponse{} }
+func (m *MiningStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*MiningStatusResponse) ProtoMessage()    {}
+func (*MiningStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
+}
+func (m *MiningStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MiningStatusResponse.Unmarshal(m, b)
+}
+func (m *MiningStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MiningStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *MiningStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MiningStatusResponse.Merge(m, src)
+}
+func (m *MiningStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_MiningStatusResponse.Size(m)
+}
+func (m *MiningStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MiningStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MiningStatusResponse proto.InternalMessageInfo
+
+func (m *MiningStatusResponse) GetMining() bool {
+	if m != nil {
+		return m.Mining
+	}
+	return false
+}
+
+func (m *MiningStatusResponse) GetNumWorkers() int32 {
+	if m != nil {
+		return m.NumWorkers
+	}
+	return 0
+}
+
+type WorkerStats struct {
+	Id                   uint32   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hashes               uint64   `protobuf:"varint,2,opt,name=hashes,proto3" json:"hashes,omitempty"`
+	HashesPerSec         float64  `protobuf:"fixed64,3,opt,name=hashes_per_sec,json=hashesPerSec,proto3" json:"hashes_per_sec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkerStats) Reset()         { *m = WorkerStats{} }
+func (m *WorkerStats) String() string { return proto.CompactTextString(m) }
+func (*WorkerStats) ProtoMessage()    {}
+func (*WorkerStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
+}
+func (m *WorkerStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkerStats.Unmarshal(m, b)
+}
+func (m *WorkerStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkerStats.Marshal(b, m, deterministic)
+}
+func (m *WorkerStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkerStats.Merge(m, src)
+}
+func (m *WorkerStats) XXX_Size() int {
+	return xxx_messageInfo_WorkerStats.Size(m)
+}
+func (m *WorkerStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkerStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkerStats proto.InternalMessageInfo
+
+func (m *WorkerStats) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *WorkerStats) GetHashes() uint64 {
+	if m != nil {
+		return m.Hashes
+	}
+	return 0
+}
+
+func (m *WorkerStats) GetHashesPerSec() float64 {
+	if m != nil {
+		return m.HashesPerSec
+	}
+	return 0
+}
+
+type GetMiningStatsResponse struct {
+	Mining               bool           `protobuf:"varint,1,opt,name=mining,proto3" json:"mining,omitempty"`
+	NumWorkers           int32          `protobuf:"varint,2,opt,name=num_workers,json=numWorkers,proto3" json:"num_workers,omitempty"`
+	HashesPerSec         float64        `protobuf:"fixed64,3,opt,name=hashes_per_sec,json=hashesPerSec,proto3" json:"hashes_per_sec,omitempty"`
+	Workers              []*WorkerStats `protobuf:"bytes,4,rep,name=workers,proto3" json:"workers,omitempty"`
+	SolvedBlocks         uint64         `protobuf:"varint,5,opt,name=solved_blocks,json=solvedBlocks,proto3" json:"solved_blocks,omitempty"`
+	TemplateHeight       uint64         `protobuf:"varint,6,opt,name=template_height,json=templateHeight,proto3" json:"template_height,omitempty"`
+	TemplateTarget       uint64         `protobuf:"varint,7,opt,name=template_target,json=templateTarget,proto3" json:"template_target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetMiningStatsResponse) Reset()         { *m = GetMiningStatsResponse{} }
+func (m *GetMiningStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMiningStatsResponse) ProtoMessage()    {}
+func (*GetMiningStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
+}
+func (m *GetMiningStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMiningStatsResponse.Unmarshal(m, b)
+}
+func (m *GetMiningStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMiningStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMiningStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMiningStatsResponse.Merge(m, src)
+}
+func (m *GetMiningStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMiningStatsResponse.Size(m)
+}
+func (m *GetMiningStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMiningStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMiningStatsResponse proto.InternalMessageInfo
+
+func (m *GetMiningStatsResponse) GetMining() bool {
+	if m != nil {
+		return m.Mining
+	}
+	return false
+}
+
+func (m *GetMiningStatsResponse) GetNumWorkers() int32 {
+	if m != nil {
+		return m.NumWorkers
+	}
+	return 0
+}
+
+func (m *GetMiningStatsResponse) GetHashesPerSec() float64 {
+	if m != nil {
+		return m.HashesPerSec
+	}
+	return 0
+}
+
+func (m *GetMiningStatsResponse) GetWorkers() []*WorkerStats {
+	if m != nil {
+		return m.Workers
+	}
+	return nil
+}
+
+func (m *GetMiningStatsResponse) GetSolvedBlocks() uint64 {
+	if m != nil {
+		return m.SolvedBlocks
+	}
+	return 0
+}
+
+func (m *GetMiningStatsResponse) GetTemplateHeight() uint64 {
+	if m != nil {
+		return m.TemplateHeight
+	}
+	return 0
+}
+
+func (m *GetMiningStatsResponse) GetTemplateTarget() uint64 {
+	if m != nil {
+		return m.TemplateTarget
+	}
+	return 0
+}
+
+type GetWalletStatusResponse struct {
+	TxCount              uint32   `protobuf:"varint,1,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+	EvidCount            uint32   `protobuf:"varint,2,opt,name=evid_count,json=evidCount,proto3" json:"evid_count,omitempty"`
+	Balance              uint64   `protobuf:"varint,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWalletStatusResponse) Reset()         { *m = GetWalletStatusResponse{} }
+func (m *GetWalletStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWalletStatusResponse) ProtoMessage()    {}
+func (*GetWalletStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
+}
+func (m *GetWalletStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWalletStatusResponse.Unmarshal(m, b)
+}
+func (m *GetWalletStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWalletStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetWalletStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWalletStatusResponse.Merge(m, src)
+}
+func (m *GetWalletStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetWalletStatusResponse.Size(m)
+}
+func (m *GetWalletStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWalletStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWalletStatusResponse proto.InternalMessageInfo
+
+func (m *GetWalletStatusResponse) GetTxCount() uint32 {
+	if m != nil {
+		return m.TxCount
+	}
+	return 0
+}
+
+func (m *GetWalletStatusResponse) GetEvidCount() uint32 {
+	if m != nil {
+		return m.EvidCount
+	}
+	return 0
+}
+
+func (m *GetWalletStatusResponse) GetBalance() uint64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+type GetWalletAddressesResponse struct {
+	Addresses            []*GetWalletAddressesResponse_Address `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	NextPageToken        string                                `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
+	XXX_unrecognized     []byte                                `json:"-"`
+	XXX_sizecache        int32                                 `json:"-"`
+}
+
+func (m *GetWalletAddressesResponse) Reset()         { *m = GetWalletAddressesResponse{} }
+func (m *GetWalletAddressesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWalletAddressesResponse) ProtoMessage()    {}
+func (*GetWalletAddressesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
+}
+func (m *GetWalletAddressesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWalletAddressesResponse.Unmarshal(m, b)
+}
+func (m *GetWalletAddressesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWalletAddressesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetWalletAddressesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWalletAddressesResponse.Merge(m, src)
+}
+func (m *GetWalletAddressesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetWalletAddressesResponse.Size(m)
+}
+func (m *GetWalletAddressesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWalletAddressesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWalletAddressesResponse proto.InternalMessageInfo
+
+func (m *GetWalletAddressesResponse) GetAddresses() []*GetWalletAddressesResponse_Address {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *GetWalletAddressesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetWalletAddressesResponse_Address struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Balance              float32  `protobuf:"fixed32,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWalletAddressesResponse_Address) Reset()         { *m = GetWalletAddressesResponse_Address{} }
+func (m *GetWalletAddressesResponse_Address) String() string { return proto.CompactTextString(m) }
+func (*GetWalletAddressesResponse_Address) ProtoMessage()    {}
+func (*GetWalletAddressesResponse_Address) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{40, 0}
+}
+func (m *GetWalletAddressesResponse_Address) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWalletAddressesResponse_Address.Unmarshal(m, b)
+}
+func (m *GetWalletAddressesResponse_Address) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWalletAddressesResponse_Address.Marshal(b, m, deterministic)
+}
+func (m *GetWalletAddressesResponse_Address) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWalletAddressesResponse_Address.Merge(m, src)
+}
+func (m *GetWalletAddressesResponse_Address) XXX_Size() int {
+	return xxx_messageInfo_GetWalletAddressesResponse_Address.Size(m)
+}
+func (m *GetWalletAddressesResponse_Address) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWalletAddressesResponse_Address.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWalletAddressesResponse_Address proto.InternalMessageInfo
+
+func (m *GetWalletAddressesResponse_Address) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *GetWalletAddressesResponse_Address) GetBalance() float32 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+type GetWalletBalanceResponse struct {
+	Balance              float32  `protobuf:"fixed32,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWalletBalanceResponse) Reset()         { *m = GetWalletBalanceResponse{} }
+func (m *GetWalletBalanceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWalletBalanceResponse) ProtoMessage()    {}
+func (*GetWalletBalanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
+}
+func (m *GetWalletBalanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWalletBalanceResponse.Unmarshal(m, b)
+}
+func (m *GetWalletBalanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWalletBalanceResponse.Marshal(b, m, deterministic)
+}
+func (m *GetWalletBalanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWalletBalanceResponse.Merge(m, src)
+}
+func (m *GetWalletBalanceResponse) XXX_Size() int {
+	return xxx_messageInfo_GetWalletBalanceResponse.Size(m)
+}
+func (m *GetWalletBalanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWalletBalanceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWalletBalanceResponse proto.InternalMessageInfo
+
+func (m *GetWalletBalanceResponse) GetBalance() float32 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+type GetWalletTransactionsResponse struct {
+	Transactions         []string `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWalletTransactionsResponse) Reset()         { *m = GetWalletTransactionsResponse{} }
+func (m *GetWalletTransactionsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWalletTransactionsResponse) ProtoMessage()    {}
+func (*GetWalletTransactionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{42}
+}
+func (m *GetWalletTransactionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWalletTransactionsResponse.Unmarshal(m, b)
+}
+func (m *GetWalletTransactionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWalletTransactionsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetWalletTransactionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWalletTransactionsResponse.Merge(m, src)
+}
+func (m *GetWalletTransactionsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetWalletTransactionsResponse.Size(m)
+}
+func (m *GetWalletTransactionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWalletTransactionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWalletTransactionsResponse proto.InternalMessageInfo
+
+func (m *GetWalletTransactionsResponse) GetTransactions() []string {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+func (m *GetWalletTransactionsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetWalletEvidencesResponse struct {
+	Evidences            []string `protobuf:"bytes,1,rep,name=evidences,proto3" json:"evidences,omitempty"`
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWalletEvidencesResponse) Reset()         { *m = GetWalletEvidencesResponse{} }
+func (m *GetWalletEvidencesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWalletEvidencesResponse) ProtoMessage()    {}
+func (*GetWalletEvidencesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{43}
+}
+func (m *GetWalletEvidencesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWalletEvidencesResponse.Unmarshal(m, b)
+}
+func (m *GetWalletEvidencesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWalletEvidencesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetWalletEvidencesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWalletEvidencesResponse.Merge(m, src)
+}
+func (m *GetWalletEvidencesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetWalletEvidencesResponse.Size(m)
+}
+func (m *GetWalletEvidencesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWalletEvidencesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWalletEvidencesResponse proto.InternalMessageInfo
+
+func (m *GetWalletEvidencesResponse) GetEvidences() []string {
+	if m != nil {
+		return m.Evidences
+	}
+	return nil
+}
+
+func (m *GetWalletEvidencesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type CreateAddressRequest struct {
+	Password             string   `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateAddressRequest) Reset()         { *m = CreateAddressRequest{} }
+func (m *CreateAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAddressRequest) ProtoMessage()    {}
+func (*CreateAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{44}
+}
+func (m *CreateAddressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAddressRequest.Unmarshal(m, b)
+}
+func (m *CreateAddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAddressRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateAddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAddressRequest.Merge(m, src)
+}
+func (m *CreateAddressRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateAddressRequest.Size(m)
+}
+func (m *CreateAddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAddressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAddressRequest proto.InternalMessageInfo
+
+func (m *CreateAddressRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type CreateAddressResponse struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Success              bool     `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateAddressResponse) Reset()         { *m = CreateAddressResponse{} }
+func (m *CreateAddressResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateAddressResponse) ProtoMessage()    {}
+func (*CreateAddressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{45}
+}
+func (m *CreateAddressResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAddressResponse.Unmarshal(m, b)
+}
+func (m *CreateAddressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAddressResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateAddressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAddressResponse.Merge(m, src)
+}
+func (m *CreateAddressResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateAddressResponse.Size(m)
+}
+func (m *CreateAddressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAddressResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAddressResponse proto.InternalMessageInfo
+
+func (m *CreateAddressResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *CreateAddressResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *CreateAddressResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type CreateTransactionRequest struct {
+	ToAddress            string   `protobuf:"bytes,1,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	Value                float32  `protobuf:"fixed32,2,opt,name=value,proto3" json:"value,omitempty"`
+	File                 string   `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	DigestMode           string   `protobuf:"bytes,4,opt,name=digest_mode,json=digestMode,proto3" json:"digest_mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateTransactionRequest) Reset()         { *m = CreateTransactionRequest{} }
+func (m *CreateTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateTransactionRequest) ProtoMessage()    {}
+func (*CreateTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{46}
+}
+func (m *CreateTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTransactionRequest.Unmarshal(m, b)
+}
+func (m *CreateTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTransactionRequest.Merge(m, src)
+}
+func (m *CreateTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateTransactionRequest.Size(m)
+}
+func (m *CreateTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTransactionRequest proto.InternalMessageInfo
+
+func (m *CreateTransactionRequest) GetToAddress() string {
+	if m != nil {
+		return m.ToAddress
+	}
+	return ""
+}
+
+func (m *CreateTransactionRequest) GetValue() float32 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *CreateTransactionRequest) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *CreateTransactionRequest) GetDigestMode() string {
+	if m != nil {
+		return m.DigestMode
+	}
+	return ""
+}
+
+type CreateTransactionResponse struct {
+	Hex                  string   `protobuf:"bytes,1,opt,name=hex,proto3" json:"hex,omitempty"`
+	Success              bool     `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateTransactionResponse) Reset()         { *m = CreateTransactionResponse{} }
+func (m *CreateTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateTransactionResponse) ProtoMessage()    {}
+func (*CreateTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{47}
+}
+func (m *CreateTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTransactionResponse.Unmarshal(m, b)
+}
+func (m *CreateTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTransactionResponse.Merge(m, src)
+}
+func (m *CreateTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateTransactionResponse.Size(m)
+}
+func (m *CreateTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTransactionResponse proto.InternalMessageInfo
+
+func (m *CreateTransactionResponse) GetHex() string {
+	if m != nil {
+		return m.Hex
+	}
+	return ""
+}
+
+func (m *CreateTransactionResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *CreateTransactionResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type SendTransactionRequest struct {
+	Hex                  string   `protobuf:"bytes,1,opt,name=hex,proto3" json:"hex,omitempty"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendTransactionRequest) Reset()         { *m = SendTransactionRequest{} }
+func (m *SendTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*SendTransactionRequest) ProtoMessage()    {}
+func (*SendTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{48}
+}
+func (m *SendTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendTransactionRequest.Unmarshal(m, b)
+}
+func (m *SendTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *SendTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendTransactionRequest.Merge(m, src)
+}
+func (m *SendTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_SendTransactionRequest.Size(m)
+}
+func (m *SendTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendTransactionRequest proto.InternalMessageInfo
+
+func (m *SendTransactionRequest) GetHex() string {
+	if m != nil {
+		return m.Hex
+	}
+	return ""
+}
+
+func (m *SendTransactionRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type SendTransactionResponse struct {
+	Txid                 string   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Success              bool     `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendTransactionResponse) Reset()         { *m = SendTransactionResponse{} }
+func (m *SendTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*SendTransactionResponse) ProtoMessage()    {}
+func (*SendTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{49}
+}
+func (m *SendTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendTransactionResponse.Unmarshal(m, b)
+}
+func (m *SendTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *SendTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendTransactionResponse.Merge(m, src)
+}
+func (m *SendTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_SendTransactionResponse.Size(m)
+}
+func (m *SendTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendTransactionResponse proto.InternalMessageInfo
+
+func (m *SendTransactionResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *SendTransactionResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *SendTransactionResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type Peer struct {
+	PeerId               string            `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	RemoteAddr           string            `protobuf:"bytes,2,opt,name=remote_addr,json=remoteAddr,proto3" json:"remote_addr,omitempty"`
+	Height               uint64            `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Ping                 string            `protobuf:"bytes,4,opt,name=ping,proto3" json:"ping,omitempty"`
+	Duration             string            `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	BanScore             uint64            `protobuf:"varint,6,opt,name=ban_score,json=banScore,proto3" json:"ban_score,omitempty"`
+	TotalSent            int64             `protobuf:"varint,7,opt,name=total_sent,json=totalSent,proto3" json:"total_sent,omitempty"`
+	TotalReceived        int64             `protobuf:"varint,8,opt,name=total_received,json=totalReceived,proto3" json:"total_received,omitempty"`
+	AverageSentRate      int64             `protobuf:"varint,9,opt,name=average_sent_rate,json=averageSentRate,proto3" json:"average_sent_rate,omitempty"`
+	AverageReceivedRate  int64             `protobuf:"varint,10,opt,name=average_received_rate,json=averageReceivedRate,proto3" json:"average_received_rate,omitempty"`
+	CurrentSentRate      int64             `protobuf:"varint,11,opt,name=current_sent_rate,json=currentSentRate,proto3" json:"current_sent_rate,omitempty"`
+	CurrentReceivedRate  int64             `protobuf:"varint,12,opt,name=current_received_rate,json=currentReceivedRate,proto3" json:"current_received_rate,omitempty"`
+	Channels             []*PeerChannel    `protobuf:"bytes,13,rep,name=channels,proto3" json:"channels,omitempty"`
+	MsgsSent             map[string]uint64 `protobuf:"bytes,14,rep,name=msgs_sent,json=msgsSent,proto3" json:"msgs_sent,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	MsgsReceived         map[string]uint64 `protobuf:"bytes,15,rep,name=msgs_received,json=msgsReceived,proto3" json:"msgs_received,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Peer) Reset()         { *m = Peer{} }
+func (m *Peer) String() string { return proto.CompactTextString(m) }
+func (*Peer) ProtoMessage()    {}
+func (*Peer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{50}
+}
+func (m *Peer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Peer.Unmarshal(m, b)
+}
+func (m *Peer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Peer.Marshal(b, m, deterministic)
+}
+func (m *Peer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Peer.Merge(m, src)
+}
+func (m *Peer) XXX_Size() int {
+	return xxx_messageInfo_Peer.Size(m)
+}
+func (m *Peer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Peer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Peer proto.InternalMessageInfo
+
+func (m *Peer) GetPeerId() string {
+	if m != nil {
+		return m.PeerId
+	}
+	return ""
+}
+
+func (m *Peer) GetRemoteAddr() string {
+	if m != nil {
+		return m.RemoteAddr
+	}
+	return ""
+}
+
+func (m *Peer) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Peer) GetPing() string {
+	if m != nil {
+		return m.Ping
+	}
+	return ""
+}
+
+func (m *Peer) GetDuration() string {
+	if m != nil {
+		return m.Duration
+	}
+	return ""
+}
+
+func (m *Peer) GetBanScore() uint64 {
+	if m != nil {
+		return m.BanScore
+	}
+	return 0
+}
+
+func (m *Peer) GetTotalSent() int64 {
+	if m != nil {
+		return m.TotalSent
+	}
+	return 0
+}
+
+func (m *Peer) GetTotalReceived() int64 {
+	if m != nil {
+		return m.TotalReceived
+	}
+	return 0
+}
+
+func (m *Peer) GetAverageSentRate() int64 {
+	if m != nil {
+		return m.AverageSentRate
+	}
+	return 0
+}
+
+func (m *Peer) GetAverageReceivedRate() int64 {
+	if m != nil {
+		return m.AverageReceivedRate
+	}
+	return 0
+}
+
+func (m *Peer) GetCurrentSentRate() int64 {
+	if m != nil {
+		return m.CurrentSentRate
+	}
+	return 0
+}
+
+func (m *Peer) GetCurrentReceivedRate() int64 {
+	if m != nil {
+		return m.CurrentReceivedRate
+	}
+	return 0
+}
+
+func (m *Peer) GetChannels() []*PeerChannel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *Peer) GetMsgsSent() map[string]uint64 {
+	if m != nil {
+		return m.MsgsSent
+	}
+	return nil
+}
+
+func (m *Peer) GetMsgsReceived() map[string]uint64 {
+	if m != nil {
+		return m.MsgsReceived
+	}
+	return nil
+}
+
+type PeerChannel struct {
+	ChannelId            uint32   `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	SentBytes            uint64   `protobuf:"varint,2,opt,name=sent_bytes,json=sentBytes,proto3" json:"sent_bytes,omitempty"`
+	ReceivedBytes        uint64   `protobuf:"varint,3,opt,name=received_bytes,json=receivedBytes,proto3" json:"received_bytes,omitempty"`
+	SentMessages         uint64   `protobuf:"varint,4,opt,name=sent_messages,json=sentMessages,proto3" json:"sent_messages,omitempty"`
+	ReceivedMessages     uint64   `protobuf:"varint,5,opt,name=received_messages,json=receivedMessages,proto3" json:"received_messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerChannel) Reset()         { *m = PeerChannel{} }
+func (m *PeerChannel) String() string { return proto.CompactTextString(m) }
+func (*PeerChannel) ProtoMessage()    {}
+func (*PeerChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{51}
+}
+func (m *PeerChannel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerChannel.Unmarshal(m, b)
+}
+func (m *PeerChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerChannel.Marshal(b, m, deterministic)
+}
+func (m *PeerChannel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerChannel.Merge(m, src)
+}
+func (m *PeerChannel) XXX_Size() int {
+	return xxx_messageInfo_PeerChannel.Size(m)
+}
+func (m *PeerChannel) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerChannel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerChannel proto.InternalMessageInfo
+
+func (m *PeerChannel) GetChannelId() uint32 {
+	if m != nil {
+		return m.ChannelId
+	}
+	return 0
+}
+
+func (m *PeerChannel) GetSentBytes() uint64 {
+	if m != nil {
+		return m.SentBytes
+	}
+	return 0
+}
+
+func (m *PeerChannel) GetReceivedBytes() uint64 {
+	if m != nil {
+		return m.ReceivedBytes
+	}
+	return 0
+}
+
+func (m *PeerChannel) GetSentMessages() uint64 {
+	if m != nil {
+		return m.SentMessages
+	}
+	return 0
+}
+
+func (m *PeerChannel) GetReceivedMessages() uint64 {
+	if m != nil {
+		return m.ReceivedMessages
+	}
+	return 0
+}
+
+type GetPeersResponse struct {
+	Peers                []*Peer  `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPeersResponse) Reset()         { *m = GetPeersResponse{} }
+func (m *GetPeersResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPeersResponse) ProtoMessage()    {}
+func (*GetPeersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{52}
+}
+func (m *GetPeersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPeersResponse.Unmarshal(m, b)
+}
+func (m *GetPeersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPeersResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPeersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPeersResponse.Merge(m, src)
+}
+func (m *GetPeersResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPeersResponse.Size(m)
+}
+func (m *GetPeersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPeersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPeersResponse proto.InternalMessageInfo
+
+func (m *GetPeersResponse) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func (m *GetPeersResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ConnectPeerRequest struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectPeerRequest) Reset()         { *m = ConnectPeerRequest{} }
+func (m *ConnectPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*ConnectPeerRequest) ProtoMessage()    {}
+func (*ConnectPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{53}
+}
+func (m *ConnectPeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectPeerRequest.Unmarshal(m, b)
+}
+func (m *ConnectPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectPeerRequest.Marshal(b, m, deterministic)
+}
+func (m *ConnectPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectPeerRequest.Merge(m, src)
+}
+func (m *ConnectPeerRequest) XXX_Size() int {
+	return xxx_messageInfo_ConnectPeerRequest.Size(m)
+}
+func (m *ConnectPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectPeerRequest proto.InternalMessageInfo
+
+func (m *ConnectPeerRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type DisconnectPeerRequest struct {
+	PeerId               string   `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisconnectPeerRequest) Reset()         { *m = DisconnectPeerRequest{} }
+func (m *DisconnectPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*DisconnectPeerRequest) ProtoMessage()    {}
+func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{54}
+}
+func (m *DisconnectPeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisconnectPeerRequest.Unmarshal(m, b)
+}
+func (m *DisconnectPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisconnectPeerRequest.Marshal(b, m, deterministic)
+}
+func (m *DisconnectPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisconnectPeerRequest.Merge(m, src)
+}
+func (m *DisconnectPeerRequest) XXX_Size() int {
+	return xxx_messageInfo_DisconnectPeerRequest.Size(m)
+}
+func (m *DisconnectPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisconnectPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisconnectPeerRequest proto.InternalMessageInfo
+
+func (m *DisconnectPeerRequest) GetPeerId() string {
+	if m != nil {
+		return m.PeerId
+	}
+	return ""
+}
+
+func (m *DisconnectPeerRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type BanPeerRequest struct {
+	Ip                   string   `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Duration             string   `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BanPeerRequest) Reset()         { *m = BanPeerRequest{} }
+func (m *BanPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*BanPeerRequest) ProtoMessage()    {}
+func (*BanPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{55}
+}
+func (m *BanPeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanPeerRequest.Unmarshal(m, b)
+}
+func (m *BanPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanPeerRequest.Marshal(b, m, deterministic)
+}
+func (m *BanPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanPeerRequest.Merge(m, src)
+}
+func (m *BanPeerRequest) XXX_Size() int {
+	return xxx_messageInfo_BanPeerRequest.Size(m)
+}
+func (m *BanPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanPeerRequest proto.InternalMessageInfo
+
+func (m *BanPeerRequest) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *BanPeerRequest) GetDuration() string {
+	if m != nil {
+		return m.Duration
+	}
+	return ""
+}
+
+type BannedPeer struct {
+	Ip                   string   `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	BannedUntil          int64    `protobuf:"varint,2,opt,name=banned_until,json=bannedUntil,proto3" json:"banned_until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BannedPeer) Reset()         { *m = BannedPeer{} }
+func (m *BannedPeer) String() string { return proto.CompactTextString(m) }
+func (*BannedPeer) ProtoMessage()    {}
+func (*BannedPeer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{56}
+}
+func (m *BannedPeer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BannedPeer.Unmarshal(m, b)
+}
+func (m *BannedPeer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BannedPeer.Marshal(b, m, deterministic)
+}
+func (m *BannedPeer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BannedPeer.Merge(m, src)
+}
+func (m *BannedPeer) XXX_Size() int {
+	return xxx_messageInfo_BannedPeer.Size(m)
+}
+func (m *BannedPeer) XXX_DiscardUnknown() {
+	xxx_messageInfo_BannedPeer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BannedPeer proto.InternalMessageInfo
+
+func (m *BannedPeer) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *BannedPeer) GetBannedUntil() int64 {
+	if m != nil {
+		return m.BannedUntil
+	}
+	return 0
+}
+
+type UnbanPeerRequest struct {
+	Ip                   string   `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnbanPeerRequest) Reset()         { *m = UnbanPeerRequest{} }
+func (m *UnbanPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*UnbanPeerRequest) ProtoMessage()    {}
+func (*UnbanPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{57}
+}
+func (m *UnbanPeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnbanPeerRequest.Unmarshal(m, b)
+}
+func (m *UnbanPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnbanPeerRequest.Marshal(b, m, deterministic)
+}
+func (m *UnbanPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnbanPeerRequest.Merge(m, src)
+}
+func (m *UnbanPeerRequest) XXX_Size() int {
+	return xxx_messageInfo_UnbanPeerRequest.Size(m)
+}
+func (m *UnbanPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnbanPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnbanPeerRequest proto.InternalMessageInfo
+
+func (m *UnbanPeerRequest) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+type ListBannedPeersResponse struct {
+	BannedPeers          []*BannedPeer `protobuf:"bytes,1,rep,name=banned_peers,json=bannedPeers,proto3" json:"banned_peers,omitempty"`
+	NextPageToken        string        `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListBannedPeersResponse) Reset()         { *m = ListBannedPeersResponse{} }
+func (m *ListBannedPeersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBannedPeersResponse) ProtoMessage()    {}
+func (*ListBannedPeersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{58}
+}
+func (m *ListBannedPeersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBannedPeersResponse.Unmarshal(m, b)
+}
+func (m *ListBannedPeersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBannedPeersResponse.Marshal(b, m, deterministic)
+}
+func (m *ListBannedPeersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBannedPeersResponse.Merge(m, src)
+}
+func (m *ListBannedPeersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBannedPeersResponse.Size(m)
+}
+func (m *ListBannedPeersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBannedPeersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBannedPeersResponse proto.InternalMessageInfo
+
+func (m *ListBannedPeersResponse) GetBannedPeers() []*BannedPeer {
+	if m != nil {
+		return m.BannedPeers
+	}
+	return nil
+}
+
+func (m *ListBannedPeersResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type NetTotals struct {
+	TotalSent            int64    `protobuf:"varint,1,opt,name=total_sent,json=totalSent,proto3" json:"total_sent,omitempty"`
+	TotalReceived        int64    `protobuf:"varint,2,opt,name=total_received,json=totalReceived,proto3" json:"total_received,omitempty"`
+	CurrentSentRate      int64    `protobuf:"varint,3,opt,name=current_sent_rate,json=currentSentRate,proto3" json:"current_sent_rate,omitempty"`
+	CurrentReceivedRate  int64    `protobuf:"varint,4,opt,name=current_received_rate,json=currentReceivedRate,proto3" json:"current_received_rate,omitempty"`
+	SendRateLimit        int64    `protobuf:"varint,5,opt,name=send_rate_limit,json=sendRateLimit,proto3" json:"send_rate_limit,omitempty"`
+	RecvRateLimit        int64    `protobuf:"varint,6,opt,name=recv_rate_limit,json=recvRateLimit,proto3" json:"recv_rate_limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NetTotals) Reset()         { *m = NetTotals{} }
+func (m *NetTotals) String() string { return proto.CompactTextString(m) }
+func (*NetTotals) ProtoMessage()    {}
+func (*NetTotals) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{59}
+}
+func (m *NetTotals) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetTotals.Unmarshal(m, b)
+}
+func (m *NetTotals) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetTotals.Marshal(b, m, deterministic)
+}
+func (m *NetTotals) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetTotals.Merge(m, src)
+}
+func (m *NetTotals) XXX_Size() int {
+	return xxx_messageInfo_NetTotals.Size(m)
+}
+func (m *NetTotals) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetTotals.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetTotals proto.InternalMessageInfo
+
+func (m *NetTotals) GetTotalSent() int64 {
+	if m != nil {
+		return m.TotalSent
+	}
+	return 0
+}
+
+func (m *NetTotals) GetTotalReceived() int64 {
+	if m != nil {
+		return m.TotalReceived
+	}
+	return 0
+}
+
+func (m *NetTotals) GetCurrentSentRate() int64 {
+	if m != nil {
+		return m.CurrentSentRate
+	}
+	return 0
+}
+
+func (m *NetTotals) GetCurrentReceivedRate() int64 {
+	if m != nil {
+		return m.CurrentReceivedRate
+	}
+	return 0
+}
+
+func (m *NetTotals) GetSendRateLimit() int64 {
+	if m != nil {
+		return m.SendRateLimit
+	}
+	return 0
+}
+
+func (m *NetTotals) GetRecvRateLimit() int64 {
+	if m != nil {
+		return m.RecvRateLimit
+	}
+	return 0
+}
+
+type GetClientStatusResponse struct {
+	LocalBestHeight      uint64   `protobuf:"varint,1,opt,name=local_best_height,json=localBestHeight,proto3" json:"local_best_height,omitempty"`
+	KnownBestHeight      uint64   `protobuf:"varint,2,opt,name=known_best_height,json=knownBestHeight,proto3" json:"known_best_height,omitempty"`
+	Mining               bool     `protobuf:"varint,3,opt,name=mining,proto3" json:"mining,omitempty"`
+	PeerListening        bool     `protobuf:"varint,4,opt,name=peer_listening,json=peerListening,proto3" json:"peer_listening,omitempty"`
+	OutboundPeers        uint32   `protobuf:"varint,5,opt,name=outbound_peers,json=outboundPeers,proto3" json:"outbound_peers,omitempty"`
+	InboundPeers         uint32   `protobuf:"varint,6,opt,name=inbound_peers,json=inboundPeers,proto3" json:"inbound_peers,omitempty"`
+	IsCaughtUp           bool     `protobuf:"varint,7,opt,name=is_caught_up,json=isCaughtUp,proto3" json:"is_caught_up,omitempty"`
+	SyncProgress         float64  `protobuf:"fixed64,8,opt,name=sync_progress,json=syncProgress,proto3" json:"sync_progress,omitempty"`
+	MempoolSize          uint64   `protobuf:"varint,9,opt,name=mempool_size,json=mempoolSize,proto3" json:"mempool_size,omitempty"`
+	MempoolBytes         uint64   `protobuf:"varint,10,opt,name=mempool_bytes,json=mempoolBytes,proto3" json:"mempool_bytes,omitempty"`
+	ChainId              string   `protobuf:"bytes,11,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Version              string   `protobuf:"bytes,12,opt,name=version,proto3" json:"version,omitempty"`
+	Uptime               int64    `protobuf:"varint,13,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClientStatusResponse) Reset()         { *m = GetClientStatusResponse{} }
+func (m *GetClientStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetClientStatusResponse) ProtoMessage()    {}
+func (*GetClientStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{60}
+}
+func (m *GetClientStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientStatusResponse.Unmarshal(m, b)
+}
+func (m *GetClientStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetClientStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientStatusResponse.Merge(m, src)
+}
+func (m *GetClientStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetClientStatusResponse.Size(m)
+}
+func (m *GetClientStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientStatusResponse proto.InternalMessageInfo
+
+func (m *GetClientStatusResponse) GetLocalBestHeight() uint64 {
+	if m != nil {
+		return m.LocalBestHeight
+	}
+	return 0
+}
+
+func (m *GetClientStatusResponse) GetKnownBestHeight() uint64 {
+	if m != nil {
+		return m.KnownBestHeight
+	}
+	return 0
+}
+
+func (m *GetClientStatusResponse) GetMining() bool {
+	if m != nil {
+		return m.Mining
+	}
+	return false
+}
+
+func (m *GetClientStatusResponse) GetPeerListening() bool {
+	if m != nil {
+		return m.PeerListening
+	}
+	return false
+}
+
+func (m *GetClientStatusResponse) GetOutboundPeers() uint32 {
+	if m != nil {
+		return m.OutboundPeers
+	}
+	return 0
+}
+
+func (m *GetClientStatusResponse) GetInboundPeers() uint32 {
+	if m != nil {
+		return m.InboundPeers
+	}
+	return 0
+}
+
+func (m *GetClientStatusResponse) GetIsCaughtUp() bool {
+	if m != nil {
+		return m.IsCaughtUp
+	}
+	return false
+}
+
+func (m *GetClientStatusResponse) GetSyncProgress() float64 {
+	if m != nil {
+		return m.SyncProgress
+	}
+	return 0
+}
+
+func (m *GetClientStatusResponse) GetMempoolSize() uint64 {
+	if m != nil {
+		return m.MempoolSize
+	}
+	return 0
+}
+
+func (m *GetClientStatusResponse) GetMempoolBytes() uint64 {
+	if m != nil {
+		return m.MempoolBytes
+	}
+	return 0
+}
+
+func (m *GetClientStatusResponse) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func (m *GetClientStatusResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GetClientStatusResponse) GetUptime() int64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+type ErrorDetail struct {
+	Reason               string            `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Detail               string            `protobuf:"bytes,2,opt,name=detail,proto3" json:"detail,omitempty"`
+	Data                 map[string]string `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ErrorDetail) Reset()         { *m = ErrorDetail{} }
+func (m *ErrorDetail) String() string { return proto.CompactTextString(m) }
+func (*ErrorDetail) ProtoMessage()    {}
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{61}
+}
+func (m *ErrorDetail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErrorDetail.Unmarshal(m, b)
+}
+func (m *ErrorDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErrorDetail.Marshal(b, m, deterministic)
+}
+func (m *ErrorDetail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorDetail.Merge(m, src)
+}
+func (m *ErrorDetail) XXX_Size() int {
+	return xxx_messageInfo_ErrorDetail.Size(m)
+}
+func (m *ErrorDetail) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorDetail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErrorDetail proto.InternalMessageInfo
+
+func (m *ErrorDetail) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ErrorDetail) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+func (m *ErrorDetail) GetData() map[string]string {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("api.BlockNotification_Type", BlockNotification_Type_name, BlockNotification_Type_value)
+	proto.RegisterEnum("api.TxNotification_Type", TxNotification_Type_name, TxNotification_Type_value)
+	proto.RegisterType((*PageRequest)(nil), "api.PageRequest")
+	proto.RegisterType((*GetBestBlockResponse)(nil), "api.GetBestBlockResponse")
+	proto.RegisterType((*Proof)(nil), "api.Proof")
+	proto.RegisterType((*GetBlockRequest)(nil), "api.GetBlockRequest")
+	proto.RegisterType((*GetBlockResponse)(nil), "api.GetBlockResponse")
+	proto.RegisterType((*GetBlockHeaderRequest)(nil), "api.GetBlockHeaderRequest")
+	proto.RegisterType((*GetBlockHeaderResponse)(nil), "api.GetBlockHeaderResponse")
+	proto.RegisterType((*GetBlockFilterRequest)(nil), "api.GetBlockFilterRequest")
+	proto.RegisterType((*GetBlockFilterResponse)(nil), "api.GetBlockFilterResponse")
+	proto.RegisterType((*GetBlockVerboseRequest)(nil), "api.GetBlockVerboseRequest")
+	proto.RegisterType((*GetBlockVerboseV0Response)(nil), "api.GetBlockVerboseV0Response")
+	proto.RegisterType((*GetBlockVerboseV0Response_Transaction)(nil), "api.GetBlockVerboseV0Response.Transaction")
+	proto.RegisterType((*GetBlockVerboseV1Response)(nil), "api.GetBlockVerboseV1Response")
+	proto.RegisterType((*ListBlocksRequest)(nil), "api.ListBlocksRequest")
+	proto.RegisterType((*ListBlocksResponse)(nil), "api.ListBlocksResponse")
+	proto.RegisterType((*Tx)(nil), "api.Tx")
+	proto.RegisterType((*Tx_TxIn)(nil), "api.Tx.TxIn")
+	proto.RegisterType((*Tx_TxIn_ValueSource)(nil), "api.Tx.TxIn.ValueSource")
+	proto.RegisterType((*Tx_TxOut)(nil), "api.Tx.TxOut")
+	proto.RegisterType((*Evidence)(nil), "api.Evidence")
+	proto.RegisterType((*BlockNotification)(nil), "api.BlockNotification")
+	proto.RegisterType((*TxNotification)(nil), "api.TxNotification")
+	proto.RegisterType((*SubscribeEvidencesRequest)(nil), "api.SubscribeEvidencesRequest")
+	proto.RegisterType((*EvidenceNotification)(nil), "api.EvidenceNotification")
+	proto.RegisterType((*GetTransactionRequest)(nil), "api.GetTransactionRequest")
+	proto.RegisterType((*GetTransactionResponse)(nil), "api.GetTransactionResponse")
+	proto.RegisterType((*GetTransactionResponse_TxIn)(nil), "api.GetTransactionResponse.TxIn")
+	proto.RegisterType((*GetTransactionResponse_TxIn_ValueSource)(nil), "api.GetTransactionResponse.TxIn.ValueSource")
+	proto.RegisterType((*GetTransactionResponse_TxOut)(nil), "api.GetTransactionResponse.TxOut")
+	proto.RegisterType((*GetEvidenceRequest)(nil), "api.GetEvidenceRequest")
+	proto.RegisterType((*GetEvidenceResponse)(nil), "api.GetEvidenceResponse")
+	proto.RegisterType((*RawTransactionRequest)(nil), "api.RawTransactionRequest")
+	proto.RegisterType((*RawBlockRequest)(nil), "api.RawBlockRequest")
+	proto.RegisterType((*SubmitRawTransactionResponse)(nil), "api.SubmitRawTransactionResponse")
+	proto.RegisterType((*TestMempoolAcceptResponse)(nil), "api.TestMempoolAcceptResponse")
+	proto.RegisterType((*GetTransactionsRequest)(nil), "api.GetTransactionsRequest")
+	proto.RegisterType((*GetTransactionsResponse)(nil), "api.GetTransactionsResponse")
+	proto.RegisterType((*GetEvidencesRequest)(nil), "api.GetEvidencesRequest")
+	proto.RegisterType((*GetEvidencesResponse)(nil), "api.GetEvidencesResponse")
+	proto.RegisterType((*GetWorkResponse)(nil), "api.GetWorkResponse")
+	proto.RegisterType((*SubmitWorkRequest)(nil), "api.SubmitWorkRequest")
+	proto.RegisterType((*SubmitWorkResponse)(nil), "api.SubmitWorkResponse")
+	proto.RegisterType((*SetMiningWorkersRequest)(nil), "api.SetMiningWorkersRequest")
+	proto.RegisterType((*MiningStatusResponse)(nil), "api.MiningStatusResponse")
+	proto.RegisterType((*WorkerStats)(nil), "api.WorkerStats")
+	proto.RegisterType((*GetMiningStatsResponse)(nil), "api.GetMiningStatsResponse")
+	proto.RegisterType((*GetWalletStatusResponse)(nil), "api.GetWalletStatusResponse")
+	proto.RegisterType((*GetWalletAddressesResponse)(nil), "api.GetWalletAddressesResponse")
+	proto.RegisterType((*GetWalletAddressesResponse_Address)(nil), "api.GetWalletAddressesResponse.Address")
+	proto.RegisterType((*GetWalletBalanceResponse)(nil), "api.GetWalletBalanceResponse")
+	proto.RegisterType((*GetWalletTransactionsResponse)(nil), "api.GetWalletTransactionsResponse")
+	proto.RegisterType((*GetWalletEvidencesResponse)(nil), "api.GetWalletEvidencesResponse")
+	proto.RegisterType((*CreateAddressRequest)(nil), "api.CreateAddressRequest")
+	proto.RegisterType((*CreateAddressResponse)(nil), "api.CreateAddressResponse")
+	proto.RegisterType((*CreateTransactionRequest)(nil), "api.CreateTransactionRequest")
+	proto.RegisterType((*CreateTransactionResponse)(nil), "api.CreateTransactionResponse")
+	proto.RegisterType((*SendTransactionRequest)(nil), "api.SendTransactionRequest")
+	proto.RegisterType((*SendTransactionResponse)(nil), "api.SendTransactionResponse")
+	proto.RegisterType((*Peer)(nil), "api.Peer")
+	proto.RegisterMapType((map[string]uint64)(nil), "api.Peer.MsgsReceivedEntry")
+	proto.RegisterMapType((map[string]uint64)(nil), "api.Peer.MsgsSentEntry")
+	proto.RegisterType((*PeerChannel)(nil), "api.PeerChannel")
+	proto.RegisterType((*GetPeersResponse)(nil), "api.GetPeersResponse")
+	proto.RegisterType((*ConnectPeerRequest)(nil), "api.ConnectPeerRequest")
+	proto.RegisterType((*DisconnectPeerRequest)(nil), "api.DisconnectPeerRequest")
+	proto.RegisterType((*BanPeerRequest)(nil), "api.BanPeerRequest")
+	proto.RegisterType((*BannedPeer)(nil), "api.BannedPeer")
+	proto.RegisterType((*UnbanPeerRequest)(nil), "api.UnbanPeerRequest")
+	proto.RegisterType((*ListBannedPeersResponse)(nil), "api.ListBannedPeersResponse")
+	proto.RegisterType((*NetTotals)(nil), "api.NetTotals")
+	proto.RegisterType((*GetClientStatusResponse)(nil), "api.GetClientStatusResponse")
+	proto.RegisterType((*ErrorDetail)(nil), "api.ErrorDetail")
+	proto.RegisterMapType((map[string]string)(nil), "api.ErrorDetail.DataEntry")
+}
+
+func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
+
+var fileDescriptor_00212fb1f9d3bf1c = []byte{
+	// 3885 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0xcd, 0x73, 0x1c, 0x49,
+	0x56, 0xa7, 0x3f, 0x24, 0x75, 0xbf, 0xee, 0x56, 0x4b, 0x69, 0x7d, 0xb4, 0x5a, 0xb2, 0x2d, 0xe7,
+	0xcc, 0x8e, 0xbd, 0xf6, 0xd0, 0xb2, 0xc5, 0xb2, 0xbb, 0x31, 0x03, 0xcc, 0x8e, 0x3f, 0xc6, 0xf6,
+	0xc6, 0xd8, 0x16, 0x25, 0x8d, 0x17, 0x66, 0x58, 0x2a, 0xaa, 0xbb, 0xd3, 0x52, 0xd9, 0xdd, 0x55,
+	0xbd, 0x95, 0xd9, 0xfa, 0xd8, 0x61, 0x0e, 0x4b, 0xf0, 0x1f, 0x70, 0x21, 0x38, 0x42, 0x04, 0x17,
+	0xfe, 0x00, 0x4e, 0x44, 0x70, 0xe0, 0xc4, 0x85, 0xc3, 0x5e, 0xe0, 0x44, 0x04, 0xc1, 0x1f, 0xc0,
+	0x09, 0x8e, 0x10, 0xf9, 0x32, 0xb3, 0x2a, 0xab, 0xba, 0xaa, 0x65, 0x2f, 0xc1, 0x69, 0xe7, 0x56,
+	0xf9, 0xf2, 0xe5, 0x7b, 0xf9, 0xf2, 0xbd, 0xfc, 0xe5, 0xcb, 0x97, 0x05, 0x75, 0x6f, 0xe2, 0xf7,
+	0x26, 0x51, 0x28, 0x42, 0x52, 0xf1, 0x26, 0x7e, 0x77, 0xe7, 0x38, 0x0c, 0x8f, 0x47, 0x6c, 0xcf,
+	0x9b, 0xf8, 0x7b, 0x5e, 0x10, 0x84, 0xc2, 0x13, 0x7e, 0x18, 0x70, 0xc5, 0xd2, 0xdd, 0xd6, 0xbd,
+	0xd8, 0xea, 0x4f, 0x5f, 0xed, 0xb1, 0xf1, 0x44, 0x5c, 0xa8, 0x4e, 0xfa, 0x14, 0x1a, 0x07, 0xde,
+	0x31, 0x73, 0xd8, 0xcf, 0xa6, 0x8c, 0x0b, 0xb2, 0x0d, 0xf5, 0x89, 0x77, 0xcc, 0x5c, 0xee, 0xff,
+	0x9c, 0x75, 0x4a, 0xbb, 0xa5, 0x5b, 0x2d, 0xa7, 0x26, 0x09, 0x87, 0xfe, 0xcf, 0x19, 0xb9, 0x0a,
+	0x80, 0x9d, 0x22, 0x7c, 0xc3, 0x82, 0x4e, 0x79, 0xb7, 0x74, 0xab, 0xee, 0x20, 0xfb, 0x91, 0x24,
+	0xd0, 0xfb, 0xb0, 0xf6, 0x98, 0x89, 0xfb, 0x8c, 0x8b, 0xfb, 0xa3, 0x70, 0xf0, 0xc6, 0x61, 0x7c,
+	0x12, 0x06, 0x9c, 0x91, 0x0d, 0x58, 0x3c, 0x61, 0xfe, 0xf1, 0x89, 0x40, 0x81, 0x55, 0x47, 0xb7,
+	0x08, 0x81, 0xea, 0x89, 0xc7, 0x4f, 0xb4, 0x20, 0xfc, 0xa6, 0x7f, 0x51, 0x82, 0x85, 0x83, 0x28,
+	0x0c, 0x5f, 0xc9, 0x51, 0xc2, 0x8b, 0x8e, 0x59, 0x3c, 0x4a, 0xb5, 0xc8, 0x1a, 0x2c, 0x04, 0x61,
+	0x30, 0x60, 0x38, 0xac, 0xea, 0xa8, 0x86, 0x94, 0x25, 0x2e, 0x26, 0xac, 0x53, 0x51, 0xb2, 0xe4,
+	0xb7, 0x94, 0xc0, 0xfd, 0xe3, 0x80, 0x45, 0x9d, 0x2a, 0x1a, 0xa2, 0x5b, 0x64, 0x13, 0x96, 0xfc,
+	0xc0, 0x15, 0xd3, 0x28, 0xe8, 0x2c, 0xec, 0x96, 0x6e, 0xd5, 0x9c, 0x45, 0x3f, 0x38, 0x9a, 0x46,
+	0x01, 0xd9, 0x81, 0xba, 0x64, 0xf1, 0xc4, 0x34, 0x62, 0x9d, 0x45, 0x65, 0x5e, 0x4c, 0xa0, 0x37,
+	0xa0, 0xfd, 0x98, 0x19, 0xd3, 0xd4, 0x6a, 0x2d, 0x43, 0xd9, 0x1f, 0xe2, 0xfc, 0xea, 0x4e, 0xd9,
+	0x1f, 0xd2, 0x7f, 0x2b, 0xc3, 0xca, 0x63, 0x96, 0x31, 0xdf, 0x98, 0x59, 0x4a, 0xcc, 0x24, 0x5b,
+	0x50, 0x1b, 0x9c, 0x78, 0x7e, 0xe0, 0xfa, 0x43, 0x6d, 0xfe, 0x12, 0xb6, 0x9f, 0x0e, 0x49, 0x07,
+	0x96, 0x4e, 0x59, 0xc4, 0xfd, 0x30, 0x40, 0x63, 0xaa, 0x8e, 0x69, 0x5a, 0xeb, 0x58, 0x4d, 0xad,
+	0xe3, 0x0e, 0xd4, 0x85, 0x3f, 0x66, 0x5c, 0x78, 0xe3, 0x09, 0x5a, 0x54, 0x75, 0x12, 0x02, 0xe9,
+	0x42, 0x6d, 0x12, 0xb1, 0x53, 0x3f, 0x9c, 0x72, 0x6d, 0x53, 0xdc, 0x26, 0xdf, 0x85, 0x15, 0x11,
+	0x79, 0x01, 0xf7, 0x06, 0x32, 0x5e, 0xdc, 0x28, 0x0c, 0x45, 0x67, 0x09, 0x79, 0xda, 0x16, 0xdd,
+	0x09, 0x43, 0x41, 0x6e, 0x40, 0xf3, 0xcc, 0x17, 0x01, 0xe3, 0x5c, 0xb1, 0xd5, 0x90, 0xad, 0xa1,
+	0x69, 0xc8, 0xb2, 0x0b, 0x0b, 0x13, 0xe9, 0xba, 0x4e, 0x7d, 0xb7, 0x74, 0xab, 0xb1, 0x0f, 0x3d,
+	0x19, 0xa5, 0xe8, 0x4c, 0x47, 0x75, 0x10, 0x0a, 0x4d, 0x4b, 0x2e, 0xef, 0xc0, 0x6e, 0xe5, 0x56,
+	0xdd, 0x49, 0xd1, 0xa4, 0x35, 0xec, 0xd4, 0x1f, 0xb2, 0x60, 0xc0, 0x78, 0xa7, 0x81, 0x0c, 0x09,
+	0x81, 0xde, 0x84, 0x75, 0xb3, 0xc0, 0x4f, 0x98, 0x37, 0x64, 0x51, 0x91, 0x2b, 0xfe, 0xba, 0x0c,
+	0x1b, 0x59, 0xce, 0x6f, 0x1d, 0x92, 0x71, 0x88, 0xbd, 0x9c, 0x9f, 0xf9, 0x23, 0x51, 0xbc, 0x9c,
+	0xbf, 0x28, 0xc1, 0x46, 0x96, 0x73, 0xce, 0x72, 0x26, 0x2b, 0x53, 0x4e, 0xad, 0xcc, 0x06, 0x2c,
+	0xbe, 0xc2, 0xd1, 0x7a, 0xa3, 0xea, 0x16, 0x79, 0x0f, 0x5a, 0xea, 0xcb, 0x3d, 0x41, 0x5f, 0xe1,
+	0x82, 0xd6, 0x9d, 0xa6, 0x22, 0x2a, 0xff, 0xd1, 0x5b, 0xc9, 0x14, 0x5e, 0xb2, 0xa8, 0x1f, 0x72,
+	0x56, 0x34, 0xdb, 0xbf, 0xab, 0xc0, 0x56, 0x86, 0xf5, 0xe5, 0xdd, 0x6f, 0xfd, 0x3f, 0xbb, 0x21,
+	0x9f, 0xe7, 0x6c, 0xc8, 0xc6, 0xfe, 0x6d, 0x64, 0x2c, 0x5c, 0xc0, 0xde, 0x91, 0x35, 0x95, 0xd4,
+	0xf8, 0xee, 0x27, 0xd0, 0xb0, 0x3a, 0x11, 0x95, 0xcf, 0x63, 0xcf, 0xe0, 0x77, 0x7a, 0x7f, 0x97,
+	0xb3, 0xfb, 0xfb, 0x97, 0xe5, 0x59, 0xcf, 0xdd, 0xfb, 0xd6, 0x73, 0xb3, 0x9e, 0xbb, 0x93, 0xeb,
+	0xb9, 0x25, 0x64, 0x3c, 0x3a, 0x4f, 0xbb, 0x85, 0xfe, 0x6d, 0x09, 0x56, 0x3f, 0xf7, 0xf5, 0xb9,
+	0xcc, 0xcd, 0xae, 0xb9, 0x0e, 0x8d, 0x57, 0x51, 0x38, 0x76, 0x53, 0x87, 0x33, 0x48, 0xd2, 0x13,
+	0xb5, 0x1a, 0xdb, 0x50, 0x17, 0xa1, 0x9b, 0xda, 0xc8, 0x35, 0x11, 0x3e, 0x89, 0x97, 0xea, 0x14,
+	0x1d, 0xe4, 0x8b, 0x0b, 0x5c, 0xde, 0x96, 0x93, 0x10, 0xd2, 0x79, 0x44, 0x75, 0x6e, 0x1e, 0xb1,
+	0x90, 0xcd, 0x23, 0xfe, 0xa7, 0x04, 0xc4, 0x9e, 0xad, 0x76, 0xfe, 0x6f, 0xc2, 0x62, 0x1f, 0x29,
+	0x9d, 0x12, 0xda, 0xba, 0x9e, 0x8a, 0x52, 0xc3, 0xe6, 0x68, 0x26, 0xf2, 0x04, 0x56, 0xd4, 0x97,
+	0xab, 0x66, 0xc5, 0xdc, 0xbb, 0x18, 0x6e, 0x8d, 0xfd, 0x6b, 0xf3, 0xc3, 0xdb, 0x59, 0x56, 0xe3,
+	0x74, 0xc7, 0xdd, 0x1c, 0x49, 0xf7, 0x3a, 0x95, 0x39, 0x92, 0xee, 0x15, 0x48, 0xba, 0x47, 0x3e,
+	0x80, 0x76, 0xc0, 0xce, 0x85, 0x6b, 0x59, 0xaf, 0x80, 0xae, 0x25, 0xc9, 0x07, 0xf1, 0x0a, 0xfc,
+	0x67, 0x05, 0xca, 0x47, 0xe7, 0xb9, 0xdb, 0xc7, 0x8a, 0xe9, 0x72, 0x3a, 0xa6, 0xdf, 0x87, 0x45,
+	0x3f, 0x98, 0x4c, 0x05, 0xd7, 0x93, 0x6b, 0xea, 0x58, 0xe8, 0x1d, 0x9d, 0x3f, 0x0d, 0x1c, 0xdd,
+	0x47, 0x6e, 0xc2, 0x52, 0x38, 0x15, 0xc8, 0x56, 0x45, 0xb6, 0x56, 0xc2, 0xf6, 0x62, 0x2a, 0x1c,
+	0xd3, 0x4b, 0xee, 0xd8, 0xfb, 0x74, 0xc1, 0x62, 0x7d, 0xa4, 0xa9, 0xd6, 0xb6, 0x95, 0xee, 0x96,
+	0x96, 0xba, 0x72, 0xaf, 0xe0, 0xd6, 0xa8, 0x3a, 0x35, 0x49, 0x38, 0xf2, 0xc7, 0xac, 0xfb, 0xef,
+	0x25, 0xa8, 0xca, 0x39, 0x90, 0x8f, 0xa1, 0x79, 0xea, 0x8d, 0xa6, 0xcc, 0xe5, 0xe1, 0x34, 0x1a,
+	0xa8, 0xfc, 0xb2, 0xb1, 0xdf, 0xb1, 0xe7, 0xd9, 0x7b, 0x29, 0x19, 0x0e, 0xb1, 0xdf, 0x69, 0x9c,
+	0x26, 0x0d, 0x79, 0x44, 0x44, 0x6c, 0xc8, 0xd8, 0xd8, 0xe5, 0x83, 0xc8, 0x9f, 0x08, 0xbd, 0xd9,
+	0x9b, 0x8a, 0x78, 0x88, 0x34, 0xc9, 0x34, 0x0d, 0x70, 0x26, 0x9a, 0x49, 0x1d, 0x33, 0x4d, 0x45,
+	0xd4, 0x4c, 0x5d, 0xa8, 0x71, 0xb9, 0x05, 0x64, 0x12, 0xa9, 0xb6, 0x7f, 0xdc, 0xee, 0xfe, 0x00,
+	0x1a, 0xd6, 0x0c, 0x72, 0x3d, 0xb0, 0x06, 0x0b, 0x7e, 0x30, 0x64, 0xe7, 0x26, 0x01, 0xc5, 0x46,
+	0xf7, 0xf7, 0x60, 0x01, 0x17, 0x50, 0x76, 0xe3, 0xb4, 0xf5, 0x7e, 0x52, 0x0d, 0xb9, 0xd7, 0xd4,
+	0x8c, 0x5c, 0x2b, 0xe5, 0x05, 0x45, 0x7a, 0x22, 0x13, 0xdf, 0x9f, 0x41, 0xcd, 0x2c, 0xac, 0xd4,
+	0x2a, 0x97, 0xd6, 0x68, 0x95, 0xdf, 0x12, 0xb1, 0x86, 0xfe, 0x31, 0xe3, 0xc6, 0x6e, 0xdd, 0x92,
+	0x74, 0xbd, 0x9a, 0xfa, 0x44, 0x55, 0x2d, 0x09, 0x32, 0xa7, 0xde, 0xc8, 0x1f, 0x9a, 0x85, 0x50,
+	0x71, 0xd6, 0x40, 0x9a, 0x5a, 0x07, 0xfa, 0xdf, 0x25, 0x58, 0xc5, 0xc0, 0x7d, 0x1e, 0x0a, 0xff,
+	0x95, 0x3f, 0xc0, 0x4b, 0x03, 0xd9, 0xd3, 0x99, 0xb4, 0x54, 0xbe, 0xbc, 0xbf, 0x8d, 0xce, 0x99,
+	0xe1, 0xea, 0x1d, 0x5d, 0x4c, 0x98, 0x4e, 0xb3, 0x73, 0xd2, 0x78, 0x0b, 0x5f, 0x2b, 0x29, 0x7c,
+	0xb5, 0x11, 0xb4, 0x9a, 0x41, 0xd0, 0xf9, 0xd8, 0x9b, 0x4d, 0x1d, 0x17, 0x67, 0x53, 0x47, 0x7a,
+	0x13, 0xaa, 0x72, 0x5e, 0xa4, 0x05, 0xf5, 0x07, 0x2f, 0x9e, 0x3f, 0x7f, 0xf4, 0xe0, 0xe8, 0xd1,
+	0xc3, 0x95, 0xdf, 0x20, 0x2b, 0xd0, 0x7c, 0xf8, 0xf4, 0x30, 0xa1, 0x94, 0xe8, 0x19, 0x2c, 0x1f,
+	0x9d, 0xa7, 0xac, 0xfe, 0x30, 0x65, 0xb5, 0x09, 0xc9, 0x22, 0x93, 0x37, 0xa1, 0x2c, 0x94, 0xff,
+	0x2d, 0xc8, 0x2d, 0x8b, 0x73, 0x7a, 0x4d, 0xcf, 0xa0, 0x0e, 0x0b, 0x9f, 0x3e, 0x7c, 0x88, 0xda,
+	0x1b, 0xb0, 0xe4, 0x3c, 0x7a, 0xf6, 0xe2, 0x25, 0x2a, 0x66, 0xb0, 0x75, 0x38, 0xed, 0x4b, 0x97,
+	0xf4, 0x99, 0x71, 0x77, 0x8c, 0xc7, 0x89, 0x8b, 0x4b, 0x05, 0x2e, 0x2e, 0xa7, 0x5c, 0xdc, 0x81,
+	0xa5, 0x31, 0x1b, 0x4f, 0xc2, 0x70, 0x84, 0xab, 0x5c, 0x73, 0x4c, 0x93, 0xfe, 0x55, 0x09, 0xd6,
+	0x8c, 0xf8, 0x94, 0x99, 0x6f, 0x1d, 0xcf, 0x12, 0xa3, 0x11, 0xbc, 0x54, 0xbc, 0xaa, 0xd8, 0xaa,
+	0x23, 0xe5, 0x49, 0xda, 0xc1, 0xe9, 0x03, 0xf4, 0xbb, 0x50, 0x33, 0xa8, 0x80, 0x3e, 0x9c, 0x01,
+	0x8d, 0xb8, 0x9b, 0xde, 0xc1, 0xdc, 0xd3, 0xce, 0x25, 0xf4, 0x3a, 0xe4, 0x4c, 0x92, 0xfe, 0x43,
+	0x15, 0x36, 0xb2, 0xdc, 0x49, 0x52, 0xf0, 0x0e, 0x28, 0xf9, 0xc3, 0x0c, 0x4a, 0xee, 0x1a, 0x08,
+	0xcf, 0x11, 0x9d, 0x46, 0xce, 0x8f, 0xb3, 0xc8, 0x79, 0x63, 0xfe, 0xd0, 0xff, 0x27, 0x34, 0xfd,
+	0x2f, 0x83, 0xa6, 0x2f, 0x72, 0xd1, 0xf4, 0xc3, 0xcb, 0xec, 0xf9, 0xb5, 0x45, 0xd8, 0x5b, 0x40,
+	0x1e, 0x33, 0x11, 0xaf, 0x77, 0x12, 0x6c, 0x59, 0xac, 0xa5, 0x7f, 0x53, 0x82, 0x2b, 0x29, 0xd6,
+	0x39, 0x91, 0x96, 0xbf, 0x7b, 0x8c, 0xd4, 0x4a, 0x2e, 0x82, 0x57, 0x0b, 0xb6, 0xf7, 0xc2, 0x5c,
+	0x04, 0x5f, 0x9c, 0x45, 0xf0, 0x1e, 0xac, 0x3b, 0xde, 0x59, 0xce, 0x16, 0x5a, 0x87, 0xc5, 0xc8,
+	0x3b, 0x73, 0xc5, 0xb9, 0x9e, 0xeb, 0x42, 0xe4, 0x9d, 0x1d, 0x9d, 0xd3, 0x1e, 0xb4, 0x1d, 0xef,
+	0x2c, 0x55, 0xc2, 0xd8, 0x86, 0xba, 0xe4, 0xc4, 0x9d, 0xad, 0x99, 0x6b, 0x91, 0xe6, 0xa1, 0x3f,
+	0x86, 0x9d, 0xc3, 0x69, 0x7f, 0xec, 0x8b, 0xac, 0x96, 0x39, 0x0b, 0xb2, 0x01, 0x8b, 0x61, 0x34,
+	0x39, 0xf1, 0xd4, 0xce, 0xab, 0x39, 0xba, 0x45, 0xff, 0xbe, 0x04, 0x5b, 0x47, 0x8c, 0x8b, 0x67,
+	0x0a, 0xa3, 0x3e, 0x1d, 0x0c, 0xd8, 0x44, 0x5c, 0xb6, 0x89, 0xbd, 0xd1, 0x28, 0x3c, 0x63, 0x43,
+	0x2d, 0xca, 0x34, 0x2d, 0x1d, 0x15, 0x5b, 0x87, 0x8a, 0xe0, 0xd7, 0x6c, 0x20, 0xdc, 0x88, 0x79,
+	0x3c, 0x34, 0xd9, 0x55, 0x53, 0x11, 0x1d, 0xa4, 0x91, 0xdf, 0x8e, 0x99, 0x86, 0x4c, 0x78, 0xfe,
+	0x48, 0xe3, 0xd4, 0x8a, 0xda, 0x8e, 0x51, 0x14, 0x46, 0x0f, 0x91, 0x6e, 0x86, 0xa9, 0x16, 0xed,
+	0x65, 0x01, 0x28, 0xc6, 0xed, 0x35, 0x58, 0x90, 0xf3, 0x55, 0x79, 0x69, 0xdd, 0x51, 0x0d, 0xfa,
+	0x25, 0x6c, 0xce, 0xf0, 0x6b, 0x63, 0x3f, 0xc9, 0x9c, 0x65, 0x2a, 0x9f, 0xdd, 0x9e, 0xb3, 0x73,
+	0x33, 0x07, 0xdd, 0x9d, 0x54, 0x7c, 0xda, 0x13, 0x91, 0x91, 0x16, 0x4f, 0x04, 0x1b, 0xf4, 0x39,
+	0xac, 0xa5, 0x99, 0xf5, 0x2c, 0xbe, 0x6f, 0x43, 0x92, 0x9a, 0x42, 0xc7, 0x4c, 0x21, 0x1b, 0xfa,
+	0xf6, 0x15, 0x6d, 0x88, 0x75, 0xb0, 0x9f, 0x84, 0x51, 0x52, 0xe2, 0xba, 0x01, 0x4d, 0x7d, 0x58,
+	0xa8, 0xdb, 0xbb, 0xf2, 0x62, 0xa3, 0x9f, 0x14, 0x5f, 0xe6, 0x55, 0x04, 0x74, 0x99, 0xaf, 0x62,
+	0x97, 0xf9, 0xe8, 0xf7, 0x61, 0x55, 0x85, 0x9e, 0x52, 0xa4, 0x0c, 0xbc, 0x5c, 0x0f, 0xfd, 0x11,
+	0x10, 0x7b, 0xdc, 0xbb, 0xd7, 0x28, 0xe8, 0x47, 0xb0, 0x79, 0xc8, 0xc4, 0x33, 0x3f, 0xf0, 0x83,
+	0x63, 0x29, 0x84, 0x45, 0xf6, 0x8d, 0x29, 0x98, 0x8e, 0xdd, 0x33, 0x45, 0x45, 0x69, 0x0b, 0x0e,
+	0x04, 0xd3, 0xb1, 0xe6, 0xa3, 0x2f, 0x60, 0x4d, 0x0d, 0x3c, 0x14, 0x9e, 0x98, 0x72, 0xbb, 0x04,
+	0x3a, 0x46, 0x3a, 0x8e, 0xa9, 0x39, 0xba, 0x95, 0x15, 0x58, 0x9e, 0x11, 0xf8, 0x15, 0x34, 0xd4,
+	0xa7, 0x14, 0xc8, 0xad, 0x42, 0x47, 0x4b, 0x16, 0x3a, 0xd0, 0x06, 0x8f, 0x9f, 0x30, 0x1e, 0xdb,
+	0x80, 0x2d, 0xf2, 0x3e, 0x2c, 0xab, 0x2f, 0x77, 0xc2, 0x22, 0x97, 0xb3, 0x01, 0xae, 0x6e, 0xc9,
+	0x69, 0x2a, 0xea, 0x01, 0x8b, 0x0e, 0xd9, 0x80, 0xfe, 0xa5, 0xaa, 0x91, 0x25, 0x33, 0xfe, 0xbf,
+	0x4f, 0xf8, 0xed, 0x34, 0x93, 0xdb, 0xb0, 0x64, 0x44, 0xa8, 0xb3, 0x54, 0xed, 0x3e, 0xcb, 0x54,
+	0xc7, 0x30, 0xc8, 0x4d, 0xcd, 0xc3, 0xd1, 0x29, 0x1b, 0xba, 0xfa, 0xfa, 0xa7, 0x72, 0xc3, 0xa6,
+	0x22, 0xaa, 0x4b, 0x22, 0xb9, 0x09, 0x6d, 0xc1, 0xc6, 0x93, 0x91, 0x27, 0x98, 0xb9, 0xb0, 0xaa,
+	0x83, 0x73, 0xd9, 0x90, 0xf5, 0xb5, 0xd5, 0x66, 0xd4, 0x81, 0xb7, 0x94, 0x66, 0x3c, 0x52, 0x01,
+	0x38, 0xc6, 0xfd, 0xfb, 0x13, 0x6f, 0x34, 0x62, 0x22, 0xe3, 0xcd, 0x2d, 0xa8, 0x89, 0x73, 0x77,
+	0x10, 0x4e, 0x03, 0xa1, 0x7d, 0xb1, 0x24, 0xce, 0x1f, 0xc8, 0xa6, 0x4c, 0x9b, 0xe4, 0x4e, 0xd1,
+	0x9d, 0x65, 0xec, 0xc4, 0xbd, 0xa3, 0xba, 0x3b, 0xb0, 0xd4, 0xf7, 0x46, 0x5e, 0xa0, 0xd3, 0xf5,
+	0xaa, 0x63, 0x9a, 0xf4, 0x9f, 0x4a, 0xd0, 0x8d, 0xf5, 0x7d, 0x3a, 0x1c, 0x46, 0x8c, 0x73, 0x6b,
+	0xb3, 0x3e, 0x82, 0xba, 0x67, 0x88, 0x7a, 0xb3, 0xde, 0x34, 0x9b, 0xb5, 0x60, 0x4c, 0x4f, 0x53,
+	0x9c, 0x64, 0x64, 0xde, 0x05, 0xb4, 0x9c, 0x73, 0x01, 0xed, 0xfe, 0x2e, 0x2c, 0xe9, 0xd1, 0x88,
+	0xc2, 0xea, 0x53, 0xef, 0x9e, 0x25, 0x2f, 0xe9, 0x31, 0xc6, 0x48, 0x21, 0xe5, 0xc4, 0x98, 0xef,
+	0x41, 0x27, 0x9e, 0xd7, 0x7d, 0x45, 0x8b, 0x2d, 0xb1, 0x46, 0x95, 0xd2, 0xa3, 0xde, 0xc0, 0xd5,
+	0x78, 0x54, 0x2e, 0x6e, 0xd2, 0x1c, 0xdc, 0xcc, 0x96, 0x8f, 0xdf, 0xd2, 0x42, 0xda, 0xb7, 0x96,
+	0x7b, 0x16, 0x1b, 0x77, 0xb2, 0xd8, 0x68, 0x17, 0xa9, 0xde, 0x5a, 0xc7, 0x3e, 0xac, 0x3d, 0x88,
+	0x98, 0x27, 0x98, 0xf1, 0x84, 0x86, 0x11, 0x79, 0x0b, 0xf2, 0x38, 0x3f, 0x0b, 0x23, 0x73, 0xe0,
+	0xc5, 0x6d, 0xea, 0xc1, 0x7a, 0x66, 0x4c, 0xb2, 0x6e, 0xc5, 0x7e, 0xe0, 0xd3, 0xc1, 0x40, 0xf6,
+	0xe8, 0x73, 0x52, 0x37, 0xf1, 0x40, 0x90, 0x07, 0x9a, 0xce, 0x43, 0x54, 0x83, 0xfe, 0x59, 0x09,
+	0x3a, 0x4a, 0x47, 0x4e, 0xe6, 0x70, 0x15, 0x40, 0x84, 0x6e, 0x5a, 0x53, 0x5d, 0x84, 0x26, 0x1a,
+	0xe2, 0xdc, 0x4b, 0x79, 0x5c, 0x35, 0x24, 0xbc, 0xbe, 0xf2, 0x47, 0xf1, 0xeb, 0x8b, 0xfc, 0x96,
+	0x48, 0xa1, 0x12, 0x1c, 0x77, 0x1c, 0x0e, 0x99, 0x3e, 0x89, 0x41, 0x91, 0x9e, 0x85, 0x43, 0x46,
+	0x7f, 0x0a, 0x5b, 0x39, 0xb3, 0xd0, 0xd6, 0xae, 0x40, 0xe5, 0x84, 0x99, 0xec, 0x45, 0x7e, 0xbe,
+	0xb3, 0x95, 0x9f, 0xc1, 0xc6, 0x21, 0x0b, 0x86, 0x39, 0x26, 0xce, 0xca, 0xb6, 0x1d, 0x52, 0xce,
+	0x38, 0xe4, 0xa7, 0xb0, 0x39, 0x23, 0x67, 0x7e, 0xd2, 0xf2, 0x4e, 0xd3, 0xfc, 0xe7, 0x05, 0xa8,
+	0x1e, 0x30, 0xf5, 0x2a, 0x35, 0x61, 0x2c, 0x72, 0x63, 0x79, 0x8b, 0xb2, 0xf9, 0x74, 0x28, 0x17,
+	0x32, 0x62, 0xe3, 0x50, 0x30, 0xf4, 0x8a, 0x49, 0x6c, 0x15, 0x49, 0xba, 0xa5, 0xf0, 0xb2, 0x4d,
+	0xa0, 0x3a, 0x91, 0x08, 0xae, 0x96, 0x1e, 0xbf, 0xa5, 0xa5, 0xc3, 0x69, 0x84, 0x97, 0x41, 0x9d,
+	0x6e, 0xc6, 0x6d, 0x99, 0x0a, 0xf6, 0xbd, 0xc0, 0xe5, 0x83, 0x30, 0x8a, 0xaf, 0x1d, 0x7d, 0x2f,
+	0x38, 0x94, 0x6d, 0x15, 0x17, 0xc2, 0x1b, 0xb9, 0x9c, 0x05, 0x0a, 0x32, 0x2b, 0x32, 0x2e, 0x84,
+	0x37, 0x3a, 0x64, 0x81, 0x20, 0xdf, 0x81, 0x65, 0xd5, 0x1d, 0xb1, 0x01, 0xf3, 0x4f, 0xd9, 0x10,
+	0xab, 0x9a, 0x15, 0xa7, 0x85, 0x54, 0x47, 0x13, 0xc9, 0x6d, 0x58, 0xf5, 0x4e, 0x59, 0x84, 0x95,
+	0x41, 0x16, 0x08, 0x37, 0xf2, 0x04, 0xc3, 0x1a, 0x67, 0xc5, 0x69, 0xeb, 0x0e, 0x29, 0xce, 0xf1,
+	0x04, 0x23, 0xfb, 0xb0, 0x6e, 0x78, 0x8d, 0x50, 0xc5, 0x0f, 0xc8, 0x7f, 0x45, 0x77, 0x1a, 0xd9,
+	0x38, 0xe6, 0x36, 0xac, 0x0e, 0xa6, 0x51, 0x24, 0x45, 0x27, 0xf2, 0x1b, 0x4a, 0xbe, 0xee, 0xb0,
+	0xe5, 0x1b, 0xde, 0xb4, 0xfc, 0xa6, 0x92, 0xaf, 0x3b, 0x53, 0xf2, 0x3f, 0xc4, 0x62, 0x73, 0x10,
+	0xb0, 0x11, 0xef, 0xb4, 0xac, 0x83, 0x4b, 0x7a, 0xf0, 0x81, 0xea, 0x70, 0x62, 0x0e, 0xf2, 0x3d,
+	0xa8, 0x8f, 0xf9, 0x31, 0x57, 0x4b, 0xb6, 0x8c, 0xec, 0x9b, 0x31, 0x7b, 0xef, 0x19, 0x3f, 0xe6,
+	0x72, 0x32, 0x8f, 0x02, 0x11, 0x5d, 0x38, 0xb5, 0xb1, 0x6e, 0x92, 0x1f, 0x41, 0x0b, 0x47, 0xc5,
+	0x2b, 0xd9, 0xb6, 0xd2, 0xc3, 0x78, 0xa4, 0x99, 0x96, 0x1a, 0xdd, 0x1c, 0x5b, 0xa4, 0xee, 0xc7,
+	0xd0, 0x4a, 0x09, 0x97, 0x11, 0xff, 0x86, 0x5d, 0x98, 0x88, 0x7f, 0xc3, 0x2e, 0xd2, 0xfb, 0xd8,
+	0xdc, 0xa1, 0x3e, 0x2a, 0xff, 0xb0, 0xd4, 0xfd, 0x04, 0x56, 0x67, 0xe4, 0xbf, 0x8b, 0x00, 0xfa,
+	0x8f, 0x25, 0x68, 0x58, 0xeb, 0x21, 0x23, 0x47, 0xaf, 0x88, 0x1b, 0xe7, 0x2e, 0x75, 0x4d, 0x79,
+	0x3a, 0x94, 0xdd, 0xe8, 0xaa, 0xfe, 0x85, 0x88, 0xd3, 0x98, 0xba, 0xa4, 0xdc, 0x97, 0x04, 0x19,
+	0x58, 0xb1, 0x77, 0x14, 0x8b, 0x0a, 0xf2, 0x96, 0xa1, 0x2a, 0x36, 0x99, 0x24, 0x48, 0x29, 0x63,
+	0xc6, 0xb9, 0x77, 0xcc, 0xb8, 0xbe, 0x76, 0x36, 0x25, 0xf1, 0x99, 0xa6, 0x91, 0x3b, 0xb0, 0x1a,
+	0xcb, 0x8a, 0x19, 0x55, 0x36, 0xb1, 0x62, 0x3a, 0x0c, 0x33, 0xfd, 0x0a, 0x9f, 0x72, 0xa5, 0x21,
+	0x09, 0x06, 0x5f, 0x87, 0x05, 0xb9, 0x29, 0xcd, 0x09, 0x5c, 0x8f, 0x5d, 0xe2, 0x28, 0xfa, 0x5b,
+	0x9f, 0x0c, 0x3d, 0x20, 0x0f, 0xc2, 0x20, 0x60, 0x03, 0x54, 0x60, 0x80, 0xa9, 0x10, 0xe2, 0xe9,
+	0x8f, 0x61, 0xfd, 0xa1, 0xcf, 0x07, 0xb3, 0x43, 0x0a, 0x51, 0xc3, 0x92, 0x55, 0x4e, 0xcb, 0xfa,
+	0x1d, 0x58, 0xbe, 0xef, 0x05, 0xb6, 0x10, 0x99, 0x55, 0x4e, 0xe2, 0xe7, 0xb3, 0x49, 0x0a, 0x24,
+	0xca, 0x69, 0x90, 0xa0, 0x9f, 0x00, 0xdc, 0x97, 0xae, 0x1b, 0x22, 0x68, 0x65, 0x47, 0xca, 0x04,
+	0x1d, 0x7b, 0xdd, 0x69, 0x20, 0xfc, 0x11, 0x8e, 0xae, 0x38, 0x0d, 0x45, 0xfb, 0x42, 0x92, 0x28,
+	0x85, 0x95, 0x2f, 0x82, 0xfe, 0xdc, 0x09, 0xd0, 0x29, 0x6c, 0xe2, 0x03, 0x40, 0xac, 0x28, 0x71,
+	0xc1, 0x7e, 0xac, 0xc1, 0xf6, 0x44, 0x5b, 0x95, 0x29, 0x63, 0x7e, 0xa3, 0xf2, 0xe0, 0x9d, 0xbc,
+	0xf2, 0x8b, 0x32, 0xd4, 0x9f, 0x33, 0x71, 0x24, 0x21, 0x8b, 0x67, 0x10, 0xaf, 0x74, 0x39, 0xe2,
+	0x95, 0x0b, 0x10, 0x6f, 0x16, 0x91, 0x2a, 0xef, 0x88, 0x48, 0xd5, 0x62, 0x44, 0xfa, 0x00, 0xda,
+	0x9c, 0x05, 0x8a, 0xcf, 0x1d, 0xf9, 0x63, 0x5f, 0x60, 0x44, 0x57, 0x1c, 0xb9, 0x1f, 0x90, 0xe5,
+	0x73, 0x49, 0x94, 0x7c, 0x11, 0x1b, 0x9c, 0xda, 0x7c, 0x8b, 0x8a, 0x4f, 0x92, 0x63, 0x3e, 0xfa,
+	0x2f, 0x15, 0xcc, 0x7b, 0x1f, 0x8c, 0x7c, 0x16, 0x64, 0xf3, 0xde, 0xdb, 0xb0, 0x3a, 0x0a, 0x07,
+	0xde, 0xc8, 0xed, 0xcb, 0x63, 0x3d, 0xf5, 0x6c, 0xd4, 0xc6, 0x8e, 0xfb, 0x8c, 0x0b, 0x9d, 0x67,
+	0xdf, 0x86, 0xd5, 0x37, 0x41, 0x78, 0x16, 0xa4, 0x78, 0xd5, 0xee, 0x6e, 0x63, 0x87, 0xc5, 0x9b,
+	0x5c, 0x36, 0x2a, 0xa9, 0xcb, 0xc6, 0x77, 0x60, 0x19, 0x83, 0x7b, 0xe4, 0x73, 0xc1, 0x02, 0x73,
+	0x94, 0xd5, 0x9c, 0x96, 0xa4, 0x7e, 0x6e, 0x88, 0x92, 0x2d, 0x9c, 0x8a, 0x7e, 0x38, 0x0d, 0x4c,
+	0x50, 0x2c, 0x20, 0xc8, 0xb4, 0x0c, 0x55, 0x45, 0xc1, 0x7b, 0xd0, 0xf2, 0x03, 0x9b, 0x6b, 0x11,
+	0xb9, 0x9a, 0x7e, 0x60, 0x31, 0xed, 0x42, 0xd3, 0xe7, 0xee, 0xc0, 0x9b, 0x1e, 0x9f, 0x08, 0x77,
+	0x3a, 0xc1, 0x83, 0xae, 0xe6, 0x80, 0xcf, 0x1f, 0x20, 0xe9, 0x8b, 0x89, 0x14, 0xc3, 0x2f, 0x82,
+	0x81, 0x3b, 0x89, 0xc2, 0x63, 0xdc, 0x5e, 0x35, 0x75, 0xbf, 0x91, 0xc4, 0x03, 0x4d, 0x93, 0xfb,
+	0x40, 0xd7, 0x62, 0xd5, 0x0b, 0x58, 0x1d, 0x0d, 0x6f, 0x68, 0x1a, 0x3e, 0x82, 0xbd, 0x07, 0x2d,
+	0xc3, 0xa2, 0x70, 0x0d, 0x14, 0x62, 0x69, 0xa2, 0x82, 0x35, 0xfb, 0x71, 0xb3, 0x51, 0xf8, 0xb8,
+	0xd9, 0x54, 0x3d, 0xd6, 0xe3, 0xe6, 0x74, 0x82, 0xb5, 0xc3, 0x16, 0x7a, 0x58, 0xb7, 0x64, 0x59,
+	0xab, 0x61, 0xd5, 0x37, 0x24, 0x9f, 0x2e, 0x93, 0x68, 0xe8, 0x50, 0x2d, 0x49, 0xd7, 0x95, 0x11,
+	0xf3, 0xd4, 0xa0, 0xf8, 0x7b, 0x50, 0x1d, 0x7a, 0xc2, 0xd3, 0x85, 0xd3, 0x6e, 0xb6, 0x5e, 0xd2,
+	0x7b, 0xe8, 0x09, 0x4f, 0x1d, 0x47, 0xc8, 0xd7, 0xfd, 0x01, 0xd4, 0x63, 0xd2, 0x65, 0x27, 0x48,
+	0xdd, 0x3a, 0x41, 0xf6, 0xff, 0xf5, 0x3a, 0xc0, 0xa7, 0x07, 0x4f, 0x0f, 0x59, 0x74, 0xea, 0x0f,
+	0x18, 0xf9, 0x12, 0x9a, 0xf6, 0x7f, 0x45, 0x64, 0xa3, 0xa7, 0x7e, 0x68, 0xea, 0x99, 0x1f, 0x9a,
+	0x7a, 0x8f, 0xe4, 0x0f, 0x4d, 0xdd, 0xad, 0xf8, 0x35, 0x2e, 0xfb, 0x0b, 0x12, 0xdd, 0xfc, 0xd3,
+	0x5f, 0xfe, 0xc7, 0x9f, 0x97, 0x57, 0x49, 0x7b, 0xef, 0xf4, 0xde, 0x9e, 0xba, 0x46, 0xee, 0xc9,
+	0xc0, 0x24, 0x07, 0x50, 0x33, 0xcf, 0x77, 0x64, 0x2d, 0xf3, 0xa0, 0x88, 0xd8, 0xd4, 0xcd, 0x7f,
+	0x66, 0xcc, 0x95, 0xf8, 0xb5, 0x3f, 0xfc, 0x86, 0xf8, 0xb0, 0x9c, 0xfe, 0xef, 0x84, 0x74, 0x53,
+	0x12, 0x52, 0xbf, 0xad, 0x74, 0xb7, 0x73, 0xfb, 0xb4, 0x8e, 0x6b, 0xa8, 0xa3, 0x43, 0x36, 0x32,
+	0x3a, 0xf6, 0x54, 0xf9, 0xc3, 0x56, 0xa5, 0xfe, 0xc9, 0xc8, 0xa8, 0x4a, 0xfd, 0xd2, 0xd1, 0xdd,
+	0xce, 0xed, 0xbb, 0x4c, 0x95, 0xfe, 0x41, 0x83, 0xc3, 0xea, 0xcc, 0x83, 0x29, 0xd9, 0xce, 0x7b,
+	0xfe, 0x34, 0xea, 0x2e, 0x79, 0x65, 0xa5, 0x37, 0x50, 0xe3, 0x36, 0xd9, 0xca, 0x6a, 0xd4, 0x8f,
+	0xac, 0x7b, 0x77, 0xf3, 0x94, 0xde, 0xfb, 0x55, 0x94, 0xde, 0x7b, 0x7b, 0xa5, 0xf7, 0xc8, 0xef,
+	0x03, 0x24, 0x8f, 0xcf, 0x64, 0x03, 0x05, 0xce, 0xbc, 0x9d, 0x77, 0x37, 0x67, 0xe8, 0x5a, 0x03,
+	0x41, 0x0d, 0x4d, 0x02, 0x89, 0x06, 0xf2, 0x1a, 0xfd, 0x64, 0xff, 0x18, 0xd1, 0xcd, 0xad, 0xf5,
+	0x65, 0xfc, 0x94, 0x73, 0xe5, 0xa0, 0xd7, 0x51, 0xfc, 0x16, 0xd9, 0x94, 0xe2, 0xed, 0x8b, 0xef,
+	0xde, 0xd7, 0xf2, 0xfa, 0xf1, 0x0d, 0xf9, 0x63, 0x68, 0x58, 0xf5, 0x3b, 0xb2, 0x39, 0x5b, 0xd1,
+	0x53, 0x5a, 0x0a, 0x4b, 0x7d, 0x74, 0x07, 0x55, 0x6c, 0x90, 0x35, 0xa9, 0x22, 0xbe, 0xf4, 0xee,
+	0x7d, 0x2d, 0x3f, 0xbf, 0x21, 0xa7, 0xb0, 0x96, 0x57, 0x12, 0xd6, 0x16, 0xe5, 0x56, 0xa3, 0xbb,
+	0xea, 0xa1, 0x64, 0x5e, 0x25, 0xd9, 0xd8, 0x45, 0xd7, 0x66, 0xec, 0x8a, 0xbc, 0xb3, 0x8f, 0x4a,
+	0xb7, 0xc9, 0x14, 0x56, 0x67, 0xaa, 0xc7, 0x73, 0x95, 0xaa, 0x50, 0x28, 0xac, 0x38, 0xd3, 0xf7,
+	0x51, 0xe3, 0x35, 0xba, 0x95, 0xa7, 0x71, 0x4f, 0x30, 0x2e, 0xa4, 0xda, 0x33, 0x58, 0x7b, 0xc8,
+	0x06, 0xe1, 0x90, 0xbd, 0x83, 0xb9, 0x73, 0x1d, 0xf8, 0x01, 0xaa, 0xdd, 0xa5, 0xdb, 0xb9, 0x6a,
+	0x87, 0xa8, 0x4b, 0x2a, 0x3e, 0x81, 0xe5, 0x58, 0xb1, 0x0d, 0x4f, 0x99, 0xfa, 0xfd, 0xa5, 0x11,
+	0xbf, 0x8b, 0xfa, 0xba, 0x74, 0xdd, 0x8a, 0xf8, 0xb4, 0xa6, 0x10, 0xda, 0xe9, 0xb9, 0x72, 0x92,
+	0x67, 0x41, 0x1c, 0xfa, 0x3b, 0xf9, 0x9d, 0xe9, 0x1d, 0xf6, 0x51, 0xe9, 0x36, 0xdd, 0x98, 0x31,
+	0xb1, 0xef, 0x89, 0xc1, 0x09, 0x19, 0x40, 0xd3, 0x8a, 0x3b, 0x4e, 0x66, 0x42, 0x31, 0x56, 0xb5,
+	0x95, 0xd3, 0x93, 0x06, 0x2c, 0x7a, 0x25, 0x1d, 0xa5, 0xa8, 0x41, 0x5a, 0x75, 0x00, 0x4b, 0xba,
+	0x4a, 0x5d, 0x78, 0x5e, 0xc4, 0x78, 0x6f, 0x97, 0x8a, 0xd3, 0xc0, 0xae, 0x12, 0x91, 0x3d, 0x59,
+	0x8a, 0x24, 0x7f, 0x08, 0x90, 0x54, 0x96, 0x35, 0x30, 0xcc, 0x94, 0xa8, 0xbb, 0x9b, 0x33, 0x74,
+	0x2d, 0xb7, 0x8b, 0x72, 0xd7, 0xe4, 0xc2, 0xcc, 0x88, 0xfe, 0x12, 0x1a, 0x87, 0xc2, 0x8b, 0x74,
+	0x25, 0xf6, 0x92, 0x03, 0x2e, 0xaf, 0xc0, 0x4c, 0x3b, 0x28, 0x9d, 0xd0, 0x15, 0x4b, 0x34, 0x97,
+	0x22, 0xc9, 0x1f, 0x00, 0x1c, 0x8a, 0x70, 0xf2, 0xab, 0x8b, 0xd6, 0x0b, 0x92, 0x9a, 0x35, 0x17,
+	0xe1, 0x84, 0xbc, 0x86, 0x95, 0x6c, 0xa1, 0x9c, 0xa8, 0xe0, 0x28, 0xa8, 0x9f, 0xcf, 0xd3, 0x72,
+	0x15, 0xb5, 0x6c, 0x52, 0x92, 0x59, 0x1b, 0x16, 0x71, 0xe9, 0x4e, 0x17, 0x21, 0x34, 0x19, 0xc9,
+	0x0b, 0x2d, 0x89, 0x63, 0x37, 0xa7, 0xac, 0x6d, 0x96, 0x89, 0x64, 0x96, 0x49, 0x70, 0xd2, 0x87,
+	0x76, 0x5c, 0x0f, 0x54, 0x53, 0x2b, 0xd4, 0xb0, 0x93, 0x2e, 0xbc, 0x66, 0x0c, 0xd9, 0x42, 0x15,
+	0x57, 0xc8, 0xaa, 0x54, 0x71, 0x86, 0x1c, 0xa8, 0x62, 0xca, 0x09, 0x03, 0x12, 0x8f, 0x8a, 0xcb,
+	0xb5, 0x44, 0x57, 0x10, 0x92, 0x9f, 0xb0, 0xbb, 0xd7, 0x2f, 0xa9, 0xec, 0xa6, 0x21, 0x5a, 0xeb,
+	0x48, 0x8a, 0xbc, 0x0c, 0x56, 0xe2, 0xb1, 0xba, 0xfa, 0x5a, 0x68, 0xcb, 0xd5, 0xb4, 0xaa, 0x4c,
+	0xb1, 0xd6, 0x04, 0x2d, 0x21, 0x96, 0x22, 0x5d, 0xae, 0x25, 0x01, 0xac, 0xc7, 0xe3, 0x52, 0xe8,
+	0x31, 0x6b, 0x10, 0x4d, 0x6b, 0xc9, 0x05, 0x8e, 0xd4, 0xc9, 0xa6, 0x55, 0xa5, 0x2a, 0xbb, 0xf6,
+	0xea, 0x25, 0xe0, 0x71, 0xe9, 0xea, 0xcd, 0x42, 0x47, 0xde, 0xea, 0x25, 0xc5, 0xdd, 0x21, 0xb4,
+	0x52, 0x05, 0x58, 0xa2, 0x82, 0x36, 0xaf, 0x90, 0xdb, 0xed, 0xe6, 0x75, 0xa5, 0xb5, 0xd0, 0x7c,
+	0x1f, 0xfd, 0x09, 0xac, 0xce, 0x14, 0x3f, 0xc9, 0x55, 0x4b, 0x5c, 0xe1, 0x89, 0x56, 0x58, 0x33,
+	0xa5, 0xb7, 0x50, 0x23, 0xa5, 0xbb, 0x05, 0x2b, 0xb8, 0x37, 0x90, 0x43, 0x25, 0x0a, 0x4c, 0xa1,
+	0x9d, 0xa9, 0x69, 0x6a, 0xc8, 0xcf, 0xaf, 0x98, 0x76, 0x77, 0xf2, 0x3b, 0xb5, 0xde, 0x9b, 0xa8,
+	0xf7, 0x06, 0xbd, 0x5e, 0xa4, 0x57, 0xde, 0x44, 0xa5, 0xda, 0xcf, 0x30, 0xd9, 0x56, 0x17, 0xad,
+	0x59, 0xbf, 0xc5, 0x89, 0x76, 0xea, 0xc2, 0x4f, 0x57, 0x51, 0x7a, 0x83, 0xd4, 0xa5, 0x74, 0x55,
+	0x65, 0xf9, 0x23, 0x68, 0x58, 0xd5, 0x13, 0x9d, 0xe3, 0xcc, 0xd6, 0x53, 0xba, 0x05, 0x41, 0x1f,
+	0xbb, 0x66, 0x35, 0x16, 0xb9, 0xa7, 0x4b, 0x2b, 0x12, 0x6a, 0x18, 0x2c, 0xa7, 0x6b, 0x2d, 0xfa,
+	0xb0, 0xcf, 0x2d, 0xc0, 0x14, 0xea, 0xd0, 0xe1, 0x2c, 0xe1, 0x7e, 0x2d, 0x51, 0x33, 0x8c, 0x65,
+	0x90, 0xa7, 0xb0, 0xa4, 0xcb, 0x30, 0xe4, 0x8a, 0xa9, 0x5e, 0xd8, 0x82, 0xb3, 0x25, 0x8d, 0x18,
+	0xe2, 0x5b, 0x89, 0xb8, 0xbe, 0x17, 0xc8, 0x19, 0xbf, 0x84, 0x7a, 0x5c, 0x52, 0x21, 0x6a, 0x19,
+	0xb3, 0x25, 0x96, 0xc2, 0x79, 0xea, 0x1d, 0x4e, 0xdb, 0x89, 0xd4, 0x69, 0xa0, 0xe5, 0x7e, 0x05,
+	0xed, 0x4c, 0x19, 0x26, 0xc7, 0x6d, 0x3b, 0x49, 0x26, 0x3c, 0x5b, 0xae, 0x49, 0x03, 0x6e, 0x3c,
+	0xe9, 0x80, 0x0d, 0xe5, 0xaf, 0xc7, 0x8f, 0x99, 0x48, 0xca, 0x2d, 0x45, 0x08, 0xb5, 0x8c, 0xf2,
+	0x63, 0x3e, 0xba, 0x81, 0x12, 0x57, 0xc8, 0xb2, 0x94, 0x18, 0xc8, 0x50, 0x53, 0xe3, 0x15, 0x80,
+	0xdb, 0x75, 0x8b, 0xcb, 0x01, 0x3c, 0xaf, 0xca, 0x91, 0x06, 0xf0, 0x01, 0x72, 0x18, 0x00, 0xf7,
+	0xa0, 0x1d, 0xff, 0xbe, 0x15, 0x5f, 0x10, 0xf2, 0x75, 0x6c, 0xe4, 0xff, 0x38, 0x97, 0x06, 0x1f,
+	0x6e, 0x84, 0xe9, 0xcc, 0xec, 0x6e, 0x89, 0xbc, 0x86, 0xf5, 0x58, 0x45, 0x0a, 0x55, 0x8b, 0x14,
+	0x5d, 0xc9, 0xf9, 0x57, 0x8d, 0x52, 0xd4, 0xb2, 0x43, 0xba, 0x69, 0x2d, 0xf6, 0xae, 0xbc, 0x5b,
+	0x22, 0x11, 0xbe, 0x95, 0x67, 0xfe, 0x46, 0x23, 0xd7, 0x4c, 0x06, 0x93, 0xff, 0x9b, 0x9a, 0x3e,
+	0xc4, 0xf3, 0x7e, 0x2f, 0x4b, 0x63, 0x78, 0xa2, 0x36, 0x06, 0xd7, 0xbb, 0xa5, 0xfe, 0x22, 0x4e,
+	0xff, 0xb7, 0xfe, 0x77, 0x00, 0x6f, 0x96, 0x27, 0xfd, 0xc3, 0x34, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// APIServiceClient is the client API for APIService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type APIServiceClient interface {
+	GetBestBlock(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetBestBlockResponse, error)
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
+	GetBlockHeader(ctx context.Context, in *GetBlockHeaderRequest, opts ...grpc.CallOption) (*GetBlockHeaderResponse, error)
+	GetBlockFilter(ctx context.Context, in *GetBlockFilterRequest, opts ...grpc.CallOption) (*GetBlockFilterResponse, error)
+	GetBlockVerboseV0(ctx context.Context, in *GetBlockVerboseRequest, opts ...grpc.CallOption) (*GetBlockVerboseV0Response, error)
+	GetBlockVerboseV1(ctx context.Context, in *GetBlockVerboseRequest, opts ...grpc.CallOption) (*GetBlockVerboseV1Response, error)
+	ListBlocks(ctx context.Context, in *ListBlocksRequest, opts ...grpc.CallOption) (*ListBlocksResponse, error)
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+	GetEvidence(ctx context.Context, in *GetEvidenceRequest, opts ...grpc.CallOption) (*GetEvidenceResponse, error)
+	SubmitRawTransaction(ctx context.Context, in *RawTransactionRequest, opts ...grpc.CallOption) (*SubmitRawTransactionResponse, error)
+	TestMempoolAccept(ctx context.Context, in *RawTransactionRequest, opts ...grpc.CallOption) (*TestMempoolAcceptResponse, error)
+	DecodeRawTransaction(ctx context.Context, in *RawTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+	DecodeRawBlock(ctx context.Context, in *RawBlockRequest, opts ...grpc.CallOption) (*GetBlockVerboseV1Response, error)
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
+	GetEvidences(ctx context.Context, in *GetEvidencesRequest, opts ...grpc.CallOption) (*GetEvidencesResponse, error)
+	GetWork(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetWorkResponse, error)
+	SubmitWork(ctx context.Context, in *SubmitWorkRequest, opts ...grpc.CallOption) (*SubmitWorkResponse, error)
+	StartMining(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MiningStatusResponse, error)
+	StopMining(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MiningStatusResponse, error)
+	SetMiningWorkers(ctx context.Context, in *SetMiningWorkersRequest, opts ...grpc.CallOption) (*MiningStatusResponse, error)
+	GetMiningStats(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetMiningStatsResponse, error)
+	GetWalletStatus(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetWalletStatusResponse, error)
+	GetWalletAddresses(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*GetWalletAddressesResponse, error)
+	GetWalletBalance(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetWalletBalanceResponse, error)
+	GetWalletTransactions(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*GetWalletTransactionsResponse, error)
+	GetWalletEvidences(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*GetWalletEvidencesResponse, error)
+	CreateAddress(ctx context.Context, in *CreateAddressRequest, opts ...grpc.CallOption) (*CreateAddressResponse, error)
+	CreateTransaction(ctx context.Context, in *CreateTransactionRequest, opts ...grpc.CallOption) (*CreateTransactionResponse, error)
+	SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	GetPeers(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*GetPeersResponse, error)
+	ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	BanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*BannedPeer, error)
+	UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListBannedPeers(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*ListBannedPeersResponse, error)
+	GetNetTotals(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*NetTotals, error)
+	GetClientStatus(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetClientStatusResponse, error)
+	SubscribeBlocks(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (APIService_SubscribeBlocksClient, error)
+	SubscribeTransactions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (APIService_SubscribeTransactionsClient, error)
+	SubscribeEvidences(ctx context.Context, in *SubscribeEvidencesRequest, opts ...grpc.CallOption) (APIService_SubscribeEvidencesClient, error)
+}
+
+type aPIServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAPIServiceClient(cc *grpc.ClientConn) APIServiceClient {
+	return &aPIServiceClient{cc}
+}
+
+func (c *aPIServiceClient) GetBestBlock(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetBestBlockResponse, error) {
+	out := new(GetBestBlockResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetBestBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error) {
+	out := new(GetBlockResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetBlockHeader(ctx context.Context, in *GetBlockHeaderRequest, opts ...grpc.CallOption) (*GetBlockHeaderResponse, error) {
+	out := new(GetBlockHeaderResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetBlockHeader", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetBlockFilter(ctx context.Context, in *GetBlockFilterRequest, opts ...grpc.CallOption) (*GetBlockFilterResponse, error) {
+	out := new(GetBlockFilterResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetBlockFilter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetBlockVerboseV0(ctx context.Context, in *GetBlockVerboseRequest, opts ...grpc.CallOption) (*GetBlockVerboseV0Response, error) {
+	out := new(GetBlockVerboseV0Response)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetBlockVerboseV0", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetBlockVerboseV1(ctx context.Context, in *GetBlockVerboseRequest, opts ...grpc.CallOption) (*GetBlockVerboseV1Response, error) {
+	out := new(GetBlockVerboseV1Response)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetBlockVerboseV1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) ListBlocks(ctx context.Context, in *ListBlocksRequest, opts ...grpc.CallOption) (*ListBlocksResponse, error) {
+	out := new(ListBlocksResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/ListBlocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
+	out := new(GetTransactionResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetEvidence(ctx context.Context, in *GetEvidenceRequest, opts ...grpc.CallOption) (*GetEvidenceResponse, error) {
+	out := new(GetEvidenceResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) SubmitRawTransaction(ctx context.Context, in *RawTransactionRequest, opts ...grpc.CallOption) (*SubmitRawTransactionResponse, error) {
+	out := new(SubmitRawTransactionResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/SubmitRawTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) TestMempoolAccept(ctx context.Context, in *RawTransactionRequest, opts ...grpc.CallOption) (*TestMempoolAcceptResponse, error) {
+	out := new(TestMempoolAcceptResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/TestMempoolAccept", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) DecodeRawTransaction(ctx context.Context, in *RawTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
+	out := new(GetTransactionResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/DecodeRawTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) DecodeRawBlock(ctx context.Context, in *RawBlockRequest, opts ...grpc.CallOption) (*GetBlockVerboseV1Response, error) {
+	out := new(GetBlockVerboseV1Response)
+	err := c.cc.Invoke(ctx, "/api.APIService/DecodeRawBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error) {
+	out := new(GetTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetEvidences(ctx context.Context, in *GetEvidencesRequest, opts ...grpc.CallOption) (*GetEvidencesResponse, error) {
+	out := new(GetEvidencesResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetEvidences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetWork(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetWorkResponse, error) {
+	out := new(GetWorkResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetWork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) SubmitWork(ctx context.Context, in *SubmitWorkRequest, opts ...grpc.CallOption) (*SubmitWorkResponse, error) {
+	out := new(SubmitWorkResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/SubmitWork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) StartMining(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MiningStatusResponse, error) {
+	out := new(MiningStatusResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/StartMining", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) StopMining(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MiningStatusResponse, error) {
+	out := new(MiningStatusResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/StopMining", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) SetMiningWorkers(ctx context.Context, in *SetMiningWorkersRequest, opts ...grpc.CallOption) (*MiningStatusResponse, error) {
+	out := new(MiningStatusResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/SetMiningWorkers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetMiningStats(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetMiningStatsResponse, error) {
+	out := new(GetMiningStatsResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetMiningStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetWalletStatus(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetWalletStatusResponse, error) {
+	out := new(GetWalletStatusResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetWalletStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetWalletAddresses(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*GetWalletAddressesResponse, error) {
+	out := new(GetWalletAddressesResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetWalletAddresses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetWalletBalance(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetWalletBalanceResponse, error) {
+	out := new(GetWalletBalanceResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetWalletBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetWalletTransactions(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*GetWalletTransactionsResponse, error) {
+	out := new(GetWalletTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetWalletTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetWalletEvidences(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*GetWalletEvidencesResponse, error) {
+	out := new(GetWalletEvidencesResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetWalletEvidences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) CreateAddress(ctx context.Context, in *CreateAddressRequest, opts ...grpc.CallOption) (*CreateAddressResponse, error) {
+	out := new(CreateAddressResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/CreateAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) CreateTransaction(ctx context.Context, in *CreateTransactionRequest, opts ...grpc.CallOption) (*CreateTransactionResponse, error) {
+	out := new(CreateTransactionResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/CreateTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	out := new(SendTransactionResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/SendTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetPeers(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*GetPeersResponse, error) {
+	out := new(GetPeersResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.APIService/ConnectPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.APIService/DisconnectPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) BanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*BannedPeer, error) {
+	out := new(BannedPeer)
+	err := c.cc.Invoke(ctx, "/api.APIService/BanPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.APIService/UnbanPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) ListBannedPeers(ctx context.Context, in *PageRequest, opts ...grpc.CallOption) (*ListBannedPeersResponse, error) {
+	out := new(ListBannedPeersResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/ListBannedPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetNetTotals(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*NetTotals, error) {
+	out := new(NetTotals)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetNetTotals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetClientStatus(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetClientStatusResponse, error) {
+	out := new(GetClientStatusResponse)
+	err := c.cc.Invoke(ctx, "/api.APIService/GetClientStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) SubscribeBlocks(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (APIService_SubscribeBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_APIService_serviceDesc.Streams[0], "/api.APIService/SubscribeBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIServiceSubscribeBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type APIService_SubscribeBlocksClient interface {
+	Recv() (*BlockNotification, error)
+	grpc.ClientStream
+}
+
+type aPIServiceSubscribeBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIServiceSubscribeBlocksClient) Recv() (*BlockNotification, error) {
+	m := new(BlockNotification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIServiceClient) SubscribeTransactions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (APIService_SubscribeTransactionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_APIService_serviceDesc.Streams[1], "/api.APIService/SubscribeTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIServiceSubscribeTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type APIService_SubscribeTransactionsClient interface {
+	Recv() (*TxNotification, error)
+	grpc.ClientStream
+}
+
+type aPIServiceSubscribeTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIServiceSubscribeTransactionsClient) Recv() (*TxNotification, error) {
+	m := new(TxNotification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIServiceClient) SubscribeEvidences(ctx context.Context, in *SubscribeEvidencesRequest, opts ...grpc.CallOption) (APIService_SubscribeEvidencesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_APIService_serviceDesc.Streams[2], "/api.APIService/SubscribeEvidences", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIServiceSubscribeEvidencesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type APIService_SubscribeEvidencesClient interface {
+	Recv() (*EvidenceNotification, error)
+	grpc.ClientStream
+}
+
+type aPIServiceSubscribeEvidencesClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIServiceSubscribeEvidencesClient) Recv() (*EvidenceNotification, error) {
+	m := new(EvidenceNotification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// APIServiceServer is the server API for APIService service.
+type APIServiceServer interface {
+	GetBestBlock(context.Context, *empty.Empty) (*GetBestBlockResponse, error)
+	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
+	GetBlockHeader(context.Context, *GetBlockHeaderRequest) (*GetBlockHeaderResponse, error)
+	GetBlockFilter(context.Context, *GetBlockFilterRequest) (*GetBlockFilterResponse, error)
+	GetBlockVerboseV0(context.Context, *GetBlockVerboseRequest) (*GetBlockVerboseV0Response, error)
+	GetBlockVerboseV1(context.Context, *GetBlockVerboseRequest) (*GetBlockVerboseV1Response, error)
+	ListBlocks(context.Context, *ListBlocksRequest) (*ListBlocksResponse, error)
+	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
+	GetEvidence(context.Context, *GetEvidenceRequest) (*GetEvidenceResponse, error)
+	SubmitRawTransaction(context.Context, *RawTransactionRequest) (*SubmitRawTransactionResponse, error)
+	TestMempoolAccept(context.Context, *RawTransactionRequest) (*TestMempoolAcceptResponse, error)
+	DecodeRawTransaction(context.Context, *RawTransactionRequest) (*GetTransactionResponse, error)
+	DecodeRawBlock(context.Context, *RawBlockRequest) (*GetBlockVerboseV1Response, error)
+	GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
+	GetEvidences(context.Context, *GetEvidencesRequest) (*GetEvidencesResponse, error)
+	GetWork(context.Context, *empty.Empty) (*GetWorkResponse, error)
+	SubmitWork(context.Context, *SubmitWorkRequest) (*SubmitWorkResponse, error)
+	StartMining(context.Context, *empty.Empty) (*MiningStatusResponse, error)
+	StopMining(context.Context, *empty.Empty) (*MiningStatusResponse, error)
+	SetMiningWorkers(context.Context, *SetMiningWorkersRequest) (*MiningStatusResponse, error)
+	GetMiningStats(context.Context, *empty.Empty) (*GetMiningStatsResponse, error)
+	GetWalletStatus(context.Context, *empty.Empty) (*GetWalletStatusResponse, error)
+	GetWalletAddresses(context.Context, *PageRequest) (*GetWalletAddressesResponse, error)
+	GetWalletBalance(context.Context, *empty.Empty) (*GetWalletBalanceResponse, error)
+	GetWalletTransactions(context.Context, *PageRequest) (*GetWalletTransactionsResponse, error)
+	GetWalletEvidences(context.Context, *PageRequest) (*GetWalletEvidencesResponse, error)
+	CreateAddress(context.Context, *CreateAddressRequest) (*CreateAddressResponse, error)
+	CreateTransaction(context.Context, *CreateTransactionRequest) (*CreateTransactionResponse, error)
+	SendTransaction(context.Context, *SendTransactionRequest) (*SendTransactionResponse, error)
+	GetPeers(context.Context, *PageRequest) (*GetPeersResponse, error)
+	ConnectPeer(context.Context, *ConnectPeerRequest) (*empty.Empty, error)
+	DisconnectPeer(context.Context, *DisconnectPeerRequest) (*empty.Empty, error)
+	BanPeer(context.Context, *BanPeerRequest) (*BannedPeer, error)
+	UnbanPeer(context.Context, *UnbanPeerRequest) (*empty.Empty, error)
+	ListBannedPeers(context.Context, *PageRequest) (*ListBannedPeersResponse, error)
+	GetNetTotals(context.Context, *empty.Empty) (*NetTotals, error)
+	GetClientStatus(context.Context, *empty.Empty) (*GetClientStatusResponse, error)
+	SubscribeBlocks(*empty.Empty, APIService_SubscribeBlocksServer) error
+	SubscribeTransactions(*empty.Empty, APIService_SubscribeTransactionsServer) error
+	SubscribeEvidences(*SubscribeEvidencesRequest, APIService_SubscribeEvidencesServer) error
+}
+
+// UnimplementedAPIServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAPIServiceServer struct {
+}
+
+func (*UnimplementedAPIServiceServer) GetBestBlock(ctx context.Context, req *empty.Empty) (*GetBestBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBestBlock not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetBlock(ctx context.Context, req *GetBlockRequest) (*GetBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetBlockHeader(ctx context.Context, req *GetBlockHeaderRequest) (*GetBlockHeaderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockHeader not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetBlockFilter(ctx context.Context, req *GetBlockFilterRequest) (*GetBlockFilterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockFilter not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetBlockVerboseV0(ctx context.Context, req *GetBlockVerboseRequest) (*GetBlockVerboseV0Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockVerboseV0 not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetBlockVerboseV1(ctx context.Context, req *GetBlockVerboseRequest) (*GetBlockVerboseV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockVerboseV1 not implemented")
+}
+func (*UnimplementedAPIServiceServer) ListBlocks(ctx context.Context, req *ListBlocksRequest) (*ListBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlocks not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetTransaction(ctx context.Context, req *GetTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetEvidence(ctx context.Context, req *GetEvidenceRequest) (*GetEvidenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvidence not implemented")
+}
+func (*UnimplementedAPIServiceServer) SubmitRawTransaction(ctx context.Context, req *RawTransactionRequest) (*SubmitRawTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitRawTransaction not implemented")
+}
+func (*UnimplementedAPIServiceServer) TestMempoolAccept(ctx context.Context, req *RawTransactionRequest) (*TestMempoolAcceptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestMempoolAccept not implemented")
+}
+func (*UnimplementedAPIServiceServer) DecodeRawTransaction(ctx context.Context, req *RawTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecodeRawTransaction not implemented")
+}
+func (*UnimplementedAPIServiceServer) DecodeRawBlock(ctx context.Context, req *RawBlockRequest) (*GetBlockVerboseV1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecodeRawBlock not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetTransactions(ctx context.Context, req *GetTransactionsRequest) (*GetTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetEvidences(ctx context.Context, req *GetEvidencesRequest) (*GetEvidencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvidences not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetWork(ctx context.Context, req *empty.Empty) (*GetWorkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWork not implemented")
+}
+func (*UnimplementedAPIServiceServer) SubmitWork(ctx context.Context, req *SubmitWorkRequest) (*SubmitWorkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitWork not implemented")
+}
+func (*UnimplementedAPIServiceServer) StartMining(ctx context.Context, req *empty.Empty) (*MiningStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartMining not implemented")
+}
+func (*UnimplementedAPIServiceServer) StopMining(ctx context.Context, req *empty.Empty) (*MiningStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopMining not implemented")
+}
+func (*UnimplementedAPIServiceServer) SetMiningWorkers(ctx context.Context, req *SetMiningWorkersRequest) (*MiningStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMiningWorkers not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetMiningStats(ctx context.Context, req *empty.Empty) (*GetMiningStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMiningStats not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetWalletStatus(ctx context.Context, req *empty.Empty) (*GetWalletStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletStatus not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetWalletAddresses(ctx context.Context, req *PageRequest) (*GetWalletAddressesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletAddresses not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetWalletBalance(ctx context.Context, req *empty.Empty) (*GetWalletBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletBalance not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetWalletTransactions(ctx context.Context, req *PageRequest) (*GetWalletTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletTransactions not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetWalletEvidences(ctx context.Context, req *PageRequest) (*GetWalletEvidencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletEvidences not implemented")
+}
+func (*UnimplementedAPIServiceServer) CreateAddress(ctx context.Context, req *CreateAddressRequest) (*CreateAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAddress not implemented")
+}
+func (*UnimplementedAPIServiceServer) CreateTransaction(ctx context.Context, req *CreateTransactionRequest) (*CreateTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransaction not implemented")
+}
+func (*UnimplementedAPIServiceServer) SendTransaction(ctx context.Context, req *SendTransactionRequest) (*SendTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTransaction not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetPeers(ctx context.Context, req *PageRequest) (*GetPeersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeers not implemented")
+}
+func (*UnimplementedAPIServiceServer) ConnectPeer(ctx context.Context, req *ConnectPeerRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConnectPeer not implemented")
+}
+func (*UnimplementedAPIServiceServer) DisconnectPeer(ctx context.Context, req *DisconnectPeerRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisconnectPeer not implemented")
+}
+func (*UnimplementedAPIServiceServer) BanPeer(ctx context.Context, req *BanPeerRequest) (*BannedPeer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanPeer not implemented")
+}
+func (*UnimplementedAPIServiceServer) UnbanPeer(ctx context.Context, req *UnbanPeerRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbanPeer not implemented")
+}
+func (*UnimplementedAPIServiceServer) ListBannedPeers(ctx context.Context, req *PageRequest) (*ListBannedPeersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBannedPeers not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetNetTotals(ctx context.Context, req *empty.Empty) (*NetTotals, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetTotals not implemented")
+}
+func (*UnimplementedAPIServiceServer) GetClientStatus(ctx context.Context, req *empty.Empty) (*GetClientStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientStatus not implemented")
+}
+func (*UnimplementedAPIServiceServer) SubscribeBlocks(req *empty.Empty, srv APIService_SubscribeBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlocks not implemented")
+}
+func (*UnimplementedAPIServiceServer) SubscribeTransactions(req *empty.Empty, srv APIService_SubscribeTransactionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTransactions not implemented")
+}
+func (*UnimplementedAPIServiceServer) SubscribeEvidences(req *SubscribeEvidencesRequest, srv APIService_SubscribeEvidencesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvidences not implemented")
+}
+
+func RegisterAPIServiceServer(s *grpc.Server, srv APIServiceServer) {
+	s.RegisterService(&_APIService_serviceDesc, srv)
+}
+
+func _APIService_GetBestBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetBestBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetBestBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetBestBlock(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetBlockHeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockHeaderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetBlockHeader(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetBlockHeader",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetBlockHeader(ctx, req.(*GetBlockHeaderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetBlockFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetBlockFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetBlockFilter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetBlockFilter(ctx, req.(*GetBlockFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetBlockVerboseV0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockVerboseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetBlockVerboseV0(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetBlockVerboseV0",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetBlockVerboseV0(ctx, req.(*GetBlockVerboseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetBlockVerboseV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockVerboseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetBlockVerboseV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetBlockVerboseV1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetBlockVerboseV1(ctx, req.(*GetBlockVerboseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_ListBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).ListBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/ListBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).ListBlocks(ctx, req.(*ListBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetEvidence(ctx, req.(*GetEvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_SubmitRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RawTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).SubmitRawTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/SubmitRawTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).SubmitRawTransaction(ctx, req.(*RawTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_TestMempoolAccept_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RawTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).TestMempoolAccept(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/TestMempoolAccept",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).TestMempoolAccept(ctx, req.(*RawTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_DecodeRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RawTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).DecodeRawTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/DecodeRawTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).DecodeRawTransaction(ctx, req.(*RawTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_DecodeRawBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RawBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).DecodeRawBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/DecodeRawBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).DecodeRawBlock(ctx, req.(*RawBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetEvidences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEvidencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetEvidences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetEvidences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetEvidences(ctx, req.(*GetEvidencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetWork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetWork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetWork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetWork(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_SubmitWork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitWorkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).SubmitWork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/SubmitWork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).SubmitWork(ctx, req.(*SubmitWorkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_StartMining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).StartMining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/StartMining",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).StartMining(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_StopMining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).StopMining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/StopMining",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).StopMining(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_SetMiningWorkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMiningWorkersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).SetMiningWorkers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/SetMiningWorkers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).SetMiningWorkers(ctx, req.(*SetMiningWorkersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetMiningStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetMiningStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetMiningStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetMiningStats(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *aPIServiceClient) GetBestBlock(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*GetBestBlockResponse, error) {
-	out := new(GetBestBlockResponse)
-	err := grpc.Invoke(ctx, "/api.APIService/GetBestBlock", in, out, c.cc, opts...)
-	if err != nil {
+func _APIService_GetWalletStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetWalletStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetWalletStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetWalletStatus(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *aPIServiceClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error) {
-	out := new(GetBlockResponse)
-	err := grpc.Invoke(ctx, "/api.APIService/GetBlock", in, out, c.cc, opts...)
-	if err != nil {
+func _APIService_GetWalletAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetWalletAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetWalletAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetWalletAddresses(ctx, req.(*PageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *aPIServiceClient) GetBlockHeader(ctx context.Context, in *GetBlockHeaderRequest, opts ...grpc.CallOption) (*GetBlockHeaderResponse, error) {
-	out := new(GetBlockHeaderResponse)
-	err := grpc.Invoke(ctx, "/api.APIService/GetBlockHeader", in, out, c.cc, opts...)
-	if err != nil {
+func _APIService_GetWalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetWalletBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetWalletBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetWalletBalance(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *aPIServiceClient) GetBlockVerboseV0(ctx context.Context, in *GetBlockVerboseRequest, opts ...grpc.CallOption) (*GetBlockVerboseV0Response, error) {
-	out := new(GetBlockVerboseV0Response)
-	err := grpc.Invoke(ctx, "/api.APIService/GetBlockVerboseV0", in, out, c.cc, opts...)
-	if err != nil {
+func _APIService_GetWalletTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetWalletTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetWalletTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetWalletTransactions(ctx, req.(*PageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *aPIServiceClient) GetBlockVerboseV1(ctx context.Context, in *GetBlockVerboseRequest, opts ...grpc.CallOption) (*GetBlockVerboseV1Response, error) {
-	out := new(GetBlockVerboseV1Response)
-	err := grpc.Invoke(ctx, "/api.APIService/GetBlockVerboseV1", in, out, c.cc, opts...)
-	if err != nil {
+func _APIService_GetWalletEvidences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetWalletEvidences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetWalletEvidences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetWalletEvidences(ctx, req.(*PageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *aPIServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
-	out := new(GetTransactionResponse)
-	err := grpc.Invoke(ctx, "/api.APIService/GetTransaction", in, out, c.cc, opts...)
-	if err != nil {
+func _APIService_CreateAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAddressRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(APIServiceServer).CreateAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/CreateAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).CreateAddress(ctx, req.(*CreateAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *aPIServiceClient) GetEvidence(ctx context.Context, in *GetEvidenceRequest, opts ...grpc.CallOption) (*GetEvidenceResponse, error) {
-	out := new(GetEvidenceResponse)
-	err := grpc.Invoke(ctx, "/api.APIService/GetEvidence", in, out, c.cc, opts...)
-	if err != nil {
+func _APIService_CreateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransactionRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(APIServiceServer).CreateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/CreateTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).CreateTransaction(ctx, req.(*CreateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// Server API for APIService service
-
-type APIServiceServer interface {
-	GetBestBlock(context.Context, *google_protobuf1.Empty) (*GetBestBlockResponse, error)
-	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
-	GetBlockHeader(context.Context, *GetBlockHeaderRequest) (*GetBlockHeaderResponse, error)
-	GetBlockVerboseV0(context.Context, *GetBlockVerboseRequest) (*GetBlockVerboseV0Response, error)
-	GetBlockVerboseV1(context.Context, *GetBlockVerboseRequest) (*GetBlockVerboseV1Response, error)
-	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
-	GetEvidence(context.Context, *GetEvidenceRequest) (*GetEvidenceResponse, error)
+func _APIService_SendTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).SendTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/SendTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).SendTransaction(ctx, req.(*SendTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterAPIServiceServer(s *grpc.Server, srv APIServiceServer) {
-	s.RegisterService(&_APIService_serviceDesc, srv)
+func _APIService_GetPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.APIService/GetPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetPeers(ctx, req.(*PageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _APIService_GetBestBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(google_protobuf1.Empty)
+func _APIService_ConnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectPeerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServiceServer).GetBestBlock(ctx, in)
+		return srv.(APIServiceServer).ConnectPeer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.APIService/GetBestBlock",
+		FullMethod: "/api.APIService/ConnectPeer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServiceServer).GetBestBlock(ctx, req.(*google_protobuf1.Empty))
+		return srv.(APIServiceServer).ConnectPeer(ctx, req.(*ConnectPeerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _APIService_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBlockRequest)
+func _APIService_DisconnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectPeerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServiceServer).GetBlock(ctx, in)
+		return srv.(APIServiceServer).DisconnectPeer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.APIService/GetBlock",
+		FullMethod: "/api.APIService/DisconnectPeer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServiceServer).GetBlock(ctx, req.(*GetBlockRequest))
+		return srv.(APIServiceServer).DisconnectPeer(ctx, req.(*DisconnectPeerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _APIService_GetBlockHeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBlockHeaderRequest)
+func _APIService_BanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanPeerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServiceServer).GetBlockHeader(ctx, in)
+		return srv.(APIServiceServer).BanPeer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.APIService/GetBlockHeader",
+		FullMethod: "/api.APIService/BanPeer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServiceServer).GetBlockHeader(ctx, req.(*GetBlockHeaderRequest))
+		return srv.(APIServiceServer).BanPeer(ctx, req.(*BanPeerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _APIService_GetBlockVerboseV0_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBlockVerboseRequest)
+func _APIService_UnbanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanPeerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServiceServer).GetBlockVerboseV0(ctx, in)
+		return srv.(APIServiceServer).UnbanPeer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.APIService/GetBlockVerboseV0",
+		FullMethod: "/api.APIService/UnbanPeer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServiceServer).GetBlockVerboseV0(ctx, req.(*GetBlockVerboseRequest))
+		return srv.(APIServiceServer).UnbanPeer(ctx, req.(*UnbanPeerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _APIService_GetBlockVerboseV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBlockVerboseRequest)
+func _APIService_ListBannedPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServiceServer).GetBlockVerboseV1(ctx, in)
+		return srv.(APIServiceServer).ListBannedPeers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.APIService/GetBlockVerboseV1",
+		FullMethod: "/api.APIService/ListBannedPeers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServiceServer).GetBlockVerboseV1(ctx, req.(*GetBlockVerboseRequest))
+		return srv.(APIServiceServer).ListBannedPeers(ctx, req.(*PageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _APIService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTransactionRequest)
+func _APIService_GetNetTotals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServiceServer).GetTransaction(ctx, in)
+		return srv.(APIServiceServer).GetNetTotals(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.APIService/GetTransaction",
+		FullMethod: "/api.APIService/GetNetTotals",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+		return srv.(APIServiceServer).GetNetTotals(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _APIService_GetEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetEvidenceRequest)
+func _APIService_GetClientStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServiceServer).GetEvidence(ctx, in)
+		return srv.(APIServiceServer).GetClientStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.APIService/GetEvidence",
+		FullMethod: "/api.APIService/GetClientStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServiceServer).GetEvidence(ctx, req.(*GetEvidenceRequest))
+		return srv.(APIServiceServer).GetClientStatus(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _APIService_SubscribeBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(empty.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServiceServer).SubscribeBlocks(m, &aPIServiceSubscribeBlocksServer{stream})
+}
+
+type APIService_SubscribeBlocksServer interface {
+	Send(*BlockNotification) error
+	grpc.ServerStream
+}
+
+type aPIServiceSubscribeBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIServiceSubscribeBlocksServer) Send(m *BlockNotification) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _APIService_SubscribeTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(empty.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServiceServer).SubscribeTransactions(m, &aPIServiceSubscribeTransactionsServer{stream})
+}
+
+type APIService_SubscribeTransactionsServer interface {
+	Send(*TxNotification) error
+	grpc.ServerStream
+}
+
+type aPIServiceSubscribeTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIServiceSubscribeTransactionsServer) Send(m *TxNotification) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _APIService_SubscribeEvidences_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEvidencesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServiceServer).SubscribeEvidences(m, &aPIServiceSubscribeEvidencesServer{stream})
+}
+
+type APIService_SubscribeEvidencesServer interface {
+	Send(*EvidenceNotification) error
+	grpc.ServerStream
+}
+
+type aPIServiceSubscribeEvidencesServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIServiceSubscribeEvidencesServer) Send(m *EvidenceNotification) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _APIService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.APIService",
 	HandlerType: (*APIServiceServer)(nil),
@@ -1218,6 +5829,10 @@ var _APIService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockHeader",
 			Handler:    _APIService_GetBlockHeader_Handler,
 		},
+		{
+			MethodName: "GetBlockFilter",
+			Handler:    _APIService_GetBlockFilter_Handler,
+		},
 		{
 			MethodName: "GetBlockVerboseV0",
 			Handler:    _APIService_GetBlockVerboseV0_Handler,
@@ -1226,6 +5841,10 @@ var _APIService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockVerboseV1",
 			Handler:    _APIService_GetBlockVerboseV1_Handler,
 		},
+		{
+			MethodName: "ListBlocks",
+			Handler:    _APIService_ListBlocks_Handler,
+		},
 		{
 			MethodName: "GetTransaction",
 			Handler:    _APIService_GetTransaction_Handler,
@@ -1234,80 +5853,135 @@ var _APIService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetEvidence",
 			Handler:    _APIService_GetEvidence_Handler,
 		},
+		{
+			MethodName: "SubmitRawTransaction",
+			Handler:    _APIService_SubmitRawTransaction_Handler,
+		},
+		{
+			MethodName: "TestMempoolAccept",
+			Handler:    _APIService_TestMempoolAccept_Handler,
+		},
+		{
+			MethodName: "DecodeRawTransaction",
+			Handler:    _APIService_DecodeRawTransaction_Handler,
+		},
+		{
+			MethodName: "DecodeRawBlock",
+			Handler:    _APIService_DecodeRawBlock_Handler,
+		},
+		{
+			MethodName: "GetTransactions",
+			Handler:    _APIService_GetTransactions_Handler,
+		},
+		{
+			MethodName: "GetEvidences",
+			Handler:    _APIService_GetEvidences_Handler,
+		},
+		{
+			MethodName: "GetWork",
+			Handler:    _APIService_GetWork_Handler,
+		},
+		{
+			MethodName: "SubmitWork",
+			Handler:    _APIService_SubmitWork_Handler,
+		},
+		{
+			MethodName: "StartMining",
+			Handler:    _APIService_StartMining_Handler,
+		},
+		{
+			MethodName: "StopMining",
+			Handler:    _APIService_StopMining_Handler,
+		},
+		{
+			MethodName: "SetMiningWorkers",
+			Handler:    _APIService_SetMiningWorkers_Handler,
+		},
+		{
+			MethodName: "GetMiningStats",
+			Handler:    _APIService_GetMiningStats_Handler,
+		},
+		{
+			MethodName: "GetWalletStatus",
+			Handler:    _APIService_GetWalletStatus_Handler,
+		},
+		{
+			MethodName: "GetWalletAddresses",
+			Handler:    _APIService_GetWalletAddresses_Handler,
+		},
+		{
+			MethodName: "GetWalletBalance",
+			Handler:    _APIService_GetWalletBalance_Handler,
+		},
+		{
+			MethodName: "GetWalletTransactions",
+			Handler:    _APIService_GetWalletTransactions_Handler,
+		},
+		{
+			MethodName: "GetWalletEvidences",
+			Handler:    _APIService_GetWalletEvidences_Handler,
+		},
+		{
+			MethodName: "CreateAddress",
+			Handler:    _APIService_CreateAddress_Handler,
+		},
+		{
+			MethodName: "CreateTransaction",
+			Handler:    _APIService_CreateTransaction_Handler,
+		},
+		{
+			MethodName: "SendTransaction",
+			Handler:    _APIService_SendTransaction_Handler,
+		},
+		{
+			MethodName: "GetPeers",
+			Handler:    _APIService_GetPeers_Handler,
+		},
+		{
+			MethodName: "ConnectPeer",
+			Handler:    _APIService_ConnectPeer_Handler,
+		},
+		{
+			MethodName: "DisconnectPeer",
+			Handler:    _APIService_DisconnectPeer_Handler,
+		},
+		{
+			MethodName: "BanPeer",
+			Handler:    _APIService_BanPeer_Handler,
+		},
+		{
+			MethodName: "UnbanPeer",
+			Handler:    _APIService_UnbanPeer_Handler,
+		},
+		{
+			MethodName: "ListBannedPeers",
+			Handler:    _APIService_ListBannedPeers_Handler,
+		},
+		{
+			MethodName: "GetNetTotals",
+			Handler:    _APIService_GetNetTotals_Handler,
+		},
+		{
+			MethodName: "GetClientStatus",
+			Handler:    _APIService_GetClientStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBlocks",
+			Handler:       _APIService_SubscribeBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeTransactions",
+			Handler:       _APIService_SubscribeTransactions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEvidences",
+			Handler:       _APIService_SubscribeEvidences_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
 }
-
-func init() { proto.RegisterFile("api.proto", fileDescriptorApi) }
-
-var fileDescriptorApi = []byte{
-	// 1067 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x57, 0xcf, 0x6e, 0xdb, 0xc6,
-	0x13, 0x86, 0x28, 0xca, 0x96, 0x86, 0x4a, 0x9c, 0xec, 0x4f, 0x91, 0x29, 0xca, 0x48, 0x64, 0xfe,
-	0x0a, 0x44, 0xad, 0x0b, 0x31, 0x72, 0x51, 0xb4, 0x40, 0x80, 0x16, 0x35, 0x10, 0x24, 0xbe, 0x34,
-	0x06, 0x2d, 0xf8, 0xd0, 0x43, 0x05, 0x4a, 0xdc, 0x48, 0xdb, 0x5a, 0x5c, 0x86, 0xbb, 0x52, 0x55,
-	0x18, 0xbe, 0xf4, 0x15, 0xfa, 0x08, 0x05, 0xfa, 0x0a, 0xbd, 0xf6, 0x1d, 0x72, 0xec, 0xa1, 0x40,
-	0xd1, 0x07, 0xe8, 0xa9, 0xe7, 0x62, 0x77, 0x49, 0x89, 0xa4, 0x68, 0xf5, 0x0f, 0xd0, 0x53, 0x7d,
-	0xe3, 0xcc, 0x7c, 0xbb, 0x33, 0xfb, 0xcd, 0xb7, 0x7f, 0x08, 0x35, 0x2f, 0x24, 0xbd, 0x30, 0xa2,
-	0x9c, 0xa2, 0xb2, 0x17, 0x12, 0xeb, 0x60, 0x42, 0xe9, 0xe4, 0x12, 0x3b, 0x5e, 0x48, 0x1c, 0x2f,
-	0x08, 0x28, 0xf7, 0x38, 0xa1, 0x01, 0x53, 0x10, 0xab, 0x1d, 0x47, 0xa5, 0x35, 0x9a, 0xbf, 0x72,
-	0xf0, 0x2c, 0xe4, 0x5f, 0xab, 0xa0, 0x7d, 0x02, 0x8d, 0xe7, 0x98, 0x9f, 0x60, 0xc6, 0x4f, 0x2e,
-	0xe9, 0xf8, 0x4b, 0x17, 0xb3, 0x90, 0x06, 0x0c, 0xa3, 0x26, 0xec, 0x4c, 0x31, 0x99, 0x4c, 0xb9,
-	0x59, 0xea, 0x94, 0xba, 0xba, 0x1b, 0x5b, 0x08, 0x81, 0x3e, 0xf5, 0xd8, 0xd4, 0xd4, 0x3a, 0xa5,
-	0x6e, 0xcd, 0x95, 0xdf, 0xf6, 0xfb, 0x50, 0x39, 0x8b, 0x28, 0x7d, 0x25, 0x06, 0x71, 0x2f, 0x9a,
-	0xe0, 0xd5, 0x20, 0x65, 0xa1, 0x06, 0x54, 0x02, 0x1a, 0x8c, 0xb1, 0x1c, 0xa5, 0xbb, 0xca, 0xb0,
-	0x0f, 0x61, 0x4f, 0xa4, 0x56, 0x69, 0x5f, 0xcf, 0x31, 0xe3, 0xe8, 0x2e, 0x68, 0xc4, 0x97, 0x83,
-	0x6b, 0xae, 0x46, 0x7c, 0xfb, 0x67, 0x0d, 0xee, 0xad, 0x31, 0x71, 0x69, 0x49, 0x09, 0xa5, 0x75,
-	0x09, 0xa8, 0x05, 0xd5, 0xf1, 0xd4, 0x23, 0xc1, 0x90, 0xf8, 0x71, 0x69, 0xbb, 0xd2, 0x3e, 0xf5,
-	0x91, 0x09, 0xbb, 0x0b, 0x1c, 0x31, 0x42, 0x03, 0xb3, 0x2c, 0xd3, 0x27, 0x66, 0x6a, 0x8d, 0x7a,
-	0x66, 0x8d, 0x07, 0x50, 0xe3, 0x64, 0x86, 0x19, 0xf7, 0x66, 0xa1, 0x59, 0x91, 0xa1, 0xb5, 0x03,
-	0x59, 0x50, 0x0d, 0x23, 0xbc, 0x20, 0x74, 0xce, 0xcc, 0x1d, 0x99, 0x6a, 0x65, 0xa3, 0xb7, 0xe1,
-	0x1e, 0x8f, 0xbc, 0x80, 0x79, 0x63, 0xd1, 0x80, 0x61, 0x44, 0x29, 0x37, 0x77, 0x25, 0x66, 0x2f,
-	0xe5, 0x77, 0x29, 0xe5, 0xe8, 0x10, 0xea, 0x5f, 0x11, 0x1e, 0x60, 0xc6, 0x14, 0xac, 0x2a, 0x61,
-	0x46, 0xec, 0x93, 0x90, 0x0e, 0x54, 0x42, 0xc1, 0xab, 0x59, 0xeb, 0x94, 0xba, 0xc6, 0x31, 0xf4,
-	0x44, 0xdb, 0x25, 0xd3, 0xae, 0x0a, 0x20, 0x1b, 0xea, 0xa9, 0x79, 0x99, 0x09, 0x9d, 0x72, 0xb7,
-	0xe6, 0x66, 0x7c, 0x62, 0x35, 0x78, 0x41, 0x7c, 0x1c, 0x8c, 0x31, 0x33, 0x0d, 0x09, 0x58, 0x3b,
-	0xec, 0xc7, 0xf0, 0x20, 0x21, 0xf8, 0x05, 0xf6, 0x7c, 0x1c, 0xdd, 0xd4, 0x8a, 0xef, 0x34, 0x68,
-	0xe6, 0x91, 0xb7, 0x0d, 0xc9, 0x35, 0xc4, 0xee, 0xae, 0x49, 0xba, 0xc0, 0xd1, 0x88, 0x32, 0x7c,
-	0x13, 0x9f, 0x3f, 0x94, 0xa1, 0x95, 0x83, 0x5e, 0x3c, 0xb9, 0xa5, 0x74, 0x53, 0xe3, 0x9f, 0x16,
-	0x68, 0xdc, 0x38, 0x7e, 0x47, 0x02, 0x6f, 0x24, 0xb0, 0x37, 0x48, 0x95, 0x92, 0x19, 0x6f, 0x7d,
-	0x0c, 0x46, 0x2a, 0x28, 0x98, 0xe6, 0xcb, 0x55, 0x67, 0xe4, 0x77, 0x76, 0xcb, 0x68, 0xf9, 0x2d,
-	0xf3, 0x46, 0xdb, 0xec, 0x5c, 0xff, 0xb6, 0x73, 0x9b, 0x9d, 0x3b, 0x2a, 0xec, 0xdc, 0xae, 0x04,
-	0x0e, 0x96, 0xd9, 0xb6, 0xd8, 0xbf, 0x95, 0x41, 0x1b, 0x2c, 0x0b, 0xdb, 0x91, 0xe2, 0x48, 0xcb,
-	0x72, 0xf4, 0x16, 0xec, 0x90, 0x20, 0x9c, 0x73, 0x66, 0x96, 0xe5, 0xdc, 0xf5, 0x78, 0xee, 0xde,
-	0x60, 0x79, 0x1a, 0xb8, 0x71, 0x0c, 0x3d, 0x86, 0x5d, 0x3a, 0xe7, 0x12, 0xa6, 0x4b, 0xd8, 0x9d,
-	0x35, 0xec, 0xe5, 0x9c, 0xbb, 0x49, 0x14, 0x1d, 0xa5, 0xfb, 0x5e, 0x49, 0x41, 0x9f, 0xc5, 0xde,
-	0x94, 0x0c, 0x50, 0x1b, 0x6a, 0x42, 0x01, 0x43, 0xc1, 0xbd, 0xa4, 0x5a, 0x77, 0xab, 0xc2, 0x31,
-	0x20, 0x33, 0x6c, 0xfd, 0x52, 0x02, 0x5d, 0xd4, 0x80, 0x9e, 0x42, 0x7d, 0xe1, 0x5d, 0xce, 0xf1,
-	0x90, 0xd1, 0x79, 0x34, 0xc6, 0x72, 0x5d, 0xc6, 0xb1, 0x99, 0xae, 0xb3, 0x77, 0x21, 0x00, 0xe7,
-	0x32, 0xee, 0x1a, 0x8b, 0xb5, 0x81, 0xfe, 0x0f, 0x77, 0x22, 0xec, 0x63, 0x3c, 0x1b, 0xb2, 0x71,
-	0x44, 0x42, 0x1e, 0x8b, 0xa7, 0xae, 0x9c, 0xe7, 0xd2, 0x27, 0x40, 0xf3, 0x40, 0x56, 0x12, 0x83,
-	0xca, 0x0a, 0xa4, 0x9c, 0x31, 0xc8, 0x82, 0x2a, 0x13, 0x07, 0x91, 0xb8, 0x84, 0x95, 0x9c, 0x56,
-	0xb6, 0xf5, 0x01, 0x18, 0xa9, 0x0a, 0x0a, 0x3b, 0xd0, 0x80, 0x0a, 0x09, 0x7c, 0xbc, 0x4c, 0x2e,
-	0x70, 0x69, 0x58, 0x1f, 0x41, 0x45, 0x12, 0x28, 0xc2, 0xb2, 0xec, 0xf8, 0xda, 0x57, 0x06, 0x7a,
-	0x04, 0x86, 0xaa, 0x68, 0x98, 0x7a, 0x31, 0x80, 0x72, 0xbd, 0x10, 0xef, 0x86, 0xd7, 0x50, 0x4d,
-	0x88, 0x15, 0x59, 0x05, 0xb5, 0x49, 0x56, 0xf1, 0x2d, 0x76, 0x80, 0x4f, 0x26, 0x98, 0x25, 0xeb,
-	0x8e, 0x2d, 0xe1, 0x8f, 0xd9, 0x54, 0x4b, 0x8d, 0x2d, 0x21, 0xda, 0x85, 0x77, 0x49, 0xfc, 0x84,
-	0x08, 0x5d, 0x89, 0x56, 0xfa, 0x14, 0x0f, 0xf6, 0x91, 0xbc, 0xee, 0xd2, 0x87, 0x43, 0x7c, 0x3c,
-	0x17, 0xac, 0xda, 0xfe, 0x51, 0x97, 0xa7, 0x79, 0x06, 0xbd, 0xde, 0xe5, 0x7f, 0x43, 0xa6, 0x1f,
-	0xe6, 0x64, 0xda, 0x49, 0x0e, 0xaf, 0x82, 0xa9, 0xb3, 0xd2, 0x7d, 0x9a, 0x97, 0xee, 0xe1, 0xf6,
-	0xa1, 0xff, 0x92, 0x9c, 0x7f, 0x4f, 0xe4, 0xfc, 0xb2, 0x50, 0xce, 0xef, 0xfe, 0xd9, 0x7a, 0xfe,
-	0xb3, 0x12, 0xef, 0x02, 0x7a, 0x8e, 0xf9, 0x8a, 0xef, 0xb5, 0xd8, 0xf2, 0x62, 0xb7, 0xbf, 0x2f,
-	0xc1, 0xff, 0x32, 0xd0, 0x2d, 0x4a, 0x2b, 0xac, 0x75, 0x35, 0x6b, 0xb9, 0x70, 0x0b, 0xe9, 0x37,
-	0x6c, 0xa1, 0xca, 0xd6, 0x2d, 0xb4, 0xb3, 0xb1, 0x85, 0x8e, 0x7f, 0xaa, 0x00, 0x7c, 0x72, 0x76,
-	0x7a, 0x8e, 0xa3, 0x05, 0x19, 0x63, 0xf4, 0x19, 0xd4, 0xd3, 0x3f, 0x10, 0xa8, 0xd9, 0x53, 0xbf,
-	0x1b, 0xbd, 0xe4, 0x77, 0xa3, 0xf7, 0x4c, 0xfc, 0x6e, 0x58, 0xad, 0xd5, 0x85, 0x9d, 0xff, 0xd7,
-	0xb0, 0xf7, 0xbf, 0x79, 0xf3, 0xeb, 0xb7, 0xda, 0x7d, 0xb4, 0xe7, 0x2c, 0xfa, 0xce, 0x48, 0x84,
-	0x98, 0x33, 0x12, 0x55, 0x9e, 0x41, 0x35, 0xb9, 0x68, 0x51, 0x23, 0x73, 0xe1, 0xc7, 0x4c, 0x5a,
-	0x0f, 0x72, 0xde, 0x2d, 0x33, 0x5e, 0x11, 0xff, 0x1a, 0x11, 0xb8, 0x9b, 0x7d, 0xc4, 0x22, 0x2b,
-	0x33, 0x43, 0xe6, 0x0d, 0x6c, 0xb5, 0x0b, 0x63, 0x71, 0x8e, 0x87, 0x32, 0x87, 0x89, 0x9a, 0xb9,
-	0x1c, 0xce, 0x54, 0x4d, 0xcc, 0xe0, 0xfe, 0xc6, 0xf3, 0x04, 0xb5, 0x8b, 0x9e, 0x2d, 0x49, 0xba,
-	0x87, 0xdb, 0xdf, 0x34, 0xf6, 0xa1, 0xcc, 0xd8, 0x46, 0xad, 0x7c, 0xc6, 0x85, 0x82, 0x3a, 0x4f,
-	0x8a, 0x92, 0xf6, 0xff, 0x49, 0xd2, 0xfe, 0x5f, 0x4f, 0xda, 0x47, 0x5f, 0x48, 0x52, 0xd3, 0x8f,
-	0x2a, 0xab, 0xf0, 0x40, 0xc8, 0x91, 0x5a, 0x70, 0x58, 0xd8, 0x8f, 0x64, 0xb6, 0x16, 0xda, 0x17,
-	0xd9, 0xd2, 0xef, 0x03, 0xe7, 0x4a, 0x28, 0xff, 0x1a, 0x7d, 0x0e, 0x46, 0x6a, 0x97, 0xa0, 0xfd,
-	0x64, 0xb2, 0xdc, 0x16, 0xb3, 0xcc, 0xcd, 0x40, 0x9c, 0xe2, 0x40, 0xa6, 0x68, 0xa2, 0x86, 0x48,
-	0xb1, 0x3a, 0xff, 0x9c, 0x2b, 0xf1, 0x79, 0x3d, 0xda, 0x91, 0xb2, 0x7d, 0xef, 0x8f, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x28, 0xcf, 0x16, 0xc4, 0x63, 0x0f, 0x00, 0x00,
-}
@@ -1,13 +1,72 @@
 package api
 
 import (
-	_ "github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
+
+	dbm "github.com/tendermint/tmlibs/db"
+
+	cfg "github.com/clarenous/go-capsule/config"
+	"github.com/clarenous/go-capsule/consensus"
+	_ "github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/database/leveldb"
+	"github.com/clarenous/go-capsule/event"
+	"github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/test/mock"
 )
 
+const apiSpec = `{
+	"chain_id": "apinet",
+	"bech32_hrp": "am",
+	"default_port": "46680",
+	"genesis": {"timestamp": 1546300800, "target": 2305843009214532812, "nonce": 7},
+	"subsidy": {"initial": 1000, "base": 100, "reduction_interval": 10},
+	"retarget": {"target_seconds_per_block": 60},
+	"proof_forks": [{"height": 0, "type": "pow"}]
+}`
+
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "api")
+	if err != nil {
+		panic(err)
+	}
+
+	specFile := filepath.Join(dir, "chain_spec.json")
+	if err := ioutil.WriteFile(specFile, []byte(apiSpec), 0644); err != nil {
+		panic(err)
+	}
+	spec, err := cfg.LoadChainSpec(specFile)
+	if err != nil {
+		panic(err)
+	}
+	if err := cfg.RegisterChainSpec(spec); err != nil {
+		panic(err)
+	}
+	consensus.ActiveNetParams = consensus.NetParams[spec.ChainID]
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// newTestChain returns a chain at the genesis of the test network, the inputs
+// of all the txs are unspent outputs to its tx pool.
+func newTestChain(t *testing.T) (*protocol.Chain, *protocol.TxPool, *event.Dispatcher) {
+	store := leveldb.NewStore(dbm.NewMemDB())
+	dispatcher := event.NewDispatcher()
+	txPool := protocol.NewTxPool(&mock.Store{}, dispatcher)
+	chain, err := protocol.NewChain(store, txPool, dispatcher)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return chain, txPool, dispatcher
+}
+
 func TestAPI(t *testing.T) {
-	a := NewAPI(nil, nil, nil)
+	a := NewAPI(nil, nil, nil, nil, nil, cfg.DefaultConfig())
 
 	err := a.Start()
 	if err != nil {
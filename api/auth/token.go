@@ -0,0 +1,275 @@
+// Package auth keeps the access tokens of the api, the tokens are saved in a
+// json file and only the hash of the secret is stored.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/clarenous/go-capsule/errors"
+)
+
+// Scopes of the tokens, the admin scope includes all the others
+const (
+	ScopeRead   = "read"
+	ScopeWallet = "wallet"
+	ScopeMining = "mining"
+	ScopeAdmin  = "admin"
+)
+
+const secretSize = 32
+
+var (
+	ErrInvalidToken  = errors.New("invalid access token")
+	ErrInvalidName   = errors.New("invalid token name")
+	ErrInvalidScope  = errors.New("invalid token scope")
+	ErrTokenExists   = errors.New("token name already exists")
+	ErrTokenNotFound = errors.New("token not found")
+
+	// Scopes are the scopes a token may be granted
+	Scopes = []string{ScopeRead, ScopeWallet, ScopeMining, ScopeAdmin}
+
+	namePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,64}$`)
+)
+
+// Token is an access token of the api, the credential given to the client is
+// the name and the hex secret joined by a colon.
+type Token struct {
+	Name    string    `json:"name"`
+	Hash    string    `json:"hash"`
+	Scopes  []string  `json:"scopes"`
+	Created time.Time `json:"created"`
+}
+
+// HasScope returns true if the token is granted the scope
+func (t *Token) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Store is the token file, it is reloaded when modified by the token commands
+// of another process.
+type Store struct {
+	file string
+
+	mtx     sync.Mutex
+	modTime time.Time
+	tokens  map[string]*Token
+}
+
+// NewStore returns the store of the token file, the file is created on the
+// first token.
+func NewStore(file string) *Store {
+	return &Store{file: file, tokens: make(map[string]*Token)}
+}
+
+// Create adds the token of the scopes and returns its credential, the secret
+// can't be recovered later.
+func (s *Store) Create(name string, scopes []string) (string, error) {
+	if !namePattern.MatchString(name) {
+		return "", ErrInvalidName
+	}
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return "", err
+	}
+
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if err := s.load(); err != nil {
+		return "", err
+	}
+	if _, ok := s.tokens[name]; ok {
+		return "", ErrTokenExists
+	}
+
+	s.tokens[name] = &Token{
+		Name:    name,
+		Hash:    secretHash(secret),
+		Scopes:  scopes,
+		Created: time.Now().UTC(),
+	}
+	if err := s.save(); err != nil {
+		delete(s.tokens, name)
+		return "", err
+	}
+	return name + ":" + hex.EncodeToString(secret), nil
+}
+
+// Delete removes the token of the name
+func (s *Store) Delete(name string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	token, ok := s.tokens[name]
+	if !ok {
+		return ErrTokenNotFound
+	}
+
+	delete(s.tokens, name)
+	if err := s.save(); err != nil {
+		s.tokens[name] = token
+		return err
+	}
+	return nil
+}
+
+// List returns the tokens sorted by name
+func (s *Store) List() ([]*Token, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*Token, 0, len(s.tokens))
+	for _, token := range s.tokens {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].Name < tokens[j].Name })
+	return tokens, nil
+}
+
+// Authenticate returns the token of the credential
+func (s *Store) Authenticate(credential string) (*Token, error) {
+	i := strings.IndexByte(credential, ':')
+	if i < 0 {
+		return nil, ErrInvalidToken
+	}
+	secret, err := hex.DecodeString(credential[i+1:])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	token, ok := s.tokens[credential[:i]]
+	if !ok || subtle.ConstantTimeCompare([]byte(token.Hash), []byte(secretHash(secret))) != 1 {
+		return nil, ErrInvalidToken
+	}
+	return token, nil
+}
+
+// load reads the token file if it is modified since the last load
+func (s *Store) load() error {
+	info, err := os.Stat(s.file)
+	if os.IsNotExist(err) {
+		s.tokens, s.modTime = make(map[string]*Token), time.Time{}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.ModTime().Equal(s.modTime) {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(s.file)
+	if err != nil {
+		return err
+	}
+	var tokens []*Token
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return errors.Wrap(err, "token file")
+	}
+
+	s.tokens = make(map[string]*Token, len(tokens))
+	for _, token := range tokens {
+		s.tokens[token.Name] = token
+	}
+	s.modTime = info.ModTime()
+	return nil
+}
+
+// save writes the tokens through a temporary file, a reader never sees a
+// partial file
+func (s *Store) save() error {
+	tokens := make([]*Token, 0, len(s.tokens))
+	for _, token := range s.tokens {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].Name < tokens[j].Name })
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpFile := s.file + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFile, s.file); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(s.file)
+	if err != nil {
+		return err
+	}
+	s.modTime = info.ModTime()
+	return nil
+}
+
+func normalizeScopes(scopes []string) ([]string, error) {
+	granted := make(map[string]bool)
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !isScope(scope) {
+			return nil, errors.Wrap(ErrInvalidScope, scope)
+		}
+		granted[scope] = true
+	}
+	if len(granted) == 0 {
+		return nil, ErrInvalidScope
+	}
+
+	var result []string
+	for _, scope := range Scopes {
+		if granted[scope] {
+			result = append(result, scope)
+		}
+	}
+	return result, nil
+}
+
+func isScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func secretHash(secret []byte) string {
+	hash := sha256.Sum256(secret)
+	return hex.EncodeToString(hash[:])
+}
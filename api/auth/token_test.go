@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clarenous/go-capsule/errors"
+)
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "tokens.json")
+	store := NewStore(file)
+	credential, err := store.Create("alice", []string{ScopeMining, ScopeRead, ScopeRead})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := store.Authenticate(credential)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !token.HasScope(ScopeRead) || !token.HasScope(ScopeMining) || token.HasScope(ScopeWallet) || len(token.Scopes) != 2 {
+		t.Fatalf("got scopes %v", token.Scopes)
+	}
+
+	for _, invalid := range []string{"", "alice", "alice:", "alice:zz", "bob" + credential[5:], "alice:" + strings.Repeat("00", secretSize)} {
+		if _, err := store.Authenticate(invalid); err != ErrInvalidToken {
+			t.Errorf("authenticate %q: got err %v", invalid, err)
+		}
+	}
+
+	if _, err := store.Create("alice", []string{ScopeRead}); err != ErrTokenExists {
+		t.Fatalf("got err %v, want %v", err, ErrTokenExists)
+	}
+	if _, err := store.Create("bad name", []string{ScopeRead}); err != ErrInvalidName {
+		t.Fatalf("got err %v, want %v", err, ErrInvalidName)
+	}
+	if _, err := store.Create("bob", []string{"root"}); errors.Root(err) != ErrInvalidScope {
+		t.Fatalf("got err %v, want %v", err, ErrInvalidScope)
+	}
+
+	// the tokens created by another process are reloaded
+	time.Sleep(10 * time.Millisecond)
+	adminCredential, err := NewStore(file).Create("admin", []string{ScopeAdmin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin, err := store.Authenticate(adminCredential)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !admin.HasScope(ScopeWallet) {
+		t.Fatal("admin scope doesn't include the wallet scope")
+	}
+
+	if err := store.Delete("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewStore(file).Authenticate(credential); err != ErrInvalidToken {
+		t.Fatalf("deleted token: got err %v", err)
+	}
+	if err := store.Delete("alice"); err != ErrTokenNotFound {
+		t.Fatalf("got err %v, want %v", err, ErrTokenNotFound)
+	}
+
+	tokens, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 || tokens[0].Name != "admin" {
+		t.Fatalf("got tokens %v", tokens)
+	}
+}
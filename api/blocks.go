@@ -1,14 +1,18 @@
 package api
 
 import (
+	"encoding/hex"
+	ca "github.com/clarenous/go-capsule/consensus/algorithm"
+	"github.com/clarenous/go-capsule/consensus/algorithm/poa"
 	"github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/errors"
 	"github.com/clarenous/go-capsule/protocol/types"
 	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context"
 )
 
 func (a *API) GetBestBlock(ctx context.Context, in *empty.Empty) (*GetBestBlockResponse, error) {
-	bestHeader := a.Chain.BestBlockHeader()
+	bestHeader := a.reader.BestBlockHeader()
 	resp := &GetBestBlockResponse{
 		Height: bestHeader.Height,
 		Hash:   bestHeader.Hash().String(),
@@ -22,6 +26,10 @@ func (a *API) GetBlock(ctx context.Context, in *GetBlockRequest) (*GetBlockRespo
 		return nil, err
 	}
 
+	return newBlockResp(block), nil
+}
+
+func newBlockResp(block *types.Block) *GetBlockResponse {
 	var evidenceCount int
 	for _, tx := range block.Transactions {
 		evidenceCount += len(tx.Evidences)
@@ -45,11 +53,11 @@ func (a *API) GetBlock(ctx context.Context, in *GetBlockRequest) (*GetBlockRespo
 		}
 	}
 
-	return resp, nil
+	return resp
 }
 
 func (a *API) GetBlockHeader(ctx context.Context, in *GetBlockHeaderRequest) (*GetBlockHeaderResponse, error) {
-	block, err := a.getBlockByID(in.Id)
+	header, err := a.getHeaderByID(in.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -58,8 +66,34 @@ func (a *API) GetBlockHeader(ctx context.Context, in *GetBlockHeaderRequest) (*G
 		Proof: &Proof{},
 	}
 
-	constructBlockHeaderResp(resp, &block.BlockHeader)
+	constructBlockHeaderResp(resp, header)
+
+	return resp, nil
+}
+
+func (a *API) GetBlockFilter(ctx context.Context, in *GetBlockFilterRequest) (*GetBlockFilterResponse, error) {
+	header, err := a.getHeaderByID(in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := header.Hash()
+	filter, err := a.Chain.GetBlockFilter(&hash)
+	if err != nil {
+		return nil, err
+	}
+
+	filterHeader, err := a.Chain.GetFilterHeader(&hash)
+	if err != nil {
+		return nil, err
+	}
 
+	resp := &GetBlockFilterResponse{
+		Hash:         hash.String(),
+		Height:       header.Height,
+		Filter:       hex.EncodeToString(filter),
+		FilterHeader: filterHeader.String(),
+	}
 	return resp, nil
 }
 
@@ -69,6 +103,10 @@ func (a *API) GetBlockVerboseV0(ctx context.Context, in *GetBlockVerboseRequest)
 		return nil, err
 	}
 
+	return newBlockVerboseV0Resp(block), nil
+}
+
+func newBlockVerboseV0Resp(block *types.Block) *GetBlockVerboseV0Response {
 	resp := &GetBlockVerboseV0Response{
 		Proof:        &Proof{},
 		Transactions: make([]*GetBlockVerboseV0Response_Transaction, len(block.Transactions)),
@@ -86,7 +124,7 @@ func (a *API) GetBlockVerboseV0(ctx context.Context, in *GetBlockVerboseRequest)
 		}
 	}
 
-	return resp, nil
+	return resp
 }
 
 func (a *API) GetBlockVerboseV1(ctx context.Context, in *GetBlockVerboseRequest) (*GetBlockVerboseV1Response, error) {
@@ -95,6 +133,10 @@ func (a *API) GetBlockVerboseV1(ctx context.Context, in *GetBlockVerboseRequest)
 		return nil, err
 	}
 
+	return newBlockVerboseV1Resp(block), nil
+}
+
+func newBlockVerboseV1Resp(block *types.Block) *GetBlockVerboseV1Response {
 	resp := &GetBlockVerboseV1Response{
 		Proof:        &Proof{},
 		Transactions: make([]*Tx, len(block.Transactions)),
@@ -107,9 +149,91 @@ func (a *API) GetBlockVerboseV1(ctx context.Context, in *GetBlockVerboseRequest)
 		resp.Transactions[i] = respTx
 	}
 
+	return resp
+}
+
+// DecodeRawBlock parses the hex encoded block without checking it.
+func (a *API) DecodeRawBlock(ctx context.Context, in *RawBlockRequest) (*GetBlockVerboseV1Response, error) {
+	buf, err := hex.DecodeString(in.RawBlock)
+	if err != nil || len(buf) == 0 {
+		return nil, ErrInvalidRawBlock
+	}
+
+	block := new(types.Block)
+	if err := block.UnmarshalText(buf); err != nil {
+		return nil, errors.WithDetail(ErrInvalidRawBlock, err.Error())
+	}
+	return newBlockVerboseV1Resp(block), nil
+}
+
+// ListBlocks returns a page of the blocks from the height up to the height,
+// 0 to height means the best height. The blocks of verbosity 0 are in the
+// blocks, of verbosity 1 and 2 in the blocks of verbose 0 and 1.
+func (a *API) ListBlocks(ctx context.Context, in *ListBlocksRequest) (*ListBlocksResponse, error) {
+	if in.Verbosity > 2 {
+		return nil, ErrInvalidVerbosity
+	}
+
+	bestHeight := a.reader.BestBlockHeader().Height
+	toHeight := in.ToHeight
+	if toHeight == 0 || toHeight > bestHeight {
+		toHeight = bestHeight
+	}
+	if in.FromHeight > toHeight {
+		return nil, ErrInvalidHeightRange
+	}
+
+	height := in.FromHeight
+	if in.PageToken != "" {
+		var err error
+		if height, err = decodeHeightToken(in.PageToken); err != nil {
+			return nil, err
+		}
+		if height < in.FromHeight || height > toHeight {
+			return nil, ErrInvalidPageToken
+		}
+	}
+
+	resp := &ListBlocksResponse{}
+	for size := pageSize(in.PageSize, maxBlocksPageSize); size > 0 && height <= toHeight; size-- {
+		block, err := a.Chain.GetBlockByHeight(height)
+		if err != nil {
+			return nil, err
+		}
+
+		switch in.Verbosity {
+		case 0:
+			resp.Blocks = append(resp.Blocks, newBlockResp(block))
+		case 1:
+			resp.BlocksVerbose_0 = append(resp.BlocksVerbose_0, newBlockVerboseV0Resp(block))
+		case 2:
+			resp.BlocksVerbose_1 = append(resp.BlocksVerbose_1, newBlockVerboseV1Resp(block))
+		}
+		height++
+	}
+
+	if height <= toHeight {
+		resp.NextPageToken = encodeHeightToken(height)
+	}
 	return resp, nil
 }
 
+// fillProofResp fills the fields of the consensus engine of the proof
+func fillProofResp(resp *Proof, proof ca.Proof) {
+	switch p := proof.(type) {
+	case *pow.WorkProof:
+		resp.Type = pow.TypePoW
+		resp.Target = p.Target
+		resp.Nonce = p.Nonce
+
+	case *poa.AuthorityProof:
+		resp.Type = poa.TypePoA
+		resp.Signer = p.Signer
+		resp.InTurn = p.InTurn
+		resp.Signature = hex.EncodeToString(p.Signature)
+	}
+}
+
 func constructBlockHeaderResp(resp interface{}, header *types.BlockHeader) {
 	switch e := resp.(type) {
 	case *GetBlockResponse:
@@ -121,8 +245,7 @@ func constructBlockHeaderResp(resp interface{}, header *types.BlockHeader) {
 		e.Previous = header.Previous.String()
 		e.TransactionRoot = header.TransactionRoot.String()
 		e.WitnessRoot = header.WitnessRoot.String()
-		e.Proof.Nonce = header.Proof.(*pow.WorkProof).Nonce
-		e.Proof.Target = header.Proof.(*pow.WorkProof).Target
+		fillProofResp(e.Proof, header.Proof)
 
 	case *GetBlockHeaderResponse:
 		e.Hash = header.Hash().String()
@@ -133,8 +256,7 @@ func constructBlockHeaderResp(resp interface{}, header *types.BlockHeader) {
 		e.Previous = header.Previous.String()
 		e.TransactionRoot = header.TransactionRoot.String()
 		e.WitnessRoot = header.WitnessRoot.String()
-		e.Proof.Nonce = header.Proof.(*pow.WorkProof).Nonce
-		e.Proof.Target = header.Proof.(*pow.WorkProof).Target
+		fillProofResp(e.Proof, header.Proof)
 
 	case *GetBlockVerboseV0Response:
 		e.Hash = header.Hash().String()
@@ -145,8 +267,7 @@ func constructBlockHeaderResp(resp interface{}, header *types.BlockHeader) {
 		e.Previous = header.Previous.String()
 		e.TransactionRoot = header.TransactionRoot.String()
 		e.WitnessRoot = header.WitnessRoot.String()
-		e.Proof.Nonce = header.Proof.(*pow.WorkProof).Nonce
-		e.Proof.Target = header.Proof.(*pow.WorkProof).Target
+		fillProofResp(e.Proof, header.Proof)
 
 	case *GetBlockVerboseV1Response:
 		e.Hash = header.Hash().String()
@@ -157,8 +278,7 @@ func constructBlockHeaderResp(resp interface{}, header *types.BlockHeader) {
 		e.Previous = header.Previous.String()
 		e.TransactionRoot = header.TransactionRoot.String()
 		e.WitnessRoot = header.WitnessRoot.String()
-		e.Proof.Nonce = header.Proof.(*pow.WorkProof).Nonce
-		e.Proof.Target = header.Proof.(*pow.WorkProof).Target
+		fillProofResp(e.Proof, header.Proof)
 	default:
 
 	}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	ca "github.com/clarenous/go-capsule/consensus/algorithm"
+	"github.com/clarenous/go-capsule/consensus/algorithm/poa"
+	"github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+func TestConstructBlockHeaderProof(t *testing.T) {
+	cases := []struct {
+		proof ca.Proof
+		want  *Proof
+	}{
+		{
+			proof: &pow.WorkProof{Target: 2, Nonce: 3},
+			want:  &Proof{Type: pow.TypePoW, Target: 2, Nonce: 3},
+		},
+		{
+			proof: &poa.AuthorityProof{Signer: 1, InTurn: true, Signature: []byte{0xab, 0xcd}},
+			want:  &Proof{Type: poa.TypePoA, Signer: 1, InTurn: true, Signature: "abcd"},
+		},
+	}
+
+	for i, c := range cases {
+		header := types.MockBlockHeader()
+		header.Proof = c.proof
+
+		headerResp := &GetBlockHeaderResponse{Proof: &Proof{}}
+		constructBlockHeaderResp(headerResp, header)
+		blockResp := &GetBlockVerboseV1Response{Proof: &Proof{}}
+		constructBlockHeaderResp(blockResp, header)
+		if !reflect.DeepEqual(headerResp.Proof, c.want) || !reflect.DeepEqual(blockResp.Proof, c.want) {
+			t.Errorf("case %d: got proofs %v, %v, want %v", i, headerResp.Proof, blockResp.Proof, c.want)
+		}
+		if headerResp.Hash != header.Hash().String() {
+			t.Errorf("case %d: got hash %s", i, headerResp.Hash)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+
+	"github.com/clarenous/go-capsule/version"
+)
+
+// GetClientStatus returns the sync and the network state of the node, the
+// sync progress is the percentage of the local height to the known height.
+func (a *API) GetClientStatus(ctx context.Context, in *empty.Empty) (*GetClientStatusResponse, error) {
+	localHeight := a.reader.BestBlockHeader().Height
+	knownHeight := localHeight
+	if bestPeer := a.syncer.BestPeer(); bestPeer != nil && bestPeer.Height > knownHeight {
+		knownHeight = bestPeer.Height
+	}
+
+	syncProgress := float64(100)
+	if knownHeight > 0 {
+		syncProgress = float64(localHeight) * 100 / float64(knownHeight)
+	}
+
+	outbound, inbound := a.syncer.PeerCounts()
+	mempoolSize, mempoolBytes := a.Chain.GetTxPool().Stats()
+	resp := &GetClientStatusResponse{
+		LocalBestHeight: localHeight,
+		KnownBestHeight: knownHeight,
+		Mining:          a.Miner != nil && a.Miner.IsMining(),
+		PeerListening:   a.syncer.IsListening(),
+		OutboundPeers:   uint32(outbound),
+		InboundPeers:    uint32(inbound),
+		IsCaughtUp:      a.syncer.IsCaughtUp(),
+		SyncProgress:    syncProgress,
+		MempoolSize:     uint64(mempoolSize),
+		MempoolBytes:    mempoolBytes,
+		ChainId:         a.config.ChainID,
+		Version:         version.Version,
+		Uptime:          int64(time.Since(a.startTime) / time.Second),
+	}
+	return resp, nil
+}
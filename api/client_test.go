@@ -0,0 +1,141 @@
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+
+	cfg "github.com/clarenous/go-capsule/config"
+	"github.com/clarenous/go-capsule/netsync"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+type mockSyncer struct {
+	bestPeer  *netsync.PeerInfo
+	caughtUp  bool
+	listening bool
+	outbound  int
+	inbound   int
+}
+
+func (s *mockSyncer) BestPeer() *netsync.PeerInfo         { return s.bestPeer }
+func (s *mockSyncer) IsCaughtUp() bool                    { return s.caughtUp }
+func (s *mockSyncer) IsListening() bool                   { return s.listening }
+func (s *mockSyncer) PeerCounts() (outbound, inbound int) { return s.outbound, s.inbound }
+
+func TestGetClientStatus(t *testing.T) {
+	chain, txPool, _ := newTestChain(t)
+	txs := []*types.Tx{types.MockTx(), types.MockTx()}
+	var mempoolBytes uint64
+	for _, tx := range txs {
+		if _, err := txPool.ProcessTransaction(tx, 0); err != nil {
+			t.Fatal(err)
+		}
+		mempoolBytes += tx.SerializedSize()
+	}
+
+	cases := []struct {
+		localHeight  uint64
+		syncer       *mockSyncer
+		knownHeight  uint64
+		syncProgress float64
+	}{
+		{
+			localHeight:  0,
+			syncer:       &mockSyncer{caughtUp: true},
+			knownHeight:  0,
+			syncProgress: 100,
+		},
+		{
+			localHeight:  50,
+			syncer:       &mockSyncer{bestPeer: &netsync.PeerInfo{Height: 200}, listening: true, outbound: 3, inbound: 2},
+			knownHeight:  200,
+			syncProgress: 25,
+		},
+		{
+			localHeight:  50,
+			syncer:       &mockSyncer{bestPeer: &netsync.PeerInfo{Height: 40}, caughtUp: true, outbound: 1},
+			knownHeight:  50,
+			syncProgress: 100,
+		},
+	}
+
+	for i, c := range cases {
+		a := &API{
+			reader: &mockReader{best: &types.BlockHeader{Height: c.localHeight}},
+			syncer: c.syncer,
+			Chain:  chain,
+			config: cfg.DefaultConfig(),
+		}
+		resp, err := a.GetClientStatus(context.Background(), &empty.Empty{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &GetClientStatusResponse{
+			LocalBestHeight: c.localHeight,
+			KnownBestHeight: c.knownHeight,
+			PeerListening:   c.syncer.listening,
+			OutboundPeers:   uint32(c.syncer.outbound),
+			InboundPeers:    uint32(c.syncer.inbound),
+			IsCaughtUp:      c.syncer.caughtUp,
+			SyncProgress:    c.syncProgress,
+			MempoolSize:     uint64(len(txs)),
+			MempoolBytes:    mempoolBytes,
+			ChainId:         a.config.ChainID,
+			Version:         resp.Version,
+			Uptime:          resp.Uptime,
+		}
+		if !reflect.DeepEqual(resp, want) {
+			t.Errorf("case %d: got status %v, want %v", i, resp, want)
+		}
+	}
+}
+
+func TestGetClientStatusGateway(t *testing.T) {
+	dir, err := ioutil.TempDir("", "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := cfg.DefaultConfig().SetRoot(dir)
+	config.ApiAddress = freeAddress(t)
+	config.GRPCAddress = "127.0.0.1:0"
+	config.Auth.Disable = true
+
+	chain, _, dispatcher := newTestChain(t)
+	defer dispatcher.Stop()
+	a := NewAPI(chain, nil, nil, nil, dispatcher, config)
+	a.syncer = &mockSyncer{bestPeer: &netsync.PeerInfo{Height: 4}, listening: true, outbound: 2, inbound: 1}
+	if err := a.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer a.Stop()
+
+	resp, err := http.Get("http://" + config.ApiAddress + "/v1/client/status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := &GetClientStatusResponse{}
+	if err := jsonMarshaler.Unmarshal(body, status); err != nil {
+		t.Fatal(err)
+	}
+	if status.KnownBestHeight != 4 || status.SyncProgress != 0 || status.OutboundPeers != 2 || status.InboundPeers != 1 || !status.PeerListening || status.ChainId != config.ChainID {
+		t.Errorf("got status %s", body)
+	}
+}
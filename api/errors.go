@@ -3,7 +3,25 @@ package api
 import "github.com/clarenous/go-capsule/errors"
 
 var (
-	ErrInvalidBlockID       = errors.New("invalid id for block")
-	ErrInvalidTransactionID = errors.New("invalid id for transaction")
-	ErrInvalidEvidenceID    = errors.New("invalid id for evidence")
+	ErrInvalidBlockID        = errors.New("invalid id for block")
+	ErrInvalidTransactionID  = errors.New("invalid id for transaction")
+	ErrInvalidEvidenceID     = errors.New("invalid id for evidence")
+	ErrInvalidBlockHeader    = errors.New("invalid block header")
+	ErrNotWorkProof          = errors.New("chain is not running proof of work")
+	ErrLightMode             = errors.New("method is not available in light mode")
+	ErrInvalidPeerAddress    = errors.New("invalid peer address")
+	ErrInvalidIP             = errors.New("invalid ip")
+	ErrInvalidBanDuration    = errors.New("invalid ban duration")
+	ErrPeerNotFound          = errors.New("peer not found")
+	ErrInvalidEvidenceFilter = errors.New("invalid digest or source of evidence filter")
+	ErrTooManyWebsockets     = errors.New("too many websocket connections")
+	ErrUnauthenticated       = errors.New("invalid or missing access token")
+	ErrPermissionDenied      = errors.New("access token is not granted the scope of the method")
+	ErrGatewayCertificate    = errors.New("unexpected certificate of the grpc server")
+	ErrInvalidPageToken      = errors.New("invalid page token")
+	ErrInvalidHeightRange    = errors.New("invalid range of block heights")
+	ErrInvalidVerbosity      = errors.New("invalid verbosity of blocks")
+	ErrBatchTooLarge         = errors.New("too many ids in the batch")
+	ErrInvalidRawTransaction = errors.New("invalid raw transaction")
+	ErrInvalidRawBlock       = errors.New("invalid raw block")
 )
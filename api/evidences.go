@@ -14,7 +14,7 @@ func (a *API) GetEvidence(ctx context.Context, in *GetEvidenceRequest) (*GetEvid
 		return nil, ErrInvalidEvidenceID
 	}
 
-	evid, tx, index, err := a.Chain.GetEvidence(&id)
+	evid, tx, index, err := a.reader.GetEvidence(&id)
 	if err != nil {
 		return nil, err
 	}
@@ -26,6 +26,26 @@ func (a *API) GetEvidence(ctx context.Context, in *GetEvidenceRequest) (*GetEvid
 	return resp, nil
 }
 
+// GetEvidences looks up the evidences in the order of the ids, the batch
+// fails on the first id not found.
+func (a *API) GetEvidences(ctx context.Context, in *GetEvidencesRequest) (*GetEvidencesResponse, error) {
+	if len(in.Evids) > maxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+
+	resp := &GetEvidencesResponse{
+		Evidences: make([]*GetEvidenceResponse, len(in.Evids)),
+	}
+	for i, evid := range in.Evids {
+		evidence, err := a.GetEvidence(ctx, &GetEvidenceRequest{Evid: evid})
+		if err != nil {
+			return nil, err
+		}
+		resp.Evidences[i] = evidence
+	}
+	return resp, nil
+}
+
 func constructEvidenceResp(resp interface{}, evid *types.Evidence, txid types.Hash, index uint64) {
 	switch e := resp.(type) {
 	case *Evidence:
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/hex"
+
+	"github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/protocol/types"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+func (a *API) GetWork(ctx context.Context, in *empty.Empty) (*GetWorkResponse, error) {
+	header, err := a.MiningPool.GetWork()
+	if err != nil {
+		return nil, err
+	}
+
+	proof, ok := header.Proof.(*pow.WorkProof)
+	if !ok {
+		return nil, ErrNotWorkProof
+	}
+
+	buf, err := header.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &GetWorkResponse{
+		BlockHeader: hex.EncodeToString(buf),
+		Height:      header.Height,
+		Target:      proof.Target,
+	}
+	return resp, nil
+}
+
+func (a *API) SubmitWork(ctx context.Context, in *SubmitWorkRequest) (*SubmitWorkResponse, error) {
+	buf, err := hex.DecodeString(in.BlockHeader)
+	if err != nil {
+		logrus.Error(err)
+		return nil, ErrInvalidBlockHeader
+	}
+
+	header := new(types.BlockHeader)
+	if err := header.UnmarshalText(buf); err != nil {
+		logrus.Error(err)
+		return nil, ErrInvalidBlockHeader
+	}
+
+	if err := a.MiningPool.SubmitWork(header); err != nil {
+		return nil, err
+	}
+
+	resp := &SubmitWorkResponse{
+		Hash:   header.Hash().String(),
+		Height: header.Height,
+	}
+	return resp, nil
+}
+
+func (a *API) StartMining(ctx context.Context, in *empty.Empty) (*MiningStatusResponse, error) {
+	a.Miner.Start()
+	return a.miningStatus(), nil
+}
+
+func (a *API) StopMining(ctx context.Context, in *empty.Empty) (*MiningStatusResponse, error) {
+	a.Miner.Stop()
+	return a.miningStatus(), nil
+}
+
+// SetMiningWorkers sets the number of mining workers, zero stops the mining
+// and a negative number resets it to the default.
+func (a *API) SetMiningWorkers(ctx context.Context, in *SetMiningWorkersRequest) (*MiningStatusResponse, error) {
+	a.Miner.SetNumWorkers(in.NumWorkers)
+	return a.miningStatus(), nil
+}
+
+func (a *API) GetMiningStats(ctx context.Context, in *empty.Empty) (*GetMiningStatsResponse, error) {
+	stats := a.Miner.Stats()
+	resp := &GetMiningStatsResponse{
+		Mining:         stats.Mining,
+		NumWorkers:     stats.NumWorkers,
+		HashesPerSec:   stats.HashesPerSec,
+		SolvedBlocks:   stats.SolvedBlocks,
+		TemplateHeight: stats.TemplateHeight,
+		TemplateTarget: stats.TemplateTarget,
+	}
+	for _, worker := range stats.Workers {
+		resp.Workers = append(resp.Workers, &WorkerStats{
+			Id:           worker.ID,
+			Hashes:       worker.Hashes,
+			HashesPerSec: worker.HashesPerSec,
+		})
+	}
+	return resp, nil
+}
+
+func (a *API) miningStatus() *MiningStatusResponse {
+	return &MiningStatusResponse{
+		Mining:     a.Miner.IsMining(),
+		NumWorkers: a.Miner.NumWorkers(),
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+
+	"github.com/clarenous/go-capsule/mining/cpuminer"
+)
+
+// waitStats waits until the stats endpoint reports the expected stats
+func waitStats(t *testing.T, a *API, expected func(*GetMiningStatsResponse) bool) *GetMiningStatsResponse {
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		stats, err := a.GetMiningStats(context.Background(), &empty.Empty{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected(stats) || time.Since(start) > 5*time.Second {
+			return stats
+		}
+	}
+}
+
+func TestMining(t *testing.T) {
+	chain, txPool, dispatcher := newTestChain(t)
+	a := &API{Miner: cpuminer.NewCPUMiner(chain, txPool, dispatcher)}
+	defer a.Miner.Stop()
+
+	ctx := context.Background()
+	cases := []struct {
+		call        func() (*MiningStatusResponse, error)
+		wantMining  bool
+		wantWorkers int32
+	}{
+		{
+			call: func() (*MiningStatusResponse, error) {
+				return a.SetMiningWorkers(ctx, &SetMiningWorkersRequest{NumWorkers: 0})
+			},
+			wantWorkers: 0,
+		},
+		{
+			call:        func() (*MiningStatusResponse, error) { return a.StartMining(ctx, &empty.Empty{}) },
+			wantMining:  true,
+			wantWorkers: 1,
+		},
+		{
+			call: func() (*MiningStatusResponse, error) {
+				return a.SetMiningWorkers(ctx, &SetMiningWorkersRequest{NumWorkers: 2})
+			},
+			wantMining:  true,
+			wantWorkers: 2,
+		},
+		{
+			call: func() (*MiningStatusResponse, error) {
+				return a.SetMiningWorkers(ctx, &SetMiningWorkersRequest{NumWorkers: -1})
+			},
+			wantMining:  true,
+			wantWorkers: 1,
+		},
+		{
+			call:        func() (*MiningStatusResponse, error) { return a.StopMining(ctx, &empty.Empty{}) },
+			wantWorkers: 1,
+		},
+		{
+			call:        func() (*MiningStatusResponse, error) { return a.StartMining(ctx, &empty.Empty{}) },
+			wantMining:  true,
+			wantWorkers: 1,
+		},
+		{
+			call: func() (*MiningStatusResponse, error) {
+				return a.SetMiningWorkers(ctx, &SetMiningWorkersRequest{NumWorkers: 0})
+			},
+			wantWorkers: 0,
+		},
+	}
+
+	for i, c := range cases {
+		resp, err := c.call()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Mining != c.wantMining || resp.NumWorkers != c.wantWorkers {
+			t.Fatalf("case %d: got mining %v with %d workers", i, resp.Mining, resp.NumWorkers)
+		}
+
+		running := 0
+		if c.wantMining {
+			running = int(c.wantWorkers)
+		}
+		stats := waitStats(t, a, func(stats *GetMiningStatsResponse) bool {
+			return len(stats.Workers) == running && (!c.wantMining || stats.TemplateHeight > 0)
+		})
+		if len(stats.Workers) != running || stats.Mining != c.wantMining || stats.NumWorkers != c.wantWorkers {
+			t.Errorf("case %d: got stats %v", i, stats)
+		}
+		if c.wantMining && stats.TemplateHeight == 0 {
+			t.Errorf("case %d: got template height %d", i, stats.TemplateHeight)
+		}
+	}
+}
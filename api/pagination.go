@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/base64"
+	"sort"
+	"strconv"
+)
+
+const (
+	defaultPageSize   = 100
+	maxPageSize       = 1000
+	maxBlocksPageSize = 100
+	maxBatchSize      = 1000
+)
+
+// pageSize returns the requested size of the page capped by max, 0 means the
+// default size.
+func pageSize(size uint32, max int) int {
+	if size == 0 {
+		size = defaultPageSize
+	}
+	if int(size) > max {
+		return max
+	}
+	return int(size)
+}
+
+// encodePageToken returns the opaque token of the key of the first item of
+// the next page.
+func encodePageToken(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+func decodePageToken(token string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(key) == 0 {
+		return "", ErrInvalidPageToken
+	}
+	return string(key), nil
+}
+
+// paginate returns the range [start, end) of the page in the sorted keys and
+// the token of the next page. The page starts from the first key not less
+// than the token, so the cursor stays valid when the items change between
+// the requests.
+func paginate(keys []string, in *PageRequest) (int, int, string, error) {
+	if in == nil {
+		in = &PageRequest{}
+	}
+
+	start := 0
+	if in.PageToken != "" {
+		key, err := decodePageToken(in.PageToken)
+		if err != nil {
+			return 0, 0, "", err
+		}
+		start = sort.SearchStrings(keys, key)
+	}
+
+	end := start + pageSize(in.PageSize, maxPageSize)
+	if end >= len(keys) {
+		return start, len(keys), "", nil
+	}
+	return start, end, encodePageToken(keys[end]), nil
+}
+
+func encodeHeightToken(height uint64) string {
+	return encodePageToken(strconv.FormatUint(height, 10))
+}
+
+func decodeHeightToken(token string) (uint64, error) {
+	key, err := decodePageToken(token)
+	if err != nil {
+		return 0, err
+	}
+	height, err := strconv.ParseUint(key, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidPageToken
+	}
+	return height, nil
+}
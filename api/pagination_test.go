@@ -0,0 +1,116 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+func TestPaginate(t *testing.T) {
+	keys := make([]string, 250)
+	for i := range keys {
+		keys[i] = string([]byte{'a' + byte(i/26%26), 'a' + byte(i%26)})
+	}
+
+	var got []string
+	in := &PageRequest{}
+	for pages := 0; ; pages++ {
+		start, end, token, err := paginate(keys, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, keys[start:end]...)
+		if token == "" {
+			if pages != 2 {
+				t.Fatalf("got %d pages, want 3", pages+1)
+			}
+			break
+		}
+		in.PageToken = token
+	}
+	if !reflect.DeepEqual(got, keys) {
+		t.Fatal("pages don't cover the keys")
+	}
+
+	// the cursor resumes after the removed key
+	_, _, token, _ := paginate(keys, &PageRequest{PageSize: 10})
+	start, end, _, err := paginate(append(keys[:5:5], keys[11:]...), &PageRequest{PageSize: 10, PageToken: token})
+	if err != nil || start != 5 || end != 15 {
+		t.Fatalf("got page [%d, %d) err %v", start, end, err)
+	}
+
+	if size := pageSize(5000, maxPageSize); size != maxPageSize {
+		t.Fatalf("got page size %d, want %d", size, maxPageSize)
+	}
+	if size := pageSize(0, maxBlocksPageSize); size != defaultPageSize {
+		t.Fatalf("got page size %d, want %d", size, defaultPageSize)
+	}
+	for _, token := range []string{"!", "YQ=="} {
+		if _, _, _, err := paginate(keys, &PageRequest{PageToken: token}); err != ErrInvalidPageToken {
+			t.Errorf("token %q: got err %v", token, err)
+		}
+	}
+}
+
+// mockReader serves the transactions and the best header of a chain
+type mockReader struct {
+	chainReader
+	best *types.BlockHeader
+	txs  map[types.Hash]*types.Tx
+}
+
+func (r *mockReader) BestBlockHeader() *types.BlockHeader {
+	return r.best
+}
+
+func (r *mockReader) GetTransaction(hash *types.Hash) (*types.Tx, error) {
+	tx, ok := r.txs[*hash]
+	if !ok {
+		return nil, ErrInvalidTransactionID
+	}
+	return tx, nil
+}
+
+func TestGetTransactions(t *testing.T) {
+	tx := types.MockTx()
+	txid := tx.Hash()
+	a := &API{reader: &mockReader{txs: map[types.Hash]*types.Tx{txid: tx}}}
+
+	resp, err := a.GetTransactions(context.Background(), &GetTransactionsRequest{Txids: []string{txid.String(), txid.String()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Transactions) != 2 || resp.Transactions[1].Txid != txid.String() {
+		t.Fatalf("got transactions %v", resp.Transactions)
+	}
+
+	if _, err := a.GetTransactions(context.Background(), &GetTransactionsRequest{Txids: []string{txid.String(), "00"}}); err != ErrInvalidTransactionID {
+		t.Fatalf("got err %v, want %v", err, ErrInvalidTransactionID)
+	}
+	if _, err := a.GetTransactions(context.Background(), &GetTransactionsRequest{Txids: make([]string, maxBatchSize+1)}); err != ErrBatchTooLarge {
+		t.Fatalf("got err %v, want %v", err, ErrBatchTooLarge)
+	}
+}
+
+func TestListBlocksArguments(t *testing.T) {
+	a := &API{reader: &mockReader{best: &types.BlockHeader{Height: 10}}}
+	cases := []struct {
+		in   *ListBlocksRequest
+		want error
+	}{
+		{in: &ListBlocksRequest{Verbosity: 3}, want: ErrInvalidVerbosity},
+		{in: &ListBlocksRequest{FromHeight: 11}, want: ErrInvalidHeightRange},
+		{in: &ListBlocksRequest{FromHeight: 5, ToHeight: 4}, want: ErrInvalidHeightRange},
+		{in: &ListBlocksRequest{FromHeight: 5, PageToken: encodeHeightToken(4)}, want: ErrInvalidPageToken},
+		{in: &ListBlocksRequest{ToHeight: 5, PageToken: encodeHeightToken(6)}, want: ErrInvalidPageToken},
+		{in: &ListBlocksRequest{PageToken: encodePageToken("x")}, want: ErrInvalidPageToken},
+	}
+	for i, c := range cases {
+		if _, err := a.ListBlocks(context.Background(), c.in); err != c.want {
+			t.Errorf("case %d: got err %v, want %v", i, err, c.want)
+		}
+	}
+}
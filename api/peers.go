@@ -0,0 +1,176 @@
+package api
+
+import (
+	"net"
+	"sort"
+	"time"
+
+	"github.com/clarenous/go-capsule/p2p"
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+)
+
+// GetPeers returns a page of the peers sorted by the remote address and the
+// peer id.
+func (a *API) GetPeers(ctx context.Context, in *PageRequest) (*GetPeersResponse, error) {
+	infos := a.SyncManager.GetPeerInfos()
+	sort.Slice(infos, func(i, j int) bool {
+		return peerKey(infos[i].RemoteAddr, infos[i].ID) < peerKey(infos[j].RemoteAddr, infos[j].ID)
+	})
+	keys := make([]string, len(infos))
+	for i, info := range infos {
+		keys[i] = peerKey(info.RemoteAddr, info.ID)
+	}
+
+	start, end, nextPageToken, err := paginate(keys, in)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &GetPeersResponse{
+		Peers:         make([]*Peer, end-start),
+		NextPageToken: nextPageToken,
+	}
+	for i, info := range infos[start:end] {
+		resp.Peers[i] = &Peer{
+			PeerId:              info.ID,
+			RemoteAddr:          info.RemoteAddr,
+			Height:              info.Height,
+			Ping:                info.Ping,
+			Duration:            info.Duration,
+			BanScore:            info.BanScore,
+			TotalSent:           info.TotalSent,
+			TotalReceived:       info.TotalReceived,
+			AverageSentRate:     info.AverageSentRate,
+			AverageReceivedRate: info.AverageReceivedRate,
+			CurrentSentRate:     info.CurrentSentRate,
+			CurrentReceivedRate: info.CurrentReceivedRate,
+			MsgsSent:            info.MsgsSent,
+			MsgsReceived:        info.MsgsReceived,
+		}
+		for _, channel := range info.Channels {
+			resp.Peers[i].Channels = append(resp.Peers[i].Channels, &PeerChannel{
+				ChannelId:        uint32(channel.ID),
+				SentBytes:        channel.SentBytes,
+				ReceivedBytes:    channel.ReceivedBytes,
+				SentMessages:     channel.SentMessages,
+				ReceivedMessages: channel.ReceivedMessages,
+			})
+		}
+	}
+	return resp, nil
+}
+
+// peerKey orders the peers by the remote address first, the separator sorts
+// before any character of the address.
+func peerKey(remoteAddr, id string) string {
+	return remoteAddr + "\x00" + id
+}
+
+func (a *API) ConnectPeer(ctx context.Context, in *ConnectPeerRequest) (*empty.Empty, error) {
+	addr, err := p2p.NewNetAddressString(in.Address)
+	if err != nil {
+		return nil, ErrInvalidPeerAddress
+	}
+
+	if err := a.SyncManager.DialPeerWithAddress(addr); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// DisconnectPeer stops the peer matching either the peer id or the remote address.
+func (a *API) DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest) (*empty.Empty, error) {
+	if in.PeerId == "" && in.Address == "" {
+		return nil, ErrPeerNotFound
+	}
+
+	for _, info := range a.SyncManager.GetPeerInfos() {
+		if (in.PeerId != "" && info.ID == in.PeerId) || (in.Address != "" && info.RemoteAddr == in.Address) {
+			if err := a.SyncManager.StopPeer(info.ID); err != nil {
+				return nil, err
+			}
+			return &empty.Empty{}, nil
+		}
+	}
+	return nil, ErrPeerNotFound
+}
+
+// BanPeer bans the ip for the duration, the p2p default is used when the
+// duration is empty.
+func (a *API) BanPeer(ctx context.Context, in *BanPeerRequest) (*BannedPeer, error) {
+	if net.ParseIP(in.Ip) == nil {
+		return nil, ErrInvalidIP
+	}
+
+	var duration time.Duration
+	if in.Duration != "" {
+		var err error
+		if duration, err = time.ParseDuration(in.Duration); err != nil || duration <= 0 {
+			return nil, ErrInvalidBanDuration
+		}
+	}
+
+	if err := a.SyncManager.BanPeer(in.Ip, duration); err != nil {
+		return nil, err
+	}
+
+	resp := &BannedPeer{
+		Ip:          in.Ip,
+		BannedUntil: a.SyncManager.BannedPeers()[in.Ip].Unix(),
+	}
+	return resp, nil
+}
+
+func (a *API) UnbanPeer(ctx context.Context, in *UnbanPeerRequest) (*empty.Empty, error) {
+	if net.ParseIP(in.Ip) == nil {
+		return nil, ErrInvalidIP
+	}
+
+	if err := a.SyncManager.UnbanPeer(in.Ip); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// ListBannedPeers returns a page of the banned ips sorted by the ip.
+func (a *API) ListBannedPeers(ctx context.Context, in *PageRequest) (*ListBannedPeersResponse, error) {
+	bannedPeers := a.SyncManager.BannedPeers()
+	ips := make([]string, 0, len(bannedPeers))
+	for ip := range bannedPeers {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+
+	start, end, nextPageToken, err := paginate(ips, in)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListBannedPeersResponse{
+		BannedPeers:   make([]*BannedPeer, end-start),
+		NextPageToken: nextPageToken,
+	}
+	for i, ip := range ips[start:end] {
+		resp.BannedPeers[i] = &BannedPeer{
+			Ip:          ip,
+			BannedUntil: bannedPeers[ip].Unix(),
+		}
+	}
+	return resp, nil
+}
+
+// GetNetTotals returns the traffic of all the peers and the global rate
+// limits, 0 limit means unlimited.
+func (a *API) GetNetTotals(ctx context.Context, in *empty.Empty) (*NetTotals, error) {
+	totals := a.SyncManager.GetNetTotals()
+	resp := &NetTotals{
+		TotalSent:           totals.TotalSent,
+		TotalReceived:       totals.TotalReceived,
+		CurrentSentRate:     totals.CurrentSentRate,
+		CurrentReceivedRate: totals.CurrentReceivedRate,
+		SendRateLimit:       totals.SendRateLimit,
+		RecvRateLimit:       totals.RecvRateLimit,
+	}
+	return resp, nil
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	golangproto "github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/mining/miningpool"
+	"github.com/clarenous/go-capsule/p2p"
+	"github.com/clarenous/go-capsule/protocol"
+)
+
+// errorCode is the grpc status code and the machine readable reason of the
+// root error
+type errorCode struct {
+	code   codes.Code
+	reason string
+}
+
+var internalErrorCode = errorCode{codes.Internal, "INTERNAL"}
+
+var errorCodes = map[error]errorCode{
+	ErrInvalidBlockID:        {codes.InvalidArgument, "INVALID_BLOCK_ID"},
+	ErrInvalidTransactionID:  {codes.InvalidArgument, "INVALID_TRANSACTION_ID"},
+	ErrInvalidEvidenceID:     {codes.InvalidArgument, "INVALID_EVIDENCE_ID"},
+	ErrInvalidBlockHeader:    {codes.InvalidArgument, "INVALID_BLOCK_HEADER"},
+	ErrNotWorkProof:          {codes.FailedPrecondition, "NOT_WORK_PROOF"},
+	ErrLightMode:             {codes.Unimplemented, "LIGHT_MODE"},
+	ErrInvalidPeerAddress:    {codes.InvalidArgument, "INVALID_PEER_ADDRESS"},
+	ErrInvalidIP:             {codes.InvalidArgument, "INVALID_IP"},
+	ErrInvalidBanDuration:    {codes.InvalidArgument, "INVALID_BAN_DURATION"},
+	ErrPeerNotFound:          {codes.NotFound, "PEER_NOT_FOUND"},
+	ErrInvalidEvidenceFilter: {codes.InvalidArgument, "INVALID_EVIDENCE_FILTER"},
+	ErrTooManyWebsockets:     {codes.Unavailable, "TOO_MANY_WEBSOCKETS"},
+	ErrUnauthenticated:       {codes.Unauthenticated, "UNAUTHENTICATED"},
+	ErrPermissionDenied:      {codes.PermissionDenied, "PERMISSION_DENIED"},
+	ErrInvalidPageToken:      {codes.InvalidArgument, "INVALID_PAGE_TOKEN"},
+	ErrInvalidHeightRange:    {codes.InvalidArgument, "INVALID_HEIGHT_RANGE"},
+	ErrInvalidVerbosity:      {codes.InvalidArgument, "INVALID_VERBOSITY"},
+	ErrBatchTooLarge:         {codes.InvalidArgument, "BATCH_TOO_LARGE"},
+	ErrInvalidRawTransaction: {codes.InvalidArgument, "INVALID_RAW_TRANSACTION"},
+	ErrInvalidRawBlock:       {codes.InvalidArgument, "INVALID_RAW_BLOCK"},
+
+	protocol.ErrBlockNotFound:       {codes.NotFound, "BLOCK_NOT_FOUND"},
+	protocol.ErrTransactionNotFound: {codes.NotFound, "TRANSACTION_NOT_FOUND"},
+	protocol.ErrEvidenceNotFound:    {codes.NotFound, "EVIDENCE_NOT_FOUND"},
+	protocol.ErrFilterNotFound:      {codes.NotFound, "FILTER_NOT_FOUND"},
+	protocol.ErrBadBlock:            {codes.InvalidArgument, "BAD_BLOCK"},
+	protocol.ErrBadTx:               {codes.InvalidArgument, "BAD_TRANSACTION"},
+	protocol.ErrTransactionExist:    {codes.AlreadyExists, "TRANSACTION_IN_POOL"},
+	protocol.ErrPoolIsFull:          {codes.ResourceExhausted, "TX_POOL_FULL"},
+	protocol.ErrDustTx:              {codes.InvalidArgument, "DUST_TRANSACTION"},
+
+	miningpool.ErrNoWork:     {codes.FailedPrecondition, "NO_WORK"},
+	miningpool.ErrStaleWork:  {codes.FailedPrecondition, "STALE_WORK"},
+	miningpool.ErrOrphanWork: {codes.FailedPrecondition, "ORPHAN_WORK"},
+
+	p2p.ErrDuplicatePeer:     {codes.AlreadyExists, "DUPLICATE_PEER"},
+	p2p.ErrConnectSelf:       {codes.InvalidArgument, "CONNECT_SELF"},
+	p2p.ErrConnectBannedPeer: {codes.FailedPrecondition, "PEER_BANNED"},
+	p2p.ErrPeerNotBanned:     {codes.NotFound, "PEER_NOT_BANNED"},
+	p2p.ErrClearnetDial:      {codes.FailedPrecondition, "ONION_ONLY"},
+	p2p.ErrOnionNoProxy:      {codes.FailedPrecondition, "ONION_NO_PROXY"},
+
+	context.Canceled:         {codes.Canceled, "CANCELED"},
+	context.DeadlineExceeded: {codes.DeadlineExceeded, "DEADLINE_EXCEEDED"},
+}
+
+// the messages are generated by gogo, the detail is also registered to the
+// golang registry resolving the Any of the grpc status and of the gateway.
+func init() {
+	golangproto.RegisterType((*ErrorDetail)(nil), "api.ErrorDetail")
+}
+
+// toStatus converts the error of a method to the grpc status of the code of
+// its root error, the reason, the detail and the data of the error are
+// attached as the ErrorDetail. The errors unknown to the api are internal.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code, detail := errorDetail(err)
+	st, derr := status.New(code.code, err.Error()).WithDetails(detail)
+	if derr != nil {
+		return status.Error(code.code, err.Error())
+	}
+	return st.Err()
+}
+
+// errorDetail returns the code and the detail of the root error of err
+func errorDetail(err error) (errorCode, *ErrorDetail) {
+	root := errors.Root(err)
+	code, ok := errorCodes[root]
+	if !ok {
+		code = internalErrorCode
+		log.WithFields(log.Fields{"err": err}).Error("internal error of api method")
+	}
+
+	detail := &ErrorDetail{Reason: code.reason}
+	if err != root {
+		detail.Detail = errors.Detail(err)
+	}
+	for k, v := range errors.Data(err) {
+		if detail.Data == nil {
+			detail.Data = map[string]string{}
+		}
+		detail.Data[k] = fmt.Sprint(v)
+	}
+	return code, detail
+}
+
+// writeHTTPError replies the error of the handlers outside of the gateway in
+// the same body and http status as the gateway.
+func writeHTTPError(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := runtime.NewServerMetadataContext(r.Context(), runtime.ServerMetadata{})
+	runtime.HTTPError(ctx, nil, jsonMarshaler, w, r, toStatus(err))
+}
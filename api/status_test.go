@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/protocol"
+)
+
+func TestToStatus(t *testing.T) {
+	notFound := errors.WithData(errors.WithDetail(protocol.ErrTransactionNotFound, "no transaction of hash 00"), "hash", "00")
+	cases := []struct {
+		err    error
+		code   codes.Code
+		detail *ErrorDetail
+	}{
+		{
+			err:    ErrInvalidBlockID,
+			code:   codes.InvalidArgument,
+			detail: &ErrorDetail{Reason: "INVALID_BLOCK_ID"},
+		},
+		{
+			err:    notFound,
+			code:   codes.NotFound,
+			detail: &ErrorDetail{Reason: "TRANSACTION_NOT_FOUND", Detail: "no transaction of hash 00", Data: map[string]string{"hash": "00"}},
+		},
+		{
+			err:    errors.New("disk failure"),
+			code:   codes.Internal,
+			detail: &ErrorDetail{Reason: "INTERNAL"},
+		},
+		{
+			err:  status.Error(codes.Aborted, "aborted"),
+			code: codes.Aborted,
+		},
+	}
+
+	for i, c := range cases {
+		st, ok := status.FromError(toStatus(c.err))
+		if !ok || st.Code() != c.code || (c.detail != nil && st.Message() != c.err.Error()) {
+			t.Errorf("case %d: got status %v", i, st)
+			continue
+		}
+
+		details := st.Details()
+		if c.detail == nil {
+			if len(details) != 0 {
+				t.Errorf("case %d: got details %v", i, details)
+			}
+			continue
+		}
+		if len(details) != 1 {
+			t.Errorf("case %d: got details %v", i, details)
+			continue
+		}
+		detail, ok := details[0].(*ErrorDetail)
+		if !ok || detail.Reason != c.detail.Reason || detail.Detail != c.detail.Detail || len(detail.Data) != len(c.detail.Data) || detail.Data["hash"] != c.detail.Data["hash"] {
+			t.Errorf("case %d: got detail %v, want %v", i, details[0], c.detail)
+		}
+	}
+
+	if toStatus(nil) != nil {
+		t.Fatal("nil error is converted")
+	}
+}
+
+func TestWriteHTTPError(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeHTTPError(w, httptest.NewRequest(http.MethodGet, "/v1/ws/blocks", nil), errors.WithDetail(ErrInvalidEvidenceFilter, "odd length digest"))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("got status %d", w.Code)
+	}
+
+	var body struct {
+		Code    int32
+		Message string
+		Details []struct {
+			Type   string `json:"@type"`
+			Reason string
+			Detail string
+		}
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Code != int32(codes.InvalidArgument) || len(body.Details) != 1 || body.Details[0].Type != "type.googleapis.com/api.ErrorDetail" ||
+		body.Details[0].Reason != "INVALID_EVIDENCE_FILTER" || body.Details[0].Detail != "odd length digest" {
+		t.Fatalf("got body %s", w.Body.String())
+	}
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+
+	"github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+func (a *API) SubscribeBlocks(in *empty.Empty, stream APIService_SubscribeBlocksServer) error {
+	return a.subscribeBlocks(stream.Context(), stream.Send)
+}
+
+func (a *API) SubscribeTransactions(in *empty.Empty, stream APIService_SubscribeTransactionsServer) error {
+	return a.subscribeTransactions(stream.Context(), stream.Send)
+}
+
+func (a *API) SubscribeEvidences(in *SubscribeEvidencesRequest, stream APIService_SubscribeEvidencesServer) error {
+	filter, err := newEvidenceFilter(in.Digest, in.Source)
+	if err != nil {
+		return err
+	}
+	return a.subscribeEvidences(stream.Context(), filter, in.Mempool, stream.Send)
+}
+
+// subscribeBlocks sends the blocks connected to and disconnected from the main
+// chain, the blocks detached by a reorganization come first from the old tip.
+func (a *API) subscribeBlocks(ctx context.Context, send func(*BlockNotification) error) error {
+	return a.subscribe(ctx, func(data interface{}) error {
+		switch ev := data.(type) {
+		case protocol.BlockConnectedEvent:
+			return send(newBlockNotification(BlockNotification_CONNECTED, ev.Block))
+		case protocol.BlockDisconnectedEvent:
+			return send(newBlockNotification(BlockNotification_DISCONNECTED, ev.Block))
+		}
+		return nil
+	}, protocol.BlockConnectedEvent{}, protocol.BlockDisconnectedEvent{})
+}
+
+// subscribeTransactions sends the txs added to and removed from the mempool
+func (a *API) subscribeTransactions(ctx context.Context, send func(*TxNotification) error) error {
+	return a.subscribe(ctx, func(data interface{}) error {
+		msg := data.(protocol.TxMsgEvent).TxMsg
+		notification := &TxNotification{Tx: new(Tx)}
+		if msg.MsgType == protocol.MsgRemoveTx {
+			notification.Type = TxNotification_REMOVED
+		}
+		constructTxResp(notification.Tx, msg.Tx)
+		return send(notification)
+	}, protocol.TxMsgEvent{})
+}
+
+// subscribeEvidences sends the evidences of the connected blocks matching the
+// filter, and the ones of the txs entering the mempool if mempool is set.
+func (a *API) subscribeEvidences(ctx context.Context, filter *evidenceFilter, mempool bool, send func(*EvidenceNotification) error) error {
+	eventTypes := []interface{}{protocol.BlockConnectedEvent{}}
+	if mempool {
+		eventTypes = append(eventTypes, protocol.TxMsgEvent{})
+	}
+
+	return a.subscribe(ctx, func(data interface{}) error {
+		switch ev := data.(type) {
+		case protocol.BlockConnectedEvent:
+			blockHash := ev.Block.Hash()
+			for _, tx := range ev.Block.Transactions {
+				if err := sendEvidences(tx, blockHash.String(), ev.Block.Height, filter, send); err != nil {
+					return err
+				}
+			}
+
+		case protocol.TxMsgEvent:
+			if ev.TxMsg.MsgType == protocol.MsgNewTx {
+				return sendEvidences(ev.TxMsg.Tx, "", 0, filter, send)
+			}
+		}
+		return nil
+	}, eventTypes...)
+}
+
+// subscribe hands the events of the types to handle until the context is done
+// or the dispatcher is stopped.
+func (a *API) subscribe(ctx context.Context, handle func(data interface{}) error, eventTypes ...interface{}) error {
+	if a.eventDispatcher == nil {
+		return ErrLightMode
+	}
+
+	sub, err := a.eventDispatcher.Subscribe(eventTypes...)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev, ok := <-sub.Chan():
+			if !ok {
+				return nil
+			}
+			if err := handle(ev.Data); err != nil {
+				return err
+			}
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func newBlockNotification(typ BlockNotification_Type, block *types.Block) *BlockNotification {
+	hash := block.Hash()
+	notification := &BlockNotification{
+		Type:         typ,
+		Hash:         hash.String(),
+		Height:       block.Height,
+		Previous:     block.Previous.String(),
+		Timestamp:    block.Timestamp,
+		Transactions: make([]string, len(block.Transactions)),
+	}
+	for i, tx := range block.Transactions {
+		notification.Transactions[i] = tx.Hash().String()
+	}
+	return notification
+}
+
+func sendEvidences(tx *types.Tx, blockHash string, height uint64, filter *evidenceFilter, send func(*EvidenceNotification) error) error {
+	txid := tx.Hash()
+	for i := range tx.Evidences {
+		evid := &tx.Evidences[i]
+		if !filter.match(evid) {
+			continue
+		}
+
+		notification := &EvidenceNotification{
+			Txid:      txid.String(),
+			Index:     uint64(i),
+			BlockHash: blockHash,
+			Height:    height,
+			Evidence:  new(Evidence),
+		}
+		constructEvidenceResp(notification.Evidence, evid, txid, uint64(i))
+		if err := send(notification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evidenceFilter matches the evidences by digest and source, an empty field
+// matches any evidence
+type evidenceFilter struct {
+	digest []byte
+	source []byte
+}
+
+func newEvidenceFilter(digest, source string) (*evidenceFilter, error) {
+	var err error
+	filter := new(evidenceFilter)
+	if filter.digest, err = hex.DecodeString(digest); err != nil {
+		return nil, ErrInvalidEvidenceFilter
+	}
+	if filter.source, err = hex.DecodeString(source); err != nil {
+		return nil, ErrInvalidEvidenceFilter
+	}
+	return filter, nil
+}
+
+func (f *evidenceFilter) match(evid *types.Evidence) bool {
+	if len(f.digest) != 0 && !bytes.Equal(f.digest, evid.Digest) {
+		return false
+	}
+	return len(f.source) == 0 || bytes.Equal(f.source, evid.Source)
+}
@@ -0,0 +1,295 @@
+package api
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	cfg "github.com/clarenous/go-capsule/config"
+	"github.com/clarenous/go-capsule/event"
+	"github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+// testSubscriber collects the notifications of a subscription
+type testSubscriber struct {
+	t             *testing.T
+	dispatcher    *event.Dispatcher
+	notifications chan proto.Message
+	last          proto.Message
+}
+
+func newTestSubscriber(t *testing.T, dispatcher *event.Dispatcher) *testSubscriber {
+	return &testSubscriber{t: t, dispatcher: dispatcher, notifications: make(chan proto.Message, 1024)}
+}
+
+func (s *testSubscriber) send(m proto.Message) error {
+	s.notifications <- m
+	return nil
+}
+
+// expect posts the event until a notification other than the previous one is
+// received, the events posted before the subscription is established are lost.
+func (s *testSubscriber) expect(ev interface{}) proto.Message {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(5 * time.Second)
+
+	for {
+		s.dispatcher.Post(ev)
+		select {
+		case m := <-s.notifications:
+			if s.last != nil && proto.Equal(m, s.last) {
+				continue
+			}
+			s.last = m
+			return m
+		case <-ticker.C:
+		case <-timeout:
+			s.t.Fatal("no notification received")
+		}
+	}
+}
+
+func mockEvidenceTx(evidences ...*types.Evidence) *types.Tx {
+	tx := types.MockTx()
+	tx.Evidences = nil
+	for _, evid := range evidences {
+		tx.Evidences = append(tx.Evidences, *evid)
+	}
+	return tx
+}
+
+func TestSubscribeBlocks(t *testing.T) {
+	dispatcher := event.NewDispatcher()
+	defer dispatcher.Stop()
+	a := &API{eventDispatcher: dispatcher}
+	s := newTestSubscriber(t, dispatcher)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- a.subscribeBlocks(ctx, func(n *BlockNotification) error { return s.send(n) })
+	}()
+
+	block := types.MockBlock()
+	cases := []struct {
+		ev   interface{}
+		want BlockNotification_Type
+	}{
+		{ev: protocol.BlockConnectedEvent{Block: block}, want: BlockNotification_CONNECTED},
+		{ev: protocol.BlockDisconnectedEvent{Block: block}, want: BlockNotification_DISCONNECTED},
+	}
+	for i, c := range cases {
+		got := s.expect(c.ev).(*BlockNotification)
+		if got.Type != c.want || got.Hash != block.Hash().String() || got.Height != block.Height || len(got.Transactions) != len(block.Transactions) {
+			t.Errorf("case %d: got notification %v", i, got)
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubscribeTransactions(t *testing.T) {
+	dispatcher := event.NewDispatcher()
+	a := &API{eventDispatcher: dispatcher}
+	s := newTestSubscriber(t, dispatcher)
+
+	done := make(chan error)
+	go func() {
+		done <- a.subscribeTransactions(context.Background(), func(n *TxNotification) error { return s.send(n) })
+	}()
+
+	tx := types.MockTx()
+	for i, typ := range []int{protocol.MsgNewTx, protocol.MsgRemoveTx} {
+		ev := protocol.TxMsgEvent{TxMsg: &protocol.TxPoolMsg{TxDesc: &protocol.TxDesc{Tx: tx}, MsgType: typ}}
+		got := s.expect(ev).(*TxNotification)
+		if int(got.Type) != typ || got.Tx.Txid != tx.Hash().String() {
+			t.Errorf("case %d: got notification %v", i, got)
+		}
+	}
+
+	// the subscription ends with the dispatcher
+	dispatcher.Stop()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubscribeEvidences(t *testing.T) {
+	evid := types.MockEvidence()
+	other := types.MockEvidence()
+	block := types.MockBlock()
+	block.Transactions = []*types.Tx{mockEvidenceTx(other), mockEvidenceTx(other, evid)}
+	poolTx := mockEvidenceTx(evid)
+	poolEvent := protocol.TxMsgEvent{TxMsg: &protocol.TxPoolMsg{TxDesc: &protocol.TxDesc{Tx: poolTx}, MsgType: protocol.MsgNewTx}}
+
+	cases := []struct {
+		digest    string
+		source    string
+		mempool   bool
+		ev        interface{}
+		wantTx    *types.Tx
+		wantIndex uint64
+	}{
+		{digest: hex.EncodeToString(evid.Digest), ev: protocol.BlockConnectedEvent{Block: block}, wantTx: block.Transactions[1], wantIndex: 1},
+		{source: hex.EncodeToString(evid.Source), ev: protocol.BlockConnectedEvent{Block: block}, wantTx: block.Transactions[1], wantIndex: 1},
+		{digest: hex.EncodeToString(evid.Digest), mempool: true, ev: poolEvent, wantTx: poolTx},
+	}
+
+	for i, c := range cases {
+		dispatcher := event.NewDispatcher()
+		a := &API{eventDispatcher: dispatcher}
+		s := newTestSubscriber(t, dispatcher)
+		filter, err := newEvidenceFilter(c.digest, c.source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		go a.subscribeEvidences(context.Background(), filter, c.mempool, func(n *EvidenceNotification) error { return s.send(n) })
+
+		got := s.expect(c.ev).(*EvidenceNotification)
+		wantBlockHash := ""
+		if _, ok := c.ev.(protocol.BlockConnectedEvent); ok {
+			wantBlockHash = block.Hash().String()
+		}
+		if got.Txid != c.wantTx.Hash().String() || got.Index != c.wantIndex || got.BlockHash != wantBlockHash || got.Evidence.Digest != hex.EncodeToString(evid.Digest) {
+			t.Errorf("case %d: got notification %v", i, got)
+		}
+		dispatcher.Stop()
+	}
+
+	if _, err := newEvidenceFilter("not hex", ""); err != ErrInvalidEvidenceFilter {
+		t.Fatalf("got err %v, want %v", err, ErrInvalidEvidenceFilter)
+	}
+}
+
+func TestSubscribeEvidencesSkipMempool(t *testing.T) {
+	dispatcher := event.NewDispatcher()
+	defer dispatcher.Stop()
+	a := &API{eventDispatcher: dispatcher}
+	s := newTestSubscriber(t, dispatcher)
+	go a.subscribeEvidences(context.Background(), &evidenceFilter{}, false, func(n *EvidenceNotification) error { return s.send(n) })
+
+	block := types.MockBlock()
+	block.Transactions = []*types.Tx{mockEvidenceTx(types.MockEvidence())}
+	s.expect(protocol.BlockConnectedEvent{Block: block})
+
+	poolTx := mockEvidenceTx(types.MockEvidence())
+	dispatcher.Post(protocol.TxMsgEvent{TxMsg: &protocol.TxPoolMsg{TxDesc: &protocol.TxDesc{Tx: poolTx}, MsgType: protocol.MsgNewTx}})
+	next := types.MockBlock()
+	next.Transactions = []*types.Tx{mockEvidenceTx(types.MockEvidence())}
+	if got := s.expect(protocol.BlockConnectedEvent{Block: next}).(*EvidenceNotification); got.Txid != next.Transactions[0].Hash().String() {
+		t.Fatalf("got notification %v of the mempool", got)
+	}
+}
+
+// dialWebsocket completes the handshake of the websocket client
+func dialWebsocket(t *testing.T, addr, path string) (net.Conn, *bufio.Reader, *http.Response) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fmt.Fprintf(conn, "GET %s HTTP/1.1\r\nHost: %s\r\nUpgrade: websocket\r\nConnection: keep-alive, Upgrade\r\n", path, addr)
+	fmt.Fprintf(conn, "Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn, br, resp
+}
+
+func readTestFrame(t *testing.T, conn net.Conn, br *bufio.Reader) (byte, []byte) {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(br, head); err != nil {
+		t.Fatal(err)
+	}
+
+	length := int(head[1] & 0x7f)
+	if length == 126 {
+		ext := make([]byte, 2)
+		if _, err := io.ReadFull(br, ext); err != nil {
+			t.Fatal(err)
+		}
+		length = int(ext[0])<<8 | int(ext[1])
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(br, payload); err != nil {
+		t.Fatal(err)
+	}
+	return head[0] & 0x0f, payload
+}
+
+func TestWebsocket(t *testing.T) {
+	dispatcher := event.NewDispatcher()
+	defer dispatcher.Stop()
+	config := cfg.DefaultConfig()
+	config.Websocket.MaxNumWebsockets = 1
+	a := &API{eventDispatcher: dispatcher, config: config}
+	server := httptest.NewServer(http.HandlerFunc(a.handleWebsocket))
+	defer server.Close()
+	addr := strings.TrimPrefix(server.URL, "http://")
+
+	conn, br, resp := dialWebsocket(t, addr, wsPathPrefix+"blocks")
+	defer conn.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("got handshake response %s %v", resp.Status, resp.Header)
+	}
+
+	refused, _, resp := dialWebsocket(t, addr, wsPathPrefix+"blocks")
+	refused.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("got status %s over the websocket limit", resp.Status)
+	}
+
+	block := types.MockBlock()
+	stop := make(chan struct{})
+	go func() {
+		for {
+			dispatcher.Post(protocol.BlockConnectedEvent{Block: block})
+			select {
+			case <-stop:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}()
+	opcode, payload := readTestFrame(t, conn, br)
+	close(stop)
+
+	var got struct {
+		Type   string `json:"type"`
+		Hash   string `json:"hash"`
+		Height string `json:"height"`
+	}
+	if err := json.Unmarshal(payload, &got); err != nil {
+		t.Fatal(err)
+	}
+	if opcode != wsOpText || got.Type != "CONNECTED" || got.Hash != block.Hash().String() || got.Height != fmt.Sprint(block.Height) {
+		t.Fatalf("got frame %d %s", opcode, payload)
+	}
+
+	// the masked close frame of the client
+	conn.Write([]byte{0x80 | wsOpClose, 0x80 | 2, 1, 2, 3, 4, 0x03 ^ 1, 0xe8 ^ 2})
+	for {
+		if opcode, _ = readTestFrame(t, conn, br); opcode == wsOpClose {
+			break
+		}
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/hex"
+	"github.com/clarenous/go-capsule/errors"
 	"github.com/clarenous/go-capsule/protocol/types"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -14,7 +15,7 @@ func (a *API) GetTransaction(ctx context.Context, in *GetTransactionRequest) (*G
 		return nil, ErrInvalidTransactionID
 	}
 
-	tx, err := a.Chain.GetTransaction(&id)
+	tx, err := a.reader.GetTransaction(&id)
 	if err != nil {
 		return nil, err
 	}
@@ -26,6 +27,92 @@ func (a *API) GetTransaction(ctx context.Context, in *GetTransactionRequest) (*G
 	return resp, nil
 }
 
+// GetTransactions looks up the transactions in the order of the ids, the
+// batch fails on the first id not found.
+func (a *API) GetTransactions(ctx context.Context, in *GetTransactionsRequest) (*GetTransactionsResponse, error) {
+	if len(in.Txids) > maxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+
+	resp := &GetTransactionsResponse{
+		Transactions: make([]*GetTransactionResponse, len(in.Txids)),
+	}
+	for i, txid := range in.Txids {
+		tx, err := a.GetTransaction(ctx, &GetTransactionRequest{Txid: txid})
+		if err != nil {
+			return nil, err
+		}
+		resp.Transactions[i] = tx
+	}
+	return resp, nil
+}
+
+// SubmitRawTransaction validates the hex encoded tx and adds it to the mempool,
+// the new txs of the mempool are relayed by netsync.
+func (a *API) SubmitRawTransaction(ctx context.Context, in *RawTransactionRequest) (*SubmitRawTransactionResponse, error) {
+	tx, err := decodeRawTx(in.RawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	isOrphan, err := a.Chain.ValidateTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &SubmitRawTransactionResponse{
+		Txid:   tx.Hash().String(),
+		Orphan: isOrphan,
+	}
+	return resp, nil
+}
+
+// TestMempoolAccept is the dry run of SubmitRawTransaction, the rejection of
+// the tx is replied as the reason instead of the error.
+func (a *API) TestMempoolAccept(ctx context.Context, in *RawTransactionRequest) (*TestMempoolAcceptResponse, error) {
+	tx, err := decodeRawTx(in.RawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &TestMempoolAcceptResponse{Txid: tx.Hash().String()}
+	isOrphan, err := a.Chain.CheckTx(tx)
+	if err != nil {
+		_, resp.RejectDetail = errorDetail(err)
+		resp.RejectReason = err.Error()
+		return resp, nil
+	}
+
+	resp.Allowed = true
+	resp.Orphan = isOrphan
+	return resp, nil
+}
+
+// DecodeRawTransaction parses the hex encoded tx without checking it.
+func (a *API) DecodeRawTransaction(ctx context.Context, in *RawTransactionRequest) (*GetTransactionResponse, error) {
+	tx, err := decodeRawTx(in.RawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(GetTransactionResponse)
+	constructTxResp(resp, tx)
+	return resp, nil
+}
+
+func decodeRawTx(rawTx string) (*types.Tx, error) {
+	buf, err := hex.DecodeString(rawTx)
+	if err != nil || len(buf) == 0 {
+		return nil, ErrInvalidRawTransaction
+	}
+
+	tx := new(types.Tx)
+	if err := tx.UnmarshalText(buf); err != nil {
+		return nil, errors.WithDetail(ErrInvalidRawTransaction, err.Error())
+	}
+	return tx, nil
+}
+
 func constructTxResp(resp interface{}, tx *types.Tx) {
 	txid := tx.Hash()
 
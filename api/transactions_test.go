@@ -0,0 +1,148 @@
+package api
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+func TestDecodeRawTransaction(t *testing.T) {
+	tx := types.MockTx()
+	buf, err := tx.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := (&API{}).DecodeRawTransaction(context.Background(), &RawTransactionRequest{RawTx: hex.EncodeToString(buf)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Txid != tx.Hash().String() || len(resp.Inputs) != len(tx.Inputs) || len(resp.Evidences) != len(tx.Evidences) {
+		t.Fatalf("got tx %v", resp)
+	}
+
+	for _, rawTx := range []string{"", "zz", hex.EncodeToString(buf[:len(buf)-1])} {
+		if _, err := (&API{}).DecodeRawTransaction(context.Background(), &RawTransactionRequest{RawTx: rawTx}); errors.Root(err) != ErrInvalidRawTransaction {
+			t.Errorf("raw tx %q: got err %v", rawTx, err)
+		}
+	}
+}
+
+func TestDecodeRawBlock(t *testing.T) {
+	block := types.MockBlock()
+	buf, err := block.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := (&API{}).DecodeRawBlock(context.Background(), &RawBlockRequest{RawBlock: hex.EncodeToString(buf)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Hash != block.Hash().String() || len(resp.Transactions) != len(block.Transactions) {
+		t.Fatalf("got block %v", resp)
+	}
+
+	if _, err := (&API{}).DecodeRawBlock(context.Background(), &RawBlockRequest{RawBlock: "00"}); errors.Root(err) != ErrInvalidRawBlock {
+		t.Fatalf("got err %v, want %v", err, ErrInvalidRawBlock)
+	}
+}
+
+func encodeRawTx(t *testing.T, tx *types.Tx) string {
+	buf, err := tx.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(buf)
+}
+
+func TestMempoolAccept(t *testing.T) {
+	chain, txPool, _ := newTestChain(t)
+	a := &API{Chain: chain}
+
+	inPool := types.MockTx()
+	if _, err := txPool.ProcessTransaction(inPool, 0); err != nil {
+		t.Fatal(err)
+	}
+	dust := types.MockTx()
+	txPool.AddErrCache(dust.Hash().Ptr(), protocol.ErrDustTx)
+	invalid := types.MockTx()
+	invalid.LockTime = 100
+
+	cases := []struct {
+		tx           *types.Tx
+		rejectReason string
+	}{
+		{tx: types.MockTx()},
+		{tx: inPool, rejectReason: "TRANSACTION_IN_POOL"},
+		{tx: dust, rejectReason: "DUST_TRANSACTION"},
+		{tx: invalid, rejectReason: "BAD_TRANSACTION"},
+	}
+
+	for i, c := range cases {
+		resp, err := a.TestMempoolAccept(context.Background(), &RawTransactionRequest{RawTx: encodeRawTx(t, c.tx)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Txid != c.tx.Hash().String() || resp.Allowed != (c.rejectReason == "") {
+			t.Errorf("case %d: got %v", i, resp)
+		}
+		if c.rejectReason != "" && (resp.RejectDetail == nil || resp.RejectDetail.Reason != c.rejectReason) {
+			t.Errorf("case %d: got reject detail %v, want %s", i, resp.RejectDetail, c.rejectReason)
+		}
+	}
+
+	if size, _ := txPool.Stats(); size != 1 {
+		t.Errorf("dry run changes the mempool, got %d txs", size)
+	}
+}
+
+func TestSubmitRawTransaction(t *testing.T) {
+	chain, txPool, dispatcher := newTestChain(t)
+	defer dispatcher.Stop()
+	a := &API{Chain: chain}
+
+	sub, err := dispatcher.Subscribe(protocol.TxMsgEvent{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	tx := types.MockTx()
+	resp, err := a.SubmitRawTransaction(context.Background(), &RawTransactionRequest{RawTx: encodeRawTx(t, tx)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Txid != tx.Hash().String() || resp.Orphan || !txPool.IsTransactionInPool(tx.Hash().Ptr()) {
+		t.Fatalf("got %v, tx in pool %v", resp, txPool.IsTransactionInPool(tx.Hash().Ptr()))
+	}
+
+	// netsync relays the new txs of the mempool event
+	select {
+	case obj := <-sub.Chan():
+		ev := obj.Data.(protocol.TxMsgEvent)
+		if ev.TxMsg.MsgType != protocol.MsgNewTx || ev.TxMsg.Tx.Hash() != tx.Hash() {
+			t.Errorf("got mempool event %v", ev.TxMsg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("submitted tx is not posted for relay")
+	}
+
+	// the rejection of the invalid tx is cached for the submission again
+	invalid := types.MockTx()
+	invalid.LockTime = 100
+	for i := 0; i < 2; i++ {
+		if _, err := a.SubmitRawTransaction(context.Background(), &RawTransactionRequest{RawTx: encodeRawTx(t, invalid)}); errors.Root(err) != protocol.ErrBadTx {
+			t.Errorf("submission %d: got err %v, want %v", i, err, protocol.ErrBadTx)
+		}
+	}
+	if txPool.IsTransactionInPool(invalid.Hash().Ptr()) {
+		t.Errorf("invalid tx is added to the mempool")
+	}
+}
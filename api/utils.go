@@ -30,6 +30,18 @@ func (a *API) getBlockByID(id string) (*types.Block, error) {
 	return nil, ErrInvalidBlockID
 }
 
+func (a *API) getHeaderByID(id string) (*types.BlockHeader, error) {
+	if height, err := decodeBlockID(id, blockIDHeight); err == nil {
+		return a.reader.GetHeaderByHeight(height.(uint64))
+	}
+
+	if hash, err := decodeBlockID(id, blockIDHash); err == nil {
+		return a.reader.GetHeaderByHash(hash.(types.Hash).Ptr())
+	}
+
+	return nil, ErrInvalidBlockID
+}
+
 func decodeBlockID(id string, typ int) (interface{}, error) {
 	switch typ {
 	case blockIDHeight:
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/clarenous/go-capsule/errors"
+)
+
+const (
+	// wsPathPrefix is followed by the name of the subscription, the evidence
+	// filter is given by the digest, source and mempool query parameters.
+	wsPathPrefix = "/v1/ws/"
+	wsGUID       = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+	wsWriteTimeout    = 10 * time.Second
+	wsMaxFramePayload = 4096
+
+	wsOpText  = 0x1
+	wsOpClose = 0x8
+	wsOpPing  = 0x9
+	wsOpPong  = 0xa
+
+	wsCloseNormal   = 1000
+	wsCloseInternal = 1011
+)
+
+var (
+	errWSHandshake = errors.New("invalid websocket handshake")
+	errWSFrame     = errors.New("invalid websocket frame")
+)
+
+// handleWebsocket bridges the subscriptions to the websocket clients, every
+// notification is sent as a text message of the json of the gateway.
+func (a *API) handleWebsocket(w http.ResponseWriter, r *http.Request) {
+	if a.LightChain != nil {
+		writeHTTPError(w, r, ErrLightMode)
+		return
+	}
+
+	var subscribe func(ctx context.Context, c *wsConn) error
+	switch strings.TrimPrefix(r.URL.Path, wsPathPrefix) {
+	case "blocks":
+		subscribe = func(ctx context.Context, c *wsConn) error {
+			return a.subscribeBlocks(ctx, func(n *BlockNotification) error { return c.writeJSON(n) })
+		}
+
+	case "transactions":
+		subscribe = func(ctx context.Context, c *wsConn) error {
+			return a.subscribeTransactions(ctx, func(n *TxNotification) error { return c.writeJSON(n) })
+		}
+
+	case "evidences":
+		query := r.URL.Query()
+		filter, err := newEvidenceFilter(query.Get("digest"), query.Get("source"))
+		if err != nil {
+			writeHTTPError(w, r, err)
+			return
+		}
+		mempool := query.Get("mempool") == "true"
+		subscribe = func(ctx context.Context, c *wsConn) error {
+			return a.subscribeEvidences(ctx, filter, mempool, func(n *EvidenceNotification) error { return c.writeJSON(n) })
+		}
+
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	defer atomic.AddInt32(&a.numWebsockets, -1)
+	if atomic.AddInt32(&a.numWebsockets, 1) > int32(a.config.Websocket.MaxNumWebsockets) {
+		writeHTTPError(w, r, ErrTooManyWebsockets)
+		return
+	}
+
+	c, err := upgradeWebsocket(w, r)
+	if err != nil {
+		log.WithFields(log.Fields{"remote": r.RemoteAddr, "err": err}).Warn("fail on websocket handshake")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		c.readLoop()
+		cancel()
+	}()
+
+	err = subscribe(ctx, c)
+	c.close(err)
+	cancel()
+}
+
+// wsConn is the server side of a websocket connection, the messages of the
+// client other than the control frames are discarded.
+type wsConn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+	mtx  sync.Mutex
+}
+
+// upgradeWebsocket completes the websocket handshake of RFC 6455, the error
+// is replied to the client unless the connection is hijacked.
+func upgradeWebsocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || key == "" || r.Header.Get("Sec-WebSocket-Version") != "13" ||
+		!headerContains(r.Header, "Connection", "upgrade") || !headerContains(r.Header, "Upgrade", "websocket") {
+		http.Error(w, errWSHandshake.Error(), http.StatusBadRequest)
+		return nil, errWSHandshake
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, errWSHandshake.Error(), http.StatusInternalServerError)
+		return nil, errors.Wrap(errWSHandshake, "connection can't be hijacked")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &wsConn{conn: conn, rw: rw}
+	conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	rw.WriteString("Sec-WebSocket-Accept: " + websocketAccept(key) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func websocketAccept(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + wsGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header[name] {
+		for _, v := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(v), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *wsConn) writeJSON(v interface{}) error {
+	data, err := jsonMarshaler.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.writeFrame(wsOpText, data)
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xffff:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(n))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(n))
+	}
+
+	c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	c.rw.Write(header)
+	c.rw.Write(payload)
+	return c.rw.Flush()
+}
+
+// readFrame returns the opcode and the unmasked payload of the next frame,
+// the frames of the client must be masked.
+func (c *wsConn) readFrame() (byte, []byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.rw, head[:]); err != nil {
+		return 0, nil, err
+	}
+	if head[1]&0x80 == 0 {
+		return 0, nil, errors.Wrap(errWSFrame, "frame is not masked")
+	}
+
+	length := uint64(head[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > wsMaxFramePayload {
+		return 0, nil, errors.Wrap(errWSFrame, "frame is too large")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.rw, mask[:]); err != nil {
+		return 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.rw, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return head[0] & 0x0f, payload, nil
+}
+
+// readLoop answers the pings of the client, it returns when the client closes
+// the connection.
+func (c *wsConn) readLoop() {
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return
+		}
+
+		switch opcode {
+		case wsOpPing:
+			c.writeFrame(wsOpPong, payload)
+		case wsOpClose:
+			return
+		}
+	}
+}
+
+// close sends the close frame of the subscription error and closes the
+// connection.
+func (c *wsConn) close(err error) {
+	code, reason := uint16(wsCloseNormal), ""
+	if err != nil {
+		code, reason = wsCloseInternal, err.Error()
+	}
+
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, code)
+	payload = append(payload, reason...)
+	if len(payload) > 125 {
+		payload = payload[:125]
+	}
+	c.writeFrame(wsOpClose, payload)
+	c.conn.Close()
+}
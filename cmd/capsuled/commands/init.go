@@ -1,8 +1,10 @@
 package commands
 
 import (
+	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -18,6 +20,7 @@ var initFilesCmd = &cobra.Command{
 
 func init() {
 	initFilesCmd.Flags().String("chain_id", config.ChainID, "Select [mainnet] or [testnet] or [solonet]")
+	initFilesCmd.Flags().String("chain_spec", config.ChainSpec, "Initialize a custom network from the json or toml chain spec file")
 
 	RootCmd.AddCommand(initFilesCmd)
 }
@@ -29,6 +32,11 @@ func initFiles(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	if config.ChainSpec != "" {
+		initChainSpec(configFilePath)
+		return
+	}
+
 	switch config.ChainID {
 	case "mainnet", "testnet":
 		cfg.EnsureRoot(config.RootDir, config.ChainID)
@@ -38,3 +46,17 @@ func initFiles(cmd *cobra.Command, args []string) {
 
 	log.WithFields(log.Fields{"module": logModule, "config": configFilePath}).Info("Initialized capsule")
 }
+
+func initChainSpec(configFilePath string) {
+	spec, err := cfg.LoadChainSpec(config.ChainSpec)
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "chain_spec": config.ChainSpec, "err": err}).Fatal("Invalid chain spec")
+	}
+	data, err := ioutil.ReadFile(config.ChainSpec)
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "chain_spec": config.ChainSpec, "err": err}).Fatal("Failed to read chain spec")
+	}
+
+	cfg.EnsureRootWithSpec(config.RootDir, spec, data, "chain_spec"+filepath.Ext(config.ChainSpec))
+	log.WithFields(log.Fields{"module": logModule, "config": configFilePath, "chain_id": spec.ChainID}).Info("Initialized capsule from chain spec")
+}
@@ -21,16 +21,24 @@ var runNodeCmd = &cobra.Command{
 func init() {
 	runNodeCmd.Flags().String("prof_laddr", config.ProfListenAddress, "Use http to profile capsuled programs")
 	runNodeCmd.Flags().Bool("mining", config.Mining, "Enable mining")
+	runNodeCmd.Flags().String("authority_key", config.AuthorityKey, "Hex encoded key to seal blocks on proof of authority chains")
 
 	runNodeCmd.Flags().Bool("simd.enable", config.Simd.Enable, "Enable SIMD mechan for tensority")
 
 	runNodeCmd.Flags().Bool("auth.disable", config.Auth.Disable, "Disable rpc access authenticate")
+	runNodeCmd.Flags().String("auth.tls_cert_file", config.Auth.TLSCertFile, "PEM certificate file to serve the api over tls")
+	runNodeCmd.Flags().String("auth.tls_key_file", config.Auth.TLSKeyFile, "PEM key file to serve the api over tls")
+	runNodeCmd.Flags().String("api_addr", config.ApiAddress, "Http gateway listen address of the api")
+	runNodeCmd.Flags().String("grpc_addr", config.GRPCAddress, "Grpc listen address of the api")
 
 	runNodeCmd.Flags().Bool("wallet.disable", config.Wallet.Disable, "Disable wallet")
 	runNodeCmd.Flags().Bool("wallet.rescan", config.Wallet.Rescan, "Rescan wallet")
 	runNodeCmd.Flags().Bool("vault_mode", config.VaultMode, "Run in the offline enviroment")
+	runNodeCmd.Flags().Bool("light", config.Light, "Sync only the block headers and the merkle proofs of the watched txs")
+	runNodeCmd.Flags().String("light_watch", config.LightWatch, "Comma delimited hex script hashes and evidence digests watched in light mode")
 	runNodeCmd.Flags().Bool("web.closed", config.Web.Closed, "Lanch web browser or not")
 	runNodeCmd.Flags().String("chain_id", config.ChainID, "Select network type")
+	runNodeCmd.Flags().String("chain_spec", config.ChainSpec, "Json or toml chain spec file of a custom network")
 
 	// log level
 	runNodeCmd.Flags().String("log_level", config.LogLevel, "Select log level(debug, info, warn, error or fatal")
@@ -46,6 +54,21 @@ func init() {
 	runNodeCmd.Flags().String("p2p.proxy_address", config.P2P.ProxyAddress, "Connect via SOCKS5 proxy (eg. 127.0.0.1:1086)")
 	runNodeCmd.Flags().String("p2p.proxy_username", config.P2P.ProxyUsername, "Username for proxy server")
 	runNodeCmd.Flags().String("p2p.proxy_password", config.P2P.ProxyPassword, "Password for proxy server")
+	runNodeCmd.Flags().Int("p2p.min_protocol", config.P2P.MinProtocol, "Minimum p2p protocol version of the peers")
+	runNodeCmd.Flags().Int64("p2p.peer_send_rate", config.P2P.PeerSendRate, "Upload limit of each peer in bytes per second, 0 for unlimited")
+	runNodeCmd.Flags().Int64("p2p.peer_recv_rate", config.P2P.PeerRecvRate, "Download limit of each peer in bytes per second, 0 for unlimited")
+	runNodeCmd.Flags().Int64("p2p.total_send_rate", config.P2P.TotalSendRate, "Upload limit of all the peers in bytes per second, 0 for unlimited")
+	runNodeCmd.Flags().Int64("p2p.total_recv_rate", config.P2P.TotalRecvRate, "Download limit of all the peers in bytes per second, 0 for unlimited")
+	runNodeCmd.Flags().String("p2p.tor_control", config.P2P.TorControl, "Tor control port to publish the onion service (eg. 127.0.0.1:9051)")
+	runNodeCmd.Flags().String("p2p.tor_password", config.P2P.TorPassword, "Password for tor control port")
+	runNodeCmd.Flags().Bool("p2p.onion_only", config.P2P.OnionOnly, "Dial and accept the onion addresses only, the outbound connections go via the proxy")
+	runNodeCmd.Flags().String("p2p.transports", config.P2P.Transports, "Comma delimited transports offered to the peers, empty for the legacy secret connection only")
+	runNodeCmd.Flags().String("p2p.allowlist", config.P2P.Allowlist, "File of the node public keys allowed to connect, enables permissioned mode")
+
+	// stratum flags
+	runNodeCmd.Flags().Bool("stratum.enable", config.Stratum.Enable, "Enable stratum server for external miners")
+	runNodeCmd.Flags().String("stratum.laddr", config.Stratum.ListenAddress, "Stratum server listen address")
+	runNodeCmd.Flags().Uint64("stratum.share_difficulty", config.Stratum.ShareDifficulty, "Difficulty of the shares accepted by stratum server")
 
 	// log flags
 	runNodeCmd.Flags().String("log_file", config.LogFile, "Log output file")
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	cmn "github.com/tendermint/tmlibs/common"
+
+	"github.com/clarenous/go-capsule/api/auth"
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage the access tokens of the api",
+}
+
+var createTokenCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an access token, the secret is shown only once",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scopes, _ := cmd.Flags().GetString("scopes")
+		credential, err := auth.NewStore(config.TokenFile()).Create(args[0], strings.Split(scopes, ","))
+		if err != nil {
+			cmn.Exit("Error: " + err.Error())
+		}
+		fmt.Println(credential)
+	},
+}
+
+var listTokenCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the access tokens",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		tokens, err := auth.NewStore(config.TokenFile()).List()
+		if err != nil {
+			cmn.Exit("Error: " + err.Error())
+		}
+		for _, token := range tokens {
+			fmt.Printf("%s\t%s\t%s\n", token.Name, strings.Join(token.Scopes, ","), token.Created.Format(time.RFC3339))
+		}
+	},
+}
+
+var deleteTokenCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete an access token",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := auth.NewStore(config.TokenFile()).Delete(args[0]); err != nil {
+			cmn.Exit("Error: " + err.Error())
+		}
+	},
+}
+
+func init() {
+	createTokenCmd.Flags().String("scopes", auth.ScopeRead, "Comma delimited scopes of the token ("+strings.Join(auth.Scopes, ", ")+")")
+
+	tokenCmd.AddCommand(createTokenCmd)
+	tokenCmd.AddCommand(listTokenCmd)
+	tokenCmd.AddCommand(deleteTokenCmd)
+	RootCmd.AddCommand(tokenCmd)
+}
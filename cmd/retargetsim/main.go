@@ -0,0 +1,70 @@
+// Command retargetsim replays synthetic hashrate schedules against the pow
+// difficulty algorithms and reports the block time variance.
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/clarenous/go-capsule/consensus"
+)
+
+var (
+	algorithm string
+	window    uint64
+	halfLife  uint64
+	blocks    uint64
+	schedule  string
+	seed      int64
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "retargetsim",
+	Short: "Simulate the pow difficulty algorithms under hashrate swings",
+	RunE:  run,
+}
+
+func init() {
+	rootCmd.Flags().StringVar(&algorithm, "algorithm", consensus.DifficultyLWMA, "Difficulty algorithm (retarget, lwma or asert)")
+	rootCmd.Flags().Uint64Var(&window, "window", 90, "Number of blocks weighted by lwma")
+	rootCmd.Flags().Uint64Var(&halfLife, "half_life", 3600, "Half life seconds of asert")
+	rootCmd.Flags().Uint64Var(&blocks, "blocks", 10000, "Number of blocks to simulate")
+	rootCmd.Flags().StringVar(&schedule, "schedule", "1:1000000,3000:10000000,6000:500000", "Hashrate schedule in height:hashes_per_second pairs")
+	rootCmd.Flags().Int64Var(&seed, "seed", 1, "Seed of the random solve times")
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	phases, err := parseSchedule(schedule)
+	if err != nil {
+		return err
+	}
+
+	fork := consensus.DifficultyFork{Algorithm: algorithm, Window: window, HalfLife: halfLife}
+	solveTimes, err := simulate(fork, phases, blocks, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("algorithm %s, target block time %ds\n\n", algorithm, consensus.ActiveNetParams.TargetSecondsPerBlock)
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "heights\thashrate\tblocks\tmean\tstddev\tvariance\tmin\tmax\t")
+	for _, s := range phaseStats(solveTimes, phases) {
+		hashrate := "all"
+		if s.hashrate > 0 {
+			hashrate = fmt.Sprintf("%.4g", s.hashrate)
+		}
+		fmt.Fprintf(w, "%d-%d\t%s\t%d\t%.1f\t%.1f\t%.1f\t%d\t%d\t\n", s.from, s.to, hashrate, s.count, s.mean, math.Sqrt(s.variance), s.variance, s.min, s.max)
+	}
+	return w.Flush()
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/clarenous/go-capsule/consensus"
+	"github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/protocol/state"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+const genesisTimestamp = uint64(1546300800)
+
+var errEmptySchedule = errors.New("hashrate schedule is empty")
+
+// phase is a period of constant hashrate starting from height
+type phase struct {
+	height   uint64
+	hashrate float64
+}
+
+// stats is the block time statistics of a range of blocks
+type stats struct {
+	from, to uint64
+	hashrate float64
+	count    int
+	mean     float64
+	variance float64
+	min, max uint64
+}
+
+// parseSchedule parses the hashrate schedule in the form of
+// "height:hashrate,height:hashrate", the first phase must start at height 1.
+func parseSchedule(s string) ([]phase, error) {
+	var phases []phase
+	for _, item := range strings.Split(s, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid schedule item %q", item)
+		}
+
+		height, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule height %q", parts[0])
+		}
+		hashrate, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || hashrate <= 0 {
+			return nil, fmt.Errorf("invalid schedule hashrate %q", parts[1])
+		}
+		phases = append(phases, phase{height: height, hashrate: hashrate})
+	}
+
+	sort.Slice(phases, func(i, j int) bool { return phases[i].height < phases[j].height })
+	if len(phases) == 0 {
+		return nil, errEmptySchedule
+	}
+	phases[0].height = 1
+	return phases, nil
+}
+
+func hashrateAt(phases []phase, height uint64) float64 {
+	hashrate := phases[0].hashrate
+	for _, p := range phases {
+		if p.height > height {
+			break
+		}
+		hashrate = p.hashrate
+	}
+	return hashrate
+}
+
+// simulate mines the given number of blocks with the difficulty algorithm and
+// returns the solve time of every block, starting from height 1. The solve
+// times are drawn from the exponential distribution with the mean of the
+// expected hashes for the target divided by the hashrate.
+func simulate(fork consensus.DifficultyFork, phases []phase, blocks uint64, rng *rand.Rand) ([]uint64, error) {
+	if len(phases) == 0 {
+		return nil, errEmptySchedule
+	}
+
+	params := consensus.ActiveNetParams
+	defer func() { consensus.ActiveNetParams = params }()
+	consensus.ActiveNetParams.DifficultyForks = []consensus.DifficultyFork{fork}
+
+	// the genesis target matches the initial hashrate
+	expectedHashes := big.NewFloat(phases[0].hashrate * float64(consensus.ActiveNetParams.TargetSecondsPerBlock))
+	genesisTarget, _ := new(big.Float).Quo(new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 256)), expectedHashes).Int(nil)
+	node, err := state.NewBlockNode(&types.BlockHeader{
+		Version:   1,
+		Timestamp: genesisTimestamp,
+		Proof:     &pow.WorkProof{Target: pow.BigToCompact(genesisTarget)},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	solveTimes := make([]uint64, 0, blocks)
+	for height := uint64(1); height <= blocks; height++ {
+		target, err := pow.CalcNextTarget(node)
+		if err != nil {
+			return nil, err
+		}
+
+		work, _ := new(big.Float).SetInt(pow.CalcWork(target)).Float64()
+		solveTime := uint64(math.Round(rng.ExpFloat64() * work / hashrateAt(phases, height)))
+		header := &types.BlockHeader{
+			Version:   1,
+			Height:    height,
+			Timestamp: node.Timestamp + solveTime,
+			Previous:  node.Hash,
+			Proof:     &pow.WorkProof{Target: target},
+		}
+		if node, err = state.NewBlockNode(header, node); err != nil {
+			return nil, err
+		}
+		solveTimes = append(solveTimes, solveTime)
+	}
+	return solveTimes, nil
+}
+
+// calcStats returns the statistics of the blocks in [from, to]
+func calcStats(solveTimes []uint64, from, to uint64) *stats {
+	s := &stats{from: from, to: to, min: math.MaxUint64}
+	var sum float64
+	for height := from; height <= to && height <= uint64(len(solveTimes)); height++ {
+		t := solveTimes[height-1]
+		sum += float64(t)
+		s.count++
+		if t < s.min {
+			s.min = t
+		}
+		if t > s.max {
+			s.max = t
+		}
+	}
+	if s.count == 0 {
+		s.min = 0
+		return s
+	}
+
+	s.mean = sum / float64(s.count)
+	for height := from; height < from+uint64(s.count); height++ {
+		d := float64(solveTimes[height-1]) - s.mean
+		s.variance += d * d
+	}
+	s.variance /= float64(s.count)
+	return s
+}
+
+// phaseStats returns the statistics of every hashrate phase and the overall
+func phaseStats(solveTimes []uint64, phases []phase) []*stats {
+	var result []*stats
+	for i, p := range phases {
+		to := uint64(len(solveTimes))
+		if i+1 < len(phases) {
+			to = phases[i+1].height - 1
+		}
+		if p.height > to {
+			continue
+		}
+
+		s := calcStats(solveTimes, p.height, to)
+		s.hashrate = p.hashrate
+		result = append(result, s)
+	}
+	return append(result, calcStats(solveTimes, 1, uint64(len(solveTimes))))
+}
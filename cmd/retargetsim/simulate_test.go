@@ -0,0 +1,77 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/clarenous/go-capsule/consensus"
+)
+
+func TestParseSchedule(t *testing.T) {
+	phases, err := parseSchedule("500:2e6, 0:1000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(phases) != 2 || phases[0] != (phase{1, 1000}) || phases[1] != (phase{500, 2e6}) {
+		t.Errorf("got phases %v", phases)
+	}
+	if hashrateAt(phases, 499) != 1000 || hashrateAt(phases, 500) != 2e6 {
+		t.Errorf("wrong hashrate at the phase boundary")
+	}
+
+	for _, s := range []string{"", "1", "1:0", "x:1", "1:x"} {
+		if _, err := parseSchedule(s); err == nil {
+			t.Errorf("schedule %q should be invalid", s)
+		}
+	}
+}
+
+// The per block algorithms must bring the block time back to the target soon
+// after the hashrate grows ten times.
+func TestSimulateHashrateSwing(t *testing.T) {
+	phases := []phase{{1, 1e6}, {500, 1e7}}
+	target := float64(consensus.ActiveNetParams.TargetSecondsPerBlock)
+
+	for _, fork := range []consensus.DifficultyFork{
+		{Algorithm: consensus.DifficultyLWMA, Window: 60},
+		{Algorithm: consensus.DifficultyASERT, HalfLife: 3600},
+	} {
+		solveTimes, err := simulate(fork, phases, 1500, rand.New(rand.NewSource(1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(solveTimes) != 1500 {
+			t.Fatalf("got %d blocks", len(solveTimes))
+		}
+
+		s := calcStats(solveTimes, 700, 1500)
+		if math.Abs(s.mean-target)/target > 0.15 {
+			t.Errorf("%s: got mean block time %.1f after the swing", fork.Algorithm, s.mean)
+		}
+	}
+
+	if _, err := simulate(consensus.DifficultyFork{Algorithm: "unknown"}, phases, 10, rand.New(rand.NewSource(1))); err == nil {
+		t.Errorf("unknown algorithm should fail")
+	}
+}
+
+func TestPhaseStats(t *testing.T) {
+	solveTimes := []uint64{1, 3, 10, 20, 30}
+	result := phaseStats(solveTimes, []phase{{1, 1}, {3, 2}})
+	if len(result) != 3 {
+		t.Fatalf("got %d stats", len(result))
+	}
+
+	want := []stats{
+		{from: 1, to: 2, hashrate: 1, count: 2, mean: 2, variance: 1, min: 1, max: 3},
+		{from: 3, to: 5, hashrate: 2, count: 3, mean: 20, variance: 200.0 / 3, min: 10, max: 30},
+		{from: 1, to: 5, count: 5, mean: 12.8, variance: 118.16, min: 1, max: 30},
+	}
+	for i, s := range result {
+		if s.from != want[i].from || s.to != want[i].to || s.hashrate != want[i].hashrate || s.count != want[i].count ||
+			math.Abs(s.mean-want[i].mean) > 1e-9 || math.Abs(s.variance-want[i].variance) > 1e-9 || s.min != want[i].min || s.max != want[i].max {
+			t.Errorf("stats %d: got %+v, want %+v", i, *s, want[i])
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package config
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/clarenous/go-capsule/consensus"
+	ca "github.com/clarenous/go-capsule/consensus/algorithm"
+	"github.com/clarenous/go-capsule/crypto/ed25519"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+var (
+	errUnknownSpecFormat = errors.New("chain spec must be a .json or .toml file")
+	errNoChainID         = errors.New("chain spec has no chain_id")
+	errNoBech32HRP       = errors.New("chain spec has no bech32_hrp")
+	errBuiltinChainID    = errors.New("chain spec chain_id conflicts with a built-in network")
+)
+
+// ChainSpec is the definition of a network loaded from a json or toml file,
+// the toml keys are the same as the json ones.
+type ChainSpec struct {
+	ChainID         string               `json:"chain_id"`
+	Bech32HRPSegwit string               `json:"bech32_hrp"`
+	DefaultPort     string               `json:"default_port"`
+	DNSSeeds        []string             `json:"dns_seeds"`
+	Seeds           []string             `json:"seeds"`
+	Genesis         GenesisSpec          `json:"genesis"`
+	Subsidy         SubsidySpec          `json:"subsidy"`
+	Retarget        RetargetSpec         `json:"retarget"`
+	Checkpoints     []CheckpointSpec     `json:"checkpoints"`
+	ProofForks      []ProofForkSpec      `json:"proof_forks"`
+	DifficultyForks []DifficultyForkSpec `json:"difficulty_forks"`
+	Authorities     []AuthoritySpec      `json:"authorities"`
+}
+
+// GenesisSpec is the genesis block header, target and nonce are only used by
+// the pow consensus.
+type GenesisSpec struct {
+	Version   uint64 `json:"version"`
+	Timestamp uint64 `json:"timestamp"`
+	Target    uint64 `json:"target"`
+	Nonce     uint64 `json:"nonce"`
+}
+
+// SubsidySpec is the coinbase reward schedule
+type SubsidySpec struct {
+	Initial           uint64 `json:"initial"`
+	Base              uint64 `json:"base"`
+	ReductionInterval uint64 `json:"reduction_interval"`
+}
+
+// RetargetSpec is the block intervals, zero values fall back to the mainnet ones
+type RetargetSpec struct {
+	BlocksPerRetarget        uint64 `json:"blocks_per_retarget"`
+	TargetSecondsPerBlock    uint64 `json:"target_seconds_per_block"`
+	AuthoritySecondsPerBlock uint64 `json:"authority_seconds_per_block"`
+}
+
+type CheckpointSpec struct {
+	Height uint64 `json:"height"`
+	Hash   string `json:"hash"`
+}
+
+type ProofForkSpec struct {
+	Height uint64 `json:"height"`
+	Type   string `json:"type"`
+}
+
+type DifficultyForkSpec struct {
+	Height    uint64 `json:"height"`
+	Algorithm string `json:"algorithm"`
+	Window    uint64 `json:"window"`
+	HalfLife  uint64 `json:"half_life"`
+}
+
+// AuthoritySpec is the poa signer set from height on, the signers are hex
+// encoded ed25519 public keys.
+type AuthoritySpec struct {
+	Height  uint64   `json:"height"`
+	Signers []string `json:"signers"`
+}
+
+// LoadChainSpec reads the chain spec file, the format is chosen by extension
+func LoadChainSpec(path string) (*ChainSpec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &ChainSpec{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(spec)
+	case ".toml":
+		err = toml.NewDecoder(bytes.NewReader(data)).SetTagName("json").Decode(spec)
+	default:
+		return nil, errUnknownSpecFormat
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fail on decode chain spec %s: %v", path, err)
+	}
+
+	if _, err := spec.Params(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// Params converts the spec into the consensus params of the network
+func (s *ChainSpec) Params() (*consensus.Params, error) {
+	if s.ChainID == "" {
+		return nil, errNoChainID
+	}
+	if s.Bech32HRPSegwit == "" {
+		return nil, errNoBech32HRP
+	}
+
+	params := &consensus.Params{
+		Name:                     s.ChainID,
+		Bech32HRPSegwit:          s.Bech32HRPSegwit,
+		DefaultPort:              s.DefaultPort,
+		DNSSeeds:                 s.DNSSeeds,
+		InitialBlockSubsidy:      s.Subsidy.Initial,
+		BaseSubsidy:              s.Subsidy.Base,
+		SubsidyReductionInterval: s.Subsidy.ReductionInterval,
+		BlocksPerRetarget:        s.Retarget.BlocksPerRetarget,
+		TargetSecondsPerBlock:    s.Retarget.TargetSecondsPerBlock,
+		AuthoritySecondsPerBlock: s.Retarget.AuthoritySecondsPerBlock,
+	}
+	if params.BlocksPerRetarget == 0 {
+		params.BlocksPerRetarget = consensus.MainNetParams.BlocksPerRetarget
+	}
+	if params.TargetSecondsPerBlock == 0 {
+		params.TargetSecondsPerBlock = consensus.MainNetParams.TargetSecondsPerBlock
+	}
+	if params.AuthoritySecondsPerBlock == 0 {
+		params.AuthoritySecondsPerBlock = consensus.MainNetParams.AuthoritySecondsPerBlock
+	}
+
+	for _, c := range s.Checkpoints {
+		hash, err := types.NewHashFromString(c.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoint hash at height %d: %v", c.Height, err)
+		}
+		params.Checkpoints = append(params.Checkpoints, consensus.Checkpoint{Height: c.Height, Hash: hash})
+	}
+
+	params.ProofForks = []ca.ProofFork{{Height: 0, Typ: consensus.ProofType}}
+	if len(s.ProofForks) > 0 {
+		params.ProofForks = nil
+		for _, fork := range s.ProofForks {
+			params.ProofForks = append(params.ProofForks, ca.ProofFork{Height: fork.Height, Typ: fork.Type})
+		}
+	}
+	if err := ca.ValidateProofForks(params.ProofForks); err != nil {
+		return nil, fmt.Errorf("invalid proof forks: %v", err)
+	}
+
+	for i, fork := range s.DifficultyForks {
+		if i > 0 && fork.Height <= s.DifficultyForks[i-1].Height {
+			return nil, errors.New("difficulty forks must be sorted by height")
+		}
+		switch fork.Algorithm {
+		case consensus.DifficultyRetarget:
+		case consensus.DifficultyLWMA:
+			if fork.Window == 0 {
+				return nil, fmt.Errorf("lwma fork at height %d has no window", fork.Height)
+			}
+		case consensus.DifficultyASERT:
+			if fork.HalfLife == 0 {
+				return nil, fmt.Errorf("asert fork at height %d has no half_life", fork.Height)
+			}
+		default:
+			return nil, fmt.Errorf("unknown difficulty algorithm %q", fork.Algorithm)
+		}
+		params.DifficultyForks = append(params.DifficultyForks, consensus.DifficultyFork{
+			Height:    fork.Height,
+			Algorithm: fork.Algorithm,
+			Window:    fork.Window,
+			HalfLife:  fork.HalfLife,
+		})
+	}
+
+	for i, set := range s.Authorities {
+		if i > 0 && set.Height <= s.Authorities[i-1].Height {
+			return nil, errors.New("authorities must be sorted by height")
+		}
+		if len(set.Signers) == 0 {
+			return nil, fmt.Errorf("authorities at height %d are empty", set.Height)
+		}
+
+		authoritySet := consensus.AuthoritySet{Height: set.Height}
+		for _, signer := range set.Signers {
+			pubKey, err := hex.DecodeString(signer)
+			if err != nil || len(pubKey) != ed25519.PublicKeySize {
+				return nil, fmt.Errorf("invalid authority %q", signer)
+			}
+			authoritySet.Signers = append(authoritySet.Signers, ed25519.PublicKey(pubKey))
+		}
+		params.AuthoritySets = append(params.AuthoritySets, authoritySet)
+	}
+	return params, nil
+}
+
+// GenesisBlock builds the genesis block of the network
+func (s *ChainSpec) GenesisBlock() (*types.Block, error) {
+	typ := consensus.ProofType
+	if len(s.ProofForks) > 0 {
+		typ = s.ProofForks[0].Type
+	}
+	proof, err := ca.NewProof(typ, s.Genesis.Target, s.Genesis.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := genesisTx()
+	merkleRoot, err := types.TxMerkleRoot([]*types.Tx{tx})
+	if err != nil {
+		return nil, err
+	}
+
+	version := s.Genesis.Version
+	if version == 0 {
+		version = 1
+	}
+	return &types.Block{
+		BlockHeader: types.BlockHeader{
+			Version:         version,
+			Height:          0,
+			Timestamp:       s.Genesis.Timestamp,
+			TransactionRoot: merkleRoot,
+			WitnessRoot:     merkleRoot,
+			Proof:           proof,
+		},
+		Transactions: []*types.Tx{tx},
+	}, nil
+}
+
+// RegisterChainSpec makes the network of the spec selectable by its chain_id
+func RegisterChainSpec(spec *ChainSpec) error {
+	if _, ok := consensus.NetParams[spec.ChainID]; ok {
+		return errBuiltinChainID
+	}
+	if _, ok := genesisBlocks[spec.ChainID]; ok {
+		return errBuiltinChainID
+	}
+
+	params, err := spec.Params()
+	if err != nil {
+		return err
+	}
+	if _, err := spec.GenesisBlock(); err != nil {
+		return err
+	}
+
+	consensus.NetParams[spec.ChainID] = *params
+	genesisBlocks[spec.ChainID] = func() *types.Block {
+		block, _ := spec.GenesisBlock()
+		return block
+	}
+	return nil
+}
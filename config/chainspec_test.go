@@ -0,0 +1,209 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/clarenous/go-capsule/consensus"
+	ca "github.com/clarenous/go-capsule/consensus/algorithm"
+	_ "github.com/clarenous/go-capsule/consensus/algorithm/poa"
+	"github.com/clarenous/go-capsule/consensus/algorithm/pow"
+)
+
+const signer = "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"
+
+var jsonSpec = `{
+	"chain_id": "devnet",
+	"bech32_hrp": "dm",
+	"default_port": "46660",
+	"seeds": ["127.0.0.1:46660", "127.0.0.2:46660"],
+	"genesis": {"timestamp": 1546300800, "target": 2305843009214532812, "nonce": 7},
+	"subsidy": {"initial": 1000, "base": 100, "reduction_interval": 10},
+	"retarget": {"target_seconds_per_block": 60},
+	"checkpoints": [{"height": 10, "hash": "0000000000000000000000000000000000000000000000000000000000000001"}],
+	"proof_forks": [{"height": 0, "type": "pow"}, {"height": 100, "type": "poa"}],
+	"difficulty_forks": [{"height": 50, "algorithm": "lwma", "window": 45}],
+	"authorities": [{"height": 100, "signers": ["` + signer + `"]}]
+}`
+
+var tomlSpec = `chain_id = "devnet"
+bech32_hrp = "dm"
+default_port = "46660"
+seeds = ["127.0.0.1:46660", "127.0.0.2:46660"]
+
+[genesis]
+timestamp = 1546300800
+target = 2305843009214532812
+nonce = 7
+
+[subsidy]
+initial = 1000
+base = 100
+reduction_interval = 10
+
+[retarget]
+target_seconds_per_block = 60
+
+[[checkpoints]]
+height = 10
+hash = "0000000000000000000000000000000000000000000000000000000000000001"
+
+[[proof_forks]]
+height = 0
+type = "pow"
+
+[[proof_forks]]
+height = 100
+type = "poa"
+
+[[difficulty_forks]]
+height = 50
+algorithm = "lwma"
+window = 45
+
+[[authorities]]
+height = 100
+signers = ["` + signer + `"]
+`
+
+func writeSpec(t *testing.T, dir, name, data string) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadChainSpec(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "chainspec-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for _, name := range []string{"spec.json", "spec.toml"} {
+		data := jsonSpec
+		if strings.HasSuffix(name, ".toml") {
+			data = tomlSpec
+		}
+
+		spec, err := LoadChainSpec(writeSpec(t, tmpDir, name, data))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		params, err := spec.Params()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if params.Name != "devnet" || params.Bech32HRPSegwit != "dm" || params.DefaultPort != "46660" {
+			t.Errorf("%s: got params %s %s %s", name, params.Name, params.Bech32HRPSegwit, params.DefaultPort)
+		}
+		if params.BlockSubsidy(0) != 1000 || params.BlockSubsidy(9) != 100 || params.BlockSubsidy(10) != 50 {
+			t.Errorf("%s: wrong subsidy schedule", name)
+		}
+		if params.TargetSecondsPerBlock != 60 || params.BlocksPerRetarget != consensus.BlocksPerRetarget {
+			t.Errorf("%s: wrong retarget params %d %d", name, params.TargetSecondsPerBlock, params.BlocksPerRetarget)
+		}
+		if len(params.Checkpoints) != 1 || params.Checkpoints[0].Height != 10 {
+			t.Errorf("%s: got checkpoints %v", name, params.Checkpoints)
+		}
+		if len(params.ProofForks) != 2 || params.ProofForks[1] != (ca.ProofFork{Height: 100, Typ: "poa"}) {
+			t.Errorf("%s: got proof forks %v", name, params.ProofForks)
+		}
+		if fork := params.DifficultyForkAt(60); fork.Algorithm != consensus.DifficultyLWMA || fork.Window != 45 {
+			t.Errorf("%s: got difficulty fork %v", name, fork)
+		}
+		if len(params.AuthoritiesAt(100)) != 1 || len(params.AuthoritiesAt(99)) != 0 {
+			t.Errorf("%s: wrong authorities", name)
+		}
+		if len(spec.Seeds) != 2 {
+			t.Errorf("%s: got seeds %v", name, spec.Seeds)
+		}
+
+		block, err := spec.GenesisBlock()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		proof, ok := block.Proof.(*pow.WorkProof)
+		if !ok || proof.Target != 2305843009214532812 || proof.Nonce != 7 || block.Timestamp != 1546300800 {
+			t.Errorf("%s: got genesis %v", name, block.BlockHeader)
+		}
+	}
+}
+
+func TestInvalidChainSpec(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "chainspec-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cases := []string{
+		`{"bech32_hrp": "dm"}`,
+		`{"chain_id": "devnet"}`,
+		`{"chain_id": "devnet", "bech32_hrp": "dm", "unknown": 1}`,
+		`{"chain_id": "devnet", "bech32_hrp": "dm", "proof_forks": [{"height": 1, "type": "pow"}]}`,
+		`{"chain_id": "devnet", "bech32_hrp": "dm", "proof_forks": [{"height": 0, "type": "unknown"}]}`,
+		`{"chain_id": "devnet", "bech32_hrp": "dm", "difficulty_forks": [{"height": 0, "algorithm": "lwma"}]}`,
+		`{"chain_id": "devnet", "bech32_hrp": "dm", "difficulty_forks": [{"height": 0, "algorithm": "unknown"}]}`,
+		`{"chain_id": "devnet", "bech32_hrp": "dm", "authorities": [{"height": 0, "signers": ["00"]}]}`,
+		`{"chain_id": "devnet", "bech32_hrp": "dm", "checkpoints": [{"height": 1, "hash": "xx"}]}`,
+	}
+	for i, c := range cases {
+		if _, err := LoadChainSpec(writeSpec(t, tmpDir, "spec.json", c)); err == nil {
+			t.Errorf("case %d: invalid spec is loaded", i)
+		}
+	}
+
+	if _, err := LoadChainSpec(writeSpec(t, tmpDir, "spec.yaml", jsonSpec)); err != errUnknownSpecFormat {
+		t.Errorf("got err %v, want %v", err, errUnknownSpecFormat)
+	}
+}
+
+func TestRegisterChainSpec(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "chainspec-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	spec, err := LoadChainSpec(writeSpec(t, tmpDir, "spec.json", jsonSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterChainSpec(spec); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		delete(consensus.NetParams, spec.ChainID)
+		delete(genesisBlocks, spec.ChainID)
+	}()
+
+	if err := RegisterChainSpec(spec); err != errBuiltinChainID {
+		t.Errorf("got err %v, want %v", err, errBuiltinChainID)
+	}
+	spec.ChainID = "mainnet"
+	if err := RegisterChainSpec(spec); err != errBuiltinChainID {
+		t.Errorf("got err %v, want %v", err, errBuiltinChainID)
+	}
+
+	params := consensus.ActiveNetParams
+	defer func() { consensus.ActiveNetParams = params }()
+	consensus.ActiveNetParams = consensus.NetParams["devnet"]
+	if block := GenesisBlock(); block == nil || block.Timestamp != 1546300800 {
+		t.Errorf("got genesis block %v", block)
+	}
+
+	EnsureRootWithSpec(tmpDir, spec, []byte(jsonSpec), "chain_spec.json")
+	data, err := ioutil.ReadFile(filepath.Join(tmpDir, "config.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `chain_spec = "chain_spec.json"`) || !strings.Contains(string(data), "tcp://0.0.0.0:46660") {
+		t.Errorf("got config %s", data)
+	}
+}
@@ -28,6 +28,7 @@ type Config struct {
 	Web       *WebConfig       `mapstructure:"web"`
 	Simd      *SimdConfig      `mapstructure:"simd"`
 	Websocket *WebsocketConfig `mapstructure:"ws"`
+	Stratum   *StratumConfig   `mapstructure:"stratum"`
 }
 
 // Default configurable parameters.
@@ -40,6 +41,7 @@ func DefaultConfig() *Config {
 		Web:        DefaultWebConfig(),
 		Simd:       DefaultSimdConfig(),
 		Websocket:  DefaultWebsocketConfig(),
+		Stratum:    DefaultStratumConfig(),
 	}
 }
 
@@ -49,6 +51,35 @@ func (cfg *Config) SetRoot(root string) *Config {
 	return cfg
 }
 
+// AllowlistFile is the file of the node public keys allowed to connect in
+// permissioned mode, the mode is off when no file is set.
+func (cfg *Config) AllowlistFile() string {
+	if cfg.P2P.Allowlist == "" {
+		return ""
+	}
+	return rootify(cfg.P2P.Allowlist, cfg.BaseConfig.RootDir)
+}
+
+// OnionKeyFile is the file of the onion service key, the onion address is
+// kept across restarts.
+func (cfg *Config) OnionKeyFile() string {
+	return rootify(cfg.P2P.OnionKeyFile, cfg.BaseConfig.RootDir)
+}
+
+// TokenFile is the file of the api access tokens
+func (cfg *Config) TokenFile() string {
+	return rootify(cfg.Auth.TokenFile, cfg.BaseConfig.RootDir)
+}
+
+// TLSFiles returns the certificate and key files of the api, both are empty
+// when tls is off.
+func (cfg *Config) TLSFiles() (string, string) {
+	if cfg.Auth.TLSCertFile == "" || cfg.Auth.TLSKeyFile == "" {
+		return "", ""
+	}
+	return rootify(cfg.Auth.TLSCertFile, cfg.BaseConfig.RootDir), rootify(cfg.Auth.TLSKeyFile, cfg.BaseConfig.RootDir)
+}
+
 // NodeKey retrieves the currently configured private key of the node, checking
 // first any manually set key, falling back to the one found in the configured
 // data folder. If no key can be found, a new one is generated.
@@ -90,6 +121,9 @@ type BaseConfig struct {
 	//The ID of the network to json
 	ChainID string `mapstructure:"chain_id"`
 
+	// Json or toml file defining a custom network, its chain_id is selectable
+	ChainSpec string `mapstructure:"chain_spec"`
+
 	//log level to set
 	LogLevel string `mapstructure:"log_level"`
 
@@ -101,6 +135,9 @@ type BaseConfig struct {
 
 	Mining bool `mapstructure:"mining"`
 
+	// Hex encoded ed25519 private key to seal blocks on proof of authority chains
+	AuthorityKey string `mapstructure:"authority_key"`
+
 	// Database backend: leveldb | memdb
 	DBBackend string `mapstructure:"db_backend"`
 
@@ -110,10 +147,21 @@ type BaseConfig struct {
 	// Keystore directory
 	KeysPath string `mapstructure:"keys_dir"`
 
+	// TCP address of the http gateway and the websockets of the api
 	ApiAddress string `mapstructure:"api_addr"`
 
+	// TCP address of the grpc server of the api
+	GRPCAddress string `mapstructure:"grpc_addr"`
+
 	VaultMode bool `mapstructure:"vault_mode"`
 
+	// Sync and validate only the block headers, the txs of the watched script
+	// hashes and evidence digests are fetched with merkle proofs from full peers
+	Light bool `mapstructure:"light"`
+
+	// Comma delimited hex script hashes and evidence digests watched in light mode
+	LightWatch string `mapstructure:"light_watch"`
+
 	// log file name
 	LogFile string `mapstructure:"log_file"`
 }
@@ -127,9 +175,15 @@ func DefaultBaseConfig() BaseConfig {
 		DBBackend:         "leveldb",
 		DBPath:            "data",
 		KeysPath:          "keystore",
+		ApiAddress:        "0.0.0.0:8868",
+		GRPCAddress:       "127.0.0.1:8867",
 	}
 }
 
+func (b BaseConfig) ChainSpecFile() string {
+	return rootify(b.ChainSpec, b.RootDir)
+}
+
 func (b BaseConfig) DBDir() string {
 	return rootify(b.DBPath, b.RootDir)
 }
@@ -151,6 +205,17 @@ type P2PConfig struct {
 	ProxyAddress     string `mapstructure:"proxy_address"`
 	ProxyUsername    string `mapstructure:"proxy_username"`
 	ProxyPassword    string `mapstructure:"proxy_password"`
+	Allowlist        string `mapstructure:"allowlist"`
+	MinProtocol      int    `mapstructure:"min_protocol"`
+	Transports       string `mapstructure:"transports"`
+	PeerSendRate     int64  `mapstructure:"peer_send_rate"`
+	PeerRecvRate     int64  `mapstructure:"peer_recv_rate"`
+	TotalSendRate    int64  `mapstructure:"total_send_rate"`
+	TotalRecvRate    int64  `mapstructure:"total_recv_rate"`
+	TorControl       string `mapstructure:"tor_control"`
+	TorPassword      string `mapstructure:"tor_password"`
+	OnionKeyFile     string `mapstructure:"onion_key_file"`
+	OnionOnly        bool   `mapstructure:"onion_only"`
 }
 
 // Default configurable p2p parameters.
@@ -165,6 +230,16 @@ func DefaultP2PConfig() *P2PConfig {
 		ProxyAddress:     "",
 		ProxyUsername:    "",
 		ProxyPassword:    "",
+		MinProtocol:      1,
+		Transports:       "noise",
+		PeerSendRate:     512000, // 500KB/s
+		PeerRecvRate:     512000, // 500KB/s
+		TotalSendRate:    0,
+		TotalRecvRate:    0,
+		TorControl:       "",
+		TorPassword:      "",
+		OnionKeyFile:     "onion_key",
+		OnionOnly:        false,
 	}
 }
 
@@ -177,6 +252,13 @@ type WalletConfig struct {
 
 type RPCAuthConfig struct {
 	Disable bool `mapstructure:"disable"`
+
+	// File of the access tokens, managed by the token commands
+	TokenFile string `mapstructure:"token_file"`
+
+	// PEM certificate and key, the api is served over tls when both are set
+	TLSCertFile string `mapstructure:"tls_cert_file"`
+	TLSKeyFile  string `mapstructure:"tls_key_file"`
 }
 
 type WebConfig struct {
@@ -192,10 +274,20 @@ type WebsocketConfig struct {
 	MaxNumConcurrentReqs int `mapstructure:"max_num_concurrent_reqs"`
 }
 
+// StratumConfig is the config for the external mining server
+type StratumConfig struct {
+	Enable          bool   `mapstructure:"enable"`
+	ListenAddress   string `mapstructure:"laddr"`
+	ShareDifficulty uint64 `mapstructure:"share_difficulty"`
+}
+
 // Default configurable rpc's auth parameters.
 func DefaultRPCAuthConfig() *RPCAuthConfig {
 	return &RPCAuthConfig{
-		Disable: false,
+		Disable:     false,
+		TokenFile:   "api_tokens.json",
+		TLSCertFile: "",
+		TLSKeyFile:  "",
 	}
 }
 
@@ -229,6 +321,15 @@ func DefaultWebsocketConfig() *WebsocketConfig {
 	}
 }
 
+// Default configurable stratum parameters.
+func DefaultStratumConfig() *StratumConfig {
+	return &StratumConfig{
+		Enable:          false,
+		ListenAddress:   "0.0.0.0:3333",
+		ShareDifficulty: 1024,
+	}
+}
+
 //-----------------------------------------------------------------------------
 // Utils
 
@@ -97,11 +97,15 @@ func soloNetGenesisBlock() *types.Block {
 	return block
 }
 
+// genesisBlocks is the genesis block of every network by name, the networks of
+// the registered chain specs are added to it
+var genesisBlocks = map[string]func() *types.Block{
+	"main": mainNetGenesisBlock,
+	"test": testNetGenesisBlock,
+	"solo": soloNetGenesisBlock,
+}
+
 // GenesisBlock will return genesis block
 func GenesisBlock() *types.Block {
-	return map[string]func() *types.Block{
-		"main": mainNetGenesisBlock,
-		"test": testNetGenesisBlock,
-		"solo": soloNetGenesisBlock,
-	}[consensus.ActiveNetParams.Name]()
+	return genesisBlocks[consensus.ActiveNetParams.Name]()
 }
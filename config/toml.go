@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"path"
+	"strings"
 
 	cmn "github.com/tendermint/tmlibs/common"
 )
@@ -19,6 +21,23 @@ func EnsureRoot(rootDir string, network string) {
 	}
 }
 
+// EnsureRootWithSpec writes the config of the chain spec network, the spec
+// file is kept in the root dir and loaded on every start.
+func EnsureRootWithSpec(rootDir string, spec *ChainSpec, specData []byte, specFile string) {
+	cmn.EnsureDir(rootDir, 0700)
+	cmn.EnsureDir(rootDir+"/data", 0700)
+
+	specFilePath := path.Join(rootDir, specFile)
+	if !cmn.FileExists(specFilePath) {
+		cmn.MustWriteFile(specFilePath, specData, 0644)
+	}
+
+	configFilePath := path.Join(rootDir, "config.toml")
+	if !cmn.FileExists(configFilePath) {
+		cmn.MustWriteFile(configFilePath, []byte(chainSpecConfig(spec, specFile)), 0644)
+	}
+}
+
 var defaultConfigTmpl = `# This is a TOML config file.
 # For more information, see https://github.com/toml-lang/toml
 fast_sync = true
@@ -32,12 +51,19 @@ laddr = "tcp://0.0.0.0:46657"
 seeds = "45.79.213.28:46657,198.74.61.131:46657,212.111.41.245:46657,47.100.214.154:46657,47.100.109.199:46657,47.100.105.165:46657"
 `
 
-var testNetConfigTmpl = `chain_id = "wisdom"
+var testNetConfigTmpl = `chain_id = "testnet"
 [p2p]
 laddr = "tcp://0.0.0.0:46656"
 seeds = "52.83.107.224:46656,52.83.107.224:46656,52.83.251.197:46656"
 `
 
+var chainSpecConfigTmpl = `chain_id = "%s"
+chain_spec = "%s"
+[p2p]
+laddr = "tcp://0.0.0.0:%s"
+seeds = "%s"
+`
+
 var soloNetConfigTmpl = `chain_id = "solonet"
 [p2p]
 laddr = "tcp://0.0.0.0:46658"
@@ -55,3 +81,11 @@ func selectNetwork(network string) string {
 		return defaultConfigTmpl + soloNetConfigTmpl
 	}
 }
+
+func chainSpecConfig(spec *ChainSpec, specFile string) string {
+	port := spec.DefaultPort
+	if port == "" {
+		port = "46656"
+	}
+	return defaultConfigTmpl + fmt.Sprintf(chainSpecConfigTmpl, spec.ChainID, specFile, port, strings.Join(spec.Seeds, ","))
+}
@@ -0,0 +1,196 @@
+// Package poa implements the proof of authority consensus algorithm, blocks
+// are signed in turn by a fixed set of authorities instead of being mined.
+package poa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/clarenous/go-capsule/consensus"
+	ca "github.com/clarenous/go-capsule/consensus/algorithm"
+	"github.com/clarenous/go-capsule/crypto/ed25519"
+	"github.com/clarenous/go-capsule/protocol/state"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+const (
+	TypePoA     = "poa"
+	ProofLength = 5 + ed25519.SignatureSize
+)
+
+var (
+	ErrInvalidBytes       = errors.New("invalid bytes to deserialize authority proof")
+	ErrNoAuthorities      = errors.New("no authorities for the block height")
+	ErrUnauthorizedSigner = errors.New("signer is not an authority")
+	ErrWrongTurn          = errors.New("authority proof turn mismatch")
+	ErrRecentlySigned     = errors.New("authority signed recently")
+	ErrTooEarly           = errors.New("block sealed before the authority period")
+	ErrBadSignature       = errors.New("invalid authority signature")
+
+	inTurnWeight    = big.NewInt(2)
+	outOfTurnWeight = big.NewInt(1)
+)
+
+// AuthorityProof is the proof of a block signed by an authority
+type AuthorityProof struct {
+	Signer    uint32
+	InTurn    bool
+	Signature []byte
+}
+
+func (ap *AuthorityProof) Bytes() []byte {
+	var buf [ProofLength]byte
+	binary.LittleEndian.PutUint32(buf[:4], ap.Signer)
+	if ap.InTurn {
+		buf[4] = 1
+	}
+	copy(buf[5:], ap.Signature)
+	return buf[:]
+}
+
+func (ap *AuthorityProof) FromBytes(buf []byte) error {
+	if len(buf) != ProofLength || buf[4] > 1 {
+		return ErrInvalidBytes
+	}
+	ap.Signer = binary.LittleEndian.Uint32(buf[:4])
+	ap.InTurn = buf[4] == 1
+	ap.Signature = append([]byte{}, buf[5:]...)
+	return nil
+}
+
+// HintNextProof sets the in turn signer of the block on top of the node
+func (ap *AuthorityProof) HintNextProof(args []interface{}) error {
+	node, ok := args[0].(*state.BlockNode)
+	if !ok {
+		return errors.New("wrong type for *BlockNode")
+	}
+
+	height := node.Height + 1
+	signers := consensus.ActiveNetParams.AuthoritiesAt(height)
+	if len(signers) == 0 {
+		return ErrNoAuthorities
+	}
+
+	ap.Signer = uint32(height % uint64(len(signers)))
+	ap.InTurn = true
+	ap.Signature = nil
+	return nil
+}
+
+// ValidateProof checks the block is signed by an authority which has not
+// signed any of the recent len(signers)/2 blocks.
+func (ap *AuthorityProof) ValidateProof(args []interface{}) error {
+	b, ok := args[0].(*types.Block)
+	if !ok {
+		return errors.New("wrong type for *Block")
+	}
+	parent, ok := args[1].(*state.BlockNode)
+	if !ok {
+		return errors.New("wrong type for *BlockNode")
+	}
+
+	signers := consensus.ActiveNetParams.AuthoritiesAt(b.Height)
+	if len(signers) == 0 {
+		return ErrNoAuthorities
+	}
+	if int(ap.Signer) >= len(signers) {
+		return ErrUnauthorizedSigner
+	}
+	if ap.InTurn != (b.Height%uint64(len(signers)) == uint64(ap.Signer)) {
+		return ErrWrongTurn
+	}
+	if b.Timestamp < parent.Timestamp+consensus.ActiveNetParams.AuthoritySecondsPerBlock {
+		return ErrTooEarly
+	}
+
+	signer := signers[ap.Signer]
+	for i, node := 0, parent; i < len(signers)/2 && node != nil; i, node = i+1, node.Parent {
+		if recent, ok := node.Proof.(*AuthorityProof); ok && bytes.Equal(signerOf(node.Height, recent), signer) {
+			return ErrRecentlySigned
+		}
+	}
+
+	sealHash, err := SealHash(&b.BlockHeader)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(signer, sealHash.Bytes(), ap.Signature) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+func (ap *AuthorityProof) CalcWeight() *big.Int {
+	if ap.InTurn {
+		return new(big.Int).Set(inTurnWeight)
+	}
+	return new(big.Int).Set(outOfTurnWeight)
+}
+
+// SealHash returns the hash signed by the authority, which is the header hash
+// with the signature left empty.
+func SealHash(bh *types.BlockHeader) (types.Hash, error) {
+	proof, ok := bh.Proof.(*AuthorityProof)
+	if !ok {
+		return types.Hash{}, errors.New("wrong type for *AuthorityProof")
+	}
+
+	header := *bh
+	header.Proof = &AuthorityProof{Signer: proof.Signer, InTurn: proof.InTurn}
+	return header.Hash(), nil
+}
+
+// Seal signs the header with the authority key, it reports whether the
+// authority is the in turn signer of the block.
+func Seal(bh *types.BlockHeader, key ed25519.PrivateKey) (bool, error) {
+	signers := consensus.ActiveNetParams.AuthoritiesAt(bh.Height)
+	if len(signers) == 0 {
+		return false, ErrNoAuthorities
+	}
+
+	pubKey := key.Public()
+	index := -1
+	for i, signer := range signers {
+		if bytes.Equal(signer, pubKey) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return false, ErrUnauthorizedSigner
+	}
+
+	proof := &AuthorityProof{
+		Signer: uint32(index),
+		InTurn: bh.Height%uint64(len(signers)) == uint64(index),
+	}
+	bh.Proof = proof
+	sealHash, err := SealHash(bh)
+	if err != nil {
+		return false, err
+	}
+
+	proof.Signature = ed25519.Sign(key, sealHash.Bytes())
+	return proof.InTurn, nil
+}
+
+func signerOf(height uint64, proof *AuthorityProof) ed25519.PublicKey {
+	signers := consensus.ActiveNetParams.AuthoritiesAt(height)
+	if int(proof.Signer) >= len(signers) {
+		return nil
+	}
+	return signers[proof.Signer]
+}
+
+func NewProof(args ...interface{}) (ca.Proof, error) {
+	return &AuthorityProof{}, nil
+}
+
+func init() {
+	ca.AddDBBackend(ca.CABackend{
+		Typ:      TypePoA,
+		NewProof: NewProof,
+	})
+}
@@ -0,0 +1,199 @@
+package poa
+
+import (
+	"testing"
+
+	"github.com/clarenous/go-capsule/consensus"
+	ca "github.com/clarenous/go-capsule/consensus/algorithm"
+	"github.com/clarenous/go-capsule/crypto/ed25519"
+	"github.com/clarenous/go-capsule/protocol/state"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+func setupAuthorities(t *testing.T, n int) []ed25519.PrivateKey {
+	keys := make([]ed25519.PrivateKey, n)
+	signers := make([]ed25519.PublicKey, n)
+	for i := range keys {
+		pub, priv, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i], signers[i] = priv, pub
+	}
+
+	params := consensus.ActiveNetParams
+	consensus.ActiveNetParams.AuthoritySets = []consensus.AuthoritySet{{Height: 0, Signers: signers}}
+	consensus.ActiveNetParams.ProofForks = []ca.ProofFork{{Height: 0, Typ: TypePoA}}
+	if err := ca.SetProofForks(consensus.ActiveNetParams.ProofForks); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { consensus.ActiveNetParams = params })
+	return keys
+}
+
+// buildChain seals a chain of the given length, block i signed by keys[signers[i]]
+func buildChain(t *testing.T, keys []ed25519.PrivateKey, signers []int) *state.BlockNode {
+	genesis := &types.BlockHeader{Version: 1, Timestamp: 1000, Proof: &AuthorityProof{}}
+	node, err := state.NewBlockNode(genesis, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, signer := range signers {
+		block := nextBlock(node)
+		if _, err := Seal(&block.BlockHeader, keys[signer]); err != nil {
+			t.Fatal(err)
+		}
+		if err := block.Proof.ValidateProof([]interface{}{block, node}); err != nil {
+			t.Fatalf("block %d: %v", block.Height, err)
+		}
+		if node, err = state.NewBlockNode(&block.BlockHeader, node); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return node
+}
+
+func nextBlock(parent *state.BlockNode) *types.Block {
+	return &types.Block{BlockHeader: types.BlockHeader{
+		Version:   1,
+		Height:    parent.Height + 1,
+		Timestamp: parent.Timestamp + consensus.ActiveNetParams.AuthoritySecondsPerBlock,
+		Previous:  parent.Hash,
+		Proof:     &AuthorityProof{},
+	}}
+}
+
+func TestValidateProof(t *testing.T) {
+	keys := setupAuthorities(t, 3)
+	parent := buildChain(t, keys, []int{1, 2, 0, 1})
+
+	if proof, err := parent.HintNextProof(); err != nil || proof.(*AuthorityProof).Signer != 2 {
+		t.Fatalf("got hint %v %v, want in turn signer 2", proof, err)
+	}
+
+	_, outsider, _ := ed25519.GenerateKey(nil)
+	cases := []struct {
+		desc   string
+		modify func(*types.Block)
+		err    error
+	}{
+		{
+			desc:   "in turn signer",
+			modify: func(b *types.Block) { Seal(&b.BlockHeader, keys[2]) },
+		},
+		{
+			desc:   "out of turn signer",
+			modify: func(b *types.Block) { Seal(&b.BlockHeader, keys[0]) },
+		},
+		{
+			desc:   "recent signer",
+			modify: func(b *types.Block) { Seal(&b.BlockHeader, keys[1]) },
+			err:    ErrRecentlySigned,
+		},
+		{
+			desc:   "unknown signer",
+			modify: func(b *types.Block) { b.Proof = &AuthorityProof{Signer: 3} },
+			err:    ErrUnauthorizedSigner,
+		},
+		{
+			desc: "fake turn",
+			modify: func(b *types.Block) {
+				Seal(&b.BlockHeader, keys[0])
+				b.Proof.(*AuthorityProof).InTurn = true
+			},
+			err: ErrWrongTurn,
+		},
+		{
+			desc: "sealed too early",
+			modify: func(b *types.Block) {
+				b.Timestamp--
+				Seal(&b.BlockHeader, keys[2])
+			},
+			err: ErrTooEarly,
+		},
+		{
+			desc: "modified after seal",
+			modify: func(b *types.Block) {
+				Seal(&b.BlockHeader, keys[2])
+				b.Timestamp++
+			},
+			err: ErrBadSignature,
+		},
+		{
+			desc: "signature of outsider",
+			modify: func(b *types.Block) {
+				b.Proof = &AuthorityProof{Signer: 2, InTurn: true}
+				hash, _ := SealHash(&b.BlockHeader)
+				b.Proof.(*AuthorityProof).Signature = ed25519.Sign(outsider, hash.Bytes())
+			},
+			err: ErrBadSignature,
+		},
+	}
+
+	for _, c := range cases {
+		block := nextBlock(parent)
+		c.modify(block)
+		if err := block.Proof.ValidateProof([]interface{}{block, parent}); err != c.err {
+			t.Errorf("%s: got error %v, want %v", c.desc, err, c.err)
+		}
+	}
+}
+
+func TestSeal(t *testing.T) {
+	keys := setupAuthorities(t, 2)
+	_, outsider, _ := ed25519.GenerateKey(nil)
+
+	header := &types.BlockHeader{Height: 3}
+	if _, err := Seal(header, outsider); err != ErrUnauthorizedSigner {
+		t.Errorf("got error %v, want %v", err, ErrUnauthorizedSigner)
+	}
+	if inTurn, err := Seal(header, keys[1]); err != nil || !inTurn {
+		t.Errorf("got in turn %v error %v, want in turn", inTurn, err)
+	}
+	if inTurn, err := Seal(header, keys[0]); err != nil || inTurn {
+		t.Errorf("got in turn %v error %v, want out of turn", inTurn, err)
+	}
+
+	buf, err := header.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &types.BlockHeader{}
+	if err := decoded.UnmarshalText(buf); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Hash() != header.Hash() {
+		t.Errorf("authority proof changed after decode")
+	}
+	if decoded.Proof.CalcWeight().Cmp(outOfTurnWeight) != 0 {
+		t.Errorf("got weight %v, want %v", decoded.Proof.CalcWeight(), outOfTurnWeight)
+	}
+}
+
+func TestProofTypeAt(t *testing.T) {
+	ca.AddDBBackend(ca.CABackend{Typ: "test", NewProof: NewProof})
+
+	if err := ca.SetProofForks([]ca.ProofFork{{Height: 1, Typ: TypePoA}}); err != ca.ErrInvalidProofForks {
+		t.Errorf("forks not starting from genesis got error %v", err)
+	}
+	if err := ca.SetProofForks([]ca.ProofFork{{Height: 0, Typ: "unknown"}}); err != ca.ErrInvalidCAType {
+		t.Errorf("unknown consensus algorithm got error %v", err)
+	}
+	if err := ca.SetProofForks([]ca.ProofFork{{Height: 0, Typ: TypePoA}, {Height: 100, Typ: "test"}, {Height: 100, Typ: TypePoA}}); err != ca.ErrInvalidProofForks {
+		t.Errorf("unsorted forks got error %v", err)
+	}
+	if err := ca.SetProofForks([]ca.ProofFork{{Height: 0, Typ: "test"}, {Height: 100, Typ: TypePoA}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		height uint64
+		typ    string
+	}{{0, "test"}, {99, "test"}, {100, TypePoA}, {1 << 40, TypePoA}} {
+		if typ := ca.ProofTypeAt(c.height); typ != c.typ {
+			t.Errorf("height %d: got type %s, want %s", c.height, typ, c.typ)
+		}
+	}
+}
@@ -126,11 +126,11 @@ func CheckProofOfWork(hash *types.Hash, bits uint64) bool {
 // for next block, when a lower difficulty Int actually reflects a more difficult
 // mining progress.
 func CalcNextRequiredDifficulty(lastBH, compareBH *types.BlockHeader) uint64 {
-	if (lastBH.Height)%consensus.BlocksPerRetarget != 0 || lastBH.Height == 0 {
+	if (lastBH.Height)%consensus.ActiveNetParams.BlocksPerRetarget != 0 || lastBH.Height == 0 {
 		return lastBH.Proof.(*WorkProof).Target
 	}
 
-	targetTimeSpan := int64(consensus.BlocksPerRetarget * consensus.TargetSecondsPerBlock)
+	targetTimeSpan := int64(consensus.ActiveNetParams.BlocksPerRetarget * consensus.ActiveNetParams.TargetSecondsPerBlock)
 	actualTimeSpan := int64(lastBH.Timestamp - compareBH.Timestamp)
 
 	oldTarget := CompactToBig(lastBH.Proof.(*WorkProof).Target)
@@ -23,7 +23,7 @@ func TestCalcNextRequiredDifficulty(t *testing.T) {
 			&types.BlockHeader{
 				Height:    consensus.BlocksPerRetarget,
 				Timestamp: targetTimeSpan,
-				Bits:      BigToCompact(big.NewInt(1000))},
+				Proof:     &WorkProof{Target: BigToCompact(big.NewInt(1000))}},
 			&types.BlockHeader{
 				Height:    0,
 				Timestamp: 0},
@@ -33,7 +33,7 @@ func TestCalcNextRequiredDifficulty(t *testing.T) {
 			&types.BlockHeader{
 				Height:    consensus.BlocksPerRetarget,
 				Timestamp: targetTimeSpan * 2,
-				Bits:      BigToCompact(big.NewInt(1000))},
+				Proof:     &WorkProof{Target: BigToCompact(big.NewInt(1000))}},
 			&types.BlockHeader{
 				Height:    0,
 				Timestamp: 0},
@@ -43,7 +43,7 @@ func TestCalcNextRequiredDifficulty(t *testing.T) {
 			&types.BlockHeader{
 				Height:    consensus.BlocksPerRetarget - 1,
 				Timestamp: targetTimeSpan*2 - consensus.TargetSecondsPerBlock,
-				Bits:      BigToCompact(big.NewInt(1000))},
+				Proof:     &WorkProof{Target: BigToCompact(big.NewInt(1000))}},
 			&types.BlockHeader{
 				Height:    0,
 				Timestamp: 0},
@@ -53,7 +53,7 @@ func TestCalcNextRequiredDifficulty(t *testing.T) {
 			&types.BlockHeader{
 				Height:    consensus.BlocksPerRetarget,
 				Timestamp: targetTimeSpan / 2,
-				Bits:      BigToCompact(big.NewInt(1000))},
+				Proof:     &WorkProof{Target: BigToCompact(big.NewInt(1000))}},
 			&types.BlockHeader{
 				Height:    0,
 				Timestamp: 0},
@@ -63,7 +63,7 @@ func TestCalcNextRequiredDifficulty(t *testing.T) {
 			&types.BlockHeader{
 				Height:    consensus.BlocksPerRetarget * 2,
 				Timestamp: targetTimeSpan + targetTimeSpan*2,
-				Bits:      BigToCompact(big.NewInt(1000))},
+				Proof:     &WorkProof{Target: BigToCompact(big.NewInt(1000))}},
 			&types.BlockHeader{
 				Height:    consensus.BlocksPerRetarget,
 				Timestamp: targetTimeSpan},
@@ -73,7 +73,7 @@ func TestCalcNextRequiredDifficulty(t *testing.T) {
 			&types.BlockHeader{
 				Height:    consensus.BlocksPerRetarget * 2,
 				Timestamp: targetTimeSpan + targetTimeSpan/2,
-				Bits:      BigToCompact(big.NewInt(1000))},
+				Proof:     &WorkProof{Target: BigToCompact(big.NewInt(1000))}},
 			&types.BlockHeader{
 				Height:    consensus.BlocksPerRetarget,
 				Timestamp: targetTimeSpan},
@@ -83,7 +83,7 @@ func TestCalcNextRequiredDifficulty(t *testing.T) {
 			&types.BlockHeader{
 				Height:    consensus.BlocksPerRetarget*2 - 1,
 				Timestamp: targetTimeSpan + targetTimeSpan*2 - consensus.TargetSecondsPerBlock,
-				Bits:      BigToCompact(big.NewInt(1000))},
+				Proof:     &WorkProof{Target: BigToCompact(big.NewInt(1000))}},
 			&types.BlockHeader{
 				Height:    consensus.BlocksPerRetarget,
 				Timestamp: targetTimeSpan},
@@ -93,7 +93,7 @@ func TestCalcNextRequiredDifficulty(t *testing.T) {
 			&types.BlockHeader{
 				Height:    consensus.BlocksPerRetarget*2 - 1,
 				Timestamp: targetTimeSpan + targetTimeSpan/2 - consensus.TargetSecondsPerBlock,
-				Bits:      BigToCompact(big.NewInt(1000))},
+				Proof:     &WorkProof{Target: BigToCompact(big.NewInt(1000))}},
 			&types.BlockHeader{
 				Height:    consensus.BlocksPerRetarget,
 				Timestamp: targetTimeSpan},
@@ -159,7 +159,7 @@ func TestHashToBig(t *testing.T) {
 	}
 
 	for i, c := range cases {
-		bhash := types.NewHash(c.in)
+		bhash := types.Hash(c.in)
 		result := HashToBig(&bhash).Bytes()
 
 		var resArr [32]byte
@@ -3,7 +3,6 @@ package pow
 import (
 	"encoding/binary"
 	"errors"
-	"github.com/clarenous/go-capsule/consensus"
 	ca "github.com/clarenous/go-capsule/consensus/algorithm"
 	"github.com/clarenous/go-capsule/protocol/state"
 	"github.com/clarenous/go-capsule/protocol/types"
@@ -44,21 +43,12 @@ func (wp *WorkProof) HintNextProof(args []interface{}) error {
 	if !ok {
 		return errors.New("wrong type for *BlockNode")
 	}
-	parentProof, ok := node.Proof.(*WorkProof)
-	if !ok {
-		return errors.New("wrong type for *WorkProof")
-	}
 
-	if node.Height%consensus.BlocksPerRetarget != 0 || node.Height == 0 {
-		wp.Target = parentProof.Target
-		return nil
-	}
-
-	compareNode := node.Parent
-	for compareNode.Height%consensus.BlocksPerRetarget != 0 {
-		compareNode = compareNode.Parent
+	target, err := CalcNextTarget(node)
+	if err != nil {
+		return err
 	}
-	wp.Target = CalcNextRequiredDifficulty(node.BlockHeader(), compareNode.BlockHeader())
+	wp.Target = target
 	return nil
 }
 
@@ -74,13 +64,13 @@ func (wp *WorkProof) ValidateProof(args []interface{}) error {
 
 	expectedProof, err := parent.HintNextProof()
 	if err != nil {
-		return nil
+		return err
 	}
-	if expectedProof.(*WorkProof).Target != wp.Target {
+	if expected, ok := expectedProof.(*WorkProof); !ok || expected.Target != wp.Target {
 		return errors.New(" validate proof target not equal")
 	}
 
-	if !CheckProofOfWork(b.Hash().Ptr(), b.Proof.(*WorkProof).Nonce) {
+	if !CheckProofOfWork(b.Hash().Ptr(), wp.Target) {
 		return ErrBadWork
 	}
 	return nil
@@ -0,0 +1,154 @@
+package pow
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/clarenous/go-capsule/consensus"
+	"github.com/clarenous/go-capsule/protocol/state"
+)
+
+const (
+	// lwmaMaxSolveTimes limits the weight of a single block with a fake
+	// timestamp far in the future, in multiples of the target block time.
+	lwmaMaxSolveTimes = 6
+
+	// asertRadixBits is the fixed point precision of the asert exponent
+	asertRadixBits = 16
+)
+
+var (
+	errNotWorkProof         = errors.New("wrong type for *WorkProof")
+	errUnknownDifficultyAlg = errors.New("unknown difficulty algorithm")
+	errZeroHalfLife         = errors.New("asert half life can not be zero")
+
+	// maxTarget is the easiest possible target, 2^256 - 1.
+	maxTarget = new(big.Int).Sub(oneLsh256, bigOne)
+)
+
+// CalcNextTarget returns the target in compact representation for the block
+// on top of node, using the difficulty algorithm active at the next height.
+func CalcNextTarget(node *state.BlockNode) (uint64, error) {
+	parentProof, ok := node.Proof.(*WorkProof)
+	if !ok {
+		return 0, errNotWorkProof
+	}
+
+	fork := consensus.ActiveNetParams.DifficultyForkAt(node.Height + 1)
+	switch fork.Algorithm {
+	case consensus.DifficultyRetarget:
+		if node.Height%consensus.ActiveNetParams.BlocksPerRetarget != 0 || node.Height == 0 {
+			return parentProof.Target, nil
+		}
+
+		compareNode := node.Parent
+		for compareNode.Height%consensus.ActiveNetParams.BlocksPerRetarget != 0 {
+			compareNode = compareNode.Parent
+		}
+		return CalcNextRequiredDifficulty(node.BlockHeader(), compareNode.BlockHeader()), nil
+
+	case consensus.DifficultyLWMA:
+		return calcNextLWMA(node, fork.Window), nil
+
+	case consensus.DifficultyASERT:
+		return calcNextASERT(node, fork)
+
+	default:
+		return 0, errUnknownDifficultyAlg
+	}
+}
+
+// calcNextLWMA implements the linearly weighted moving average, the solve
+// times of the recent window blocks are weighted by their recency so that the
+// target reacts quickly to hashrate swings.
+func calcNextLWMA(node *state.BlockNode, window uint64) uint64 {
+	targetSpacing := int64(consensus.ActiveNetParams.TargetSecondsPerBlock)
+	sumTarget := new(big.Int)
+	var weightedTimes, sumWeights, count int64
+
+	// blocks are visited from the newest one, which gets the highest weight
+	for iter := node; count < int64(window) && iter.Parent != nil; iter = iter.Parent {
+		proof, ok := iter.Proof.(*WorkProof)
+		if !ok {
+			break
+		}
+
+		solveTime := int64(iter.Timestamp) - int64(iter.Parent.Timestamp)
+		if solveTime < 1 {
+			solveTime = 1
+		} else if solveTime > lwmaMaxSolveTimes*targetSpacing {
+			solveTime = lwmaMaxSolveTimes * targetSpacing
+		}
+
+		weight := int64(window) - count
+		weightedTimes += weight * solveTime
+		sumWeights += weight
+		sumTarget.Add(sumTarget, CompactToBig(proof.Target))
+		count++
+	}
+
+	if count == 0 {
+		return node.Proof.(*WorkProof).Target
+	}
+
+	// nextTarget = avgTarget * weightedTimes / (sumWeights * targetSpacing)
+	nextTarget := new(big.Int).Mul(sumTarget, big.NewInt(weightedTimes))
+	nextTarget.Div(nextTarget, big.NewInt(count*sumWeights*targetSpacing))
+	return BigToCompact(clampTarget(nextTarget))
+}
+
+// calcNextASERT implements the absolutely scheduled exponentially rising
+// targets, the target doubles or halves every half life the chain falls
+// behind or runs ahead of the ideal schedule since the anchor block, which is
+// the parent of the fork activation block.
+func calcNextASERT(node *state.BlockNode, fork consensus.DifficultyFork) (uint64, error) {
+	if fork.HalfLife == 0 {
+		return 0, errZeroHalfLife
+	}
+
+	anchorHeight := uint64(0)
+	if fork.Height > 0 {
+		anchorHeight = fork.Height - 1
+	}
+	anchor := node
+	for anchor.Height > anchorHeight {
+		anchor = anchor.Parent
+	}
+	anchorProof, ok := anchor.Proof.(*WorkProof)
+	if !ok {
+		return 0, errNotWorkProof
+	}
+
+	timeDelta := int64(node.Timestamp) - int64(anchor.Timestamp)
+	heightDelta := int64(node.Height - anchor.Height)
+	exponent := (timeDelta - int64(consensus.ActiveNetParams.TargetSecondsPerBlock)*heightDelta) * (1 << asertRadixBits) / int64(fork.HalfLife)
+
+	// 2^frac is approximated by the cubic polynomial of aserti3-2d
+	shifts := exponent >> asertRadixBits
+	frac := big.NewInt(exponent & (1<<asertRadixBits - 1))
+	poly := new(big.Int).Mul(big.NewInt(195766423245049), frac)
+	frac2 := new(big.Int).Mul(frac, frac)
+	poly.Add(poly, new(big.Int).Mul(big.NewInt(971821376), frac2))
+	poly.Add(poly, new(big.Int).Mul(big.NewInt(5127), frac2.Mul(frac2, frac)))
+	poly.Add(poly, new(big.Int).Lsh(bigOne, 47))
+	factor := poly.Rsh(poly, 48)
+	factor.Add(factor, big.NewInt(1<<asertRadixBits))
+
+	nextTarget := new(big.Int).Mul(CompactToBig(anchorProof.Target), factor)
+	if shifts -= asertRadixBits; shifts < 0 {
+		nextTarget.Rsh(nextTarget, uint(-shifts))
+	} else {
+		nextTarget.Lsh(nextTarget, uint(shifts))
+	}
+	return BigToCompact(clampTarget(nextTarget)), nil
+}
+
+func clampTarget(target *big.Int) *big.Int {
+	if target.Sign() <= 0 {
+		return big.NewInt(1)
+	}
+	if target.Cmp(maxTarget) > 0 {
+		return new(big.Int).Set(maxTarget)
+	}
+	return target
+}
@@ -0,0 +1,162 @@
+package pow
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/clarenous/go-capsule/consensus"
+	"github.com/clarenous/go-capsule/protocol/state"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+// buildNodes returns the tip of a chain with the given solve times, all the
+// blocks use the same target
+func buildNodes(t *testing.T, target *big.Int, solveTimes []uint64) *state.BlockNode {
+	header := &types.BlockHeader{Timestamp: 1000000, Proof: &WorkProof{Target: BigToCompact(target)}}
+	node, err := state.NewBlockNode(header, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, solveTime := range solveTimes {
+		header = &types.BlockHeader{
+			Height:    node.Height + 1,
+			Timestamp: node.Timestamp + solveTime,
+			Previous:  node.Hash,
+			Proof:     &WorkProof{Target: BigToCompact(target)},
+		}
+		if node, err = state.NewBlockNode(header, node); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return node
+}
+
+func repeat(solveTime uint64, n int) []uint64 {
+	times := make([]uint64, n)
+	for i := range times {
+		times[i] = solveTime
+	}
+	return times
+}
+
+func setDifficultyFork(t *testing.T, fork consensus.DifficultyFork) {
+	params := consensus.ActiveNetParams
+	consensus.ActiveNetParams.DifficultyForks = []consensus.DifficultyFork{fork}
+	t.Cleanup(func() { consensus.ActiveNetParams = params })
+}
+
+func TestCalcNextLWMA(t *testing.T) {
+	setDifficultyFork(t, consensus.DifficultyFork{Algorithm: consensus.DifficultyLWMA, Window: 10})
+	target := big.NewInt(1 << 40)
+	spacing := consensus.ActiveNetParams.TargetSecondsPerBlock
+
+	cases := []struct {
+		desc       string
+		solveTimes []uint64
+		want       *big.Int
+	}{
+		{
+			desc:       "on schedule",
+			solveTimes: repeat(spacing, 20),
+			want:       target,
+		},
+		{
+			desc:       "twice slower",
+			solveTimes: repeat(spacing*2, 20),
+			want:       new(big.Int).Mul(target, big.NewInt(2)),
+		},
+		{
+			desc:       "twice faster",
+			solveTimes: repeat(spacing/2, 20),
+			want:       new(big.Int).Div(target, big.NewInt(2)),
+		},
+		{
+			desc:       "fake timestamp is clamped",
+			solveTimes: append(repeat(spacing, 19), spacing*100),
+			want:       new(big.Int).Div(new(big.Int).Mul(target, big.NewInt(10*11/2+5*10)), big.NewInt(10*11/2)),
+		},
+		{
+			desc:       "short chain",
+			solveTimes: repeat(spacing, 3),
+			want:       target,
+		},
+	}
+
+	for _, c := range cases {
+		got, err := CalcNextTarget(buildNodes(t, target, c.solveTimes))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != BigToCompact(c.want) {
+			t.Errorf("%s: got target %v, want %v", c.desc, CompactToBig(got), c.want)
+		}
+	}
+}
+
+func TestCalcNextASERT(t *testing.T) {
+	halfLife := uint64(3600)
+	target := big.NewInt(1 << 40)
+	spacing := consensus.ActiveNetParams.TargetSecondsPerBlock
+
+	cases := []struct {
+		desc       string
+		forkHeight uint64
+		solveTimes []uint64
+		want       *big.Int
+	}{
+		{
+			desc:       "on schedule",
+			solveTimes: repeat(spacing, 20),
+			want:       target,
+		},
+		{
+			desc:       "one half life behind",
+			solveTimes: append(repeat(spacing, 19), spacing+halfLife),
+			want:       new(big.Int).Mul(target, big.NewInt(2)),
+		},
+		{
+			desc:       "two half lives ahead",
+			solveTimes: append(repeat(spacing, 10), repeat(0, int(2*halfLife/spacing))...),
+			want:       new(big.Int).Div(target, big.NewInt(4)),
+		},
+		{
+			desc:       "delay before the anchor is ignored",
+			forkHeight: 11,
+			solveTimes: append(repeat(spacing+halfLife, 10), repeat(spacing, 10)...),
+			want:       target,
+		},
+	}
+
+	for _, c := range cases {
+		setDifficultyFork(t, consensus.DifficultyFork{Height: c.forkHeight, Algorithm: consensus.DifficultyASERT, HalfLife: halfLife})
+		got, err := CalcNextTarget(buildNodes(t, target, c.solveTimes))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != BigToCompact(c.want) {
+			t.Errorf("%s: got target %v, want %v", c.desc, CompactToBig(got), c.want)
+		}
+	}
+}
+
+func TestCalcNextTargetFork(t *testing.T) {
+	target := big.NewInt(1 << 40)
+	node := buildNodes(t, target, repeat(consensus.ActiveNetParams.TargetSecondsPerBlock*2, 20))
+
+	// the lwma fork is active from the next block on
+	setDifficultyFork(t, consensus.DifficultyFork{Height: 21, Algorithm: consensus.DifficultyLWMA, Window: 10})
+	if got, _ := CalcNextTarget(node); got != BigToCompact(new(big.Int).Mul(target, big.NewInt(2))) {
+		t.Errorf("lwma is not active at the fork height, got target %v", CompactToBig(got))
+	}
+
+	setDifficultyFork(t, consensus.DifficultyFork{Height: 22, Algorithm: consensus.DifficultyLWMA, Window: 10})
+	if got, _ := CalcNextTarget(node); got != BigToCompact(target) {
+		t.Errorf("lwma is active before the fork height, got target %v", CompactToBig(got))
+	}
+
+	setDifficultyFork(t, consensus.DifficultyFork{Algorithm: "unknown"})
+	if _, err := CalcNextTarget(node); err != errUnknownDifficultyAlg {
+		t.Errorf("got error %v, want %v", err, errUnknownDifficultyAlg)
+	}
+}
@@ -44,3 +44,68 @@ func NewProof(dbType string, args ...interface{}) (Proof, error) {
 	}
 	return nil, ErrInvalidCAType
 }
+
+// ProofFork activates the consensus algorithm Typ from Height on
+type ProofFork struct {
+	Height uint64
+	Typ    string
+}
+
+var (
+	ErrInvalidProofForks = errors.New("invalid consensus algorithm forks")
+
+	proofForks = []ProofFork{{Height: 0, Typ: "pow"}}
+)
+
+// ValidateProofForks checks the forks are sorted by height, start from the
+// genesis block and only use registered consensus algorithms.
+func ValidateProofForks(forks []ProofFork) error {
+	if len(forks) == 0 || forks[0].Height != 0 {
+		return ErrInvalidProofForks
+	}
+	for i, fork := range forks {
+		if i > 0 && fork.Height <= forks[i-1].Height {
+			return ErrInvalidProofForks
+		}
+		if !isRegistered(fork.Typ) {
+			return ErrInvalidCAType
+		}
+	}
+	return nil
+}
+
+// SetProofForks replaces the consensus algorithm schedule
+func SetProofForks(forks []ProofFork) error {
+	if err := ValidateProofForks(forks); err != nil {
+		return err
+	}
+
+	proofForks = append([]ProofFork{}, forks...)
+	return nil
+}
+
+// ProofTypeAt returns the consensus algorithm type of the block at height
+func ProofTypeAt(height uint64) string {
+	typ := proofForks[0].Typ
+	for _, fork := range proofForks {
+		if fork.Height > height {
+			break
+		}
+		typ = fork.Typ
+	}
+	return typ
+}
+
+// NewProofAt creates a proof of the consensus algorithm active at height
+func NewProofAt(height uint64, args ...interface{}) (Proof, error) {
+	return NewProof(ProofTypeAt(height), args...)
+}
+
+func isRegistered(typ string) bool {
+	for _, cab := range CABackendList {
+		if cab.Typ == typ {
+			return true
+		}
+	}
+	return false
+}
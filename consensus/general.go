@@ -1,6 +1,8 @@
 package consensus
 
 import (
+	ca "github.com/clarenous/go-capsule/consensus/algorithm"
+	"github.com/clarenous/go-capsule/crypto/ed25519"
 	"github.com/clarenous/go-capsule/protocol/types"
 	"strings"
 )
@@ -33,6 +35,11 @@ const (
 	BlocksPerRetarget     = uint64(2016)
 	TargetSecondsPerBlock = uint64(150)
 
+	// difficulty algorithms for pow mining
+	DifficultyRetarget = "retarget"
+	DifficultyLWMA     = "lwma"
+	DifficultyASERT    = "asert"
+
 	// MaxTimeOffsetSeconds is the maximum number of seconds a block time is allowed to be ahead of the current time
 	MaxTimeOffsetSeconds = uint64(60 * 60)
 	MedianTimeBlocks     = 11
@@ -42,14 +49,15 @@ const (
 	CoinbaseArbitrarySizeLimit     = 128
 
 	ProofType = "pow"
+
+	// config for poa sealing
+	AuthoritySecondsPerBlock = uint64(15)
 )
 
-// BlockSubsidy calculate the coinbase rewards on given block height
+// BlockSubsidy calculate the coinbase rewards on given block height of the
+// active network
 func BlockSubsidy(height uint64) uint64 {
-	if height == 0 {
-		return InitialBlockSubsidy
-	}
-	return baseSubsidy >> uint(height/subsidyReductionInterval)
+	return ActiveNetParams.BlockSubsidy(height)
 }
 
 // IsBech32SegwitPrefix returns whether the prefix is a known prefix for segwit
@@ -80,6 +88,76 @@ type Params struct {
 	// as one method to discover peers.
 	DNSSeeds    []string
 	Checkpoints []Checkpoint
+
+	// subsidy schedule, the base subsidy halves every reduction interval
+	InitialBlockSubsidy      uint64
+	BaseSubsidy              uint64
+	SubsidyReductionInterval uint64
+
+	// pow retarget and poa sealing intervals
+	BlocksPerRetarget        uint64
+	TargetSecondsPerBlock    uint64
+	AuthoritySecondsPerBlock uint64
+
+	// ProofForks is the schedule of the consensus algorithms by height
+	ProofForks []ca.ProofFork
+	// AuthoritySets is the schedule of the poa signer sets by height
+	AuthoritySets []AuthoritySet
+	// DifficultyForks is the schedule of the pow difficulty algorithms by height
+	DifficultyForks []DifficultyFork
+}
+
+// BlockSubsidy calculate the coinbase rewards on given block height
+func (p *Params) BlockSubsidy(height uint64) uint64 {
+	if height == 0 {
+		return p.InitialBlockSubsidy
+	}
+	if p.SubsidyReductionInterval == 0 {
+		return p.BaseSubsidy
+	}
+	return p.BaseSubsidy >> uint(height/p.SubsidyReductionInterval)
+}
+
+// DifficultyFork activates the pow difficulty algorithm from Height on
+type DifficultyFork struct {
+	Height    uint64
+	Algorithm string
+	// Window is the number of recent blocks weighted by lwma
+	Window uint64
+	// HalfLife is the seconds ahead of schedule for asert to halve the target
+	HalfLife uint64
+}
+
+// DifficultyForkAt returns the difficulty algorithm for the block at height,
+// the bitcoin style retarget is used when no fork is active.
+func (p *Params) DifficultyForkAt(height uint64) DifficultyFork {
+	fork := DifficultyFork{Algorithm: DifficultyRetarget}
+	for _, f := range p.DifficultyForks {
+		if f.Height > height {
+			break
+		}
+		fork = f
+	}
+	return fork
+}
+
+// AuthoritySet is the ordered signer set of the poa engine from Height on,
+// the block at height h is expected to be signed by Signers[h%len(Signers)].
+type AuthoritySet struct {
+	Height  uint64
+	Signers []ed25519.PublicKey
+}
+
+// AuthoritiesAt returns the poa signer set for the block at height
+func (p *Params) AuthoritiesAt(height uint64) []ed25519.PublicKey {
+	var signers []ed25519.PublicKey
+	for _, set := range p.AuthoritySets {
+		if set.Height > height {
+			break
+		}
+		signers = set.Signers
+	}
+	return signers
 }
 
 // ActiveNetParams is ...
@@ -88,23 +166,54 @@ var ActiveNetParams = MainNetParams
 // NetParams is the correspondence between chain_id and Params
 var NetParams = map[string]Params{
 	"mainnet": MainNetParams,
+	"testnet": TestNetParams,
 	"solonet": SoloNetParams,
 }
 
 // MainNetParams is the config for production
 var MainNetParams = Params{
-	Name:            "main",
-	Bech32HRPSegwit: "bm",
-	DefaultPort:     "46657",
-	DNSSeeds:        []string{},
-	Checkpoints:     []Checkpoint{
+	Name:                     "main",
+	Bech32HRPSegwit:          "bm",
+	DefaultPort:              "46657",
+	DNSSeeds:                 []string{},
+	InitialBlockSubsidy:      InitialBlockSubsidy,
+	BaseSubsidy:              baseSubsidy,
+	SubsidyReductionInterval: subsidyReductionInterval,
+	BlocksPerRetarget:        BlocksPerRetarget,
+	TargetSecondsPerBlock:    TargetSecondsPerBlock,
+	AuthoritySecondsPerBlock: AuthoritySecondsPerBlock,
+	ProofForks:               []ca.ProofFork{{Height: 0, Typ: ProofType}},
+	Checkpoints:              []Checkpoint{
 		//{10000, types.NewHash([32]byte{0x93, 0xe1, 0xeb, 0x78, 0x21, 0xd2, 0xb4, 0xad, 0x0f, 0x5b, 0x1c, 0xea, 0x82, 0xe8, 0x43, 0xad, 0x8c, 0x09, 0x9a, 0xb6, 0x5d, 0x8f, 0x70, 0xc5, 0x84, 0xca, 0xa2, 0xdd, 0xf1, 0x74, 0x65, 0x2c})},
 	},
 }
 
+// TestNetParams is the config for the public test network
+var TestNetParams = Params{
+	Name:                     "test",
+	Bech32HRPSegwit:          "tm",
+	DefaultPort:              "46656",
+	DNSSeeds:                 []string{},
+	InitialBlockSubsidy:      InitialBlockSubsidy,
+	BaseSubsidy:              baseSubsidy,
+	SubsidyReductionInterval: subsidyReductionInterval,
+	BlocksPerRetarget:        BlocksPerRetarget,
+	TargetSecondsPerBlock:    TargetSecondsPerBlock,
+	AuthoritySecondsPerBlock: AuthoritySecondsPerBlock,
+	ProofForks:               []ca.ProofFork{{Height: 0, Typ: ProofType}},
+	Checkpoints:              []Checkpoint{},
+}
+
 // SoloNetParams is the config for test-net
 var SoloNetParams = Params{
-	Name:            "solo",
-	Bech32HRPSegwit: "sm",
-	Checkpoints:     []Checkpoint{},
+	Name:                     "solo",
+	Bech32HRPSegwit:          "sm",
+	InitialBlockSubsidy:      InitialBlockSubsidy,
+	BaseSubsidy:              baseSubsidy,
+	SubsidyReductionInterval: subsidyReductionInterval,
+	BlocksPerRetarget:        BlocksPerRetarget,
+	TargetSecondsPerBlock:    TargetSecondsPerBlock,
+	AuthoritySecondsPerBlock: AuthoritySecondsPerBlock,
+	Checkpoints:              []Checkpoint{},
+	ProofForks:               []ca.ProofFork{{Height: 0, Typ: ProofType}},
 }
@@ -11,8 +11,17 @@ const (
 	SFFastSync
 	// SFSPV indicate peer support spv mode
 	SFSPV
+	// SFBlockFilter indicate peer serves the golomb-coded set block filters
+	SFBlockFilter
+	// SFCompactBlock indicate peer relays and rebuilds the compact blocks
+	SFCompactBlock
+	// SFPruned indicate peer only keeps the recent blocks
+	SFPruned
+	// SFTxRelay indicate peer announces the transactions by inventory and
+	// serves them on request instead of pushing them
+	SFTxRelay
 	// DefaultServices is the server that this node support
-	DefaultServices = SFFullNode | SFFastSync | SFSPV
+	DefaultServices = SFFullNode | SFFastSync | SFSPV | SFBlockFilter | SFCompactBlock | SFTxRelay
 )
 
 // IsEnable check does the flag support the input flag function
@@ -0,0 +1,123 @@
+package leveldb
+
+import (
+	log "github.com/sirupsen/logrus"
+	dbm "github.com/tendermint/tmlibs/db"
+
+	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/protocol/blockfilter"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+var (
+	blockFilterPrefix  = []byte("BF:")
+	filterHeaderPrefix = []byte("BFH:")
+)
+
+func calcBlockFilterKey(hash *types.Hash) []byte {
+	return append(append([]byte{}, blockFilterPrefix...), hash.Bytes()...)
+}
+
+func calcFilterHeaderKey(hash *types.Hash) []byte {
+	return append(append([]byte{}, filterHeaderPrefix...), hash.Bytes()...)
+}
+
+// filterIndexBatchSize is the number of blocks written in a batch when the
+// filters of the blocks saved before the filter index are built.
+const filterIndexBatchSize = 1000
+
+// saveBlockFilter saves the filter of the block and its header chained to the
+// filter header of the previous block into batch.
+func (s *Store) saveBlockFilter(batch dbm.Batch, block *types.Block) error {
+	prevHeader := &types.Hash{}
+	if block.Height != 0 {
+		var err error
+		if prevHeader, err = s.indexBlockFilters(&block.Previous); err != nil {
+			return err
+		}
+	}
+
+	setBlockFilter(batch, block, prevHeader)
+	return nil
+}
+
+// setBlockFilter sets the filter of the block and its filter header into
+// batch, and returns the filter header.
+func setBlockFilter(batch dbm.Batch, block *types.Block, prevHeader *types.Hash) *types.Hash {
+	filter := blockfilter.BuildBlockFilter(block)
+	filterHash := blockfilter.FilterHash(filter)
+	filterHeader := blockfilter.FilterHeader(&filterHash, prevHeader)
+
+	blockHash := block.Hash()
+	batch.Set(calcBlockFilterKey(&blockHash), filter)
+	batch.Set(calcFilterHeaderKey(&blockHash), filterHeader.Bytes())
+	return &filterHeader
+}
+
+// indexBlockFilters returns the filter header of the block. The stores
+// upgraded from the versions without the filter index have no filters of the
+// old blocks, they are built back to the last indexed ancestor first.
+func (s *Store) indexBlockFilters(hash *types.Hash) (*types.Hash, error) {
+	prevHeader := &types.Hash{}
+	var unindexed []*types.Hash
+	for h := hash; ; {
+		if header, err := s.GetFilterHeader(h); err == nil {
+			prevHeader = header
+			break
+		}
+
+		block := GetBlock(s.db, h)
+		if block == nil {
+			return nil, errors.WithDetailf(protocol.ErrBlockNotFound, "no block of hash %s", h.String())
+		}
+
+		unindexed = append(unindexed, h)
+		if block.Height == 0 {
+			break
+		}
+		h = &block.Previous
+	}
+	if len(unindexed) == 0 {
+		return prevHeader, nil
+	}
+
+	batch := s.db.NewBatch()
+	for i := len(unindexed) - 1; i >= 0; i-- {
+		block := GetBlock(s.db, unindexed[i])
+		if block == nil {
+			return nil, errors.WithDetailf(protocol.ErrBlockNotFound, "no block of hash %s", unindexed[i].String())
+		}
+
+		prevHeader = setBlockFilter(batch, block, prevHeader)
+		if (len(unindexed)-i)%filterIndexBatchSize == 0 {
+			batch.Write()
+			batch = s.db.NewBatch()
+		}
+	}
+	batch.Write()
+
+	log.WithFields(log.Fields{"module": logModule, "blocks": len(unindexed), "hash": hash.String()}).Info("block filters indexed")
+	return prevHeader, nil
+}
+
+// GetBlockFilter returns the golomb-coded set filter of the block
+func (s *Store) GetBlockFilter(hash *types.Hash) ([]byte, error) {
+	filter := s.db.Get(calcBlockFilterKey(hash))
+	if filter == nil {
+		return nil, errors.WithDetailf(protocol.ErrFilterNotFound, "no block filter of hash %s", hash.String())
+	}
+	return filter, nil
+}
+
+// GetFilterHeader returns the filter header of the block
+func (s *Store) GetFilterHeader(hash *types.Hash) (*types.Hash, error) {
+	bytes := s.db.Get(calcFilterHeaderKey(hash))
+	if len(bytes) != 32 {
+		return nil, errors.WithDetailf(protocol.ErrFilterNotFound, "no filter header of hash %s", hash.String())
+	}
+
+	var header types.Hash
+	copy(header[:], bytes)
+	return &header, nil
+}
@@ -0,0 +1,55 @@
+package leveldb
+
+import (
+	"os"
+	"testing"
+
+	dbm "github.com/tendermint/tmlibs/db"
+
+	_ "github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/protocol/blockfilter"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+func TestSaveBlockWithoutFilters(t *testing.T) {
+	defer os.RemoveAll("temp")
+	testDB := dbm.NewDB("testdb", "leveldb", "temp")
+	store := NewStore(testDB)
+
+	blocks := make([]*types.Block, 5)
+	for i := range blocks {
+		blocks[i] = types.MockBlock()
+		blocks[i].Height = uint64(i)
+		if i > 0 {
+			blocks[i].Previous = blocks[i-1].Hash()
+		}
+	}
+
+	// the blocks saved by the versions without the filter index
+	for _, block := range blocks[:4] {
+		binaryBlock, _, err := block.MarshalTextForStore()
+		if err != nil {
+			t.Fatal(err)
+		}
+		blockHash := block.Hash()
+		testDB.Set(calcBlockKey(&blockHash), binaryBlock)
+	}
+
+	if err := store.SaveBlock(blocks[4]); err != nil {
+		t.Fatal(err)
+	}
+
+	prevHeader := &types.Hash{}
+	for i, block := range blocks {
+		filterHash := blockfilter.FilterHash(blockfilter.BuildBlockFilter(block))
+		want := blockfilter.FilterHeader(&filterHash, prevHeader)
+		blockHash := block.Hash()
+		if got, err := store.GetFilterHeader(&blockHash); err != nil || *got != want {
+			t.Fatalf("block %d: got filter header %v err %v, want %v", i, got, err, want)
+		}
+		if _, err := store.GetBlockFilter(&blockHash); err != nil {
+			t.Fatalf("block %d: %v", i, err)
+		}
+		prevHeader = &want
+	}
+}
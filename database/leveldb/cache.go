@@ -1,19 +1,20 @@
 package leveldb
 
 import (
-	"fmt"
 	"sync"
 
 	"github.com/golang/groupcache/lru"
 	"github.com/golang/groupcache/singleflight"
 
+	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/protocol"
 	"github.com/clarenous/go-capsule/protocol/types"
 )
 
 const maxCachedBlocks = 30
 
-func newBlockCache(fillFn func(hash *types.Hash) *types.Block) blockCache {
-	return blockCache{
+func newBlockCache(fillFn func(hash *types.Hash) *types.Block) *blockCache {
+	return &blockCache{
 		lru:    lru.New(maxCachedBlocks),
 		fillFn: fillFn,
 	}
@@ -34,7 +35,7 @@ func (c *blockCache) lookup(hash *types.Hash) (*types.Block, error) {
 	block, err := c.single.Do(hash.String(), func() (interface{}, error) {
 		b := c.fillFn(hash)
 		if b == nil {
-			return nil, fmt.Errorf("there are no block with given hash %s", hash.String())
+			return nil, errors.WithDetailf(protocol.ErrBlockNotFound, "no block of hash %s", hash.String())
 		}
 
 		c.add(b)
@@ -3,7 +3,7 @@ package leveldb
 import (
 	"testing"
 
-
+	_ "github.com/clarenous/go-capsule/consensus/algorithm/pow"
 	"github.com/clarenous/go-capsule/protocol/types"
 )
 
@@ -12,11 +12,12 @@ func TestBlockCache(t *testing.T) {
 		return &types.Block{
 			BlockHeader: types.BlockHeader{
 				Height: h,
+				Proof:  types.MockProof(),
 			},
 		}
 	}
 	blocks := make(map[types.Hash]*types.Block)
-	for i := 0; i < maxCachedBlocks + 10; i++ {
+	for i := 0; i < maxCachedBlocks+10; i++ {
 		block := newBlock(uint64(i))
 		blocks[block.Hash()] = block
 	}
@@ -25,7 +26,7 @@ func TestBlockCache(t *testing.T) {
 		return blocks[*hash]
 	})
 
-	for i := 0; i < maxCachedBlocks + 10; i++ {
+	for i := 0; i < maxCachedBlocks+10; i++ {
 		block := newBlock(uint64(i))
 		hash := block.Hash()
 		cache.lookup(&hash)
@@ -39,7 +40,7 @@ func TestBlockCache(t *testing.T) {
 		}
 	}
 
-	for i := 10; i < maxCachedBlocks + 10; i++ {
+	for i := 10; i < maxCachedBlocks+10; i++ {
 		block := newBlock(uint64(i))
 		hash := block.Hash()
 		if b, _ := cache.get(&hash); b == nil {
@@ -43,7 +43,7 @@ func loadBlockStoreStateJSON(db dbm.DB) *protocol.BlockStoreState {
 // methods for querying current data.
 type Store struct {
 	db    dbm.DB
-	cache blockCache
+	cache *blockCache
 }
 
 func calcBlockKey(hash *types.Hash) []byte {
@@ -171,6 +171,9 @@ func (s *Store) SaveBlock(block *types.Block) error {
 	batch.Set(calcBlockHeaderKey(block.Height, &blockHash), binaryBlockHeader)
 	s.saveTxLocs(batch, txLocs)
 	s.saveEvidLocs(batch, block)
+	if err := s.saveBlockFilter(batch, block); err != nil {
+		return errors.Wrap(err, "save block filter")
+	}
 	batch.Write()
 
 	log.WithFields(log.Fields{
@@ -4,11 +4,10 @@ import (
 	"os"
 	"testing"
 
-	"github.com/clarenous/go-capsule/config"
+	dbm "github.com/tendermint/tmlibs/db"
 
+	_ "github.com/clarenous/go-capsule/consensus/algorithm/pow"
 	"github.com/clarenous/go-capsule/protocol/types"
-
-	dbm "github.com/tendermint/tmlibs/db"
 )
 
 func TestLoadBlockIndex(t *testing.T) {
@@ -16,18 +15,18 @@ func TestLoadBlockIndex(t *testing.T) {
 	testDB := dbm.NewDB("testdb", "leveldb", "temp")
 	store := NewStore(testDB)
 
-	block := config.GenesisBlock()
-	txStatus := types.NewTransactionStatus()
+	block := types.MockBlock()
+	block.Height = 0
 
-	if err := store.SaveBlock(block, txStatus); err != nil {
+	if err := store.SaveBlock(block); err != nil {
 		t.Fatal(err)
 	}
 
 	for block.Height <= 128 {
 		preHash := block.Hash()
-		block.PreviousBlockHash = preHash
+		block.Previous = preHash
 		block.Height += 1
-		if err := store.SaveBlock(block, txStatus); err != nil {
+		if err := store.SaveBlock(block); err != nil {
 			t.Fatal(err)
 		}
 
@@ -36,8 +35,8 @@ func TestLoadBlockIndex(t *testing.T) {
 		}
 
 		for i := uint64(0); i < block.Height/32; i++ {
-			block.Nonce += 1
-			if err := store.SaveBlock(block, txStatus); err != nil {
+			block.Timestamp++
+			if err := store.SaveBlock(block); err != nil {
 				t.Fatal(err)
 			}
 		}
@@ -73,16 +72,16 @@ func TestLoadBlockIndexBestHeight(t *testing.T) {
 	var savedBlocks []types.Block
 
 	for _, c := range cases {
-		block := config.GenesisBlock()
-		txStatus := types.NewTransactionStatus()
+		block := types.MockBlock()
+		block.Height = 0
 
 		for i := uint64(0); i < c.blockBestHeight; i++ {
-			if err := store.SaveBlock(block, txStatus); err != nil {
+			if err := store.SaveBlock(block); err != nil {
 				t.Fatal(err)
 			}
 
 			savedBlocks = append(savedBlocks, *block)
-			block.PreviousBlockHash = block.Hash()
+			block.Previous = block.Hash()
 			block.Height++
 		}
 
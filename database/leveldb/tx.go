@@ -4,6 +4,8 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/protocol"
 	"github.com/clarenous/go-capsule/protocol/types"
 	dbm "github.com/tendermint/tmlibs/db"
 )
@@ -33,7 +35,7 @@ func (s *Store) GetTransaction(hash *types.Hash) (*types.Tx, error) {
 		iter.Next()
 	}
 
-	return nil, fmt.Errorf("fail to find transaction by hash %s", hash.String())
+	return nil, errors.WithDetailf(protocol.ErrTransactionNotFound, "no transaction of hash %s", hash.String())
 }
 
 func (s *Store) saveTxLocs(batch dbm.Batch, locs []*types.TxLoc) {
@@ -76,7 +78,7 @@ func (s *Store) GetEvidence(hash *types.Hash) (*types.Evidence, *types.Tx, int,
 		iter.Next()
 	}
 
-	return nil, nil, 0, fmt.Errorf("fail to find evidence by hash %s", hash.String())
+	return nil, nil, 0, errors.WithDetailf(protocol.ErrEvidenceNotFound, "no evidence of hash %s", hash.String())
 }
 
 // saveEvidLoc saves evidence loc into batch
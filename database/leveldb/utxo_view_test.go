@@ -7,8 +7,8 @@ import (
 	dbm "github.com/tendermint/tmlibs/db"
 
 	"github.com/clarenous/go-capsule/database/storage"
-
 	"github.com/clarenous/go-capsule/protocol/state"
+	"github.com/clarenous/go-capsule/protocol/types"
 	"github.com/clarenous/go-capsule/testutil"
 )
 
@@ -23,22 +23,22 @@ func TestSaveUtxoView(t *testing.T) {
 		exist     bool
 	}{
 		{
-			hash:      types.Hash{V0: 0},
+			hash:      types.Hash{0},
 			utxoEntry: storage.NewUtxoEntry(true, 0, true),
 			exist:     true,
 		},
 		{
-			hash:      types.Hash{V0: 1},
+			hash:      types.Hash{1},
 			utxoEntry: storage.NewUtxoEntry(true, 0, false),
 			exist:     true,
 		},
 		{
-			hash:      types.Hash{V0: 2},
+			hash:      types.Hash{2},
 			utxoEntry: storage.NewUtxoEntry(false, 0, false),
 			exist:     true,
 		},
 		{
-			hash:      types.Hash{V0: 3},
+			hash:      types.Hash{3},
 			utxoEntry: storage.NewUtxoEntry(false, 0, true),
 			exist:     false,
 		},
@@ -68,6 +68,21 @@ func TestSaveUtxoView(t *testing.T) {
 	}
 }
 
+// utxoHash returns the utxo key of the output index of the zero tx id
+func utxoHash(index uint64) types.Hash {
+	vs := &types.ValueSource{Index: index}
+	return vs.Hash()
+}
+
+// spendTx returns a tx spending the utxoHash outputs
+func spendTx(indexes ...uint64) *types.Tx {
+	tx := &types.Tx{}
+	for _, index := range indexes {
+		tx.Inputs = append(tx.Inputs, types.TxIn{ValueSource: types.ValueSource{Index: index}})
+	}
+	return tx
+}
+
 func TestGetTransactionsUtxo(t *testing.T) {
 	testDB := dbm.NewDB("testdb", "leveldb", "temp")
 	defer os.RemoveAll("temp")
@@ -75,7 +90,7 @@ func TestGetTransactionsUtxo(t *testing.T) {
 	batch := testDB.NewBatch()
 	inputView := state.NewUtxoViewpoint()
 	for i := 0; i <= 2; i++ {
-		inputView.Entries[types.Hash{V0: uint64(i)}] = storage.NewUtxoEntry(false, uint64(i), false)
+		inputView.Entries[utxoHash(uint64(i))] = storage.NewUtxoEntry(false, uint64(i), false)
 	}
 	saveUtxoView(batch, inputView)
 	batch.Write()
@@ -89,9 +104,7 @@ func TestGetTransactionsUtxo(t *testing.T) {
 
 		{
 			txs: []*types.Tx{
-				&types.Tx{
-					SpentOutputIDs: []types.Hash{types.Hash{V0: 10}},
-				},
+				spendTx(10),
 			},
 			inputView: state.NewUtxoViewpoint(),
 			fetchView: state.NewUtxoViewpoint(),
@@ -99,74 +112,56 @@ func TestGetTransactionsUtxo(t *testing.T) {
 		},
 		{
 			txs: []*types.Tx{
-				&types.Tx{
-					SpentOutputIDs: []types.Hash{types.Hash{V0: 0}},
-				},
+				spendTx(0),
 			},
 			inputView: state.NewUtxoViewpoint(),
 			fetchView: &state.UtxoViewpoint{
 				Entries: map[types.Hash]*storage.UtxoEntry{
-					types.Hash{V0: 0}: storage.NewUtxoEntry(false, 0, false),
+					utxoHash(0): storage.NewUtxoEntry(false, 0, false),
 				},
 			},
 			err: false,
 		},
 		{
 			txs: []*types.Tx{
-				&types.Tx{
-					SpentOutputIDs: []types.Hash{
-						types.Hash{V0: 0},
-						types.Hash{V0: 1},
-					},
-				},
+				spendTx(0, 1),
 			},
 			inputView: state.NewUtxoViewpoint(),
 			fetchView: &state.UtxoViewpoint{
 				Entries: map[types.Hash]*storage.UtxoEntry{
-					types.Hash{V0: 0}: storage.NewUtxoEntry(false, 0, false),
-					types.Hash{V0: 1}: storage.NewUtxoEntry(false, 1, false),
+					utxoHash(0): storage.NewUtxoEntry(false, 0, false),
+					utxoHash(1): storage.NewUtxoEntry(false, 1, false),
 				},
 			},
 			err: false,
 		},
 		{
 			txs: []*types.Tx{
-				&types.Tx{
-					SpentOutputIDs: []types.Hash{
-						types.Hash{V0: 0},
-						types.Hash{V0: 1},
-					},
-				},
-				&types.Tx{
-					SpentOutputIDs: []types.Hash{
-						types.Hash{V0: 2},
-					},
-				},
+				spendTx(0, 1),
+				spendTx(2),
 			},
 			inputView: state.NewUtxoViewpoint(),
 			fetchView: &state.UtxoViewpoint{
 				Entries: map[types.Hash]*storage.UtxoEntry{
-					types.Hash{V0: 0}: storage.NewUtxoEntry(false, 0, false),
-					types.Hash{V0: 1}: storage.NewUtxoEntry(false, 1, false),
-					types.Hash{V0: 2}: storage.NewUtxoEntry(false, 2, false),
+					utxoHash(0): storage.NewUtxoEntry(false, 0, false),
+					utxoHash(1): storage.NewUtxoEntry(false, 1, false),
+					utxoHash(2): storage.NewUtxoEntry(false, 2, false),
 				},
 			},
 			err: false,
 		},
 		{
 			txs: []*types.Tx{
-				&types.Tx{
-					SpentOutputIDs: []types.Hash{types.Hash{V0: 0}},
-				},
+				spendTx(0),
 			},
 			inputView: &state.UtxoViewpoint{
 				Entries: map[types.Hash]*storage.UtxoEntry{
-					types.Hash{V0: 0}: storage.NewUtxoEntry(false, 1, false),
+					utxoHash(0): storage.NewUtxoEntry(false, 1, false),
 				},
 			},
 			fetchView: &state.UtxoViewpoint{
 				Entries: map[types.Hash]*storage.UtxoEntry{
-					types.Hash{V0: 0}: storage.NewUtxoEntry(false, 1, false),
+					utxoHash(0): storage.NewUtxoEntry(false, 1, false),
 				},
 			},
 			err: false,
@@ -0,0 +1,212 @@
+package light
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/clarenous/go-capsule/config"
+	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/protocol/state"
+	"github.com/clarenous/go-capsule/protocol/types"
+	"github.com/clarenous/go-capsule/protocol/validation"
+)
+
+const logModule = "light"
+
+var (
+	errOrphanHeader   = errors.New("header doesn't connect to the header chain")
+	errUnknownHeader  = errors.New("merkle proof of an unknown block")
+	errInvalidProof   = errors.New("invalid merkle proof")
+	errNotInMainChain = errors.New("tx is not in the main chain")
+)
+
+// Chain is the header only chain of the light client, the txs of the watched
+// addresses are kept with the merkle proofs verified against the headers.
+type Chain struct {
+	mtx   sync.Mutex
+	index *state.BlockIndex
+	store *Store
+}
+
+// NewChain returns a new light chain using store as the underlying storage
+func NewChain(store *Store) (*Chain, error) {
+	c := &Chain{store: store}
+	status := store.GetStoreStatus()
+	if status == nil {
+		genesisHeader := &config.GenesisBlock().BlockHeader
+		node, err := state.NewBlockNode(genesisHeader, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := store.SaveHeaders([]*types.BlockHeader{genesisHeader}, node); err != nil {
+			return nil, err
+		}
+		status = store.GetStoreStatus()
+	}
+
+	var err error
+	if c.index, err = store.LoadBlockIndex(status.Height); err != nil {
+		return nil, err
+	}
+
+	bestNode := c.index.GetNode(status.Hash)
+	if bestNode == nil {
+		return nil, errors.New("can't find the best header in the header store")
+	}
+	c.index.SetMainChain(bestNode)
+	return c, nil
+}
+
+// BestBlockHeader returns the header of the chain tail
+func (c *Chain) BestBlockHeader() *types.BlockHeader {
+	return c.index.BestNode().BlockHeader()
+}
+
+// BestBlockHeight returns the height of the chain tail
+func (c *Chain) BestBlockHeight() uint64 {
+	return c.index.BestNode().Height
+}
+
+// GetBlockNode return the block index node by given hash
+func (c *Chain) GetBlockNode(hash *types.Hash) (*state.BlockNode, error) {
+	node := c.index.GetNode(hash)
+	if node == nil {
+		return nil, errors.WithDetailf(protocol.ErrBlockNotFound, "no block of hash %s", hash.String())
+	}
+	return node, nil
+}
+
+// GetHeaderByHash return a block header by given hash
+func (c *Chain) GetHeaderByHash(hash *types.Hash) (*types.BlockHeader, error) {
+	node := c.index.GetNode(hash)
+	if node == nil {
+		return nil, errors.WithDetailf(protocol.ErrBlockNotFound, "no block of hash %s", hash.String())
+	}
+	return node.BlockHeader(), nil
+}
+
+// GetHeaderByHeight return a block header by given height
+func (c *Chain) GetHeaderByHeight(height uint64) (*types.BlockHeader, error) {
+	node := c.index.NodeByHeight(height)
+	if node == nil {
+		return nil, errors.WithDetailf(protocol.ErrBlockNotFound, "no block at height %d", height)
+	}
+	return node.BlockHeader(), nil
+}
+
+// InMainChain checks wheather a block is in the main chain
+func (c *Chain) InMainChain(hash types.Hash) bool {
+	return c.index.InMainchain(hash)
+}
+
+// ProcessHeaders validates the headers including their proof against the
+// parents, the chain switches to the headers when they have more work.
+func (c *Chain) ProcessHeaders(headers []*types.BlockHeader) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	newHeaders := []*types.BlockHeader{}
+	var last *state.BlockNode
+	for _, header := range headers {
+		hash := header.Hash()
+		if node := c.index.GetNode(&hash); node != nil {
+			last = node
+			continue
+		}
+
+		parent := c.index.GetNode(&header.Previous)
+		if parent == nil {
+			return errOrphanHeader
+		}
+		if err := validation.ValidateBlockHeader(&types.Block{BlockHeader: *header}, parent); err != nil {
+			return err
+		}
+
+		node, err := state.NewBlockNode(header, parent)
+		if err != nil {
+			return err
+		}
+		c.index.AddNode(node)
+		newHeaders = append(newHeaders, header)
+		last = node
+	}
+
+	if last == nil {
+		return nil
+	}
+
+	bestNode := c.index.BestNode()
+	if last.WorkSum.Cmp(bestNode.WorkSum) > 0 {
+		bestNode = last
+	}
+	if err := c.store.SaveHeaders(newHeaders, bestNode); err != nil {
+		return err
+	}
+
+	c.index.SetMainChain(bestNode)
+	log.WithFields(log.Fields{"module": logModule, "height": bestNode.Height, "hash": bestNode.Hash.String()}).Debug("light chain best header has been update")
+	return nil
+}
+
+// ProcessMerkleProof keeps the txs proven to be in the block by the merkle
+// tree hashes and flags.
+func (c *Chain) ProcessMerkleProof(blockHash *types.Hash, hashes []*types.Hash, flags []uint8, txs []*types.Tx) error {
+	node := c.index.GetNode(blockHash)
+	if node == nil {
+		return errUnknownHeader
+	}
+
+	relatedHashes := []*types.Hash{}
+	for _, tx := range txs {
+		relatedHashes = append(relatedHashes, tx.Hash().Ptr())
+	}
+	if !types.ValidateTxMerkleTreeProof(hashes, flags, relatedHashes, node.TransactionRoot) {
+		return errInvalidProof
+	}
+	if len(txs) == 0 {
+		return nil
+	}
+	return c.store.SaveTxs(blockHash, txs)
+}
+
+// GetProofStatus returns the last header of the processed merkle blocks, which
+// is the genesis header before any merkle block is processed.
+func (c *Chain) GetProofStatus() *protocol.BlockStoreState {
+	if status := c.store.GetProofStatus(); status != nil {
+		return status
+	}
+
+	genesis := c.index.NodeByHeight(0)
+	return &protocol.BlockStoreState{Height: genesis.Height, Hash: &genesis.Hash}
+}
+
+// SaveProofStatus persists the last header of the processed merkle blocks
+func (c *Chain) SaveProofStatus(height uint64, hash *types.Hash) error {
+	return c.store.SaveProofStatus(&protocol.BlockStoreState{Height: height, Hash: hash})
+}
+
+// GetTransaction returns the proven tx of a main chain block
+func (c *Chain) GetTransaction(hash *types.Hash) (*types.Tx, error) {
+	tx, blockHash, err := c.store.GetTransaction(hash)
+	if err != nil {
+		return nil, err
+	}
+	if !c.InMainChain(*blockHash) {
+		return nil, errNotInMainChain
+	}
+	return tx, nil
+}
+
+// GetEvidence returns the proven evidence of a main chain block
+func (c *Chain) GetEvidence(hash *types.Hash) (*types.Evidence, *types.Tx, int, error) {
+	evid, tx, index, blockHash, err := c.store.GetEvidence(hash)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if !c.InMainChain(*blockHash) {
+		return nil, nil, 0, errNotInMainChain
+	}
+	return evid, tx, index, nil
+}
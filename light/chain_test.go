@@ -0,0 +1,204 @@
+package light
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	dbm "github.com/tendermint/tmlibs/db"
+
+	"github.com/clarenous/go-capsule/config"
+	"github.com/clarenous/go-capsule/consensus"
+	"github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+const lightSpec = `{
+	"chain_id": "lightnet",
+	"bech32_hrp": "lm",
+	"default_port": "46670",
+	"genesis": {"timestamp": 1546300800, "target": 2305843009214532812, "nonce": 7},
+	"subsidy": {"initial": 1000, "base": 100, "reduction_interval": 10},
+	"retarget": {"target_seconds_per_block": 60},
+	"proof_forks": [{"height": 0, "type": "pow"}]
+}`
+
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "light")
+	if err != nil {
+		panic(err)
+	}
+
+	specFile := filepath.Join(dir, "chain_spec.json")
+	if err := ioutil.WriteFile(specFile, []byte(lightSpec), 0644); err != nil {
+		panic(err)
+	}
+	spec, err := config.LoadChainSpec(specFile)
+	if err != nil {
+		panic(err)
+	}
+	if err := config.RegisterChainSpec(spec); err != nil {
+		panic(err)
+	}
+	consensus.ActiveNetParams = consensus.NetParams[spec.ChainID]
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func mockHeaders(parent *types.BlockHeader, count int, timeStep uint64) []*types.BlockHeader {
+	headers := []*types.BlockHeader{}
+	for i := 0; i < count; i++ {
+		header := &types.BlockHeader{
+			Version:   1,
+			Height:    parent.Height + 1,
+			Timestamp: parent.Timestamp + timeStep,
+			Previous:  parent.Hash(),
+			Proof:     &pow.WorkProof{Target: parent.Proof.(*pow.WorkProof).Target},
+		}
+		headers = append(headers, header)
+		parent = header
+	}
+	return headers
+}
+
+func TestProcessHeaders(t *testing.T) {
+	db := dbm.NewMemDB()
+	chain, err := NewChain(NewStore(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	genesis := chain.BestBlockHeader()
+	mainHeaders := mockHeaders(genesis, 5, 100)
+	if err := chain.ProcessHeaders(mainHeaders); err != nil {
+		t.Fatal(err)
+	}
+	if chain.BestBlockHeight() != 5 {
+		t.Fatalf("got best height %d", chain.BestBlockHeight())
+	}
+
+	forkHeaders := mockHeaders(mainHeaders[1], 2, 101)
+	if err := chain.ProcessHeaders(forkHeaders); err != nil {
+		t.Fatal(err)
+	}
+	if chain.BestBlockHeader().Hash() != mainHeaders[4].Hash() {
+		t.Errorf("switch to the fork with less work")
+	}
+
+	forkHeaders = append(forkHeaders, mockHeaders(forkHeaders[1], 2, 101)...)
+	if err := chain.ProcessHeaders(forkHeaders); err != nil {
+		t.Fatal(err)
+	}
+	if chain.BestBlockHeader().Hash() != forkHeaders[3].Hash() || chain.InMainChain(mainHeaders[2].Hash()) {
+		t.Errorf("fail to switch to the fork with more work")
+	}
+
+	orphan := mockHeaders(&types.BlockHeader{Height: 10, Proof: &pow.WorkProof{}}, 1, 1)
+	if err := chain.ProcessHeaders(orphan); err != errOrphanHeader {
+		t.Errorf("got err %v want %v", err, errOrphanHeader)
+	}
+
+	bad := mockHeaders(forkHeaders[3], 1, 1)
+	bad[0].Proof.(*pow.WorkProof).Target++
+	if err := chain.ProcessHeaders(bad); err == nil {
+		t.Errorf("header with a wrong target is accepted")
+	}
+
+	reloaded, err := NewChain(NewStore(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.BestBlockHeader().Hash() != forkHeaders[3].Hash() {
+		t.Errorf("best header is not reloaded from the store")
+	}
+}
+
+func TestProcessMerkleProof(t *testing.T) {
+	chain, err := NewChain(NewStore(dbm.NewMemDB()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txs := []*types.Tx{}
+	for i := 0; i < 5; i++ {
+		tx := types.MockTx()
+		tx.Evidences = []types.Evidence{*types.MockEvidence()}
+		txs = append(txs, tx)
+	}
+
+	headers := mockHeaders(chain.BestBlockHeader(), 1, 100)
+	headers[0].TransactionRoot, _ = types.TxMerkleRoot(txs)
+	if err := chain.ProcessHeaders(headers); err != nil {
+		t.Fatal(err)
+	}
+
+	blockHash := headers[0].Hash()
+	related := []*types.Tx{txs[1], txs[3]}
+	hashes, flags := types.GetTxMerkleTreeProof(txs, related)
+	if err := chain.ProcessMerkleProof(&blockHash, hashes, flags, []*types.Tx{txs[1], txs[2]}); err != errInvalidProof {
+		t.Errorf("got err %v want %v", err, errInvalidProof)
+	}
+	if err := chain.ProcessMerkleProof(&blockHash, hashes, flags, related); err != nil {
+		t.Fatal(err)
+	}
+
+	txHash := txs[3].Hash()
+	if tx, err := chain.GetTransaction(&txHash); err != nil || tx.Hash() != txHash {
+		t.Errorf("got err %v", err)
+	}
+
+	evidHash := txs[1].Evidences[0].Hash(txs[1].Hash(), 0)
+	evid, tx, index, err := chain.GetEvidence(&evidHash)
+	if err != nil || tx.Hash() != txs[1].Hash() || index != 0 || evid.Hash(tx.Hash(), 0) != evidHash {
+		t.Errorf("got err %v", err)
+	}
+
+	unrelated := txs[2].Hash()
+	if _, err := chain.GetTransaction(&unrelated); err == nil {
+		t.Errorf("unproven tx is found")
+	}
+
+	genesis, err := chain.GetHeaderByHeight(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := chain.ProcessHeaders(mockHeaders(genesis, 2, 101)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chain.GetTransaction(&txHash); err != errNotInMainChain {
+		t.Errorf("got err %v want %v", err, errNotInMainChain)
+	}
+}
+
+func TestProofStatus(t *testing.T) {
+	db := dbm.NewMemDB()
+	chain, err := NewChain(NewStore(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	genesis := chain.BestBlockHeader()
+	if status := chain.GetProofStatus(); status.Height != 0 || *status.Hash != genesis.Hash() {
+		t.Errorf("got proof status %v, want the genesis", status)
+	}
+
+	headers := mockHeaders(genesis, 3, 100)
+	if err := chain.ProcessHeaders(headers); err != nil {
+		t.Fatal(err)
+	}
+	hash := headers[1].Hash()
+	if err := chain.SaveProofStatus(headers[1].Height, &hash); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := NewChain(NewStore(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := reloaded.GetProofStatus(); status.Height != 2 || *status.Hash != hash {
+		t.Errorf("proof status is not reloaded from the store, got %v", status)
+	}
+}
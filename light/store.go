@@ -0,0 +1,202 @@
+package light
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	dbm "github.com/tendermint/tmlibs/db"
+
+	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/protocol/state"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+var (
+	headerStoreKey = []byte("lightHeaderStore")
+	proofStoreKey  = []byte("lightProofStore")
+	headerPrefix   = []byte("LH:")
+	txPrefix       = []byte("LT:")
+	evidLocPrefix  = []byte("LEVIDL:")
+)
+
+// Store keeps the block headers and the txs proven by merkle blocks
+type Store struct {
+	db dbm.DB
+}
+
+// NewStore creates and returns a new light client store
+func NewStore(db dbm.DB) *Store {
+	return &Store{db: db}
+}
+
+func calcHeaderKey(height uint64, hash *types.Hash) []byte {
+	buf := [8]byte{}
+	binary.BigEndian.PutUint64(buf[:], height)
+	key := append(append([]byte{}, headerPrefix...), buf[:]...)
+	return append(key, hash.Bytes()...)
+}
+
+func calcTxKey(hash *types.Hash) []byte {
+	return append(append([]byte{}, txPrefix...), hash.Bytes()...)
+}
+
+func calcEvidLocKey(hash *types.Hash) []byte {
+	return append(append([]byte{}, evidLocPrefix...), hash.Bytes()...)
+}
+
+// GetStoreStatus return the best header of the store, nil for an empty store
+func (s *Store) GetStoreStatus() *protocol.BlockStoreState {
+	bytes := s.db.Get(headerStoreKey)
+	if bytes == nil {
+		return nil
+	}
+
+	status := &protocol.BlockStoreState{}
+	if err := json.Unmarshal(bytes, status); err != nil {
+		return nil
+	}
+	return status
+}
+
+// LoadBlockIndex loads the headers not higher than the best header
+func (s *Store) LoadBlockIndex(bestHeight uint64) (*state.BlockIndex, error) {
+	blockIndex := state.NewBlockIndex()
+	iter := dbm.IteratePrefix(s.db, headerPrefix)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		header := &types.BlockHeader{}
+		if err := header.UnmarshalText(iter.Value()); err != nil {
+			return nil, err
+		}
+		if header.Height > bestHeight {
+			break
+		}
+
+		var parent *state.BlockNode
+		if header.Height != 0 {
+			if parent = blockIndex.GetNode(&header.Previous); parent == nil {
+				continue
+			}
+		}
+
+		node, err := state.NewBlockNode(header, parent)
+		if err != nil {
+			return nil, err
+		}
+		blockIndex.AddNode(node)
+	}
+	return blockIndex, nil
+}
+
+// SaveHeaders persists the headers and the best header of the chain
+func (s *Store) SaveHeaders(headers []*types.BlockHeader, best *state.BlockNode) error {
+	batch := s.db.NewBatch()
+	for _, header := range headers {
+		rawHeader, err := header.MarshalText()
+		if err != nil {
+			return errors.Wrap(err, "marshal block header")
+		}
+
+		hash := header.Hash()
+		batch.Set(calcHeaderKey(header.Height, &hash), rawHeader)
+	}
+
+	bytes, err := json.Marshal(protocol.BlockStoreState{Height: best.Height, Hash: &best.Hash})
+	if err != nil {
+		return err
+	}
+
+	batch.Set(headerStoreKey, bytes)
+	batch.Write()
+	return nil
+}
+
+// GetProofStatus return the last header whose merkle block and all the merkle
+// blocks below it are processed, nil when no merkle block is processed yet.
+func (s *Store) GetProofStatus() *protocol.BlockStoreState {
+	bytes := s.db.Get(proofStoreKey)
+	if bytes == nil {
+		return nil
+	}
+
+	status := &protocol.BlockStoreState{}
+	if err := json.Unmarshal(bytes, status); err != nil {
+		return nil
+	}
+	return status
+}
+
+// SaveProofStatus persists the last header of the processed merkle blocks
+func (s *Store) SaveProofStatus(status *protocol.BlockStoreState) error {
+	bytes, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	s.db.Set(proofStoreKey, bytes)
+	return nil
+}
+
+// SaveTxs persists the txs proven in the block and the locations of their
+// evidences, the value of a tx is the block hash followed by the raw tx.
+func (s *Store) SaveTxs(blockHash *types.Hash, txs []*types.Tx) error {
+	batch := s.db.NewBatch()
+	for _, tx := range txs {
+		rawTx, err := tx.MarshalText()
+		if err != nil {
+			return errors.Wrap(err, "marshal tx")
+		}
+
+		txHash := tx.Hash()
+		batch.Set(calcTxKey(&txHash), append(blockHash.Bytes(), rawTx...))
+		for i, evid := range tx.Evidences {
+			var loc [40]byte
+			copy(loc[:32], txHash[:])
+			binary.LittleEndian.PutUint64(loc[32:], uint64(i))
+
+			evidHash := evid.Hash(txHash, uint64(i))
+			batch.Set(calcEvidLocKey(&evidHash), loc[:])
+		}
+	}
+	batch.Write()
+	return nil
+}
+
+// GetTransaction returns the proven tx and the hash of the block including it
+func (s *Store) GetTransaction(hash *types.Hash) (*types.Tx, *types.Hash, error) {
+	bytes := s.db.Get(calcTxKey(hash))
+	if len(bytes) < 32 {
+		return nil, nil, errors.WithDetailf(protocol.ErrTransactionNotFound, "no transaction of hash %s", hash.String())
+	}
+
+	var blockHash types.Hash
+	copy(blockHash[:], bytes[:32])
+	tx := &types.Tx{}
+	if err := tx.UnmarshalText(bytes[32:]); err != nil {
+		return nil, nil, err
+	}
+	return tx, &blockHash, nil
+}
+
+// GetEvidence returns the evidence, the tx including it and its index in the tx
+func (s *Store) GetEvidence(hash *types.Hash) (*types.Evidence, *types.Tx, int, *types.Hash, error) {
+	loc := s.db.Get(calcEvidLocKey(hash))
+	if len(loc) != 40 {
+		return nil, nil, 0, nil, errors.WithDetailf(protocol.ErrEvidenceNotFound, "no evidence of hash %s", hash.String())
+	}
+
+	var txHash types.Hash
+	copy(txHash[:], loc[:32])
+	tx, blockHash, err := s.GetTransaction(&txHash)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+
+	index := int(binary.LittleEndian.Uint64(loc[32:]))
+	if index >= len(tx.Evidences) {
+		return nil, nil, 0, nil, errors.WithDetailf(protocol.ErrEvidenceNotFound, "no evidence of hash %s", hash.String())
+	}
+	return &tx.Evidences[index], tx, index, blockHash, nil
+}
@@ -1,9 +1,13 @@
 package cpuminer
 
 import (
+	"github.com/clarenous/go-capsule/consensus"
+	"github.com/clarenous/go-capsule/consensus/algorithm/poa"
 	"github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/crypto/ed25519"
 	"github.com/clarenous/go-capsule/event"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -33,19 +37,79 @@ type CPUMiner struct {
 	updateNumWorkers chan struct{}
 	quit             chan struct{}
 	eventDispatcher  *event.Dispatcher
+	authorityKey     ed25519.PrivateKey
+
+	statsMtx       sync.RWMutex
+	workers        []*workerStats
+	nextWorkerID   uint32
+	solvedBlocks   uint64
+	templateHeight uint64
+	templateTarget uint64
+}
+
+// WorkerStats is the hashing metrics of one mining worker
+type WorkerStats struct {
+	ID           uint32
+	Hashes       uint64
+	HashesPerSec float64
+}
+
+// MiningStats is the snapshot of the CPU miner status
+type MiningStats struct {
+	Mining         bool
+	NumWorkers     int32
+	HashesPerSec   float64
+	Workers        []WorkerStats
+	SolvedBlocks   uint64
+	TemplateHeight uint64
+	TemplateTarget uint64
+}
+
+// workerStats tracks the hashing metrics of a running worker
+type workerStats struct {
+	sync.Mutex
+	id           uint32
+	hashes       uint64
+	hashesPerSec float64
+	lastUpdate   time.Time
+}
+
+// update adds the completed hashes and recalculates the hash rate
+func (ws *workerStats) update(hashes uint64) {
+	ws.Lock()
+	defer ws.Unlock()
+
+	now := time.Now()
+	if elapsed := now.Sub(ws.lastUpdate).Seconds(); elapsed > 0 {
+		ws.hashesPerSec = float64(hashes) / elapsed
+	}
+	ws.hashes += hashes
+	ws.lastUpdate = now
+}
+
+func (ws *workerStats) snapshot() WorkerStats {
+	ws.Lock()
+	defer ws.Unlock()
+
+	return WorkerStats{ID: ws.id, Hashes: ws.hashes, HashesPerSec: ws.hashesPerSec}
 }
 
 // solveBlock attempts to find some combination of a nonce, extra nonce, and
 // current timestamp which makes the passed block hash to a value less than the
 // target difficulty.
-func (m *CPUMiner) solveBlock(block *types.Block, ticker *time.Ticker, quit chan struct{}) bool {
+func (m *CPUMiner) solveBlock(block *types.Block, ticker *time.Ticker, quit chan struct{}, stats *workerStats) bool {
 	header := &block.BlockHeader
 
+	hashesCompleted := uint64(0)
+	defer func() { stats.update(hashesCompleted) }()
+
 	for i := uint64(0); i <= maxNonce; i++ {
 		select {
 		case <-quit:
 			return false
 		case <-ticker.C:
+			stats.update(hashesCompleted)
+			hashesCompleted = 0
 			if m.chain.BestBlockHeight() >= header.Height {
 				return false
 			}
@@ -54,6 +118,7 @@ func (m *CPUMiner) solveBlock(block *types.Block, ticker *time.Ticker, quit chan
 
 		header.Proof.(*pow.WorkProof).Nonce = i
 		headerHash := header.Hash()
+		hashesCompleted++
 		if pow.CheckProofOfWork(&headerHash, header.Proof.(*pow.WorkProof).Target) {
 			return true
 		}
@@ -61,6 +126,54 @@ func (m *CPUMiner) solveBlock(block *types.Block, ticker *time.Ticker, quit chan
 	return false
 }
 
+// sealBlock signs the block with the authority key once the authority period
+// since the parent block has passed. Out of turn authorities wait one more
+// period so that the in turn authority gets the chance to seal first.
+func (m *CPUMiner) sealBlock(block *types.Block, ticker *time.Ticker, quit chan struct{}) bool {
+	key := m.AuthorityKey()
+	if key == nil {
+		log.WithFields(log.Fields{"module": logModule, "height": block.Height}).Warning("no authority key to seal block")
+		select {
+		case <-quit:
+		case <-time.After(time.Second * time.Duration(consensus.ActiveNetParams.AuthoritySecondsPerBlock)):
+		}
+		return false
+	}
+
+	header := &block.BlockHeader
+	parent := m.chain.BestBlockHeader()
+	if parent.Hash() != header.Previous {
+		return false
+	}
+
+	sealTime := parent.Timestamp + consensus.ActiveNetParams.AuthoritySecondsPerBlock
+	inTurn, err := poa.Seal(header, key)
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "height": header.Height, "err": err}).Warning("fail on seal block")
+		return false
+	}
+	if !inTurn {
+		sealTime += consensus.ActiveNetParams.AuthoritySecondsPerBlock
+	}
+
+	for uint64(time.Now().Unix()) < sealTime {
+		select {
+		case <-quit:
+			return false
+		case <-ticker.C:
+			if m.chain.BestBlockHeight() >= header.Height {
+				return false
+			}
+		}
+	}
+
+	header.Timestamp = uint64(time.Now().Unix())
+	if _, err := poa.Seal(header, key); err != nil {
+		return false
+	}
+	return true
+}
+
 // generateBlocks is a worker that is controlled by the miningWorkerController.
 // It is self contained in that it creates block templates and attempts to solve
 // them while detecting when it is performing stale work and reacting
@@ -68,7 +181,7 @@ func (m *CPUMiner) solveBlock(block *types.Block, ticker *time.Ticker, quit chan
 // is submitted.
 //
 // It must be run as a goroutine.
-func (m *CPUMiner) generateBlocks(quit chan struct{}) {
+func (m *CPUMiner) generateBlocks(quit chan struct{}, stats *workerStats) {
 	ticker := time.NewTicker(time.Second * hashUpdateSecs)
 	defer ticker.Stop()
 
@@ -85,9 +198,19 @@ out:
 			log.Errorf("Mining: failed on create NewBlockTemplate: %v", err)
 			continue
 		}
+		m.setTemplate(&block.BlockHeader)
 
-		if m.solveBlock(block, ticker, quit) {
+		var solved bool
+		switch block.Proof.(type) {
+		case *poa.AuthorityProof:
+			solved = m.sealBlock(block, ticker, quit)
+		default:
+			solved = m.solveBlock(block, ticker, quit, stats)
+		}
+
+		if solved {
 			if isOrphan, err := m.chain.ProcessBlock(block); err == nil {
+				atomic.AddUint64(&m.solvedBlocks, 1)
 				log.WithFields(log.Fields{
 					"height":   block.BlockHeader.Height,
 					"isOrphan": isOrphan,
@@ -126,7 +249,7 @@ func (m *CPUMiner) miningWorkerController() {
 			runningWorkers = append(runningWorkers, quit)
 
 			m.workerWg.Add(1)
-			go m.generateBlocks(quit)
+			go m.generateBlocks(quit, m.addWorkerStats())
 		}
 	}
 
@@ -157,6 +280,7 @@ out:
 				runningWorkers[i] = nil
 				runningWorkers = runningWorkers[:i]
 			}
+			m.truncateWorkerStats(len(runningWorkers))
 
 		case <-m.quit:
 			for _, quit := range runningWorkers {
@@ -167,11 +291,65 @@ out:
 	}
 
 	m.workerWg.Wait()
+	m.truncateWorkerStats(0)
+}
+
+// addWorkerStats creates the metrics for a newly launched worker
+func (m *CPUMiner) addWorkerStats() *workerStats {
+	m.statsMtx.Lock()
+	defer m.statsMtx.Unlock()
+
+	m.nextWorkerID++
+	stats := &workerStats{id: m.nextWorkerID, lastUpdate: time.Now()}
+	m.workers = append(m.workers, stats)
+	return stats
+}
+
+// truncateWorkerStats drops the metrics of the stopped workers, the workers
+// are always stopped from the most recently created one.
+func (m *CPUMiner) truncateWorkerStats(numRunning int) {
+	m.statsMtx.Lock()
+	defer m.statsMtx.Unlock()
+
+	if numRunning < len(m.workers) {
+		m.workers = m.workers[:numRunning]
+	}
+}
+
+func (m *CPUMiner) setTemplate(header *types.BlockHeader) {
+	m.statsMtx.Lock()
+	defer m.statsMtx.Unlock()
+
+	m.templateHeight = header.Height
+	m.templateTarget = 0
+	if proof, ok := header.Proof.(*pow.WorkProof); ok {
+		m.templateTarget = proof.Target
+	}
+}
+
+// SetAuthorityKey sets the key used to seal blocks when the chain runs the
+// proof of authority consensus.
+//
+// This function is safe for concurrent access.
+func (m *CPUMiner) SetAuthorityKey(key ed25519.PrivateKey) {
+	m.Lock()
+	defer m.Unlock()
+	m.authorityKey = key
+}
+
+// AuthorityKey returns the key used to seal blocks.
+//
+// This function is safe for concurrent access.
+func (m *CPUMiner) AuthorityKey() ed25519.PrivateKey {
+	m.Lock()
+	defer m.Unlock()
+	return m.authorityKey
 }
 
 // Start begins the CPU mining process as well as the speed monitor used to
 // track hashing metrics.  Calling this function when the CPU miner has
-// already been started will have no effect.
+// already been started will have no effect.  The default number of workers is
+// restored when the workers have been set to 0.
 //
 // This function is safe for concurrent access.
 func (m *CPUMiner) Start() {
@@ -183,6 +361,11 @@ func (m *CPUMiner) Start() {
 		return
 	}
 
+	// Mining with no worker would report mining while nothing is hashed.
+	if m.numWorkers == 0 {
+		m.numWorkers = defaultNumWorkers
+	}
+
 	m.quit = make(chan struct{})
 	go m.miningWorkerController()
 
@@ -250,6 +433,30 @@ func (m *CPUMiner) SetNumWorkers(numWorkers int32) {
 	}
 }
 
+// Stats returns the hashing metrics of every running worker, the number of
+// blocks solved and the block template currently being mined.
+//
+// This function is safe for concurrent access.
+func (m *CPUMiner) Stats() *MiningStats {
+	stats := &MiningStats{
+		Mining:       m.IsMining(),
+		NumWorkers:   m.NumWorkers(),
+		SolvedBlocks: atomic.LoadUint64(&m.solvedBlocks),
+	}
+
+	m.statsMtx.RLock()
+	defer m.statsMtx.RUnlock()
+
+	stats.TemplateHeight = m.templateHeight
+	stats.TemplateTarget = m.templateTarget
+	stats.Workers = make([]WorkerStats, len(m.workers))
+	for i, worker := range m.workers {
+		stats.Workers[i] = worker.snapshot()
+		stats.HashesPerSec += stats.Workers[i].HashesPerSec
+	}
+	return stats
+}
+
 // NumWorkers returns the number of workers which are running to solve blocks.
 //
 // This function is safe for concurrent access.
@@ -38,6 +38,9 @@ func NewBlockTemplate(c *protocol.Chain, txPool *protocol.TxPool) (b *types.Bloc
 	b.Previous = preBlockHash
 	b.Height = nextBlockHeight
 	b.Timestamp = uint64(time.Now().Unix())
+	if b.Proof, err = c.CalcNextProof(&preBlockHash); err != nil {
+		return nil, errors.Wrap(err, "fail on CalcNextProof")
+	}
 	//nextBits, err := c.CalcNextBits(&preBlockHash)
 	//if err != nil {
 	//	return nil, err
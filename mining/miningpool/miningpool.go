@@ -16,6 +16,12 @@ const (
 	maxSubmitChSize = 50
 )
 
+var (
+	ErrNoWork     = errors.New("no block is ready for mining")
+	ErrStaleWork  = errors.New("pending mining block has been changed")
+	ErrOrphanWork = errors.New("submit result is orphan")
+)
+
 type submitBlockMsg struct {
 	blockHeader *types.BlockHeader
 	reply       chan error
@@ -83,7 +89,7 @@ func (m *MiningPool) GetWork() (*types.BlockHeader, error) {
 		bh := m.block.BlockHeader
 		return &bh, nil
 	}
-	return nil, errors.New("no block is ready for mining")
+	return nil, ErrNoWork
 }
 
 // SubmitWork will try to submit the result to the blockchain
@@ -102,7 +108,7 @@ func (m *MiningPool) submitWork(bh *types.BlockHeader) error {
 	defer m.mutex.Unlock()
 
 	if m.block == nil || bh.Previous != m.block.Previous {
-		return errors.New("pending mining block has been changed")
+		return ErrStaleWork
 	}
 
 	m.block.Proof = bh.Proof
@@ -112,7 +118,7 @@ func (m *MiningPool) submitWork(bh *types.BlockHeader) error {
 		return err
 	}
 	if isOrphan {
-		return errors.New("submit result is orphan")
+		return ErrOrphanWork
 	}
 
 	if err := m.eventDispatcher.Post(event.NewMinedBlockEvent{Block: m.block}); err != nil {
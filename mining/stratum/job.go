@@ -0,0 +1,114 @@
+package stratum
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strconv"
+	"sync"
+
+	"github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+var (
+	errInvalidProof = errors.New("block header proof is not a work proof")
+
+	// maxTarget is the easiest possible target, 2^256 - 1.
+	maxTarget = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+)
+
+// job is a unit of work handed out to the stratum clients. Every job keeps a
+// private copy of the block header so that solving one job never touches the
+// template owned by the mining pool.
+type job struct {
+	id          string
+	header      *types.BlockHeader
+	shareTarget *big.Int
+
+	mtx       sync.Mutex
+	submitted map[uint64]struct{}
+}
+
+func newJob(seq uint64, header *types.BlockHeader, shareTarget *big.Int) (*job, error) {
+	h, err := copyHeader(header, header.Timestamp, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// the share target must never be harder than the block target, or a
+	// miner could find blocks that are not even accepted as shares
+	blockTarget := pow.CompactToBig(h.Proof.(*pow.WorkProof).Target)
+	target := new(big.Int).Set(shareTarget)
+	if target.Cmp(blockTarget) < 0 {
+		target.Set(blockTarget)
+	}
+
+	return &job{
+		id:          strconv.FormatUint(seq, 16),
+		header:      h,
+		shareTarget: target,
+		submitted:   make(map[uint64]struct{}),
+	}, nil
+}
+
+// headerHex returns the serialized block header of the job in hex
+func (j *job) headerHex() (string, error) {
+	buf, err := j.header.MarshalText()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// markSubmitted records the nonce and reports whether it was seen before
+func (j *job) markSubmitted(nonce uint64) bool {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	if _, ok := j.submitted[nonce]; ok {
+		return true
+	}
+	j.submitted[nonce] = struct{}{}
+	return false
+}
+
+// solve builds the header candidate for the given nonce and timestamp
+func (j *job) solve(nonce, timestamp uint64) (*types.BlockHeader, error) {
+	return copyHeader(j.header, timestamp, nonce)
+}
+
+// copyHeader returns a deep copy of the header with a fresh work proof
+func copyHeader(bh *types.BlockHeader, timestamp, nonce uint64) (*types.BlockHeader, error) {
+	proof, ok := bh.Proof.(*pow.WorkProof)
+	if !ok {
+		return nil, errInvalidProof
+	}
+
+	newProof, err := pow.NewProof(proof.Target, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	h := *bh
+	h.Timestamp = timestamp
+	h.Proof = newProof
+	return &h, nil
+}
+
+// difficultyToTarget converts the share difficulty into a 256-bit target,
+// difficulty 1 being the easiest possible target.
+func difficultyToTarget(difficulty uint64) *big.Int {
+	if difficulty == 0 {
+		difficulty = 1
+	}
+	return new(big.Int).Div(maxTarget, new(big.Int).SetUint64(difficulty))
+}
+
+// targetHex encodes the target as 32 bytes big-endian hex
+func targetHex(target *big.Int) string {
+	var b32 [32]byte
+	buf := target.Bytes()
+	copy(b32[32-len(buf):], buf)
+	return hex.EncodeToString(b32[:])
+}
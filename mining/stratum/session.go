@@ -0,0 +1,285 @@
+package stratum
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/clarenous/go-capsule/consensus"
+	"github.com/clarenous/go-capsule/consensus/algorithm/pow"
+)
+
+const (
+	maxLineSize = 16 * 1024
+	readTimeout = 10 * time.Minute
+)
+
+// stratum error codes
+const (
+	errCodeOther         = 20
+	errCodeJobNotFound   = 21
+	errCodeDuplicate     = 22
+	errCodeLowDifficulty = 23
+	errCodeUnauthorized  = 24
+	errCodeNotSubscribed = 25
+)
+
+type request struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type response struct {
+	ID     json.RawMessage `json:"id"`
+	Result interface{}     `json:"result"`
+	Error  interface{}     `json:"error"`
+}
+
+type notification struct {
+	ID     interface{}   `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+type stratumError struct {
+	code int
+	msg  string
+}
+
+func (e *stratumError) Error() string {
+	return e.msg
+}
+
+func (e *stratumError) toJSON() []interface{} {
+	return []interface{}{e.code, e.msg, nil}
+}
+
+func newError(code int, format string, args ...interface{}) *stratumError {
+	return &stratumError{code: code, msg: fmt.Sprintf(format, args...)}
+}
+
+// session is the connection with one miner
+type session struct {
+	server      *Server
+	conn        net.Conn
+	extraNonce1 uint32
+
+	writeMtx   sync.Mutex
+	encoder    *json.Encoder
+	stateMtx   sync.RWMutex
+	subscribed bool
+	authorized bool
+	worker     string
+	target     *big.Int // the share target last sent to the miner
+	closeOnce  sync.Once
+}
+
+func newSession(server *Server, conn net.Conn, extraNonce1 uint32) *session {
+	return &session{
+		server:      server,
+		conn:        conn,
+		extraNonce1: extraNonce1,
+		encoder:     json.NewEncoder(conn),
+	}
+}
+
+func (s *session) close() {
+	s.closeOnce.Do(func() { s.conn.Close() })
+}
+
+// handle reads the requests line by line until the connection is closed
+func (s *session) handle() {
+	scanner := bufio.NewScanner(s.conn)
+	scanner.Buffer(make([]byte, 0, maxLineSize), maxLineSize)
+	for {
+		s.conn.SetReadDeadline(time.Now().Add(readTimeout))
+		if !scanner.Scan() {
+			return
+		}
+
+		req := &request{}
+		if err := json.Unmarshal(scanner.Bytes(), req); err != nil {
+			log.WithFields(log.Fields{"module": logModule, "remote": s.conn.RemoteAddr().String(), "err": err}).Warning("fail on decode stratum request")
+			return
+		}
+
+		result, err := s.dispatch(req)
+		resp := &response{ID: req.ID, Result: result}
+		if err != nil {
+			resp.Error = err.toJSON()
+		}
+		if err := s.send(resp); err != nil {
+			return
+		}
+
+		if req.Method == "mining.subscribe" && err == nil {
+			if j := s.server.getCurrentJob(); j != nil {
+				s.notifyJob(j, true)
+			}
+		}
+	}
+}
+
+func (s *session) dispatch(req *request) (interface{}, *stratumError) {
+	switch req.Method {
+	case "mining.subscribe":
+		return s.handleSubscribe()
+
+	case "mining.authorize":
+		return s.handleAuthorize(req.Params)
+
+	case "mining.submit":
+		return s.handleSubmit(req.Params)
+
+	default:
+		return nil, newError(errCodeOther, "unknown method %s", req.Method)
+	}
+}
+
+func (s *session) handleSubscribe() (interface{}, *stratumError) {
+	s.stateMtx.Lock()
+	s.subscribed = true
+	s.stateMtx.Unlock()
+
+	subID := strconv.FormatUint(uint64(s.extraNonce1), 16)
+	subscriptions := [][]string{{"mining.set_target", subID}, {"mining.notify", subID}}
+	return []interface{}{subscriptions, fmt.Sprintf("%08x", s.extraNonce1), extraNonce2Size}, nil
+}
+
+func (s *session) handleAuthorize(params []json.RawMessage) (interface{}, *stratumError) {
+	var worker string
+	if len(params) < 1 || json.Unmarshal(params[0], &worker) != nil || worker == "" {
+		return false, newError(errCodeOther, "invalid worker name")
+	}
+
+	s.stateMtx.Lock()
+	s.authorized = true
+	s.worker = worker
+	s.stateMtx.Unlock()
+
+	log.WithFields(log.Fields{"module": logModule, "remote": s.conn.RemoteAddr().String(), "worker": worker}).Info("miner authorized")
+	return true, nil
+}
+
+// handleSubmit validates the share, the params are the worker name, the job
+// id, the 64 bit nonce and optionally the rolled timestamp, all hex encoded.
+func (s *session) handleSubmit(params []json.RawMessage) (interface{}, *stratumError) {
+	s.stateMtx.RLock()
+	subscribed, authorized := s.subscribed, s.authorized
+	s.stateMtx.RUnlock()
+	if !subscribed {
+		return false, newError(errCodeNotSubscribed, "not subscribed")
+	}
+	if !authorized {
+		return false, newError(errCodeUnauthorized, "unauthorized worker")
+	}
+
+	args := make([]string, len(params))
+	for i, param := range params {
+		if err := json.Unmarshal(param, &args[i]); err != nil {
+			return false, newError(errCodeOther, "invalid params")
+		}
+	}
+	if len(args) < 3 {
+		return false, newError(errCodeOther, "invalid params")
+	}
+
+	j := s.server.getJob(args[1])
+	if j == nil {
+		return false, newError(errCodeJobNotFound, "job not found")
+	}
+
+	nonce, err := strconv.ParseUint(args[2], 16, 64)
+	if err != nil {
+		return false, newError(errCodeOther, "invalid nonce")
+	}
+	if uint32(nonce>>(8*extraNonce2Size)) != s.extraNonce1 {
+		return false, newError(errCodeOther, "nonce out of extranonce range")
+	}
+
+	timestamp := j.header.Timestamp
+	if len(args) > 3 {
+		if timestamp, err = strconv.ParseUint(args[3], 16, 64); err != nil {
+			return false, newError(errCodeOther, "invalid ntime")
+		}
+	}
+	if timestamp < j.header.Timestamp || timestamp > uint64(time.Now().Unix())+consensus.MaxTimeOffsetSeconds {
+		return false, newError(errCodeOther, "ntime out of range")
+	}
+
+	if j.markSubmitted(nonce) {
+		return false, newError(errCodeDuplicate, "duplicate share")
+	}
+
+	header, err := j.solve(nonce, timestamp)
+	if err != nil {
+		return false, newError(errCodeOther, "%v", err)
+	}
+
+	hash := header.Hash()
+	if pow.HashToBig(&hash).Cmp(j.shareTarget) > 0 {
+		return false, newError(errCodeLowDifficulty, "low difficulty share")
+	}
+
+	if pow.CheckProofOfWork(&hash, header.Proof.(*pow.WorkProof).Target) {
+		if err := s.server.pool.SubmitWork(header); err != nil {
+			log.WithFields(log.Fields{"module": logModule, "worker": args[0], "height": header.Height, "err": err}).Warning("fail on submit block found by miner")
+			return false, newError(errCodeOther, "%v", err)
+		}
+		log.WithFields(log.Fields{"module": logModule, "worker": args[0], "height": header.Height, "hash": hash.String()}).Info("miner found block")
+	}
+	return true, nil
+}
+
+// sendTarget sends the share target of the job clamped to its block target
+func (s *session) sendTarget(j *job) {
+	s.send(&notification{Method: "mining.set_target", Params: []interface{}{targetHex(j.shareTarget)}})
+}
+
+func (s *session) notifyJob(j *job, clean bool) {
+	s.stateMtx.Lock()
+	subscribed := s.subscribed
+	newTarget := subscribed && (s.target == nil || s.target.Cmp(j.shareTarget) != 0)
+	if newTarget {
+		s.target = j.shareTarget
+	}
+	s.stateMtx.Unlock()
+	if !subscribed {
+		return
+	}
+
+	if newTarget {
+		s.sendTarget(j)
+	}
+
+	header, err := j.headerHex()
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on serialize job header")
+		return
+	}
+
+	s.send(&notification{
+		Method: "mining.notify",
+		Params: []interface{}{j.id, header, targetHex(j.shareTarget), j.header.Height, clean},
+	})
+}
+
+func (s *session) send(msg interface{}) error {
+	s.writeMtx.Lock()
+	defer s.writeMtx.Unlock()
+
+	if err := s.encoder.Encode(msg); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "remote": s.conn.RemoteAddr().String(), "err": err}).Debug("fail on send stratum message")
+		s.close()
+		return err
+	}
+	return nil
+}
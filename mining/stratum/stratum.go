@@ -0,0 +1,289 @@
+// Package stratum implements a line based JSON-RPC mining server on top of
+// the mining pool, so that external miners can work against a node.
+package stratum
+
+import (
+	"errors"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+const (
+	logModule = "stratum"
+
+	// extraNonce2Size is the number of nonce bytes left to the miner, the
+	// remaining high bytes of the 64 bit nonce are fixed per session.
+	extraNonce2Size = 4
+
+	jobRefreshInterval = 30 * time.Second
+	jobRetryInterval   = 200 * time.Millisecond
+)
+
+var (
+	errServerStarted = errors.New("stratum server has been started")
+)
+
+// WorkPool is the interface for the mining pool which assembles block
+// templates and accepts the solved block headers.
+type WorkPool interface {
+	GetWork() (*types.BlockHeader, error)
+	SubmitWork(*types.BlockHeader) error
+}
+
+// Chain is the interface for the chain state used to detect stale jobs
+type Chain interface {
+	BestBlockHeight() uint64
+	BlockWaiter(uint64) <-chan struct{}
+}
+
+// Server is the stratum mining server
+type Server struct {
+	mtx         sync.RWMutex
+	chain       Chain
+	pool        WorkPool
+	shareTarget *big.Int
+
+	listener       net.Listener
+	jobSeq         uint64
+	currentJob     *job
+	jobs           map[string]*job
+	sessions       map[uint32]*session
+	nextExtraNonce uint32
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewServer creates a stratum server, shares are accepted when they meet the
+// target derived from shareDifficulty.
+func NewServer(chain Chain, pool WorkPool, shareDifficulty uint64) *Server {
+	return &Server{
+		chain:       chain,
+		pool:        pool,
+		shareTarget: difficultyToTarget(shareDifficulty),
+		jobs:        make(map[string]*job),
+		sessions:    make(map[uint32]*session),
+	}
+}
+
+// Start begins listening for miners on the given address
+func (s *Server) Start(address string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.listener != nil {
+		return errServerStarted
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	s.listener = listener
+	s.quit = make(chan struct{})
+	s.wg.Add(2)
+	go s.acceptLoop(listener)
+	go s.jobUpdater()
+
+	log.WithFields(log.Fields{"module": logModule, "address": listener.Addr().String()}).Info("stratum server started")
+	return nil
+}
+
+// Stop closes the listener and disconnects all the miners
+func (s *Server) Stop() {
+	s.mtx.Lock()
+	if s.listener == nil {
+		s.mtx.Unlock()
+		return
+	}
+
+	close(s.quit)
+	s.listener.Close()
+	s.listener = nil
+	for _, sess := range s.sessions {
+		sess.close()
+	}
+	s.mtx.Unlock()
+
+	s.wg.Wait()
+	log.WithFields(log.Fields{"module": logModule}).Info("stratum server stopped")
+}
+
+// Addr returns the listening address, nil if the server is not started
+func (s *Server) Addr() net.Addr {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+func (s *Server) acceptLoop(listener net.Listener) {
+	defer s.wg.Done()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-s.quit:
+				return
+			default:
+			}
+
+			log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on accept miner connection")
+			time.Sleep(jobRetryInterval)
+			continue
+		}
+
+		sess := s.addSession(conn)
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			sess.handle()
+			s.removeSession(sess)
+		}()
+	}
+}
+
+func (s *Server) addSession(conn net.Conn) *session {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// every session works on its own range of the nonce space
+	for {
+		s.nextExtraNonce++
+		if _, ok := s.sessions[s.nextExtraNonce]; !ok {
+			break
+		}
+	}
+
+	sess := newSession(s, conn, s.nextExtraNonce)
+	s.sessions[sess.extraNonce1] = sess
+	log.WithFields(log.Fields{"module": logModule, "remote": conn.RemoteAddr().String(), "extra_nonce": sess.extraNonce1}).Info("new miner connected")
+	return sess
+}
+
+func (s *Server) removeSession(sess *session) {
+	s.mtx.Lock()
+	delete(s.sessions, sess.extraNonce1)
+	s.mtx.Unlock()
+
+	sess.close()
+	log.WithFields(log.Fields{"module": logModule, "remote": sess.conn.RemoteAddr().String()}).Info("miner disconnected")
+}
+
+// jobUpdater keeps the job up to date with the mining pool, every new block
+// on the chain makes all previous jobs stale.
+func (s *Server) jobUpdater() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(jobRefreshInterval)
+	defer ticker.Stop()
+
+	var retry <-chan time.Time
+	if !s.refreshJob(true) {
+		retry = time.After(jobRetryInterval)
+	}
+
+	// the waiter is only renewed once it fires, the other wakeups reuse it
+	waiter := s.chain.BlockWaiter(s.chain.BestBlockHeight() + 1)
+	for {
+		select {
+		case <-waiter:
+			waiter = s.chain.BlockWaiter(s.chain.BestBlockHeight() + 1)
+			if !s.refreshJob(true) {
+				retry = time.After(jobRetryInterval)
+			}
+
+		case <-retry:
+			retry = nil
+			if !s.refreshJob(true) {
+				retry = time.After(jobRetryInterval)
+			}
+
+		case <-ticker.C:
+			s.refreshJob(false)
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// refreshJob fetches the latest work from the mining pool and notifies the
+// miners. It returns false when the pool has no work on top of the current
+// best block yet.
+func (s *Server) refreshJob(clean bool) bool {
+	header, err := s.pool.GetWork()
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Warning("fail on get work from mining pool")
+		return false
+	}
+
+	if header.Height <= s.chain.BestBlockHeight() {
+		return false
+	}
+
+	s.mtx.Lock()
+	if cur := s.currentJob; cur != nil && cur.header.Previous == header.Previous && cur.header.TransactionRoot == header.TransactionRoot {
+		s.mtx.Unlock()
+		return true
+	}
+
+	s.jobSeq++
+	j, err := newJob(s.jobSeq, header, s.shareTarget)
+	if err != nil {
+		s.mtx.Unlock()
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on create mining job")
+		return true
+	}
+
+	// a job on top of a new previous block always invalidates the old ones
+	if cur := s.currentJob; cur != nil && cur.header.Previous != header.Previous {
+		clean = true
+	}
+	if clean {
+		s.jobs = make(map[string]*job)
+	}
+	s.jobs[j.id] = j
+	s.currentJob = j
+
+	sessions := make([]*session, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		sessions = append(sessions, sess)
+	}
+	s.mtx.Unlock()
+
+	log.WithFields(log.Fields{"module": logModule, "job": j.id, "height": j.header.Height, "clean": clean}).Debug("new mining job")
+	for _, sess := range sessions {
+		sess.notifyJob(j, clean)
+	}
+	return true
+}
+
+// getJob returns the job by id, stale jobs are treated as missing
+func (s *Server) getJob(id string) *job {
+	s.mtx.RLock()
+	j, ok := s.jobs[id]
+	s.mtx.RUnlock()
+
+	if !ok || j.header.Height <= s.chain.BestBlockHeight() {
+		return nil
+	}
+	return j
+}
+
+func (s *Server) getCurrentJob() *job {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.currentJob
+}
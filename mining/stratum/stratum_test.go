@@ -0,0 +1,303 @@
+package stratum
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+type mockChain struct {
+	cond    *sync.Cond
+	height  uint64
+	waiters int
+}
+
+func newMockChain(height uint64) *mockChain {
+	return &mockChain{cond: sync.NewCond(new(sync.Mutex)), height: height}
+}
+
+func (c *mockChain) BestBlockHeight() uint64 {
+	c.cond.L.Lock()
+	defer c.cond.L.Unlock()
+	return c.height
+}
+
+func (c *mockChain) BlockWaiter(height uint64) <-chan struct{} {
+	c.cond.L.Lock()
+	c.waiters++
+	c.cond.L.Unlock()
+
+	ch := make(chan struct{}, 1)
+	go func() {
+		c.cond.L.Lock()
+		defer c.cond.L.Unlock()
+		for c.height < height {
+			c.cond.Wait()
+		}
+		ch <- struct{}{}
+	}()
+	return ch
+}
+
+func (c *mockChain) waiterCount() int {
+	c.cond.L.Lock()
+	defer c.cond.L.Unlock()
+	return c.waiters
+}
+
+func (c *mockChain) setHeight(height uint64) {
+	c.cond.L.Lock()
+	c.height = height
+	c.cond.L.Unlock()
+	c.cond.Broadcast()
+}
+
+type mockPool struct {
+	mtx       sync.Mutex
+	header    *types.BlockHeader
+	submitted []*types.BlockHeader
+}
+
+func (p *mockPool) GetWork() (*types.BlockHeader, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	bh := *p.header
+	return &bh, nil
+}
+
+func (p *mockPool) SubmitWork(bh *types.BlockHeader) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.submitted = append(p.submitted, bh)
+	return nil
+}
+
+func (p *mockPool) setWork(height uint64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.header = &types.BlockHeader{
+		Version:   1,
+		Height:    height,
+		Timestamp: uint64(time.Now().Unix()),
+		Previous:  types.Hash{byte(height)},
+		Proof:     &pow.WorkProof{Target: 0},
+	}
+}
+
+type client struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+	id      int
+}
+
+func dial(t *testing.T, s *Server) *client {
+	conn, err := net.Dial("tcp", s.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &client{conn: conn, scanner: bufio.NewScanner(conn)}
+}
+
+func (c *client) read(t *testing.T) map[string]interface{} {
+	c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if !c.scanner.Scan() {
+		t.Fatal("fail on read stratum message", c.scanner.Err())
+	}
+	msg := map[string]interface{}{}
+	if err := json.Unmarshal(c.scanner.Bytes(), &msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func (c *client) call(t *testing.T, method string, params ...interface{}) map[string]interface{} {
+	c.id++
+	req := map[string]interface{}{"id": c.id, "method": method, "params": params}
+	if err := json.NewEncoder(c.conn).Encode(req); err != nil {
+		t.Fatal(err)
+	}
+
+	for {
+		msg := c.read(t)
+		if msg["id"] != nil {
+			return msg
+		}
+	}
+}
+
+func (c *client) waitNotify(t *testing.T) []interface{} {
+	for {
+		msg := c.read(t)
+		if msg["method"] == "mining.notify" {
+			return msg["params"].([]interface{})
+		}
+	}
+}
+
+func errCode(msg map[string]interface{}) int {
+	e, ok := msg["error"].([]interface{})
+	if !ok {
+		return 0
+	}
+	return int(e[0].(float64))
+}
+
+func TestStratumMining(t *testing.T) {
+	chain := newMockChain(10)
+	pool := &mockPool{}
+	pool.setWork(11)
+
+	s := NewServer(chain, pool, 1)
+	if err := s.Start("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+
+	c := dial(t, s)
+	defer c.conn.Close()
+
+	if code := errCode(c.call(t, "mining.submit", "worker", "1", "0")); code != errCodeNotSubscribed {
+		t.Fatalf("submit before subscribe got error code %d", code)
+	}
+
+	resp := c.call(t, "mining.subscribe", "test-miner")
+	result := resp["result"].([]interface{})
+	extraNonce1, err := strconv.ParseUint(result[1].(string), 16, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result[2].(float64) != extraNonce2Size {
+		t.Fatalf("got extranonce2 size %v, want %d", result[2], extraNonce2Size)
+	}
+
+	notify := c.waitNotify(t)
+	jobID := notify[0].(string)
+	if notify[3].(float64) != 11 {
+		t.Fatalf("got job height %v, want 11", notify[3])
+	}
+
+	if code := errCode(c.call(t, "mining.submit", "worker", jobID, "0")); code != errCodeUnauthorized {
+		t.Fatalf("submit before authorize got error code %d", code)
+	}
+	if resp := c.call(t, "mining.authorize", "worker", "x"); resp["result"] != true {
+		t.Fatalf("authorize failed: %v", resp)
+	}
+
+	nonce := fmt.Sprintf("%016x", extraNonce1<<32|1)
+	otherNonce := fmt.Sprintf("%016x", (extraNonce1+1)<<32|1)
+	cases := []struct {
+		job   string
+		nonce string
+		code  int
+	}{
+		{job: "ff", nonce: nonce, code: errCodeJobNotFound},
+		{job: jobID, nonce: otherNonce, code: errCodeOther},
+		{job: jobID, nonce: nonce, code: 0},
+		{job: jobID, nonce: nonce, code: errCodeDuplicate},
+	}
+	for i, c2 := range cases {
+		if code := errCode(c.call(t, "mining.submit", "worker", c2.job, c2.nonce)); code != c2.code {
+			t.Errorf("case %d: got error code %d, want %d", i, code, c2.code)
+		}
+	}
+
+	pool.mtx.Lock()
+	if len(pool.submitted) != 1 || pool.submitted[0].Proof.(*pow.WorkProof).Nonce != extraNonce1<<32|1 {
+		t.Errorf("block was not submitted to the pool")
+	}
+	pool.mtx.Unlock()
+
+	// a new block makes the old job stale and pushes a clean job
+	pool.setWork(12)
+	chain.setHeight(11)
+	notify = c.waitNotify(t)
+	if notify[0].(string) == jobID || notify[4] != true {
+		t.Fatalf("expect a new clean job, got %v", notify)
+	}
+	if code := errCode(c.call(t, "mining.submit", "worker", jobID, fmt.Sprintf("%016x", extraNonce1<<32|2))); code != errCodeJobNotFound {
+		t.Errorf("stale job got error code %d", code)
+	}
+}
+
+func TestNewJobShareTarget(t *testing.T) {
+	header := &types.BlockHeader{Height: 1, Proof: &pow.WorkProof{Target: pow.BigToCompact(maxTarget)}}
+	j, err := newJob(1, header, difficultyToTarget(1<<20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if j.shareTarget.Cmp(pow.CompactToBig(header.Proof.(*pow.WorkProof).Target)) != 0 {
+		t.Errorf("share target should not be harder than the block target")
+	}
+
+	solved, err := j.solve(7, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if solved.Proof.(*pow.WorkProof).Nonce != 7 || j.header.Proof.(*pow.WorkProof).Nonce != 0 {
+		t.Errorf("solve should not touch the job header")
+	}
+}
+
+func TestJobUpdaterReuseWaiter(t *testing.T) {
+	chain := newMockChain(10)
+	pool := &mockPool{}
+	pool.setWork(10)
+
+	s := NewServer(chain, pool, 1)
+	if err := s.Start("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+
+	// the retries of the stale work keep waiting on the same block
+	time.Sleep(5 * jobRetryInterval)
+	if n := chain.waiterCount(); n != 1 {
+		t.Fatalf("got %d block waiters on retry, want 1", n)
+	}
+
+	pool.setWork(12)
+	chain.setHeight(11)
+	for start := time.Now(); s.getCurrentJob() == nil && time.Since(start) < 5*time.Second; {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := chain.waiterCount(); n != 2 {
+		t.Errorf("got %d block waiters after the new block, want 2", n)
+	}
+}
+
+func TestSetTargetOfJob(t *testing.T) {
+	chain := newMockChain(10)
+	pool := &mockPool{}
+	pool.setWork(11)
+	pool.header.Proof = &pow.WorkProof{Target: pow.BigToCompact(maxTarget)}
+
+	s := NewServer(chain, pool, 1<<20)
+	if err := s.Start("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+
+	c := dial(t, s)
+	defer c.conn.Close()
+	c.call(t, "mining.subscribe", "test-miner")
+
+	want := targetHex(pow.CompactToBig(pow.BigToCompact(maxTarget)))
+	for {
+		msg := c.read(t)
+		if msg["method"] != "mining.set_target" {
+			continue
+		}
+		if got := msg["params"].([]interface{})[0]; got != want {
+			t.Fatalf("got target %v, want the block target %s", got, want)
+		}
+		return
+	}
+}
@@ -0,0 +1,66 @@
+package netsync
+
+import (
+	"net"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/clarenous/go-capsule/p2p"
+	"github.com/clarenous/go-capsule/p2p/tor"
+)
+
+const maxAddrsPerMsg = 1000
+
+// handleGetAddrMsg sends a selection of the address book to the peer
+func (sm *SyncManager) handleGetAddrMsg(peer *peer) {
+	addrs := sm.sw.AddrBook().GetSelection(maxAddrsPerMsg)
+	if ok := peer.sendAddrs(addrs); !ok {
+		sm.peers.removePeer(peer.ID())
+	}
+}
+
+// handleAddrMsg adds the addresses gossiped by the peer to the address book
+func (sm *SyncManager) handleAddrMsg(peer *peer, msg *AddrMessage) {
+	if len(msg.Addresses) > maxAddrsPerMsg {
+		sm.peers.addBanScore(peer.ID(), 20, 0, "too many addresses")
+		return
+	}
+
+	src := p2p.NewNetAddress(peer.Addr())
+	for _, address := range msg.Addresses {
+		addr := parseGossipAddress(address)
+		if addr == nil {
+			continue
+		}
+
+		if err := sm.sw.AddrBook().AddAddress(addr, src); err != nil {
+			log.WithFields(log.Fields{"module": logModule, "address": address, "err": err}).Debug("fail on handleAddrMsg add address")
+		}
+	}
+}
+
+// parseGossipAddress parses the gossiped address of an ip literal or an onion
+// host, the hostnames are dropped without resolving them.
+func parseGossipAddress(address string) *p2p.NetAddress {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil
+	}
+
+	if tor.IsOnion(host) {
+		addr, err := p2p.NewOnionNetAddress(host, uint16(port))
+		if err != nil {
+			return nil
+		}
+		return addr
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return p2p.NewNetAddressIPPort(ip, uint16(port))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package netsync
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clarenous/go-capsule/p2p"
+)
+
+func TestSendAddrsOnce(t *testing.T) {
+	basePeer := &windowPeer{id: "peer"}
+	peer := newPeer(0, nil, basePeer)
+
+	addr, err := p2p.NewNetAddressString("1.2.3.4:46656")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !peer.sendAddrs([]*p2p.NetAddress{addr}) || !peer.sendAddrs([]*p2p.NetAddress{addr}) {
+		t.Fatal("fail on send addresses")
+	}
+	if len(basePeer.sent) != 1 {
+		t.Fatalf("got %d messages, want 1", len(basePeer.sent))
+	}
+
+	_, decoded, err := DecodeMessage(cdc.MustMarshalBinaryLengthPrefixed(basePeer.sent[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1.2.3.4:46656"}; !reflect.DeepEqual(decoded.(*AddrMessage).Addresses, want) {
+		t.Fatalf("got addresses %v, want %v", decoded.(*AddrMessage).Addresses, want)
+	}
+}
+
+func TestParseGossipAddress(t *testing.T) {
+	cases := []struct {
+		address string
+		want    string
+	}{
+		{address: "1.2.3.4:46656", want: "1.2.3.4:46656"},
+		{address: "[2001:db8::1]:46656", want: "[2001:db8::1]:46656"},
+		{address: "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion:8770", want: "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion:8770"},
+		{address: "invalid.onion:8770"},
+		{address: "localhost:46656"},
+		{address: "seed.example.com:46656"},
+		{address: "1.2.3.4:port"},
+		{address: "1.2.3.4"},
+	}
+
+	for i, c := range cases {
+		got := ""
+		if addr := parseGossipAddress(c.address); addr != nil {
+			got = addr.String()
+		}
+		if got != c.want {
+			t.Errorf("case %d: got %q, want %q", i, got, c.want)
+		}
+	}
+}
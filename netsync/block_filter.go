@@ -0,0 +1,73 @@
+package netsync
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/clarenous/go-capsule/protocol/blockfilter"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+const maxCFHeadersPerMsg = 2000
+
+// handleGetCFilterMsg sends the golomb-coded set filter of the block
+func (sm *SyncManager) handleGetCFilterMsg(peer *peer, msg *GetCFilterMessage) {
+	filter, err := sm.chain.GetBlockFilter(msg.GetHash())
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Debug("fail on handleGetCFilterMsg get block filter")
+		return
+	}
+
+	if ok := peer.sendCFilter(msg.GetHash(), filter); !ok {
+		sm.peers.removePeer(peer.ID())
+	}
+}
+
+// handleGetCFHeadersMsg sends the filter hashes of the main chain blocks from
+// the start height to the stop hash, the client chains them from the filter
+// header of the block before the start height.
+func (sm *SyncManager) handleGetCFHeadersMsg(peer *peer, msg *GetCFHeadersMessage) {
+	stopHeader, err := sm.chain.GetHeaderByHash(msg.GetStopHash())
+	if err != nil || !sm.chain.InMainChain(*msg.GetStopHash()) {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Debug("fail on handleGetCFHeadersMsg get stop header")
+		return
+	}
+	if msg.StartHeight > stopHeader.Height || stopHeader.Height-msg.StartHeight >= maxCFHeadersPerMsg {
+		sm.peers.addBanScore(peer.ID(), 0, 10, "invalid filter headers range")
+		return
+	}
+
+	prevFilterHeader := &types.Hash{}
+	if msg.StartHeight > 0 {
+		prevHeader, err := sm.chain.GetHeaderByHeight(msg.StartHeight - 1)
+		if err != nil {
+			return
+		}
+
+		prevHash := prevHeader.Hash()
+		if prevFilterHeader, err = sm.chain.GetFilterHeader(&prevHash); err != nil {
+			log.WithFields(log.Fields{"module": logModule, "err": err}).Warning("fail on handleGetCFHeadersMsg get filter header")
+			return
+		}
+	}
+
+	filterHashes := []*types.Hash{}
+	for height := msg.StartHeight; height <= stopHeader.Height; height++ {
+		header, err := sm.chain.GetHeaderByHeight(height)
+		if err != nil {
+			return
+		}
+
+		hash := header.Hash()
+		filter, err := sm.chain.GetBlockFilter(&hash)
+		if err != nil {
+			log.WithFields(log.Fields{"module": logModule, "err": err}).Warning("fail on handleGetCFHeadersMsg get block filter")
+			return
+		}
+		filterHash := blockfilter.FilterHash(filter)
+		filterHashes = append(filterHashes, &filterHash)
+	}
+
+	if ok := peer.sendCFHeaders(NewCFHeadersMessage(msg.GetStopHash(), prevFilterHeader, filterHashes)); !ok {
+		sm.peers.removePeer(peer.ID())
+	}
+}
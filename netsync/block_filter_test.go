@@ -0,0 +1,39 @@
+package netsync
+
+import (
+	"testing"
+
+	"github.com/clarenous/go-capsule/protocol/blockfilter"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+func TestCFHeadersMessage(t *testing.T) {
+	genesis := mockBlocks(nil, 0)[0]
+	blocks := []*types.Block{mockTxsBlock(genesis, mockTxs(3)), mockTxsBlock(genesis, mockTxs(2)), mockTxsBlock(genesis, mockTxs(4))}
+	prevHeader := types.Hash{1}
+
+	filterHashes, wantHeaders := []*types.Hash{}, []types.Hash{}
+	header := prevHeader
+	for _, block := range blocks {
+		filterHash := blockfilter.FilterHash(blockfilter.BuildBlockFilter(block))
+		header = blockfilter.FilterHeader(&filterHash, &header)
+		filterHashes = append(filterHashes, &filterHash)
+		wantHeaders = append(wantHeaders, header)
+	}
+
+	stopHash := blocks[2].Hash()
+	_, decoded, err := DecodeMessage(cdc.MustMarshalBinaryLengthPrefixed(NewCFHeadersMessage(&stopHash, &prevHeader, filterHashes)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := decoded.(*CFHeadersMessage).GetFilterHeaders()
+	if len(headers) != len(wantHeaders) {
+		t.Fatalf("got %d headers", len(headers))
+	}
+	for i := range headers {
+		if *headers[i] != wantHeaders[i] {
+			t.Errorf("header %d: got %v want %v", i, headers[i], wantHeaders[i])
+		}
+	}
+}
@@ -360,6 +360,17 @@ func (bk *blockKeeper) startSync() bool {
 
 	blockHeight := bk.chain.BestBlockHeight()
 	peer = bk.peers.bestPeer(consensus.SFFullNode)
+	if peer != nil && peer.Height() > blockHeight+maxBlockPerMsg {
+		bk.syncPeer = peer
+		if err := bk.headersFirstSync(); err != nil {
+			log.WithFields(log.Fields{"module": logModule, "err": err}).Warning("fail on headersFirstSync")
+			if errors.Root(err) == errPeerMisbehave || errors.Root(err) == errRequestTimeout {
+				bk.peers.errorHandler(peer.ID(), err)
+			}
+		}
+		return bk.chain.BestBlockHeight() > blockHeight
+	}
+
 	if peer != nil && peer.Height() > blockHeight {
 		bk.syncPeer = peer
 		targetHeight := blockHeight + maxBlockPerMsg
@@ -3,7 +3,7 @@ package netsync
 import (
 	"container/list"
 	"encoding/hex"
-	"encoding/json"
+	"reflect"
 	"testing"
 	"time"
 
@@ -11,9 +11,18 @@ import (
 	"github.com/clarenous/go-capsule/errors"
 	"github.com/clarenous/go-capsule/protocol/types"
 	"github.com/clarenous/go-capsule/test/mock"
-	"github.com/clarenous/go-capsule/testutil"
 )
 
+// blockHashes returns the hashes of the blocks, the blocks received from the
+// peers are decoded copies.
+func blockHashes(blocks []*types.Block) []types.Hash {
+	hashes := []types.Hash{}
+	for _, block := range blocks {
+		hashes = append(hashes, block.Hash())
+	}
+	return hashes
+}
+
 func TestAppendHeaderList(t *testing.T) {
 	blocks := mockBlocks(nil, 7)
 	cases := []struct {
@@ -69,7 +78,7 @@ func TestAppendHeaderList(t *testing.T) {
 			gotHeaders = append(gotHeaders, e.Value.(*types.BlockHeader))
 		}
 
-		if !testutil.DeepEqual(gotHeaders, c.wantHeaders) {
+		if !reflect.DeepEqual(gotHeaders, c.wantHeaders) {
 			t.Errorf("case %d: got %v want %v", i, gotHeaders, c.wantHeaders)
 		}
 	}
@@ -121,7 +130,7 @@ func TestBlockLocator(t *testing.T) {
 			want = append(want, &hash)
 		}
 
-		if got := bk.blockLocator(); !testutil.DeepEqual(got, want) {
+		if got := bk.blockLocator(); !reflect.DeepEqual(got, want) {
 			t.Errorf("case %d: got %v want %v", i, got, want)
 		}
 	}
@@ -175,8 +184,13 @@ func TestFastBlockSync(t *testing.T) {
 		},
 	}
 
+	defer func(checkpoints []consensus.Checkpoint) {
+		consensus.ActiveNetParams.Checkpoints = checkpoints
+	}(consensus.ActiveNetParams.Checkpoints)
+
 	for i, c := range cases {
 		syncTimeout = c.syncTimeout
+		consensus.ActiveNetParams.Checkpoints = []consensus.Checkpoint{*c.checkPoint}
 		a := mockSync(c.aBlocks)
 		b := mockSync(c.bBlocks)
 		netWork := NewNetWork()
@@ -203,8 +217,8 @@ func TestFastBlockSync(t *testing.T) {
 			got = append(got, block)
 		}
 
-		if !testutil.DeepEqual(got, c.want) {
-			t.Errorf("case %d: got %v want %v", i, got, c.want)
+		if !reflect.DeepEqual(blockHashes(got), blockHashes(c.want)) {
+			t.Errorf("case %d: got %v want %v", i, blockHashes(got), blockHashes(c.want))
 		}
 	}
 }
@@ -243,7 +257,7 @@ func TestLocateBlocks(t *testing.T) {
 		}
 
 		got, _ := bk.locateBlocks(locator, &c.stopHash)
-		if !testutil.DeepEqual(got, want) {
+		if !reflect.DeepEqual(got, want) {
 			t.Errorf("case %d: got %v want %v", i, got, want)
 		}
 	}
@@ -325,7 +339,7 @@ func TestLocateHeaders(t *testing.T) {
 		if err != nil != c.err {
 			t.Errorf("case %d: got %v want err = %v", i, err, c.err)
 		}
-		if !testutil.DeepEqual(got, want) {
+		if err == nil && !reflect.DeepEqual(got, want) {
 			t.Errorf("case %d: got %v want %v", i, got, want)
 		}
 	}
@@ -344,34 +358,34 @@ func TestNextCheckpoint(t *testing.T) {
 		},
 		{
 			checkPoints: []consensus.Checkpoint{
-				{10000, types.Hash{V0: 1}},
+				{Height: 10000, Hash: types.Hash{1}},
 			},
 			bestHeight: 5000,
-			want:       &consensus.Checkpoint{10000, types.Hash{V0: 1}},
+			want:       &consensus.Checkpoint{Height: 10000, Hash: types.Hash{1}},
 		},
 		{
 			checkPoints: []consensus.Checkpoint{
-				{10000, types.Hash{V0: 1}},
-				{20000, types.Hash{V0: 2}},
-				{30000, types.Hash{V0: 3}},
+				{Height: 10000, Hash: types.Hash{1}},
+				{Height: 20000, Hash: types.Hash{2}},
+				{Height: 30000, Hash: types.Hash{3}},
 			},
 			bestHeight: 15000,
-			want:       &consensus.Checkpoint{20000, types.Hash{V0: 2}},
+			want:       &consensus.Checkpoint{Height: 20000, Hash: types.Hash{2}},
 		},
 		{
 			checkPoints: []consensus.Checkpoint{
-				{10000, types.Hash{V0: 1}},
-				{20000, types.Hash{V0: 2}},
-				{30000, types.Hash{V0: 3}},
+				{Height: 10000, Hash: types.Hash{1}},
+				{Height: 20000, Hash: types.Hash{2}},
+				{Height: 30000, Hash: types.Hash{3}},
 			},
 			bestHeight: 10000,
-			want:       &consensus.Checkpoint{20000, types.Hash{V0: 2}},
+			want:       &consensus.Checkpoint{Height: 20000, Hash: types.Hash{2}},
 		},
 		{
 			checkPoints: []consensus.Checkpoint{
-				{10000, types.Hash{V0: 1}},
-				{20000, types.Hash{V0: 2}},
-				{30000, types.Hash{V0: 3}},
+				{Height: 10000, Hash: types.Hash{1}},
+				{Height: 20000, Hash: types.Hash{2}},
+				{Height: 30000, Hash: types.Hash{3}},
 			},
 			bestHeight: 35000,
 			want:       nil,
@@ -384,7 +398,7 @@ func TestNextCheckpoint(t *testing.T) {
 		mockChain.SetBestBlockHeader(&types.BlockHeader{Height: c.bestHeight})
 		bk := &blockKeeper{chain: mockChain}
 
-		if got := bk.nextCheckpoint(); !testutil.DeepEqual(got, c.want) {
+		if got := bk.nextCheckpoint(); !reflect.DeepEqual(got, c.want) {
 			t.Errorf("case %d: got %v want %v", i, got, c.want)
 		}
 	}
@@ -464,8 +478,8 @@ func TestRegularBlockSync(t *testing.T) {
 			got = append(got, block)
 		}
 
-		if !testutil.DeepEqual(got, c.want) {
-			t.Errorf("case %d: got %v want %v", i, got, c.want)
+		if !reflect.DeepEqual(blockHashes(got), blockHashes(c.want)) {
+			t.Errorf("case %d: got %v want %v", i, blockHashes(got), blockHashes(c.want))
 		}
 	}
 }
@@ -512,7 +526,7 @@ func TestRequireBlock(t *testing.T) {
 	for i, c := range cases {
 		syncTimeout = c.syncTimeout
 		got, err := c.testNode.blockKeeper.requireBlock(c.requireHeight)
-		if !testutil.DeepEqual(got, c.want) {
+		if (got == nil) != (c.want == nil) || (got != nil && got.Hash() != c.want.Hash()) {
 			t.Errorf("case %d: got %v want %v", i, got, c.want)
 		}
 		if errors.Root(err) != c.err {
@@ -548,92 +562,60 @@ func TestSendMerkleBlock(t *testing.T) {
 		},
 	}
 
-	for _, c := range cases {
-		blocks := mockBlocks(nil, 2)
-		targetBlock := blocks[1]
-		txs, bcTxs := mockTxs(c.txCount)
-		var err error
-
-		targetBlock.Transactions = txs
-		if targetBlock.TransactionsMerkleRoot, err = types.TxMerkleRoot(bcTxs); err != nil {
-			t.Fatal(err)
-		}
+	for i, c := range cases {
+		blocks := mockBlocks(nil, 1)
+		txs := mockTxs(c.txCount)
+		blocks = append(blocks, mockTxsBlock(blocks[1], txs))
+		targetBlock := blocks[2]
 
 		spvNode := mockSync(blocks)
-		blockHash := targetBlock.Hash()
-		var statusResult *types.TransactionStatus
-		if statusResult, err = spvNode.chain.GetTransactionStatus(&blockHash); err != nil {
-			t.Fatal(err)
-		}
-
-		if targetBlock.TransactionStatusHash, err = types.TxStatusMerkleRoot(statusResult.VerifyStatus); err != nil {
-			t.Fatal(err)
-		}
-
 		fullNode := mockSync(blocks)
 		netWork := NewNetWork()
 		netWork.Register(spvNode, "192.168.0.1", "spv_node", consensus.SFFastSync)
 		netWork.Register(fullNode, "192.168.0.2", "full_node", consensus.DefaultServices)
 
-		var F2S *P2PPeer
-		if F2S, _, err = netWork.HandsShake(spvNode, fullNode); err != nil {
-			t.Errorf("fail on peer hands shake %v", err)
+		F2S, _, err := netWork.HandsShake(spvNode, fullNode)
+		if err != nil {
+			t.Fatalf("fail on peer hands shake %v", err)
 		}
 
 		completed := make(chan error)
 		go func() {
 			msgBytes := <-F2S.msgCh
-			_, msg, _ := DecodeMessage(msgBytes)
-			switch m := msg.(type) {
-			case *MerkleBlockMessage:
-				var relatedTxIDs []*types.Hash
-				for _, rawTx := range m.RawTxDatas {
-					tx := &types.Tx{}
-					if err := tx.UnmarshalText(rawTx); err != nil {
-						completed <- err
-					}
-
-					relatedTxIDs = append(relatedTxIDs, &tx.ID)
-				}
-				var txHashes []*types.Hash
-				for _, hashByte := range m.TxHashes {
-					hash := types.NewHash(hashByte)
-					txHashes = append(txHashes, &hash)
-				}
-				if ok := types.ValidateTxMerkleTreeProof(txHashes, m.Flags, relatedTxIDs, targetBlock.TransactionsMerkleRoot); !ok {
-					completed <- errors.New("validate tx fail")
-				}
-
-				var statusHashes []*types.Hash
-				for _, statusByte := range m.StatusHashes {
-					hash := types.NewHash(statusByte)
-					statusHashes = append(statusHashes, &hash)
-				}
-				var relatedStatuses []*types.TxVerifyResult
-				for _, statusByte := range m.RawTxStatuses {
-					status := &types.TxVerifyResult{}
-					err := json.Unmarshal(statusByte, status)
-					if err != nil {
-						completed <- err
-					}
-					relatedStatuses = append(relatedStatuses, status)
-				}
-				if ok := types.ValidateStatusMerkleTreeProof(statusHashes, m.Flags, relatedStatuses, targetBlock.TransactionStatusHash); !ok {
-					completed <- errors.New("validate status fail")
-				}
-
-				completed <- nil
+			_, msg, err := DecodeMessage(msgBytes)
+			if err != nil {
+				completed <- err
+				return
+			}
+
+			m, ok := msg.(*MerkleBlockMessage)
+			if !ok {
+				completed <- errors.New("not a merkle block message")
+				return
+			}
+			if err := m.Validate(); err != nil {
+				completed <- err
+				return
+			}
+			if header, _ := m.GetBlockHeader(); header.Hash() != targetBlock.Hash() {
+				completed <- errors.New("merkle block of another block")
+				return
+			}
+			if len(m.RawTxDatas) != len(c.relatedTxIndex) {
+				completed <- errors.New("merkle block without the related txs")
+				return
 			}
+			completed <- nil
 		}()
 
 		spvPeer := fullNode.peers.getPeer("spv_node")
-		for i := 0; i < len(c.relatedTxIndex); i++ {
-			spvPeer.filterAdds.Add(hex.EncodeToString(txs[c.relatedTxIndex[i]].Outputs[0].ControlProgram))
+		for _, index := range c.relatedTxIndex {
+			spvPeer.filterAdds.Add(hex.EncodeToString(txs[index].Outputs[0].ScriptHash[:]))
 		}
-		msg := &GetMerkleBlockMessage{RawHash: targetBlock.Hash().Byte32()}
+		msg := &GetMerkleBlockMessage{RawHash: targetBlock.Hash()}
 		fullNode.handleGetMerkleBlockMsg(spvPeer, msg)
 		if err := <-completed; err != nil {
-			t.Fatal(err)
+			t.Fatalf("case %d: %v", i, err)
 		}
 	}
 }
@@ -0,0 +1,309 @@
+package netsync
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/clarenous/go-capsule/crypto/sha3pool"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+const (
+	shortTxIDMask           = uint64(1)<<48 - 1
+	maxCompactBlockTxs      = 100000
+	maxPendingCompactBlocks = 16
+	compactBlockTimeout     = 30 * time.Second
+)
+
+var (
+	errInvalidCompactBlock = errors.New("invalid compact block")
+	errInvalidBlockTxn     = errors.New("block txn doesn't match the missing txs")
+	errCompactBlockRoot    = errors.New("rebuilt compact block has a wrong merkle root")
+)
+
+// shortTxID returns the 48 bits id of the tx in the compact block, it is keyed
+// by the block hash and the nonce so that collisions can't be made in advance.
+func shortTxID(blockHash *types.Hash, nonce uint64, txHash *types.Hash) uint64 {
+	var buf [72]byte
+	copy(buf[:32], blockHash[:])
+	binary.LittleEndian.PutUint64(buf[32:40], nonce)
+	copy(buf[40:], txHash[:])
+
+	var hash [32]byte
+	sha3pool.Sum256(hash[:], buf[:])
+	return binary.LittleEndian.Uint64(hash[:8]) & shortTxIDMask
+}
+
+// compactBlock is a block being rebuilt from a compact block, the txs of the
+// missing indexes are nil until they are received.
+type compactBlock struct {
+	peerID    string
+	block     *types.Block
+	missing   []uint32
+	createdAt time.Time
+}
+
+// newCompactBlock fills the txs of the compact block with the mempool txs, the
+// short ids matching none or several txs are left missing.
+func newCompactBlock(peerID string, msg *CompactBlockMessage, poolTxs []*types.Tx) (*compactBlock, error) {
+	header, err := msg.GetHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(msg.ShortIDs) + len(msg.PrefilledTxs)
+	if total == 0 || total > maxCompactBlockTxs {
+		return nil, errInvalidCompactBlock
+	}
+
+	txs := make([]*types.Tx, total)
+	for _, prefilled := range msg.PrefilledTxs {
+		if int(prefilled.Index) >= total || txs[prefilled.Index] != nil {
+			return nil, errInvalidCompactBlock
+		}
+
+		tx := &types.Tx{}
+		if err := tx.UnmarshalText(prefilled.RawTx); err != nil {
+			return nil, err
+		}
+		txs[prefilled.Index] = tx
+	}
+
+	blockHash := header.Hash()
+	candidates := make(map[uint64]*types.Tx, len(poolTxs))
+	collided := make(map[uint64]bool)
+	for _, tx := range poolTxs {
+		id := shortTxID(&blockHash, msg.Nonce, tx.Hash().Ptr())
+		if _, ok := candidates[id]; ok {
+			collided[id] = true
+		}
+		candidates[id] = tx
+	}
+
+	cb := &compactBlock{
+		peerID:    peerID,
+		block:     &types.Block{BlockHeader: *header, Transactions: txs},
+		createdAt: time.Now(),
+	}
+	shortIDs := msg.ShortIDs
+	for i := range txs {
+		if txs[i] != nil {
+			continue
+		}
+
+		id := shortIDs[0]
+		shortIDs = shortIDs[1:]
+		if tx, ok := candidates[id]; ok && !collided[id] {
+			txs[i] = tx
+			continue
+		}
+		cb.missing = append(cb.missing, uint32(i))
+	}
+	return cb, nil
+}
+
+// fill puts the received txs to the missing indexes in order
+func (cb *compactBlock) fill(txs []*types.Tx) error {
+	if len(txs) != len(cb.missing) {
+		return errInvalidBlockTxn
+	}
+
+	for i, index := range cb.missing {
+		cb.block.Transactions[index] = txs[i]
+	}
+	cb.missing = nil
+	return nil
+}
+
+// verify checks the rebuilt txs against the merkle root of the header
+func (cb *compactBlock) verify() error {
+	merkleRoot, err := types.TxMerkleRoot(cb.block.Transactions)
+	if err != nil {
+		return err
+	}
+	if merkleRoot != cb.block.TransactionRoot {
+		return errCompactBlockRoot
+	}
+	return nil
+}
+
+// compactBlockPool keeps the compact blocks waiting for the missing txs and
+// the full blocks requested after rebuilding compact blocks failed.
+type compactBlockPool struct {
+	mtx           sync.Mutex
+	blocks        map[types.Hash]*compactBlock
+	fullBlockReqs map[types.Hash]string
+}
+
+func newCompactBlockPool() *compactBlockPool {
+	return &compactBlockPool{
+		blocks:        make(map[types.Hash]*compactBlock),
+		fullBlockReqs: make(map[types.Hash]string),
+	}
+}
+
+// add keeps the compact block, the expired ones are dropped and it is ignored
+// when the pool is full.
+func (p *compactBlockPool) add(cb *compactBlock) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for hash, pending := range p.blocks {
+		if time.Since(pending.createdAt) > compactBlockTimeout {
+			delete(p.blocks, hash)
+		}
+	}
+	if len(p.blocks) >= maxPendingCompactBlocks {
+		return false
+	}
+
+	p.blocks[cb.block.Hash()] = cb
+	return true
+}
+
+// take removes and returns the compact block requested from the peer
+func (p *compactBlockPool) take(hash *types.Hash, peerID string) *compactBlock {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	cb, ok := p.blocks[*hash]
+	if !ok || cb.peerID != peerID {
+		return nil
+	}
+	delete(p.blocks, *hash)
+	return cb
+}
+
+func (p *compactBlockPool) addFullBlockReq(hash *types.Hash, peerID string) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if len(p.fullBlockReqs) >= maxPendingCompactBlocks {
+		return
+	}
+	p.fullBlockReqs[*hash] = peerID
+}
+
+// takeFullBlockReq reports whether the full block has been requested from
+// the peer to replace a compact block.
+func (p *compactBlockPool) takeFullBlockReq(hash *types.Hash, peerID string) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.fullBlockReqs[*hash] != peerID {
+		return false
+	}
+	delete(p.fullBlockReqs, *hash)
+	return true
+}
+
+// handleCompactBlockMsg rebuilds the block from the mempool, the missing txs
+// are requested from the peer.
+func (sm *SyncManager) handleCompactBlockMsg(peer *peer, msg *CompactBlockMessage) {
+	header, err := msg.GetHeader()
+	if err != nil {
+		sm.peers.addBanScore(peer.ID(), 0, 10, "fail on get header from compact block")
+		return
+	}
+
+	hash := header.Hash()
+	peer.markBlock(&hash)
+	peer.setStatus(header.Height, &hash)
+	if sm.chain.InMainChain(hash) {
+		return
+	}
+
+	poolTxs := []*types.Tx{}
+	for _, txDesc := range sm.txPool.GetTransactions() {
+		poolTxs = append(poolTxs, txDesc.Tx)
+	}
+
+	cb, err := newCompactBlock(peer.ID(), msg, poolTxs)
+	if err != nil {
+		sm.peers.addBanScore(peer.ID(), 20, 0, err.Error())
+		return
+	}
+
+	if len(cb.missing) == 0 {
+		sm.processCompactBlock(peer, cb)
+		return
+	}
+
+	if !sm.compactBlocks.add(cb) {
+		sm.requestFullBlock(peer, &hash)
+		return
+	}
+	if ok := peer.getBlockTxn(&hash, cb.missing); !ok {
+		sm.peers.removePeer(peer.ID())
+	}
+}
+
+// handleGetBlockTxnMsg sends the requested txs of the block
+func (sm *SyncManager) handleGetBlockTxnMsg(peer *peer, msg *GetBlockTxnMessage) {
+	block, err := sm.chain.GetBlockByHash(msg.GetHash())
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Warning("fail on handleGetBlockTxnMsg get block from chain")
+		return
+	}
+
+	txs := []*types.Tx{}
+	for _, index := range msg.Indexes {
+		if int(index) >= len(block.Transactions) {
+			sm.peers.addBanScore(peer.ID(), 0, 10, "block txn index out of range")
+			return
+		}
+		txs = append(txs, block.Transactions[index])
+	}
+
+	ok, err := peer.sendBlockTxn(msg.GetHash(), txs)
+	if !ok {
+		sm.peers.removePeer(peer.ID())
+	}
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on handleGetBlockTxnMsg sendBlockTxn")
+	}
+}
+
+// handleBlockTxnMsg completes the compact block with the received txs, the
+// full block is requested when it still can't be rebuilt.
+func (sm *SyncManager) handleBlockTxnMsg(peer *peer, msg *BlockTxnMessage) {
+	cb := sm.compactBlocks.take(msg.GetHash(), peer.ID())
+	if cb == nil {
+		sm.peers.addBanScore(peer.ID(), 0, 10, "unsolicited block txn")
+		return
+	}
+
+	txs, err := msg.GetTransactions()
+	if err != nil {
+		sm.peers.addBanScore(peer.ID(), 0, 10, "fail on get txs from block txn")
+		return
+	}
+
+	if err := cb.fill(txs); err != nil {
+		sm.peers.addBanScore(peer.ID(), 20, 0, err.Error())
+		return
+	}
+	sm.processCompactBlock(peer, cb)
+}
+
+func (sm *SyncManager) processCompactBlock(peer *peer, cb *compactBlock) {
+	if err := cb.verify(); err != nil {
+		hash := cb.block.Hash()
+		log.WithFields(log.Fields{"module": logModule, "hash": hash.String(), "err": err}).Debug("fall back to full block")
+		sm.requestFullBlock(peer, &hash)
+		return
+	}
+
+	sm.blockFetcher.processNewBlock(&blockMsg{peerID: peer.ID(), block: cb.block})
+}
+
+func (sm *SyncManager) requestFullBlock(peer *peer, hash *types.Hash) {
+	sm.compactBlocks.addFullBlockReq(hash, peer.ID())
+	if ok := peer.getBlockByHash(hash); !ok {
+		sm.peers.removePeer(peer.ID())
+	}
+}
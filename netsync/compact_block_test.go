@@ -0,0 +1,160 @@
+package netsync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clarenous/go-capsule/consensus"
+	_ "github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+func TestCompactBlockRoundTrip(t *testing.T) {
+	block := mockTxsBlock(mockBlocks(nil, 0)[0], mockTxs(6))
+	msg, err := NewCompactBlockMessage(block, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msg.PrefilledTxs) != 1 || len(msg.ShortIDs) != 5 {
+		t.Fatalf("got %d prefilled and %d short ids", len(msg.PrefilledTxs), len(msg.ShortIDs))
+	}
+
+	_, decoded, err := DecodeMessage(cdc.MustMarshalBinaryLengthPrefixed(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg = decoded.(*CompactBlockMessage)
+
+	cases := []struct {
+		poolTxs     []*types.Tx
+		wantMissing []uint32
+	}{
+		{poolTxs: block.Transactions[1:], wantMissing: nil},
+		{poolTxs: block.Transactions[3:], wantMissing: []uint32{1, 2}},
+		{poolTxs: nil, wantMissing: []uint32{1, 2, 3, 4, 5}},
+	}
+	for i, c := range cases {
+		cb, err := newCompactBlock("peer", msg, c.poolTxs)
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if len(cb.missing) != len(c.wantMissing) {
+			t.Fatalf("case %d: got missing %v want %v", i, cb.missing, c.wantMissing)
+		}
+		for j := range cb.missing {
+			if cb.missing[j] != c.wantMissing[j] {
+				t.Errorf("case %d: got missing %v want %v", i, cb.missing, c.wantMissing)
+			}
+		}
+
+		missingTxs := []*types.Tx{}
+		for _, index := range cb.missing {
+			missingTxs = append(missingTxs, block.Transactions[index])
+		}
+		if err := cb.fill(missingTxs); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if err := cb.verify(); err != nil {
+			t.Errorf("case %d: %v", i, err)
+		}
+		if cb.block.Hash() != block.Hash() {
+			t.Errorf("case %d: rebuilt block hash mismatch", i)
+		}
+	}
+}
+
+func TestCompactBlockFallback(t *testing.T) {
+	block := mockTxsBlock(mockBlocks(nil, 0)[0], mockTxs(3))
+	msg, err := NewCompactBlockMessage(block, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cb, err := newCompactBlock("peer", msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cb.fill(block.Transactions[1:2]); err != errInvalidBlockTxn {
+		t.Errorf("got err %v want %v", err, errInvalidBlockTxn)
+	}
+	if err := cb.fill([]*types.Tx{types.MockTx(), types.MockTx()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cb.verify(); err != errCompactBlockRoot {
+		t.Errorf("got err %v want %v", err, errCompactBlockRoot)
+	}
+
+	pool := newCompactBlockPool()
+	hash := block.Hash()
+	pool.addFullBlockReq(&hash, "peer")
+	if pool.takeFullBlockReq(&hash, "other") {
+		t.Errorf("full block is requested from another peer")
+	}
+	if !pool.takeFullBlockReq(&hash, "peer") || pool.takeFullBlockReq(&hash, "peer") {
+		t.Errorf("full block request should be taken once")
+	}
+
+	msg.PrefilledTxs = append(msg.PrefilledTxs, msg.PrefilledTxs[0])
+	if _, err := newCompactBlock("peer", msg, nil); err != errInvalidCompactBlock {
+		t.Errorf("got err %v want %v", err, errInvalidCompactBlock)
+	}
+}
+
+func TestCompactBlockSync(t *testing.T) {
+	cases := []struct {
+		services     consensus.ServiceFlag
+		poolTxs      int
+		wantMsg      string
+		wantBlockTxn uint64
+	}{
+		{services: consensus.DefaultServices, poolTxs: 5, wantMsg: "CompactBlockMessage"},
+		{services: consensus.DefaultServices, poolTxs: 3, wantMsg: "CompactBlockMessage", wantBlockTxn: 1},
+		{services: consensus.DefaultServices, poolTxs: 0, wantMsg: "CompactBlockMessage", wantBlockTxn: 1},
+		{services: consensus.SFFullNode | consensus.SFFastSync, poolTxs: 5, wantMsg: "MineBlockMessage"},
+	}
+
+	for i, c := range cases {
+		blocks := mockBlocks(nil, 1)
+		block := mockTxsBlock(blocks[1], mockTxs(6))
+		a := mockSync(blocks)
+		b := mockSync(blocks)
+		if _, err := a.chain.ProcessBlock(block); err != nil {
+			t.Fatal(err)
+		}
+		for _, tx := range block.Transactions[1 : c.poolTxs+1] {
+			if _, err := b.txPool.ProcessTransaction(tx, 1); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		netWork := NewNetWork()
+		netWork.Register(a, "192.168.0.1", "test node A", consensus.DefaultServices)
+		netWork.Register(b, "192.168.0.2", "test node B", c.services)
+		B2A, A2B, err := netWork.HandsShake(a, b)
+		if err != nil {
+			t.Fatalf("fail on peer hands shake %v", err)
+		}
+		go B2A.postMan()
+		go A2B.postMan()
+
+		if err := a.peers.broadcastMinedBlock(block); err != nil {
+			t.Fatal(err)
+		}
+		for start := time.Now(); b.chain.BestBlockHeight() != block.Height; time.Sleep(10 * time.Millisecond) {
+			if time.Since(start) > 5*time.Second {
+				t.Fatalf("case %d: block is not synced", i)
+			}
+		}
+
+		if got, err := b.chain.GetBlockByHeight(block.Height); err != nil || got.Hash() != block.Hash() {
+			t.Errorf("case %d: got block %v err %v", i, got, err)
+		}
+		sent := a.peers.getPeer("test node B").msgsSent.snapshot()
+		if sent[c.wantMsg] != 1 {
+			t.Errorf("case %d: got sent messages %v, want %s", i, sent, c.wantMsg)
+		}
+		if sent["BlockTxnMessage"] != c.wantBlockTxn {
+			t.Errorf("case %d: got %d block txn messages, want %d", i, sent["BlockTxnMessage"], c.wantBlockTxn)
+		}
+	}
+}
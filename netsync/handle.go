@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/tendermint/go-crypto"
 	"reflect"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -11,7 +12,9 @@ import (
 	"github.com/clarenous/go-capsule/consensus"
 	"github.com/clarenous/go-capsule/event"
 	"github.com/clarenous/go-capsule/p2p"
+	"github.com/clarenous/go-capsule/p2p/connection"
 	core "github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/protocol/state"
 	"github.com/clarenous/go-capsule/protocol/types"
 )
 
@@ -26,12 +29,28 @@ var (
 	errVaultModeDialPeer = errors.New("can't dial peer in vault mode")
 )
 
+// requestServices are the services the node must advertise to serve the requests
+var requestServices = map[reflect.Type]consensus.ServiceFlag{
+	reflect.TypeOf(&GetHeadersMessage{}):     consensus.SFFastSync,
+	reflect.TypeOf(&GetBlockTxnMessage{}):    consensus.SFCompactBlock,
+	reflect.TypeOf(&GetTxsMessage{}):         consensus.SFTxRelay,
+	reflect.TypeOf(&FilterLoadMessage{}):     consensus.SFSPV,
+	reflect.TypeOf(&FilterAddMessage{}):      consensus.SFSPV,
+	reflect.TypeOf(&FilterClearMessage{}):    consensus.SFSPV,
+	reflect.TypeOf(&GetMerkleBlockMessage{}): consensus.SFSPV,
+	reflect.TypeOf(&GetCFilterMessage{}):     consensus.SFBlockFilter,
+	reflect.TypeOf(&GetCFHeadersMessage{}):   consensus.SFBlockFilter,
+}
+
 // Chain is the interface for Bytom core
 type Chain interface {
 	BestBlockHeader() *types.BlockHeader
 	BestBlockHeight() uint64
 	GetBlockByHash(*types.Hash) (*types.Block, error)
 	GetBlockByHeight(uint64) (*types.Block, error)
+	GetBlockFilter(*types.Hash) ([]byte, error)
+	GetBlockNode(*types.Hash) (*state.BlockNode, error)
+	GetFilterHeader(*types.Hash) (*types.Hash, error)
 	GetHeaderByHash(*types.Hash) (*types.BlockHeader, error)
 	GetHeaderByHeight(uint64) (*types.BlockHeader, error)
 	InMainChain(types.Hash) bool
@@ -42,6 +61,11 @@ type Chain interface {
 type Switch interface {
 	AddReactor(name string, reactor p2p.Reactor) p2p.Reactor
 	AddBannedPeer(string) error
+	AddrBook() *p2p.AddrBook
+	BanPeer(string, time.Duration) error
+	BannedPeers() map[string]time.Time
+	Bandwidth() *connection.Bandwidth
+	UnbanPeer(string) error
 	StopPeerGracefully(string)
 	NodeInfo() *p2p.NodeInfo
 	Start() error
@@ -61,6 +85,8 @@ type SyncManager struct {
 	blockKeeper  *blockKeeper
 	peers        *peerSet
 
+	compactBlocks *compactBlockPool
+
 	txSyncCh chan *txSyncMsg
 	quitSync chan struct{}
 	config   *cfg.Config
@@ -95,6 +121,7 @@ func newSyncManager(config *cfg.Config, sw Switch, chain Chain, txPool *core.TxP
 		blockFetcher:    newBlockFetcher(chain, peers),
 		blockKeeper:     newBlockKeeper(chain, peers),
 		peers:           peers,
+		compactBlocks:   newCompactBlockPool(),
 		txSyncCh:        make(chan *txSyncMsg),
 		quitSync:        make(chan struct{}),
 		config:          config,
@@ -108,6 +135,21 @@ func newSyncManager(config *cfg.Config, sw Switch, chain Chain, txPool *core.TxP
 	return manager, nil
 }
 
+// BanPeer bans the ip for the duration and disconnects the peers from it.
+func (sm *SyncManager) BanPeer(ip string, duration time.Duration) error {
+	if err := sm.sw.BanPeer(ip, duration); err != nil {
+		return err
+	}
+
+	sm.peers.removePeersByBanKey(ip)
+	return nil
+}
+
+// BannedPeers returns the banned ips and the time their bans end.
+func (sm *SyncManager) BannedPeers() map[string]time.Time {
+	return sm.sw.BannedPeers()
+}
+
 //BestPeer return the highest p2p peerInfo
 func (sm *SyncManager) BestPeer() *PeerInfo {
 	bestPeer := sm.peers.bestPeer(consensus.SFFullNode)
@@ -134,6 +176,20 @@ func (sm *SyncManager) GetPeerInfos() []*PeerInfo {
 	return sm.peers.getPeerInfos()
 }
 
+// GetNetTotals return the traffic and the rate limits of all the peers
+func (sm *SyncManager) GetNetTotals() *NetTotals {
+	bandwidth := sm.sw.Bandwidth()
+	sentStatus, receivedStatus := bandwidth.TrafficStatus()
+	return &NetTotals{
+		TotalSent:           sentStatus.Bytes,
+		TotalReceived:       receivedStatus.Bytes,
+		CurrentSentRate:     sentStatus.CurRate,
+		CurrentReceivedRate: receivedStatus.CurRate,
+		SendRateLimit:       bandwidth.SendRate,
+		RecvRateLimit:       bandwidth.RecvRate,
+	}
+}
+
 //IsCaughtUp check wheather the peer finish the sync
 func (sm *SyncManager) IsCaughtUp() bool {
 	peer := sm.peers.bestPeer(consensus.SFFullNode)
@@ -149,11 +205,22 @@ func (sm *SyncManager) StopPeer(peerID string) error {
 	return nil
 }
 
+// UnbanPeer removes the ip from the blacklist.
+func (sm *SyncManager) UnbanPeer(ip string) error {
+	return sm.sw.UnbanPeer(ip)
+}
+
 func (sm *SyncManager) handleBlockMsg(peer *peer, msg *BlockMessage) {
 	block, err := msg.GetBlock()
 	if err != nil {
 		return
 	}
+
+	hash := block.Hash()
+	if sm.compactBlocks.takeFullBlockReq(&hash, peer.ID()) {
+		sm.blockFetcher.processNewBlock(&blockMsg{peerID: peer.ID(), block: block})
+		return
+	}
 	sm.blockKeeper.processBlock(peer.ID(), block)
 }
 
@@ -232,6 +299,28 @@ func (sm *SyncManager) handleGetBlocksMsg(peer *peer, msg *GetBlocksMessage) {
 	}
 }
 
+func (sm *SyncManager) handleGetMerkleBlockMsg(peer *peer, msg *GetMerkleBlockMessage) {
+	var block *types.Block
+	var err error
+	if msg.Height != 0 {
+		block, err = sm.chain.GetBlockByHeight(msg.Height)
+	} else {
+		block, err = sm.chain.GetBlockByHash(msg.GetHash())
+	}
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Warning("fail on handleGetMerkleBlockMsg get block from chain")
+		return
+	}
+
+	ok, err := peer.sendMerkleBlock(block)
+	if !ok {
+		sm.peers.removePeer(peer.ID())
+	}
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on handleGetMerkleBlockMsg sendMerkleBlock")
+	}
+}
+
 func (sm *SyncManager) handleGetHeadersMsg(peer *peer, msg *GetHeadersMessage) {
 	headers, err := sm.blockKeeper.locateHeaders(msg.GetBlockLocator(), msg.GetStopHash())
 	if err != nil || len(headers) == 0 {
@@ -297,15 +386,32 @@ func (sm *SyncManager) handleStatusResponseMsg(basePeer BasePeer, msg *StatusRes
 	}
 
 	sm.peers.addPeer(basePeer, msg.Height, msg.GetHash())
+	if peer := sm.peers.getPeer(basePeer.ID()); peer != nil && !peer.isSPVNode() {
+		peer.getAddrs()
+	}
 }
 
 func (sm *SyncManager) handleTransactionMsg(peer *peer, msg *TransactionMessage) {
+	if len(msg.RawTx) > maxTxMsgSize {
+		sm.peers.addBanScore(peer.ID(), 20, 0, "oversized tx")
+		return
+	}
+
 	tx, err := msg.GetTransaction()
 	if err != nil {
 		sm.peers.addBanScore(peer.ID(), 0, 10, "fail on get tx from message")
 		return
 	}
 
+	// the peers relaying by inventory send the requested txs only, the legacy
+	// peers push the txs
+	hash := tx.Hash().Ptr()
+	if !peer.fulfillTxRequest(hash) && peer.isTxRelayNode() {
+		sm.peers.addBanScore(peer.ID(), 0, 10, "unsolicited tx")
+		return
+	}
+	peer.markTransaction(hash)
+
 	if isOrphan, err := sm.chain.ValidateTx(tx); err != nil && err != core.ErrDustTx && !isOrphan {
 		sm.peers.addBanScore(peer.ID(), 10, 0, "fail on validate tx transaction")
 	}
@@ -332,6 +438,21 @@ func (sm *SyncManager) PeerCount() int {
 	return len(sm.sw.Peers().List())
 }
 
+// PeerCounts returns the number of the outbound and the inbound peers
+func (sm *SyncManager) PeerCounts() (outbound, inbound int) {
+	if sm.config.VaultMode {
+		return 0, 0
+	}
+	for _, peer := range sm.sw.Peers().List() {
+		if peer.IsOutbound() {
+			outbound++
+		} else {
+			inbound++
+		}
+	}
+	return
+}
+
 func (sm *SyncManager) processMsg(basePeer BasePeer, msgType byte, msg BlockchainMessage) {
 	peer := sm.peers.getPeer(basePeer.ID())
 	if peer == nil && msgType != StatusResponseByte && msgType != StatusRequestByte {
@@ -345,6 +466,15 @@ func (sm *SyncManager) processMsg(basePeer BasePeer, msgType byte, msg Blockchai
 		"message": msg.String(),
 	}).Info("receive message from peer")
 
+	if peer != nil {
+		peer.msgsReceived.add(msg)
+	}
+
+	if flag, ok := requestServices[reflect.TypeOf(msg)]; ok && !sm.sw.NodeInfo().ServiceFlag().IsEnable(flag) {
+		log.WithFields(log.Fields{"module": logModule, "peer": basePeer.Addr(), "type": reflect.TypeOf(msg)}).Debug("ignore request of the service not advertised")
+		return
+	}
+
 	switch msg := msg.(type) {
 	case *GetBlockMessage:
 		sm.handleGetBlockMsg(peer, msg)
@@ -358,12 +488,33 @@ func (sm *SyncManager) processMsg(basePeer BasePeer, msgType byte, msg Blockchai
 	case *StatusResponseMessage:
 		sm.handleStatusResponseMsg(basePeer, msg)
 
+	case *GetAddrMessage:
+		sm.handleGetAddrMsg(peer)
+
+	case *AddrMessage:
+		sm.handleAddrMsg(peer, msg)
+
 	case *TransactionMessage:
 		sm.handleTransactionMsg(peer, msg)
 
+	case *TxInvMessage:
+		sm.handleTxInvMsg(peer, msg)
+
+	case *GetTxsMessage:
+		sm.handleGetTxsMsg(peer, msg)
+
 	case *MineBlockMessage:
 		sm.handleMineBlockMsg(peer, msg)
 
+	case *CompactBlockMessage:
+		sm.handleCompactBlockMsg(peer, msg)
+
+	case *GetBlockTxnMessage:
+		sm.handleGetBlockTxnMsg(peer, msg)
+
+	case *BlockTxnMessage:
+		sm.handleBlockTxnMsg(peer, msg)
+
 	case *GetHeadersMessage:
 		sm.handleGetHeadersMsg(peer, msg)
 
@@ -385,6 +536,15 @@ func (sm *SyncManager) processMsg(basePeer BasePeer, msgType byte, msg Blockchai
 	case *FilterClearMessage:
 		sm.handleFilterClearMsg(peer)
 
+	case *GetMerkleBlockMessage:
+		sm.handleGetMerkleBlockMsg(peer, msg)
+
+	case *GetCFilterMessage:
+		sm.handleGetCFilterMsg(peer, msg)
+
+	case *GetCFHeadersMessage:
+		sm.handleGetCFHeadersMsg(peer, msg)
+
 	default:
 		log.WithFields(log.Fields{
 			"module":       logModule,
@@ -0,0 +1,317 @@
+package netsync
+
+import (
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/clarenous/go-capsule/consensus"
+	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/protocol/state"
+	"github.com/clarenous/go-capsule/protocol/types"
+	"github.com/clarenous/go-capsule/protocol/validation"
+)
+
+const (
+	// maxHeadersPerSync is the maximum headers downloaded in one round of the
+	// headers-first sync, the rest is synced in the next round.
+	maxHeadersPerSync = 32768
+	// maxWindowsAhead is the maximum windows downloaded ahead of the block
+	// being processed, it limits the memory of the downloaded blocks.
+	maxWindowsAhead  = 16
+	maxSyncPeers     = 8
+	maxWindowFailure = 3
+	windowCheckCycle = time.Second
+)
+
+var (
+	errLessWorkHeaders = errors.New("header chain has less work than the best chain")
+	errWindowMismatch  = errors.New("blocks don't match the requested window")
+)
+
+// blockWindow is a range of the header chain downloaded from a single peer
+type blockWindow struct {
+	start       int
+	headers     []*types.BlockHeader
+	blocks      []*types.Block
+	peerID      string
+	requestedAt time.Time
+}
+
+func (w *blockWindow) done() bool {
+	return len(w.blocks) == len(w.headers)
+}
+
+// matches reports whether the blocks reply the outstanding request of the
+// window, the late replies of the earlier requests don't match.
+func (w *blockWindow) matches(blocks []*types.Block) bool {
+	return len(blocks) > 0 && blocks[0].Previous == w.headers[len(w.blocks)].Previous
+}
+
+// syncPeerStats ranks the peers of a headers-first sync by their response time
+type syncPeerStats struct {
+	peer     *peer
+	window   *blockWindow
+	latency  time.Duration
+	failures int
+}
+
+// headersFirstSync downloads and validates the header chain of the sync peer,
+// then downloads the blocks in parallel windows from several peers and feeds
+// them in order to the chain while the next windows are downloading.
+func (bk *blockKeeper) headersFirstSync() error {
+	headers, err := bk.requireHeaderChain()
+	if err != nil {
+		return err
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+
+	windows := []*blockWindow{}
+	for start := 0; start < len(headers); start += int(maxBlockPerMsg) {
+		end := start + int(maxBlockPerMsg)
+		if end > len(headers) {
+			end = len(headers)
+		}
+		windows = append(windows, &blockWindow{start: start, headers: headers[start:end]})
+	}
+	return bk.downloadWindows(windows)
+}
+
+// requireHeaderChain downloads the headers from the sync peer until its best
+// block, the headers are validated against the proof of their parents and the
+// chain must have more work than the local best chain.
+func (bk *blockKeeper) requireHeaderChain() ([]*types.BlockHeader, error) {
+	bestHeader := bk.chain.BestBlockHeader()
+	bestHash := bestHeader.Hash()
+	bestNode, err := bk.chain.GetBlockNode(&bestHash)
+	if err != nil {
+		return nil, err
+	}
+
+	stopHash := bk.syncPeer.Hash()
+	locator := bk.blockLocator()
+	headers := []*types.BlockHeader{}
+	var parent *state.BlockNode
+	for len(headers) < maxHeadersPerSync {
+		batch, err := bk.requireHeaders(locator, stopHash)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		if parent == nil {
+			if parent, err = bk.chain.GetBlockNode(&batch[0].Previous); err != nil {
+				return nil, errors.Wrap(errPeerMisbehave, "header chain doesn't connect to the local chain")
+			}
+		}
+
+		for _, header := range batch {
+			if err := validation.ValidateBlockHeader(&types.Block{BlockHeader: *header}, parent); err != nil {
+				return nil, errors.Wrap(errPeerMisbehave, err.Error())
+			}
+			if parent, err = state.NewBlockNode(header, parent); err != nil {
+				return nil, err
+			}
+		}
+
+		headers = append(headers, batch...)
+		lastHash := parent.Hash
+		if lastHash == *stopHash {
+			break
+		}
+		locator = []*types.Hash{&lastHash}
+	}
+
+	if len(headers) == 0 {
+		return headers, nil
+	}
+	if parent.WorkSum.Cmp(bestNode.WorkSum) <= 0 && len(headers) < maxHeadersPerSync {
+		return nil, errors.Wrap(errPeerMisbehave, errLessWorkHeaders.Error())
+	}
+
+	// the headers already in the main chain are skipped
+	for len(headers) > 0 && bk.chain.InMainChain(headers[0].Hash()) {
+		headers = headers[1:]
+	}
+	return headers, nil
+}
+
+// downloadWindows assigns the windows to the idle peers ranked by latency and
+// hands the downloaded blocks in order to the block processor. The stalled
+// windows are assigned to other peers and the peers failing repeatedly are
+// dropped from the sync for good.
+func (bk *blockKeeper) downloadWindows(windows []*blockWindow) error {
+	stats := make(map[string]*syncPeerStats)
+	dropped := make(map[string]bool)
+	pending := append([]*blockWindow{}, windows...)
+	handed, nextBlock := 0, 0
+
+	processCh := make(chan *blockMsg, maxWindowsAhead*int(maxBlockPerMsg))
+	errCh := make(chan error, 1)
+	go bk.blockProcessor(processCh, errCh)
+
+	checkTicker := time.NewTicker(windowCheckCycle)
+	defer checkTicker.Stop()
+
+	for handed < len(windows) {
+		bk.refreshSyncPeers(stats, dropped, windows[len(windows)-1])
+		if len(stats) == 0 {
+			close(processCh)
+			return errors.Wrap(errPeerDropped, "no peer to download blocks")
+		}
+		pending = bk.assignWindows(stats, dropped, pending, windows[handed].start)
+
+		select {
+		case msg := <-bk.blocksProcessCh:
+			s, ok := stats[msg.peerID]
+			if !ok || s.window == nil || !s.window.matches(msg.blocks) {
+				continue
+			}
+
+			window := s.window
+			s.window = nil
+			if err := fillWindow(window, msg.blocks); err != nil {
+				bk.dropSyncPeer(stats, dropped, msg.peerID, err)
+				pending = append([]*blockWindow{window}, pending...)
+				continue
+			}
+
+			s.latency = (s.latency + time.Since(window.requestedAt)) / 2
+			if !window.done() {
+				pending = append([]*blockWindow{window}, pending...)
+			}
+
+		case <-checkTicker.C:
+			for peerID, s := range stats {
+				if s.window == nil || time.Since(s.window.requestedAt) < syncTimeout {
+					continue
+				}
+
+				log.WithFields(log.Fields{"module": logModule, "peer": peerID, "start": s.window.headers[0].Height}).Debug("block window stalled")
+				pending = append([]*blockWindow{s.window}, pending...)
+				s.window = nil
+				s.latency += syncTimeout
+				if s.failures++; s.failures >= maxWindowFailure {
+					bk.dropSyncPeer(stats, dropped, peerID, errRequestTimeout)
+				}
+			}
+
+		case err := <-errCh:
+			close(processCh)
+			return err
+		}
+
+	handOff:
+		for handed < len(windows) && nextBlock < len(windows[handed].blocks) {
+			window := windows[handed]
+			select {
+			case processCh <- &blockMsg{block: window.blocks[nextBlock], peerID: window.peerID}:
+			default:
+				break handOff
+			}
+
+			if nextBlock++; nextBlock == len(window.headers) {
+				window.blocks = nil
+				handed, nextBlock = handed+1, 0
+			}
+		}
+	}
+
+	close(processCh)
+	return <-errCh
+}
+
+// blockProcessor processes the downloaded blocks in order until processCh is
+// closed, the peer sending an invalid block is punished and the sync stops.
+func (bk *blockKeeper) blockProcessor(processCh <-chan *blockMsg, errCh chan<- error) {
+	for msg := range processCh {
+		if _, err := bk.chain.ProcessBlock(msg.block); err != nil {
+			bk.peers.addBanScore(msg.peerID, 20, 0, err.Error())
+			errCh <- errors.Wrap(err, "fail on headersFirstSync process block")
+			return
+		}
+	}
+	errCh <- nil
+}
+
+// refreshSyncPeers adds the full node peers having all the windows to the sync
+// and removes the disconnected ones, the dropped peers are not added again.
+func (bk *blockKeeper) refreshSyncPeers(stats map[string]*syncPeerStats, dropped map[string]bool, last *blockWindow) {
+	height := last.headers[len(last.headers)-1].Height
+	peers := bk.peers.peersWithHeight(consensus.SFFullNode, height)
+	connected := make(map[string]bool, len(peers))
+	for _, peer := range peers {
+		connected[peer.ID()] = true
+		if _, ok := stats[peer.ID()]; !ok && !dropped[peer.ID()] && len(stats) < maxSyncPeers {
+			stats[peer.ID()] = &syncPeerStats{peer: peer}
+		}
+	}
+
+	for peerID, s := range stats {
+		if !connected[peerID] && s.window == nil {
+			delete(stats, peerID)
+		}
+	}
+}
+
+// assignWindows requests the pending windows from the idle peers, the faster
+// peers are served first. Only the windows not too far ahead of the block
+// being processed are assigned.
+func (bk *blockKeeper) assignWindows(stats map[string]*syncPeerStats, dropped map[string]bool, pending []*blockWindow, processing int) []*blockWindow {
+	idle := []*syncPeerStats{}
+	for _, s := range stats {
+		if s.window == nil {
+			idle = append(idle, s)
+		}
+	}
+	sort.Slice(idle, func(i, j int) bool { return idle[i].latency < idle[j].latency })
+
+	for _, s := range idle {
+		if len(pending) == 0 || pending[0].start-processing >= maxWindowsAhead*int(maxBlockPerMsg) {
+			break
+		}
+
+		window := pending[0]
+		first := window.headers[len(window.blocks)]
+		last := window.headers[len(window.headers)-1]
+		locator, stopHash := first.Previous, last.Hash()
+		if ok := s.peer.getBlocks([]*types.Hash{&locator}, &stopHash); !ok {
+			bk.dropSyncPeer(stats, dropped, s.peer.ID(), errPeerDropped)
+			continue
+		}
+
+		window.peerID = s.peer.ID()
+		window.requestedAt = time.Now()
+		s.window = window
+		pending = pending[1:]
+	}
+	return pending
+}
+
+func (bk *blockKeeper) dropSyncPeer(stats map[string]*syncPeerStats, dropped map[string]bool, peerID string, err error) {
+	log.WithFields(log.Fields{"module": logModule, "peer": peerID, "err": err}).Warning("drop peer from headers-first sync")
+	delete(stats, peerID)
+	dropped[peerID] = true
+	bk.peers.errorHandler(peerID, err)
+}
+
+// fillWindow appends the blocks matching the next headers of the window, the
+// peer may send less blocks than requested due to the message size limit.
+func fillWindow(window *blockWindow, blocks []*types.Block) error {
+	if len(blocks) == 0 || len(window.blocks)+len(blocks) > len(window.headers) {
+		return errors.Wrap(errPeerMisbehave, errWindowMismatch.Error())
+	}
+
+	for i, block := range blocks {
+		if block.Hash() != window.headers[len(window.blocks)+i].Hash() {
+			return errors.Wrap(errPeerMisbehave, errWindowMismatch.Error())
+		}
+	}
+	window.blocks = append(window.blocks, blocks...)
+	return nil
+}
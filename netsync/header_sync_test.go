@@ -0,0 +1,146 @@
+package netsync
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/tendermint/tmlibs/flowrate"
+
+	"github.com/clarenous/go-capsule/consensus"
+	_ "github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/p2p/connection"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+type windowPeer struct {
+	id   string
+	sent []BlockchainMessage
+}
+
+func (p *windowPeer) Addr() net.Addr                            { return &net.IPAddr{IP: net.ParseIP("127.0.0.1")} }
+func (p *windowPeer) BanKey() string                            { return "127.0.0.1" }
+func (p *windowPeer) ChannelStatus() []connection.ChannelStatus { return nil }
+func (p *windowPeer) ID() string                                { return p.id }
+func (p *windowPeer) Latency() time.Duration                    { return 0 }
+func (p *windowPeer) ServiceFlag() consensus.ServiceFlag        { return consensus.SFFullNode }
+func (p *windowPeer) TrafficStatus() (*flowrate.Status, *flowrate.Status) {
+	return nil, nil
+}
+
+func (p *windowPeer) TrySend(b byte, msg interface{}) bool {
+	p.sent = append(p.sent, msg.(struct{ BlockchainMessage }).BlockchainMessage)
+	return true
+}
+
+func mockWindowBlocks(count int) []*types.Block {
+	blocks := []*types.Block{}
+	previous := types.Hash{}
+	for i := 0; i < count; i++ {
+		block := &types.Block{BlockHeader: *types.MockBlockHeader()}
+		block.Height = uint64(i + 1)
+		block.Previous = previous
+		previous = block.Hash()
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func TestFillWindow(t *testing.T) {
+	blocks := mockWindowBlocks(4)
+	window := &blockWindow{}
+	for _, block := range blocks {
+		window.headers = append(window.headers, &block.BlockHeader)
+	}
+
+	if err := fillWindow(window, blocks[1:2]); err == nil {
+		t.Errorf("blocks out of the window order are accepted")
+	}
+	if err := fillWindow(window, blocks[:2]); err != nil || window.done() {
+		t.Fatalf("got err %v, done %v", err, window.done())
+	}
+	if err := fillWindow(window, nil); err == nil {
+		t.Errorf("empty response is accepted")
+	}
+	if err := fillWindow(window, blocks[2:]); err != nil || !window.done() {
+		t.Fatalf("got err %v, done %v", err, window.done())
+	}
+	if err := fillWindow(window, blocks[:1]); err == nil {
+		t.Errorf("blocks over the window are accepted")
+	}
+}
+
+func TestWindowMatches(t *testing.T) {
+	blocks := mockWindowBlocks(4)
+	window := &blockWindow{}
+	for _, block := range blocks {
+		window.headers = append(window.headers, &block.BlockHeader)
+	}
+
+	if !window.matches(blocks[:2]) || window.matches(blocks[1:2]) || window.matches(nil) {
+		t.Errorf("reply doesn't match the request of the window")
+	}
+	if err := fillWindow(window, blocks[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if window.matches(blocks[:2]) || !window.matches(blocks[2:]) {
+		t.Errorf("late reply matches the request of the window")
+	}
+}
+
+func TestAssignWindows(t *testing.T) {
+	blocks := mockWindowBlocks(int(maxBlockPerMsg) * (maxWindowsAhead + 2))
+	windows := []*blockWindow{}
+	for start := 0; start < len(blocks); start += int(maxBlockPerMsg) {
+		window := &blockWindow{start: start}
+		for _, block := range blocks[start : start+int(maxBlockPerMsg)] {
+			window.headers = append(window.headers, &block.BlockHeader)
+		}
+		windows = append(windows, window)
+	}
+
+	bk := &blockKeeper{peers: newPeerSet(nil)}
+	stats := make(map[string]*syncPeerStats)
+	basePeers := map[string]*windowPeer{}
+	for i, id := range []string{"slow", "fast", "busy"} {
+		basePeers[id] = &windowPeer{id: id}
+		stats[id] = &syncPeerStats{peer: newPeer(0, nil, basePeers[id]), latency: time.Duration(10-i) * time.Second}
+	}
+	stats["busy"].window = windows[0]
+
+	pending := bk.assignWindows(stats, map[string]bool{}, windows[1:], 0)
+	if len(pending) != len(windows)-3 {
+		t.Fatalf("got %d pending windows", len(pending))
+	}
+	if stats["fast"].window != windows[1] || stats["slow"].window != windows[2] {
+		t.Errorf("the faster peer should get the earlier window")
+	}
+	if msg, ok := basePeers["fast"].sent[0].(*GetBlocksMessage); !ok || *msg.GetStopHash() != blocks[2*maxBlockPerMsg-1].Hash() {
+		t.Errorf("got request %v", basePeers["fast"].sent)
+	}
+
+	stats["fast"].window, stats["slow"].window = nil, nil
+	pending = bk.assignWindows(stats, map[string]bool{}, windows[maxWindowsAhead-1:], 0)
+	if len(pending) != 2 || stats["fast"].window != windows[maxWindowsAhead-1] || stats["slow"].window != nil {
+		t.Errorf("windows too far ahead are assigned, %d pending", len(pending))
+	}
+}
+
+func TestRefreshSyncPeers(t *testing.T) {
+	blocks := mockWindowBlocks(4)
+	window := &blockWindow{}
+	for _, block := range blocks {
+		window.headers = append(window.headers, &block.BlockHeader)
+	}
+
+	bk := &blockKeeper{peers: newPeerSet(nil)}
+	bk.peers.addPeer(&windowPeer{id: "dropped"}, 4, nil)
+	bk.peers.addPeer(&windowPeer{id: "synced"}, 4, nil)
+	bk.peers.addPeer(&windowPeer{id: "behind"}, 3, nil)
+
+	stats := make(map[string]*syncPeerStats)
+	bk.refreshSyncPeers(stats, map[string]bool{"dropped": true}, window)
+	if _, ok := stats["synced"]; !ok || len(stats) != 1 {
+		t.Errorf("got sync peers %v", stats)
+	}
+}
@@ -0,0 +1,460 @@
+package netsync
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	cfg "github.com/clarenous/go-capsule/config"
+	"github.com/clarenous/go-capsule/consensus"
+	"github.com/clarenous/go-capsule/p2p"
+	core "github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+const (
+	// lightServices are the services of the peers the light client syncs from
+	lightServices = consensus.SFFullNode | consensus.SFSPV
+
+	// maxPendingProofs is the max number of the merkle blocks requested above
+	// the processed ones.
+	maxPendingProofs = 128
+)
+
+var errNoFullPeer = errors.New("no full node peer to sync headers")
+
+// LightChain is the interface for the header only chain of the light client
+type LightChain interface {
+	BestBlockHeader() *types.BlockHeader
+	BestBlockHeight() uint64
+	GetHeaderByHash(*types.Hash) (*types.BlockHeader, error)
+	GetHeaderByHeight(uint64) (*types.BlockHeader, error)
+	GetProofStatus() *core.BlockStoreState
+	InMainChain(types.Hash) bool
+	ProcessHeaders([]*types.BlockHeader) error
+	ProcessMerkleProof(*types.Hash, []*types.Hash, []uint8, []*types.Tx) error
+	SaveProofStatus(uint64, *types.Hash) error
+}
+
+// merkleRequest is a merkle block requested from the sync peer
+type merkleRequest struct {
+	hash        types.Hash
+	requestTime time.Time
+	received    bool
+}
+
+// LightSyncManager syncs the headers from the full node peers and fetches the
+// merkle blocks of the watched addresses and evidence digests.
+type LightSyncManager struct {
+	sw          Switch
+	genesisHash types.Hash
+	chain       LightChain
+	peers       *peerSet
+	watched     [][]byte
+
+	// proofHeight is the height of the last merkle block processed with all
+	// the ones below it, the merkle blocks between it and nextProofHeight are
+	// pending and requested again on timeout.
+	proofMtx        sync.Mutex
+	proofHeight     uint64
+	proofHash       types.Hash
+	nextProofHeight uint64
+	pendingProofs   map[uint64]*merkleRequest
+	headersCh       chan *headersMsg
+	quitSync        chan struct{}
+	config          *cfg.Config
+}
+
+// NewLightSyncManager create light sync manager and set switch.
+func NewLightSyncManager(config *cfg.Config, chain LightChain, watched [][]byte) (*LightSyncManager, error) {
+	sw, err := p2p.NewSwitch(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLightSyncManager(config, sw, chain, watched)
+}
+
+func newLightSyncManager(config *cfg.Config, sw Switch, chain LightChain, watched [][]byte) (*LightSyncManager, error) {
+	genesisHeader, err := chain.GetHeaderByHeight(0)
+	if err != nil {
+		return nil, err
+	}
+
+	proofStatus := chain.GetProofStatus()
+	peers := newPeerSet(sw)
+	manager := &LightSyncManager{
+		sw:              sw,
+		genesisHash:     genesisHeader.Hash(),
+		chain:           chain,
+		peers:           peers,
+		watched:         watched,
+		proofHeight:     proofStatus.Height,
+		proofHash:       *proofStatus.Hash,
+		nextProofHeight: proofStatus.Height + 1,
+		pendingProofs:   make(map[uint64]*merkleRequest),
+		headersCh:       make(chan *headersMsg, headersProcessChSize),
+		quitSync:        make(chan struct{}),
+		config:          config,
+	}
+
+	protocolReactor := NewProtocolReactor(manager, peers)
+	manager.sw.AddReactor("PROTOCOL", protocolReactor)
+	return manager, nil
+}
+
+// BestPeer return the highest full node peerInfo
+func (lm *LightSyncManager) BestPeer() *PeerInfo {
+	if bestPeer := lm.peers.bestPeer(lightServices); bestPeer != nil {
+		return bestPeer.getPeerInfo()
+	}
+	return nil
+}
+
+// GetPeerInfos return peer info of all peers
+func (lm *LightSyncManager) GetPeerInfos() []*PeerInfo {
+	return lm.peers.getPeerInfos()
+}
+
+// IsCaughtUp check wheather the headers are synced to the best peer
+func (lm *LightSyncManager) IsCaughtUp() bool {
+	peer := lm.peers.bestPeer(lightServices)
+	return peer == nil || peer.Height() <= lm.chain.BestBlockHeight()
+}
+
+func (lm *LightSyncManager) NodeInfo() *p2p.NodeInfo {
+	return lm.sw.NodeInfo()
+}
+
+func (lm *LightSyncManager) PeerCount() int {
+	return len(lm.sw.Peers().List())
+}
+
+func (lm *LightSyncManager) Start() error {
+	if err := lm.sw.Start(); err != nil {
+		log.Error("switch start err")
+		return err
+	}
+
+	go lm.syncWorker()
+	return nil
+}
+
+func (lm *LightSyncManager) Stop() {
+	close(lm.quitSync)
+	lm.sw.Stop()
+}
+
+// blockLocator returns the locator of the best header like the full node
+func (lm *LightSyncManager) blockLocator() []*types.Hash {
+	header := lm.chain.BestBlockHeader()
+	locator := []*types.Hash{}
+
+	step := uint64(1)
+	for header != nil {
+		headerHash := header.Hash()
+		locator = append(locator, &headerHash)
+		if header.Height == 0 {
+			break
+		}
+
+		var err error
+		if header.Height < step {
+			header, err = lm.chain.GetHeaderByHeight(0)
+		} else {
+			header, err = lm.chain.GetHeaderByHeight(header.Height - step)
+		}
+		if err != nil {
+			log.WithFields(log.Fields{"module": logModule, "err": err}).Error("lightSyncManager fail on get blockLocator")
+			break
+		}
+
+		if len(locator) >= 9 {
+			step *= 2
+		}
+	}
+	return locator
+}
+
+func (lm *LightSyncManager) handleHeadersMsg(peer *peer, msg *HeadersMessage) {
+	headers, err := msg.GetHeaders()
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Debug("fail on handleHeadersMsg GetHeaders")
+		return
+	}
+
+	select {
+	case lm.headersCh <- &headersMsg{headers: headers, peerID: peer.ID()}:
+	default:
+		log.WithFields(log.Fields{"module": logModule, "peer": peer.Addr()}).Debug("drop headers due to the full channel")
+	}
+}
+
+// handleMerkleBlockMsg keeps the txs proven by the merkle block, the header of
+// a new block relayed by the peer is processed first.
+func (lm *LightSyncManager) handleMerkleBlockMsg(peer *peer, msg *MerkleBlockMessage) {
+	header, err := msg.GetBlockHeader()
+	if err != nil {
+		lm.peers.addBanScore(peer.ID(), 0, 10, "fail on get header from merkle block")
+		return
+	}
+
+	txs, err := msg.GetTransactions()
+	if err != nil {
+		lm.peers.addBanScore(peer.ID(), 0, 10, "fail on get txs from merkle block")
+		return
+	}
+
+	hash := header.Hash()
+	peer.markBlock(&hash)
+	if header.Height > peer.Height() {
+		peer.setStatus(header.Height, &hash)
+	}
+
+	if err := lm.chain.ProcessHeaders([]*types.BlockHeader{header}); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "hash": hash.String(), "err": err}).Debug("fail on process merkle block header")
+		return
+	}
+	if err := lm.chain.ProcessMerkleProof(&hash, fromRawHashes(msg.TxHashes), msg.Flags, txs); err != nil {
+		lm.peers.addBanScore(peer.ID(), 20, 0, err.Error())
+		return
+	}
+	lm.processedMerkleBlock(&hash)
+}
+
+// processedMerkleBlock marks the pending merkle block as received and moves
+// the proof status up to the last one received without a gap.
+func (lm *LightSyncManager) processedMerkleBlock(hash *types.Hash) {
+	lm.proofMtx.Lock()
+	defer lm.proofMtx.Unlock()
+
+	for height := lm.proofHeight + 1; height < lm.nextProofHeight; height++ {
+		if req := lm.pendingProofs[height]; req.hash == *hash {
+			req.received = true
+			break
+		}
+	}
+
+	proofHeight := lm.proofHeight
+	for req, ok := lm.pendingProofs[lm.proofHeight+1]; ok && req.received; req, ok = lm.pendingProofs[lm.proofHeight+1] {
+		delete(lm.pendingProofs, lm.proofHeight+1)
+		lm.proofHeight++
+		lm.proofHash = req.hash
+	}
+	if lm.proofHeight != proofHeight {
+		lm.saveProofStatus()
+	}
+}
+
+// rewindProofs drops the pending merkle blocks no longer in the main chain,
+// the proof status is rewound to the fork point when its header is reorged.
+func (lm *LightSyncManager) rewindProofs() {
+	if !lm.chain.InMainChain(lm.proofHash) {
+		header, err := lm.chain.GetHeaderByHash(&lm.proofHash)
+		for err == nil && !lm.chain.InMainChain(header.Hash()) {
+			header, err = lm.chain.GetHeaderByHash(&header.Previous)
+		}
+		if err != nil {
+			if header, err = lm.chain.GetHeaderByHeight(0); err != nil {
+				return
+			}
+		}
+
+		lm.proofHeight = header.Height
+		lm.proofHash = header.Hash()
+		lm.nextProofHeight = header.Height + 1
+		lm.pendingProofs = make(map[uint64]*merkleRequest)
+		lm.saveProofStatus()
+		return
+	}
+
+	for height := lm.proofHeight + 1; height < lm.nextProofHeight; height++ {
+		if lm.chain.InMainChain(lm.pendingProofs[height].hash) {
+			continue
+		}
+
+		for ; height < lm.nextProofHeight; lm.nextProofHeight-- {
+			delete(lm.pendingProofs, lm.nextProofHeight-1)
+		}
+	}
+}
+
+func (lm *LightSyncManager) saveProofStatus() {
+	if err := lm.chain.SaveProofStatus(lm.proofHeight, &lm.proofHash); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "height": lm.proofHeight, "err": err}).Error("fail on save light client proof status")
+	}
+}
+
+func (lm *LightSyncManager) handleStatusRequestMsg(peer BasePeer) {
+	msg := NewStatusResponseMessage(lm.chain.BestBlockHeader(), &lm.genesisHash)
+	if ok := peer.TrySend(BlockchainChannel, struct{ BlockchainMessage }{msg}); !ok {
+		lm.peers.removePeer(peer.ID())
+	}
+}
+
+// handleStatusResponseMsg adds the peer and loads the watched filter to it,
+// the full node peer relays only the txs and merkle blocks of the filter.
+func (lm *LightSyncManager) handleStatusResponseMsg(basePeer BasePeer, msg *StatusResponseMessage) {
+	if peer := lm.peers.getPeer(basePeer.ID()); peer != nil {
+		peer.setStatus(msg.Height, msg.GetHash())
+		return
+	}
+
+	if genesisHash := msg.GetGenesisHash(); lm.genesisHash != *genesisHash {
+		log.WithFields(log.Fields{"module": logModule, "remote genesis": genesisHash.String(), "local genesis": lm.genesisHash.String()}).Warn("fail hand shake due to differnt genesis")
+		return
+	}
+
+	lm.peers.addPeer(basePeer, msg.Height, msg.GetHash())
+	if peer := lm.peers.getPeer(basePeer.ID()); peer != nil && peer.services.IsEnable(lightServices) {
+		if ok := peer.loadFilter(lm.watched); !ok {
+			lm.peers.removePeer(peer.ID())
+		}
+	}
+}
+
+func (lm *LightSyncManager) processMsg(basePeer BasePeer, msgType byte, msg BlockchainMessage) {
+	peer := lm.peers.getPeer(basePeer.ID())
+	if peer == nil && msgType != StatusResponseByte && msgType != StatusRequestByte {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"module":  logModule,
+		"peer":    basePeer.Addr(),
+		"type":    reflect.TypeOf(msg),
+		"message": msg.String(),
+	}).Info("receive message from peer")
+
+	switch msg := msg.(type) {
+	case *StatusRequestMessage:
+		lm.handleStatusRequestMsg(basePeer)
+
+	case *StatusResponseMessage:
+		lm.handleStatusResponseMsg(basePeer, msg)
+
+	case *HeadersMessage:
+		lm.handleHeadersMsg(peer, msg)
+
+	case *MerkleBlockMessage:
+		lm.handleMerkleBlockMsg(peer, msg)
+
+	default:
+		log.WithFields(log.Fields{
+			"module":       logModule,
+			"peer":         basePeer.Addr(),
+			"message_type": reflect.TypeOf(msg),
+		}).Debug("ignore message in light mode")
+	}
+}
+
+// syncHeaders downloads the headers from the best full node peer until its
+// best block, then the merkle blocks of the main chain are requested when
+// there is any watched address.
+func (lm *LightSyncManager) syncHeaders() error {
+	syncPeer := lm.peers.bestPeer(lightServices)
+	if syncPeer == nil {
+		return errNoFullPeer
+	}
+
+	for syncPeer.Height() > lm.chain.BestBlockHeight() {
+		if ok := syncPeer.getHeaders(lm.blockLocator(), syncPeer.Hash()); !ok {
+			return errPeerDropped
+		}
+
+		headers, err := lm.requireHeaders(syncPeer.ID())
+		if err != nil {
+			return err
+		}
+
+		bestHeight := lm.chain.BestBlockHeight()
+		if err := lm.chain.ProcessHeaders(headers); err != nil {
+			lm.peers.addBanScore(syncPeer.ID(), 20, 0, err.Error())
+			return err
+		}
+		if lm.chain.BestBlockHeight() <= bestHeight {
+			break
+		}
+	}
+
+	if len(lm.watched) != 0 {
+		lm.requireMerkleBlocks(syncPeer)
+	}
+	return nil
+}
+
+// requireMerkleBlocks requests again the pending merkle blocks timed out, then
+// the new ones until the send queue of the peer is full or too many blocks are
+// pending, the rest are requested in the next sync cycle.
+func (lm *LightSyncManager) requireMerkleBlocks(syncPeer *peer) {
+	lm.proofMtx.Lock()
+	defer lm.proofMtx.Unlock()
+
+	lm.rewindProofs()
+	for height := lm.proofHeight + 1; height < lm.nextProofHeight; height++ {
+		req := lm.pendingProofs[height]
+		if req.received || time.Since(req.requestTime) < syncTimeout {
+			continue
+		}
+
+		if ok := syncPeer.getMerkleBlock(&req.hash); !ok {
+			return
+		}
+		req.requestTime = time.Now()
+	}
+
+	for ; lm.nextProofHeight <= lm.chain.BestBlockHeight() && lm.nextProofHeight <= lm.proofHeight+maxPendingProofs; lm.nextProofHeight++ {
+		header, err := lm.chain.GetHeaderByHeight(lm.nextProofHeight)
+		if err != nil {
+			return
+		}
+
+		hash := header.Hash()
+		if ok := syncPeer.getMerkleBlock(&hash); !ok {
+			return
+		}
+		lm.pendingProofs[lm.nextProofHeight] = &merkleRequest{hash: hash, requestTime: time.Now()}
+	}
+}
+
+func (lm *LightSyncManager) requireHeaders(peerID string) ([]*types.BlockHeader, error) {
+	waitTicker := time.NewTimer(syncTimeout)
+	defer waitTicker.Stop()
+
+	for {
+		select {
+		case msg := <-lm.headersCh:
+			if msg.peerID != peerID {
+				continue
+			}
+			return msg.headers, nil
+		case <-waitTicker.C:
+			return nil, errRequestTimeout
+		case <-lm.quitSync:
+			return nil, errPeerDropped
+		}
+	}
+}
+
+// syncTransactions implements msgHandler, the light client has no mempool to
+// announce.
+func (lm *LightSyncManager) syncTransactions(peerID string) {}
+
+func (lm *LightSyncManager) syncWorker() {
+	syncTicker := time.NewTicker(syncCycle)
+	defer syncTicker.Stop()
+
+	for {
+		select {
+		case <-syncTicker.C:
+			if err := lm.syncHeaders(); err != nil && err != errNoFullPeer {
+				log.WithFields(log.Fields{"module": logModule, "err": err}).Warning("fail on light client header sync")
+			}
+		case <-lm.quitSync:
+			return
+		}
+	}
+}
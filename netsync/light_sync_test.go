@@ -0,0 +1,111 @@
+package netsync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clarenous/go-capsule/consensus"
+	core "github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/protocol/types"
+	"github.com/clarenous/go-capsule/test/mock"
+)
+
+type mockLightChain struct {
+	*mock.Chain
+	proofStatus *core.BlockStoreState
+}
+
+func (c *mockLightChain) GetProofStatus() *core.BlockStoreState { return c.proofStatus }
+
+func (c *mockLightChain) ProcessHeaders([]*types.BlockHeader) error { return nil }
+
+func (c *mockLightChain) ProcessMerkleProof(*types.Hash, []*types.Hash, []uint8, []*types.Tx) error {
+	return nil
+}
+
+func (c *mockLightChain) SaveProofStatus(height uint64, hash *types.Hash) error {
+	c.proofStatus = &core.BlockStoreState{Height: height, Hash: hash}
+	return nil
+}
+
+func TestRequireMerkleBlocks(t *testing.T) {
+	blocks := mockBlocks(nil, 5)
+	chain := &mockLightChain{Chain: mock.NewChain()}
+	chain.SetBestBlockHeader(&blocks[len(blocks)-1].BlockHeader)
+	for _, block := range blocks {
+		chain.SetBlockByHeight(block.Height, block)
+	}
+	chain.SaveProofStatus(0, blocks[0].Hash().Ptr())
+
+	lm, err := newLightSyncManager(nil, newMockSwitch(consensus.SFFullNode), chain, [][]byte{{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	basePeer := NewP2PPeer("192.168.0.1", "full node", lightServices)
+	basePeer.msgCh = make(chan []byte, 32)
+	basePeer.setAsync(true)
+	syncPeer := newPeer(5, blocks[5].Hash().Ptr(), basePeer)
+	requested := func() uint64 { return syncPeer.msgsSent.snapshot()["GetMerkleBlockMessage"] }
+
+	lm.requireMerkleBlocks(syncPeer)
+	if requested() != 5 || lm.nextProofHeight != 6 {
+		t.Fatalf("got %d requests, next proof height %d", requested(), lm.nextProofHeight)
+	}
+
+	lm.processedMerkleBlock(blocks[2].Hash().Ptr())
+	if lm.proofHeight != 0 {
+		t.Errorf("proof height moves over the missing merkle block")
+	}
+	lm.processedMerkleBlock(blocks[1].Hash().Ptr())
+	if lm.proofHeight != 2 || chain.proofStatus.Height != 2 || *chain.proofStatus.Hash != blocks[2].Hash() {
+		t.Errorf("got proof height %d, saved %d", lm.proofHeight, chain.proofStatus.Height)
+	}
+
+	lm.requireMerkleBlocks(syncPeer)
+	if requested() != 5 {
+		t.Errorf("pending merkle blocks are requested before timeout")
+	}
+
+	defer func(timeout time.Duration) { syncTimeout = timeout }(syncTimeout)
+	syncTimeout = 0
+	lm.requireMerkleBlocks(syncPeer)
+	if requested() != 8 {
+		t.Errorf("got %d requests, want the 3 timed out requested again", requested())
+	}
+
+	forkBlocks := mockBlocks(blocks[3], 6)
+	for _, block := range forkBlocks {
+		chain.ProcessBlock(block)
+	}
+	lm.requireMerkleBlocks(syncPeer)
+	if lm.nextProofHeight != 7 || lm.pendingProofs[4].hash != forkBlocks[0].Hash() || lm.pendingProofs[3].hash != blocks[3].Hash() {
+		t.Errorf("pending merkle blocks are not rewound on reorg")
+	}
+
+	lm.processedMerkleBlock(blocks[3].Hash().Ptr())
+	lm.processedMerkleBlock(forkBlocks[0].Hash().Ptr())
+	if lm.proofHeight != 4 {
+		t.Fatalf("got proof height %d", lm.proofHeight)
+	}
+
+	forkBlocks = mockBlocks(blocks[1], 7)
+	for _, block := range forkBlocks {
+		chain.ProcessBlock(block)
+	}
+	lm.requireMerkleBlocks(syncPeer)
+	if lm.proofHeight != 1 || chain.proofStatus.Height != 1 || *chain.proofStatus.Hash != blocks[1].Hash() {
+		t.Errorf("proof status is not rewound to the fork point, got height %d", lm.proofHeight)
+	}
+	if lm.pendingProofs[2].hash != forkBlocks[0].Hash() {
+		t.Errorf("merkle blocks of the new main chain are not requested")
+	}
+
+	reloaded, err := newLightSyncManager(nil, newMockSwitch(consensus.SFFullNode), chain, [][]byte{{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.proofHeight != 1 || reloaded.nextProofHeight != 2 {
+		t.Errorf("proof status is not reloaded, got height %d", reloaded.proofHeight)
+	}
+}
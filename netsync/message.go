@@ -3,12 +3,13 @@ package netsync
 import (
 	"bytes"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/tendermint/go-amino"
 
+	"github.com/clarenous/go-capsule/protocol/blockfilter"
 	"github.com/clarenous/go-capsule/protocol/types"
 )
 
@@ -28,32 +29,78 @@ const (
 	BlocksResponseByte  = byte(0x15)
 	StatusRequestByte   = byte(0x20)
 	StatusResponseByte  = byte(0x21)
+	GetAddrByte         = byte(0x22)
+	AddrByte            = byte(0x23)
 	NewTransactionByte  = byte(0x30)
+	TxInvByte           = byte(0x31)
+	GetTxsByte          = byte(0x32)
 	NewMineBlockByte    = byte(0x40)
+	CompactBlockByte    = byte(0x41)
+	GetBlockTxnByte     = byte(0x42)
+	BlockTxnByte        = byte(0x43)
 	FilterLoadByte      = byte(0x50)
 	FilterAddByte       = byte(0x51)
 	FilterClearByte     = byte(0x52)
+	MerkleRequestByte   = byte(0x60)
+	MerkleResponseByte  = byte(0x61)
+	GetCFilterByte      = byte(0x70)
+	CFilterByte         = byte(0x71)
+	GetCFHeadersByte    = byte(0x72)
+	CFHeadersByte       = byte(0x73)
 
 	maxBlockchainResponseSize = 22020096 + 2
 )
 
 var cdc = amino.NewCodec()
 
+// messages are the messages of the protocol and the bytes of their names
+var messages = []struct {
+	msg     BlockchainMessage
+	msgType byte
+}{
+	{&GetBlockMessage{}, BlockRequestByte},
+	{&BlockMessage{}, BlockResponseByte},
+	{&GetHeadersMessage{}, HeadersRequestByte},
+	{&HeadersMessage{}, HeadersResponseByte},
+	{&GetBlocksMessage{}, BlocksRequestByte},
+	{&BlocksMessage{}, BlocksResponseByte},
+	{&StatusRequestMessage{}, StatusRequestByte},
+	{&StatusResponseMessage{}, StatusResponseByte},
+	{&GetAddrMessage{}, GetAddrByte},
+	{&AddrMessage{}, AddrByte},
+	{&TransactionMessage{}, NewTransactionByte},
+	{&TxInvMessage{}, TxInvByte},
+	{&GetTxsMessage{}, GetTxsByte},
+	{&MineBlockMessage{}, NewMineBlockByte},
+	{&CompactBlockMessage{}, CompactBlockByte},
+	{&GetBlockTxnMessage{}, GetBlockTxnByte},
+	{&BlockTxnMessage{}, BlockTxnByte},
+	{&FilterLoadMessage{}, FilterLoadByte},
+	{&FilterAddMessage{}, FilterAddByte},
+	{&FilterClearMessage{}, FilterClearByte},
+	{&GetMerkleBlockMessage{}, MerkleRequestByte},
+	{&MerkleBlockMessage{}, MerkleResponseByte},
+	{&GetCFilterMessage{}, GetCFilterByte},
+	{&CFilterMessage{}, CFilterByte},
+	{&GetCFHeadersMessage{}, GetCFHeadersByte},
+	{&CFHeadersMessage{}, CFHeadersByte},
+}
+
 func RegisterAmino(cdc *amino.Codec) {
 	cdc.RegisterInterface((*BlockchainMessage)(nil), nil)
-	cdc.RegisterConcrete(&GetBlockMessage{}, string(BlockRequestByte), nil)
-	cdc.RegisterConcrete(&BlockMessage{}, string(BlockResponseByte), nil)
-	cdc.RegisterConcrete(&GetHeadersMessage{}, string(HeadersRequestByte), nil)
-	cdc.RegisterConcrete(&HeadersMessage{}, string(HeadersResponseByte), nil)
-	cdc.RegisterConcrete(&GetBlocksMessage{}, string(BlocksRequestByte), nil)
-	cdc.RegisterConcrete(&BlocksMessage{}, string(BlocksResponseByte), nil)
-	cdc.RegisterConcrete(&StatusRequestMessage{}, string(StatusRequestByte), nil)
-	cdc.RegisterConcrete(&StatusResponseMessage{}, string(StatusResponseByte), nil)
-	cdc.RegisterConcrete(&TransactionMessage{}, string(NewTransactionByte), nil)
-	cdc.RegisterConcrete(&MineBlockMessage{}, string(NewMineBlockByte), nil)
-	cdc.RegisterConcrete(&FilterLoadMessage{}, string(FilterLoadByte), nil)
-	cdc.RegisterConcrete(&FilterAddMessage{}, string(FilterAddByte), nil)
-	cdc.RegisterConcrete(&FilterClearMessage{}, string(FilterClearByte), nil)
+	for _, m := range messages {
+		cdc.RegisterConcrete(m.msg, string(m.msgType), nil)
+	}
+}
+
+// messageType returns the byte of the name of the message
+func messageType(msg BlockchainMessage) byte {
+	for _, m := range messages {
+		if reflect.TypeOf(m.msg) == reflect.TypeOf(msg) {
+			return m.msgType
+		}
+	}
+	return 0
 }
 
 //BlockchainMessage is a generic message for this reactor.
@@ -63,13 +110,13 @@ type BlockchainMessage interface {
 
 //DecodeMessage decode msg
 func DecodeMessage(bz []byte) (msgType byte, msg BlockchainMessage, err error) {
-	msgType = bz[0]
 	r := bytes.NewReader(bz)
 	n := int64(0)
 	n, err = cdc.UnmarshalBinaryLengthPrefixedReader(r, &msg, maxBlockchainResponseSize)
 	if err != nil && int(n) != len(bz) {
 		err = errors.New("DecodeMessage() had bytes left over")
 	}
+	msgType = messageType(msg)
 	return
 }
 
@@ -173,7 +220,7 @@ type HeadersMessage struct {
 func NewHeadersMessage(headers []*types.BlockHeader) (*HeadersMessage, error) {
 	RawHeaders := [][]byte{}
 	for _, header := range headers {
-		data, err := json.Marshal(header)
+		data, err := header.MarshalText()
 		if err != nil {
 			return nil, err
 		}
@@ -188,7 +235,7 @@ func (m *HeadersMessage) GetHeaders() ([]*types.BlockHeader, error) {
 	headers := []*types.BlockHeader{}
 	for _, data := range m.RawHeaders {
 		header := &types.BlockHeader{}
-		if err := json.Unmarshal(data, header); err != nil {
+		if err := header.UnmarshalText(data); err != nil {
 			return nil, err
 		}
 
@@ -247,7 +294,7 @@ type BlocksMessage struct {
 func NewBlocksMessage(blocks []*types.Block) (*BlocksMessage, error) {
 	rawBlocks := [][]byte{}
 	for _, block := range blocks {
-		data, err := json.Marshal(block)
+		data, err := block.MarshalText()
 		if err != nil {
 			return nil, err
 		}
@@ -262,7 +309,7 @@ func (m *BlocksMessage) GetBlocks() ([]*types.Block, error) {
 	blocks := []*types.Block{}
 	for _, data := range m.RawBlocks {
 		block := &types.Block{}
-		if err := json.Unmarshal(data, block); err != nil {
+		if err := block.UnmarshalText(data); err != nil {
 			return nil, err
 		}
 
@@ -345,6 +392,61 @@ func (m *TransactionMessage) String() string {
 	return fmt.Sprintf("{tx_size: %d, tx_hash: %s}", len(m.RawTx), tx.Hash().String())
 }
 
+//TxInvMessage announces the hashes of transactions the peer can serve
+type TxInvMessage struct {
+	RawHashes [][32]byte
+}
+
+//NewTxInvMessage construct tx inventory msg
+func NewTxInvMessage(hashes []*types.Hash) *TxInvMessage {
+	return &TxInvMessage{RawHashes: rawHashes(hashes)}
+}
+
+//GetHashes return the announced tx hashes
+func (m *TxInvMessage) GetHashes() []*types.Hash {
+	return fromRawHashes(m.RawHashes)
+}
+
+func (m *TxInvMessage) String() string {
+	return fmt.Sprintf("{hashes_length: %d}", len(m.RawHashes))
+}
+
+//GetTxsMessage requests the transactions of the hashes from the peer
+type GetTxsMessage struct {
+	RawHashes [][32]byte
+}
+
+//NewGetTxsMessage construct get txs msg
+func NewGetTxsMessage(hashes []*types.Hash) *GetTxsMessage {
+	return &GetTxsMessage{RawHashes: rawHashes(hashes)}
+}
+
+//GetHashes return the requested tx hashes
+func (m *GetTxsMessage) GetHashes() []*types.Hash {
+	return fromRawHashes(m.RawHashes)
+}
+
+func (m *GetTxsMessage) String() string {
+	return fmt.Sprintf("{hashes_length: %d}", len(m.RawHashes))
+}
+
+func rawHashes(hashes []*types.Hash) [][32]byte {
+	result := make([][32]byte, 0, len(hashes))
+	for _, hash := range hashes {
+		result = append(result, hash.Value())
+	}
+	return result
+}
+
+func fromRawHashes(rawHashes [][32]byte) []*types.Hash {
+	hashes := make([]*types.Hash, 0, len(rawHashes))
+	for _, rawHash := range rawHashes {
+		hash := types.Hash(rawHash)
+		hashes = append(hashes, &hash)
+	}
+	return hashes
+}
+
 //MineBlockMessage new mined block msg
 type MineBlockMessage struct {
 	RawBlock []byte
@@ -377,6 +479,120 @@ func (m *MineBlockMessage) String() string {
 	return fmt.Sprintf("{block_height: %d, block_hash: %s}", block.Height, blockHash.String())
 }
 
+//PrefilledTx is a tx sent in full within the compact block
+type PrefilledTx struct {
+	Index uint32
+	RawTx []byte
+}
+
+//CompactBlockMessage announces a new block by its header and the short ids
+//of its txs, the receiver rebuilds the block from its mempool.
+type CompactBlockMessage struct {
+	RawHeader    []byte
+	Nonce        uint64
+	ShortIDs     []uint64
+	PrefilledTxs []PrefilledTx
+}
+
+//NewCompactBlockMessage construct compact block msg, the coinbase is prefilled
+func NewCompactBlockMessage(block *types.Block, nonce uint64) (*CompactBlockMessage, error) {
+	rawHeader, err := block.BlockHeader.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &CompactBlockMessage{RawHeader: rawHeader, Nonce: nonce}
+	blockHash := block.Hash()
+	for i, tx := range block.Transactions {
+		if i == 0 {
+			rawTx, err := tx.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			msg.PrefilledTxs = append(msg.PrefilledTxs, PrefilledTx{Index: 0, RawTx: rawTx})
+			continue
+		}
+		msg.ShortIDs = append(msg.ShortIDs, shortTxID(&blockHash, nonce, tx.Hash().Ptr()))
+	}
+	return msg, nil
+}
+
+//GetHeader get block header from msg
+func (m *CompactBlockMessage) GetHeader() (*types.BlockHeader, error) {
+	header := &types.BlockHeader{}
+	if err := header.UnmarshalText(m.RawHeader); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func (m *CompactBlockMessage) String() string {
+	header, err := m.GetHeader()
+	if err != nil {
+		return "{err: wrong message}"
+	}
+	blockHash := header.Hash()
+	return fmt.Sprintf("{block_height: %d, block_hash: %s, short_ids: %d, prefilled: %d}", header.Height, blockHash.String(), len(m.ShortIDs), len(m.PrefilledTxs))
+}
+
+//GetBlockTxnMessage requests the txs of the compact block missing in the mempool
+type GetBlockTxnMessage struct {
+	RawHash [32]byte
+	Indexes []uint32
+}
+
+//GetHash return the hash of the block
+func (m *GetBlockTxnMessage) GetHash() *types.Hash {
+	hash := types.Hash(m.RawHash)
+	return &hash
+}
+
+func (m *GetBlockTxnMessage) String() string {
+	return fmt.Sprintf("{hash: %s, indexes_length: %d}", hex.EncodeToString(m.RawHash[:]), len(m.Indexes))
+}
+
+//BlockTxnMessage responses the requested txs of the compact block in order
+type BlockTxnMessage struct {
+	RawHash [32]byte
+	RawTxs  [][]byte
+}
+
+//NewBlockTxnMessage construct block txn msg
+func NewBlockTxnMessage(hash *types.Hash, txs []*types.Tx) (*BlockTxnMessage, error) {
+	msg := &BlockTxnMessage{RawHash: hash.Value()}
+	for _, tx := range txs {
+		rawTx, err := tx.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		msg.RawTxs = append(msg.RawTxs, rawTx)
+	}
+	return msg, nil
+}
+
+//GetHash return the hash of the block
+func (m *BlockTxnMessage) GetHash() *types.Hash {
+	hash := types.Hash(m.RawHash)
+	return &hash
+}
+
+//GetTransactions get txs from msg
+func (m *BlockTxnMessage) GetTransactions() ([]*types.Tx, error) {
+	txs := []*types.Tx{}
+	for _, rawTx := range m.RawTxs {
+		tx := &types.Tx{}
+		if err := tx.UnmarshalText(rawTx); err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+func (m *BlockTxnMessage) String() string {
+	return fmt.Sprintf("{hash: %s, txs_length: %d}", hex.EncodeToString(m.RawHash[:]), len(m.RawTxs))
+}
+
 //FilterLoadMessage tells the receiving peer to filter the transactions according to address.
 type FilterLoadMessage struct {
 	Addresses [][]byte
@@ -401,3 +617,201 @@ type FilterClearMessage struct{}
 func (m *FilterClearMessage) String() string {
 	return "{}"
 }
+
+//GetMerkleBlockMessage requests the merkle block by height/hash
+type GetMerkleBlockMessage struct {
+	Height  uint64
+	RawHash [32]byte
+}
+
+//GetHash reutrn the hash of the request
+func (m *GetMerkleBlockMessage) GetHash() *types.Hash {
+	hash := types.Hash(m.RawHash)
+	return &hash
+}
+
+func (m *GetMerkleBlockMessage) String() string {
+	if m.Height > 0 {
+		return fmt.Sprintf("{height: %d}", m.Height)
+	}
+	return fmt.Sprintf("{hash: %s}", hex.EncodeToString(m.RawHash[:]))
+}
+
+//MerkleBlockMessage is the block header with the txs matching the filter of
+//the peer and the merkle proof of the txs
+type MerkleBlockMessage struct {
+	RawBlockHeader []byte
+	TxHashes       [][32]byte
+	RawTxDatas     [][]byte
+	Flags          []byte
+}
+
+//NewMerkleBlockMessage construct merkle block msg of the related txs
+func NewMerkleBlockMessage(block *types.Block, relatedTxs []*types.Tx) (*MerkleBlockMessage, error) {
+	rawHeader, err := block.BlockHeader.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &MerkleBlockMessage{RawBlockHeader: rawHeader}
+	hashes, flags := types.GetTxMerkleTreeProof(block.Transactions, relatedTxs)
+	for _, hash := range hashes {
+		msg.TxHashes = append(msg.TxHashes, hash.Value())
+	}
+	msg.Flags = flags
+
+	for _, tx := range relatedTxs {
+		rawTx, err := tx.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		msg.RawTxDatas = append(msg.RawTxDatas, rawTx)
+	}
+	return msg, nil
+}
+
+//GetBlockHeader get block header from msg
+func (m *MerkleBlockMessage) GetBlockHeader() (*types.BlockHeader, error) {
+	header := &types.BlockHeader{}
+	if err := header.UnmarshalText(m.RawBlockHeader); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+//GetTransactions get the related txs from msg
+func (m *MerkleBlockMessage) GetTransactions() ([]*types.Tx, error) {
+	txs := []*types.Tx{}
+	for _, rawTx := range m.RawTxDatas {
+		tx := &types.Tx{}
+		if err := tx.UnmarshalText(rawTx); err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+//Validate checks the related txs of the msg are in the block header
+func (m *MerkleBlockMessage) Validate() error {
+	header, err := m.GetBlockHeader()
+	if err != nil {
+		return err
+	}
+	txs, err := m.GetTransactions()
+	if err != nil {
+		return err
+	}
+
+	hashes := fromRawHashes(m.TxHashes)
+	relatedHashes := []*types.Hash{}
+	for _, tx := range txs {
+		relatedHashes = append(relatedHashes, tx.Hash().Ptr())
+	}
+	if !types.ValidateTxMerkleTreeProof(hashes, m.Flags, relatedHashes, header.TransactionRoot) {
+		return errors.New("invalid merkle block proof")
+	}
+	return nil
+}
+
+func (m *MerkleBlockMessage) String() string {
+	return fmt.Sprintf("{txs_length: %d}", len(m.RawTxDatas))
+}
+
+//GetCFilterMessage requests the golomb-coded set filter of the block
+type GetCFilterMessage struct {
+	RawHash [32]byte
+}
+
+//GetHash reutrn the hash of the request
+func (m *GetCFilterMessage) GetHash() *types.Hash {
+	hash := types.Hash(m.RawHash)
+	return &hash
+}
+
+func (m *GetCFilterMessage) String() string {
+	return fmt.Sprintf("{hash: %s}", hex.EncodeToString(m.RawHash[:]))
+}
+
+//CFilterMessage is the golomb-coded set filter of the block
+type CFilterMessage struct {
+	RawHash [32]byte
+	Filter  []byte
+}
+
+//GetHash reutrn the hash of the block
+func (m *CFilterMessage) GetHash() *types.Hash {
+	hash := types.Hash(m.RawHash)
+	return &hash
+}
+
+func (m *CFilterMessage) String() string {
+	return fmt.Sprintf("{hash: %s, filter_size: %d}", hex.EncodeToString(m.RawHash[:]), len(m.Filter))
+}
+
+//GetCFHeadersMessage requests the filter hashes of the main chain blocks from
+//the start height to the stop hash
+type GetCFHeadersMessage struct {
+	StartHeight uint64
+	RawStopHash [32]byte
+}
+
+//GetStopHash reutrn the stop hash of the request
+func (m *GetCFHeadersMessage) GetStopHash() *types.Hash {
+	hash := types.Hash(m.RawStopHash)
+	return &hash
+}
+
+func (m *GetCFHeadersMessage) String() string {
+	return fmt.Sprintf("{start_height: %d, stop_hash: %s}", m.StartHeight, hex.EncodeToString(m.RawStopHash[:]))
+}
+
+//CFHeadersMessage is the filter hashes of the requested blocks and the filter
+//header of the block before them, the filter headers are chained from it.
+type CFHeadersMessage struct {
+	RawStopHash      [32]byte
+	PrevFilterHeader [32]byte
+	FilterHashes     [][32]byte
+}
+
+//NewCFHeadersMessage construct the filter headers msg
+func NewCFHeadersMessage(stopHash, prevFilterHeader *types.Hash, filterHashes []*types.Hash) *CFHeadersMessage {
+	return &CFHeadersMessage{
+		RawStopHash:      stopHash.Value(),
+		PrevFilterHeader: prevFilterHeader.Value(),
+		FilterHashes:     rawHashes(filterHashes),
+	}
+}
+
+//GetFilterHeaders return the filter headers chained from the previous one
+func (m *CFHeadersMessage) GetFilterHeaders() []*types.Hash {
+	headers := []*types.Hash{}
+	prevHeader := types.Hash(m.PrevFilterHeader)
+	for _, rawHash := range m.FilterHashes {
+		filterHash := types.Hash(rawHash)
+		prevHeader = blockfilter.FilterHeader(&filterHash, &prevHeader)
+		header := prevHeader
+		headers = append(headers, &header)
+	}
+	return headers
+}
+
+func (m *CFHeadersMessage) String() string {
+	return fmt.Sprintf("{stop_hash: %s, headers_length: %d}", hex.EncodeToString(m.RawStopHash[:]), len(m.FilterHashes))
+}
+
+//GetAddrMessage requests the known addresses of the peer
+type GetAddrMessage struct{}
+
+func (m *GetAddrMessage) String() string {
+	return "{}"
+}
+
+//AddrMessage gossips the known addresses of the network
+type AddrMessage struct {
+	Addresses []string
+}
+
+func (m *AddrMessage) String() string {
+	return fmt.Sprintf("{addresses_length: %d}", len(m.Addresses))
+}
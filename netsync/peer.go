@@ -3,12 +3,16 @@ package netsync
 import (
 	"encoding/hex"
 	"gopkg.in/fatih/set.v0"
+	"math/rand"
 	"net"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/clarenous/go-capsule/consensus"
 	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/p2p"
+	"github.com/clarenous/go-capsule/p2p/connection"
 	"github.com/clarenous/go-capsule/p2p/trust"
 	"github.com/clarenous/go-capsule/protocol/types"
 	log "github.com/sirupsen/logrus"
@@ -24,7 +28,10 @@ const (
 //BasePeer is the interface for connection level peer
 type BasePeer interface {
 	Addr() net.Addr
+	BanKey() string
+	ChannelStatus() []connection.ChannelStatus
 	ID() string
+	Latency() time.Duration
 	ServiceFlag() consensus.ServiceFlag
 	TrafficStatus() (*flowrate.Status, *flowrate.Status)
 	TrySend(byte, interface{}) bool
@@ -43,12 +50,17 @@ type PeerInfo struct {
 	Height              uint64 `json:"height"`
 	Ping                string `json:"ping"`
 	Duration            string `json:"duration"`
+	BanScore            uint64 `json:"ban_score"`
 	TotalSent           int64  `json:"total_sent"`
 	TotalReceived       int64  `json:"total_received"`
 	AverageSentRate     int64  `json:"average_sent_rate"`
 	AverageReceivedRate int64  `json:"average_received_rate"`
 	CurrentSentRate     int64  `json:"current_sent_rate"`
 	CurrentReceivedRate int64  `json:"current_received_rate"`
+
+	Channels     []*ChannelInfo    `json:"channels"`
+	MsgsSent     map[string]uint64 `json:"msgs_sent"`
+	MsgsReceived map[string]uint64 `json:"msgs_received"`
 }
 
 type peer struct {
@@ -61,6 +73,13 @@ type peer struct {
 	knownTxs    *set.Set // Set of transaction hashes known to be known by this peer
 	knownBlocks *set.Set // Set of block hashes known to be known by this peer
 	filterAdds  *set.Set // Set of addresses that the spv node cares about.
+	addrsSent   bool     // Whether the known addresses are sent to the peer
+
+	txInvQueue   []*types.Hash            // Tx hashes waiting to be announced to the peer
+	requestedTxs map[types.Hash]time.Time // Tx hashes requested from the peer and the request time
+
+	msgsSent     msgCounter
+	msgsReceived msgCounter
 }
 
 func newPeer(height uint64, hash *types.Hash, basePeer BasePeer) *peer {
@@ -72,6 +91,8 @@ func newPeer(height uint64, hash *types.Hash, basePeer BasePeer) *peer {
 		knownTxs:    set.New(set.ThreadSafe).(*set.Set),
 		knownBlocks: set.New(set.ThreadSafe).(*set.Set),
 		filterAdds:  set.New(set.ThreadSafe).(*set.Set),
+
+		requestedTxs: make(map[types.Hash]time.Time),
 	}
 }
 
@@ -81,6 +102,12 @@ func (p *peer) Height() uint64 {
 	return p.height
 }
 
+func (p *peer) Hash() *types.Hash {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	return p.hash
+}
+
 func (p *peer) addBanScore(persistent, transient uint64, reason string) bool {
 	score := p.banScore.Increase(persistent, transient)
 	if score > defaultBanThreshold {
@@ -130,11 +157,54 @@ func (p *peer) addFilterAddresses(addresses [][]byte) {
 	}
 }
 
+// expiredTxRequests removes and returns the tx requests sent before deadline
+func (p *peer) expiredTxRequests(deadline time.Time) []*types.Hash {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	var expired []*types.Hash
+	for hash, requestTime := range p.requestedTxs {
+		if requestTime.Before(deadline) {
+			expired = append(expired, hash.Ptr())
+			delete(p.requestedTxs, hash)
+		}
+	}
+	return expired
+}
+
+// fulfillTxRequest removes the tx request, it reports whether the tx has
+// been requested from the peer.
+func (p *peer) fulfillTxRequest(hash *types.Hash) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if _, ok := p.requestedTxs[*hash]; !ok {
+		return false
+	}
+	delete(p.requestedTxs, *hash)
+	return true
+}
+
 func (p *peer) getBlockByHeight(height uint64) bool {
 	msg := struct{ BlockchainMessage }{&GetBlockMessage{Height: height}}
 	return p.TrySend(BlockchainChannel, msg)
 }
 
+func (p *peer) getBlockByHash(hash *types.Hash) bool {
+	msg := struct{ BlockchainMessage }{&GetBlockMessage{RawHash: hash.Value()}}
+	return p.TrySend(BlockchainChannel, msg)
+}
+
+func (p *peer) getBlockTxn(hash *types.Hash, indexes []uint32) bool {
+	msg := struct{ BlockchainMessage }{&GetBlockTxnMessage{RawHash: hash.Value(), Indexes: indexes}}
+	return p.TrySend(BlockchainChannel, msg)
+}
+
+func (p *peer) getAddrs() bool {
+	msg := struct{ BlockchainMessage }{&GetAddrMessage{}}
+	return p.TrySend(BlockchainChannel, msg)
+}
+
 func (p *peer) getBlocks(locator []*types.Hash, stopHash *types.Hash) bool {
 	msg := struct{ BlockchainMessage }{NewGetBlocksMessage(locator, stopHash)}
 	return p.TrySend(BlockchainChannel, msg)
@@ -145,35 +215,106 @@ func (p *peer) getHeaders(locator []*types.Hash, stopHash *types.Hash) bool {
 	return p.TrySend(BlockchainChannel, msg)
 }
 
+func (p *peer) getMerkleBlock(hash *types.Hash) bool {
+	msg := struct{ BlockchainMessage }{&GetMerkleBlockMessage{RawHash: hash.Value()}}
+	return p.TrySend(BlockchainChannel, msg)
+}
+
 func (p *peer) getPeerInfo() *PeerInfo {
 	p.mtx.RLock()
 	defer p.mtx.RUnlock()
 
 	sentStatus, receivedStatus := p.TrafficStatus()
-	ping := sentStatus.Idle - receivedStatus.Idle
-	if receivedStatus.Idle > sentStatus.Idle {
-		ping = -ping
-	}
-
 	return &PeerInfo{
 		ID:                  p.ID(),
 		RemoteAddr:          p.Addr().String(),
 		Height:              p.height,
-		Ping:                ping.String(),
+		Ping:                p.Latency().String(),
 		Duration:            sentStatus.Duration.String(),
+		BanScore:            p.banScore.Int(),
 		TotalSent:           sentStatus.Bytes,
 		TotalReceived:       receivedStatus.Bytes,
 		AverageSentRate:     sentStatus.AvgRate,
 		AverageReceivedRate: receivedStatus.AvgRate,
 		CurrentSentRate:     sentStatus.CurRate,
 		CurrentReceivedRate: receivedStatus.CurRate,
+		Channels:            newChannelInfos(p.ChannelStatus()),
+		MsgsSent:            p.msgsSent.snapshot(),
+		MsgsReceived:        p.msgsReceived.snapshot(),
+	}
+}
+
+func (p *peer) getTxs(hashes []*types.Hash) bool {
+	msg := struct{ BlockchainMessage }{NewGetTxsMessage(hashes)}
+	if ok := p.TrySend(BlockchainChannel, msg); !ok {
+		return false
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	now := time.Now()
+	for _, hash := range hashes {
+		p.requestedTxs[*hash] = now
+	}
+	return true
+}
+
+// loadFilter asks the full node peer to relay only the txs and merkle blocks
+// related to the addresses.
+func (p *peer) loadFilter(addresses [][]byte) bool {
+	msg := struct{ BlockchainMessage }{&FilterLoadMessage{Addresses: addresses}}
+	return p.TrySend(BlockchainChannel, msg)
+}
+
+// ip returns the remote host of the peer, which is the key of the blacklist.
+func (p *peer) ip() string {
+	addr := p.Addr().String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
 	}
+	return addr
+}
+
+// isRelatedTx reports whether the tx matches the filter of the peer by the
+// output script hash, the input redeem script or the evidence digest.
+func (p *peer) isRelatedTx(tx *types.Tx) bool {
+	for _, out := range tx.Outputs {
+		if p.filterAdds.Has(hex.EncodeToString(out.ScriptHash[:])) {
+			return true
+		}
+	}
+	for _, in := range tx.Inputs {
+		if p.filterAdds.Has(hex.EncodeToString(in.RedeemScript)) {
+			return true
+		}
+	}
+	for _, evidence := range tx.Evidences {
+		if p.filterAdds.Has(hex.EncodeToString(evidence.Digest)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *peer) getRelatedTxs(txs []*types.Tx) []*types.Tx {
+	relatedTxs := []*types.Tx{}
+	for _, tx := range txs {
+		if p.isRelatedTx(tx) {
+			relatedTxs = append(relatedTxs, tx)
+		}
+	}
+	return relatedTxs
 }
 
 func (p *peer) isSPVNode() bool {
 	return !p.services.IsEnable(consensus.SFFullNode)
 }
 
+// isTxRelayNode reports whether the peer relays the txs by inventory
+func (p *peer) isTxRelayNode() bool {
+	return p.services.IsEnable(consensus.SFTxRelay)
+}
+
 func (p *peer) markBlock(hash *types.Hash) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
@@ -184,6 +325,15 @@ func (p *peer) markBlock(hash *types.Hash) {
 	p.knownBlocks.Add(hash.String())
 }
 
+// isTxRequested reports whether the tx has been requested from the peer
+func (p *peer) isTxRequested(hash *types.Hash) bool {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	_, ok := p.requestedTxs[*hash]
+	return ok
+}
+
 func (p *peer) markTransaction(hash *types.Hash) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
@@ -194,6 +344,40 @@ func (p *peer) markTransaction(hash *types.Hash) {
 	p.knownTxs.Add(hash.String())
 }
 
+// queueTxInv adds the tx hash to the next announcement, it returns the hashes
+// to announce at once when the queue is full.
+func (p *peer) queueTxInv(hash *types.Hash) []*types.Hash {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.txInvQueue = append(p.txInvQueue, hash)
+	if len(p.txInvQueue) < maxTxInvSize {
+		return nil
+	}
+
+	hashes := p.txInvQueue
+	p.txInvQueue = nil
+	return hashes
+}
+
+// sendAddrs sends the known addresses once per connection, the later
+// requests are ignored.
+func (p *peer) sendAddrs(addrs []*p2p.NetAddress) bool {
+	p.mtx.Lock()
+	if p.addrsSent {
+		p.mtx.Unlock()
+		return true
+	}
+	p.addrsSent = true
+	p.mtx.Unlock()
+
+	msg := &AddrMessage{Addresses: make([]string, len(addrs))}
+	for i, addr := range addrs {
+		msg.Addresses[i] = addr.String()
+	}
+	return p.TrySend(BlockchainChannel, struct{ BlockchainMessage }{msg})
+}
+
 func (p *peer) sendBlock(block *types.Block) (bool, error) {
 	msg, err := NewBlockMessage(block)
 	if err != nil {
@@ -208,6 +392,16 @@ func (p *peer) sendBlock(block *types.Block) (bool, error) {
 	return ok, nil
 }
 
+func (p *peer) sendBlockTxn(hash *types.Hash, txs []*types.Tx) (bool, error) {
+	msg, err := NewBlockTxnMessage(hash, txs)
+	if err != nil {
+		return false, errors.Wrap(err, "fail on NewBlockTxnMessage")
+	}
+
+	ok := p.TrySend(BlockchainChannel, struct{ BlockchainMessage }{msg})
+	return ok, nil
+}
+
 func (p *peer) sendBlocks(blocks []*types.Block) (bool, error) {
 	msg, err := NewBlocksMessage(blocks)
 	if err != nil {
@@ -225,6 +419,15 @@ func (p *peer) sendBlocks(blocks []*types.Block) (bool, error) {
 	return true, nil
 }
 
+func (p *peer) sendCFHeaders(msg *CFHeadersMessage) bool {
+	return p.TrySend(BlockchainChannel, struct{ BlockchainMessage }{msg})
+}
+
+func (p *peer) sendCFilter(hash *types.Hash, filter []byte) bool {
+	msg := struct{ BlockchainMessage }{&CFilterMessage{RawHash: hash.Value(), Filter: filter}}
+	return p.TrySend(BlockchainChannel, msg)
+}
+
 func (p *peer) sendHeaders(headers []*types.BlockHeader) (bool, error) {
 	msg, err := NewHeadersMessage(headers)
 	if err != nil {
@@ -235,6 +438,48 @@ func (p *peer) sendHeaders(headers []*types.BlockHeader) (bool, error) {
 	return ok, nil
 }
 
+// sendTxInv announces the tx hashes unknown to the peer
+func (p *peer) sendTxInv(hashes []*types.Hash) bool {
+	unknown := []*types.Hash{}
+	for _, hash := range hashes {
+		if !p.knownTxs.Has(hash.String()) {
+			unknown = append(unknown, hash)
+		}
+	}
+
+	for len(unknown) > 0 {
+		size := len(unknown)
+		if size > maxTxInvSize {
+			size = maxTxInvSize
+		}
+		if ok := p.TrySend(BlockchainChannel, struct{ BlockchainMessage }{NewTxInvMessage(unknown[:size])}); !ok {
+			return false
+		}
+		for _, hash := range unknown[:size] {
+			p.markTransaction(hash)
+		}
+		unknown = unknown[size:]
+	}
+	return true
+}
+
+// sendMerkleBlock sends the block header with the txs matching the filter of
+// the peer and the merkle proof of them.
+func (p *peer) sendMerkleBlock(block *types.Block) (bool, error) {
+	msg, err := NewMerkleBlockMessage(block, p.getRelatedTxs(block.Transactions))
+	if err != nil {
+		return false, errors.Wrap(err, "fail on NewMerkleBlockMessage")
+	}
+
+	ok := p.TrySend(BlockchainChannel, struct{ BlockchainMessage }{msg})
+	if ok {
+		blockHash := block.Hash()
+		p.markBlock(&blockHash)
+	}
+	return ok, nil
+}
+
+// sendTransactions sends the full transactions requested by the peer
 func (p *peer) sendTransactions(txs []*types.Tx) (bool, error) {
 	for _, tx := range txs {
 		msg, err := NewTransactionMessage(tx)
@@ -242,17 +487,24 @@ func (p *peer) sendTransactions(txs []*types.Tx) (bool, error) {
 			return false, errors.Wrap(err, "failed to tx msg")
 		}
 
-		if p.knownTxs.Has(tx.Hash().String()) {
-			continue
-		}
 		if ok := p.TrySend(BlockchainChannel, struct{ BlockchainMessage }{msg}); !ok {
 			return ok, nil
 		}
-		p.knownTxs.Add(tx.Hash().String())
+		p.markTransaction(tx.Hash().Ptr())
 	}
 	return true, nil
 }
 
+// takeTxInvQueue returns and clears the tx hashes waiting to be announced
+func (p *peer) takeTxInvQueue() []*types.Hash {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	hashes := p.txInvQueue
+	p.txInvQueue = nil
+	return hashes
+}
+
 func (p *peer) setStatus(height uint64, hash *types.Hash) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
@@ -260,6 +512,16 @@ func (p *peer) setStatus(height uint64, hash *types.Hash) {
 	p.hash = hash
 }
 
+// TrySend counts the sent message by type before handing it to the base peer.
+func (p *peer) TrySend(chID byte, msg interface{}) bool {
+	if ok := p.BasePeer.TrySend(chID, msg); !ok {
+		return false
+	}
+
+	p.msgsSent.add(msg)
+	return true
+}
+
 type peerSet struct {
 	BasePeerSet
 	mtx   sync.RWMutex
@@ -285,7 +547,7 @@ func (ps *peerSet) addBanScore(peerID string, persistent, transient uint64, reas
 	if ban := peer.addBanScore(persistent, transient, reason); !ban {
 		return
 	}
-	if err := ps.AddBannedPeer(peer.Addr().String()); err != nil {
+	if err := ps.AddBannedPeer(peer.BanKey()); err != nil {
 		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on add ban peer")
 	}
 	ps.removePeer(peerID)
@@ -318,18 +580,35 @@ func (ps *peerSet) bestPeer(flag consensus.ServiceFlag) *peer {
 	return bestPeer
 }
 
+// broadcastMinedBlock announces the block as a compact block to the peers
+// negotiated compact blocks, the full block is sent to the others or if the
+// compact block can't be built.
 func (ps *peerSet) broadcastMinedBlock(block *types.Block) error {
-	msg, err := NewMinedBlockMessage(block)
+	fullMsg, err := NewMinedBlockMessage(block)
 	if err != nil {
 		return errors.Wrap(err, "fail on broadcast mined block")
 	}
 
+	var compactMsg BlockchainMessage = fullMsg
+	if cbMsg, err := NewCompactBlockMessage(block, rand.Uint64()); err == nil {
+		compactMsg = cbMsg
+	}
+
 	hash := block.Hash()
 	peers := ps.peersWithoutBlock(&hash)
 	for _, peer := range peers {
 		if peer.isSPVNode() {
+			if ok, err := peer.sendMerkleBlock(block); !ok || err != nil {
+				log.WithFields(log.Fields{"module": logModule, "peer": peer.Addr(), "err": err}).Warning("fail on send merkle block to spv peer")
+				ps.removePeer(peer.ID())
+			}
 			continue
 		}
+
+		var msg BlockchainMessage = fullMsg
+		if peer.services.IsEnable(consensus.SFCompactBlock) {
+			msg = compactMsg
+		}
 		if ok := peer.TrySend(BlockchainChannel, struct{ BlockchainMessage }{msg}); !ok {
 			log.WithFields(log.Fields{"module": logModule, "peer": peer.Addr(), "type": reflect.TypeOf(msg), "message": msg.String()}).Warning("send message to peer error")
 			ps.removePeer(peer.ID())
@@ -356,29 +635,51 @@ func (ps *peerSet) broadcastNewStatus(bestBlock, genesisBlock *types.Block) erro
 	return nil
 }
 
+// broadcastTx queues the tx hash to be announced to the peers without it, the
+// queue of a peer is announced at once when it is full. The legacy peers not
+// relaying by inventory are pushed the tx.
 func (ps *peerSet) broadcastTx(tx *types.Tx) error {
-	msg, err := NewTransactionMessage(tx)
-	if err != nil {
-		return errors.Wrap(err, "fail on broadcast tx")
-	}
-
-	peers := ps.peersWithoutTx(tx.Hash().Ptr())
+	hash := tx.Hash().Ptr()
+	peers := ps.peersWithoutTx(hash)
 	for _, peer := range peers {
-		if ok := peer.TrySend(BlockchainChannel, struct{ BlockchainMessage }{msg}); !ok {
-			log.WithFields(log.Fields{
-				"module":  logModule,
-				"peer":    peer.Addr(),
-				"type":    reflect.TypeOf(msg),
-				"message": msg.String(),
-			}).Warning("send message to peer error")
-			ps.removePeer(peer.ID())
+		if peer.isSPVNode() && !peer.isRelatedTx(tx) {
+			continue
+		}
+
+		if !peer.isTxRelayNode() {
+			if ok, err := peer.sendTransactions([]*types.Tx{tx}); !ok || err != nil {
+				log.WithFields(log.Fields{"module": logModule, "peer": peer.Addr(), "err": err}).Warning("fail on send tx to peer")
+				ps.removePeer(peer.ID())
+			}
+			continue
+		}
+
+		hashes := peer.queueTxInv(hash)
+		if len(hashes) == 0 {
 			continue
 		}
-		peer.markTransaction(tx.Hash().Ptr())
+		if ok := peer.sendTxInv(hashes); !ok {
+			log.WithFields(log.Fields{"module": logModule, "peer": peer.Addr(), "count": len(hashes)}).Warning("fail on announce txs to peer")
+			ps.removePeer(peer.ID())
+		}
 	}
 	return nil
 }
 
+// flushTxInvs announces the queued tx hashes of every peer
+func (ps *peerSet) flushTxInvs() {
+	for _, peer := range ps.getPeers() {
+		hashes := peer.takeTxInvQueue()
+		if len(hashes) == 0 {
+			continue
+		}
+		if ok := peer.sendTxInv(hashes); !ok {
+			log.WithFields(log.Fields{"module": logModule, "peer": peer.Addr(), "count": len(hashes)}).Warning("fail on announce txs to peer")
+			ps.removePeer(peer.ID())
+		}
+	}
+}
+
 func (ps *peerSet) errorHandler(peerID string, err error) {
 	if errors.Root(err) == errPeerMisbehave {
 		ps.addBanScore(peerID, 20, 0, err.Error())
@@ -394,6 +695,17 @@ func (ps *peerSet) getPeer(id string) *peer {
 	return ps.peers[id]
 }
 
+func (ps *peerSet) getPeers() []*peer {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	peers := make([]*peer, 0, len(ps.peers))
+	for _, peer := range ps.peers {
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
 func (ps *peerSet) getPeerInfos() []*PeerInfo {
 	ps.mtx.RLock()
 	defer ps.mtx.RUnlock()
@@ -405,6 +717,47 @@ func (ps *peerSet) getPeerInfos() []*PeerInfo {
 	return result
 }
 
+// isTxRequested reports whether the tx has been requested from any peer
+func (ps *peerSet) isTxRequested(hash *types.Hash) bool {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	for _, peer := range ps.peers {
+		if peer.isTxRequested(hash) {
+			return true
+		}
+	}
+	return false
+}
+
+// peersWithHeight returns the peers of the flag at or above the height
+func (ps *peerSet) peersWithHeight(flag consensus.ServiceFlag, height uint64) []*peer {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	peers := []*peer{}
+	for _, p := range ps.peers {
+		if p.services.IsEnable(flag) && p.Height() >= height {
+			peers = append(peers, p)
+		}
+	}
+	return peers
+}
+
+// peersWithTx returns the peers which have announced or sent the tx
+func (ps *peerSet) peersWithTx(hash *types.Hash) []*peer {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	peers := []*peer{}
+	for _, peer := range ps.peers {
+		if peer.knownTxs.Has(hash.String()) {
+			peers = append(peers, peer)
+		}
+	}
+	return peers
+}
+
 func (ps *peerSet) peersWithoutBlock(hash *types.Hash) []*peer {
 	ps.mtx.RLock()
 	defer ps.mtx.RUnlock()
@@ -437,3 +790,11 @@ func (ps *peerSet) removePeer(peerID string) {
 	ps.mtx.Unlock()
 	ps.StopPeerGracefully(peerID)
 }
+
+func (ps *peerSet) removePeersByBanKey(key string) {
+	for _, peer := range ps.getPeers() {
+		if peer.BanKey() == key {
+			ps.removePeer(peer.ID())
+		}
+	}
+}
@@ -20,16 +20,22 @@ var (
 	errStatusRequest            = errors.New("Status request error")
 )
 
+// msgHandler is the sync manager handling the messages of the peers
+type msgHandler interface {
+	processMsg(basePeer BasePeer, msgType byte, msg BlockchainMessage)
+	syncTransactions(peerID string)
+}
+
 //ProtocolReactor handles new coming protocol message.
 type ProtocolReactor struct {
 	p2p.BaseReactor
 
-	sm    *SyncManager
+	sm    msgHandler
 	peers *peerSet
 }
 
 // NewProtocolReactor returns the reactor of whole blockchain.
-func NewProtocolReactor(sm *SyncManager, peers *peerSet) *ProtocolReactor {
+func NewProtocolReactor(sm msgHandler, peers *peerSet) *ProtocolReactor {
 	pr := &ProtocolReactor{
 		sm:    sm,
 		peers: peers,
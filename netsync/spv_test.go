@@ -0,0 +1,55 @@
+package netsync
+
+import (
+	"testing"
+
+	_ "github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+func TestRelatedTxs(t *testing.T) {
+	txs := []*types.Tx{}
+	for i := 0; i < 4; i++ {
+		tx := types.MockTx()
+		tx.Evidences = []types.Evidence{*types.MockEvidence()}
+		txs = append(txs, tx)
+	}
+
+	p := newPeer(0, nil, &windowPeer{id: "spv"})
+	p.addFilterAddresses([][]byte{txs[0].Outputs[0].ScriptHash[:], txs[1].Inputs[0].RedeemScript, txs[3].Evidences[0].Digest})
+
+	related := p.getRelatedTxs(txs)
+	if len(related) != 3 || related[0] != txs[0] || related[1] != txs[1] || related[2] != txs[3] {
+		t.Fatalf("got %d related txs", len(related))
+	}
+	if p.isRelatedTx(txs[2]) {
+		t.Errorf("unrelated tx matches the filter")
+	}
+}
+
+func TestMerkleBlockMessage(t *testing.T) {
+	block := mockTxsBlock(mockBlocks(nil, 0)[0], mockTxs(8))
+	msg, err := NewMerkleBlockMessage(block, []*types.Tx{block.Transactions[2], block.Transactions[5]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, decoded, err := DecodeMessage(cdc.MustMarshalBinaryLengthPrefixed(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg = decoded.(*MerkleBlockMessage)
+	if err := msg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	header, err := msg.GetBlockHeader()
+	if err != nil || header.Hash() != block.Hash() {
+		t.Fatalf("got header err %v", err)
+	}
+
+	msg.RawTxDatas[0], msg.RawTxDatas[1] = msg.RawTxDatas[1], msg.RawTxDatas[0]
+	if err := msg.Validate(); err == nil {
+		t.Errorf("merkle block with swapped txs is valid")
+	}
+}
@@ -6,9 +6,14 @@ import (
 	"net"
 	"time"
 
+	dbm "github.com/tendermint/tmlibs/db"
 	"github.com/tendermint/tmlibs/flowrate"
 
 	"github.com/clarenous/go-capsule/consensus"
+	"github.com/clarenous/go-capsule/event"
+	"github.com/clarenous/go-capsule/p2p"
+	"github.com/clarenous/go-capsule/p2p/connection"
+	core "github.com/clarenous/go-capsule/protocol"
 	"github.com/clarenous/go-capsule/protocol/types"
 	"github.com/clarenous/go-capsule/test/mock"
 )
@@ -38,10 +43,22 @@ func (p *P2PPeer) Addr() net.Addr {
 	return p.ip
 }
 
+func (p *P2PPeer) BanKey() string {
+	return p.ip.String()
+}
+
+func (p *P2PPeer) ChannelStatus() []connection.ChannelStatus {
+	return nil
+}
+
 func (p *P2PPeer) ID() string {
 	return p.id
 }
 
+func (p *P2PPeer) Latency() time.Duration {
+	return 0
+}
+
 func (p *P2PPeer) ServiceFlag() consensus.ServiceFlag {
 	return p.flag
 }
@@ -55,8 +72,13 @@ func (p *P2PPeer) TrafficStatus() (*flowrate.Status, *flowrate.Status) {
 	return nil, nil
 }
 
+// TrySend encodes the message by the codec of the protocol, the peers wrap the
+// messages in a struct for the encoding of the connection.
 func (p *P2PPeer) TrySend(b byte, msg interface{}) bool {
-	msgBytes := cdc.MustMarshalBinary(msg)
+	if wrapped, ok := msg.(struct{ BlockchainMessage }); ok {
+		msg = wrapped.BlockchainMessage
+	}
+	msgBytes := cdc.MustMarshalBinaryLengthPrefixed(msg)
 	if p.async {
 		p.msgCh <- msgBytes
 	} else {
@@ -120,11 +142,31 @@ func (nw *NetWork) HandsShake(nodeA, nodeB *SyncManager) (*P2PPeer, *P2PPeer, er
 	return &B2A, &A2B, nil
 }
 
+// mockSwitch advertises the services of the node and serves an address book
+// in memory
+type mockSwitch struct {
+	Switch
+	nodeInfo *p2p.NodeInfo
+	addrBook *p2p.AddrBook
+}
+
+func newMockSwitch(flag consensus.ServiceFlag) *mockSwitch {
+	addrBook, _ := p2p.NewAddrBook(dbm.NewMemDB())
+	return &mockSwitch{
+		nodeInfo: &p2p.NodeInfo{Protocol: p2p.ProtocolVersion, Services: uint64(flag)},
+		addrBook: addrBook,
+	}
+}
+
+func (sw *mockSwitch) AddReactor(name string, reactor p2p.Reactor) p2p.Reactor { return reactor }
+func (sw *mockSwitch) AddrBook() *p2p.AddrBook                                 { return sw.addrBook }
+func (sw *mockSwitch) NodeInfo() *p2p.NodeInfo                                 { return sw.nodeInfo }
+
 func mockBlocks(startBlock *types.Block, height uint64) []*types.Block {
 	blocks := []*types.Block{}
 	indexBlock := &types.Block{}
 	if startBlock == nil {
-		indexBlock = &types.Block{BlockHeader: types.BlockHeader{Nonce: uint64(rand.Uint32())}}
+		indexBlock = &types.Block{BlockHeader: types.BlockHeader{Timestamp: uint64(rand.Uint32()), Proof: types.MockProof()}}
 		blocks = append(blocks, indexBlock)
 	} else {
 		indexBlock = startBlock
@@ -133,9 +175,10 @@ func mockBlocks(startBlock *types.Block, height uint64) []*types.Block {
 	for indexBlock.Height < height {
 		block := &types.Block{
 			BlockHeader: types.BlockHeader{
-				Height:            indexBlock.Height + 1,
-				PreviousBlockHash: indexBlock.Hash(),
-				Nonce:             uint64(rand.Uint32()),
+				Height:    indexBlock.Height + 1,
+				Previous:  indexBlock.Hash(),
+				Timestamp: uint64(rand.Uint32()),
+				Proof:     types.MockProof(),
 			},
 		}
 		blocks = append(blocks, block)
@@ -154,36 +197,36 @@ func mockSync(blocks []*types.Block) *SyncManager {
 
 	genesis, _ := chain.GetHeaderByHeight(0)
 	return &SyncManager{
-		genesisHash: genesis.Hash(),
-		chain:       chain,
-		blockKeeper: newBlockKeeper(chain, peers),
-		peers:       peers,
+		sw:            newMockSwitch(consensus.DefaultServices),
+		genesisHash:   genesis.Hash(),
+		chain:         chain,
+		txPool:        core.NewTxPool(&mock.Store{}, event.NewDispatcher()),
+		blockFetcher:  newBlockFetcher(chain, peers),
+		blockKeeper:   newBlockKeeper(chain, peers),
+		peers:         peers,
+		compactBlocks: newCompactBlockPool(),
 	}
 }
 
-func mockTxs(txCount int) ([]*types.Tx, []*types.Tx) {
-	var txs []*types.Tx
-	var bcTxs []*types.Tx
+func mockTxs(txCount int) []*types.Tx {
+	txs := []*types.Tx{}
 	for i := 0; i < txCount; i++ {
-		trueProg := mockControlProgram(60)
-		assetID := types.ComputeAssetID(trueProg, 1, &types.EmptyHash)
-		now := []byte(time.Now().String())
-		issuanceInp := types.NewIssuanceInput(now, 1, trueProg, nil, nil)
-		tx := types.NewTx(types.TxData{
-			Version: 1,
-			Inputs:  []*types.TxInput{issuanceInp},
-			Outputs: []*types.TxOutput{types.NewTxOutput(assetID, 1, trueProg)},
-		})
-		txs = append(txs, tx)
-		bcTxs = append(bcTxs, tx.Tx)
+		txs = append(txs, types.MockTx())
 	}
-	return txs, bcTxs
-}
-
-func mockControlProgram(length int) []byte {
-	var cp []byte
-	for i := 0; i < length; i++ {
-		cp = append(cp, byte(rand.Intn(1<<8)))
+	return txs
+}
+
+// mockTxsBlock returns the block of the txs following the previous block
+func mockTxsBlock(previous *types.Block, txs []*types.Tx) *types.Block {
+	block := &types.Block{
+		BlockHeader: types.BlockHeader{
+			Height:    previous.Height + 1,
+			Previous:  previous.Hash(),
+			Timestamp: uint64(rand.Uint32()),
+			Proof:     types.MockProof(),
+		},
+		Transactions: txs,
 	}
-	return cp
+	block.TransactionRoot, _ = types.TxMerkleRoot(txs)
+	return block
 }
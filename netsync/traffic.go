@@ -0,0 +1,83 @@
+package netsync
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/clarenous/go-capsule/p2p/connection"
+)
+
+// ChannelInfo is the traffic of a p2p channel of the peer
+type ChannelInfo struct {
+	ID               byte   `json:"channel_id"`
+	SentBytes        uint64 `json:"sent_bytes"`
+	ReceivedBytes    uint64 `json:"received_bytes"`
+	SentMessages     uint64 `json:"sent_messages"`
+	ReceivedMessages uint64 `json:"received_messages"`
+}
+
+// NetTotals is the traffic and the rate limits of all the peers, 0 limit
+// means unlimited
+type NetTotals struct {
+	TotalSent           int64 `json:"total_sent"`
+	TotalReceived       int64 `json:"total_received"`
+	CurrentSentRate     int64 `json:"current_sent_rate"`
+	CurrentReceivedRate int64 `json:"current_received_rate"`
+	SendRateLimit       int64 `json:"send_rate_limit"`
+	RecvRateLimit       int64 `json:"recv_rate_limit"`
+}
+
+func newChannelInfos(status []connection.ChannelStatus) []*ChannelInfo {
+	infos := make([]*ChannelInfo, 0, len(status))
+	for _, s := range status {
+		infos = append(infos, &ChannelInfo{
+			ID:               s.ID,
+			SentBytes:        s.SentBytes,
+			ReceivedBytes:    s.RecvBytes,
+			SentMessages:     s.SentMessages,
+			ReceivedMessages: s.RecvMessages,
+		})
+	}
+	return infos
+}
+
+// msgCounter counts the messages by type name.
+type msgCounter struct {
+	mtx    sync.Mutex
+	counts map[string]uint64
+}
+
+func (c *msgCounter) add(msg interface{}) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.counts == nil {
+		c.counts = make(map[string]uint64)
+	}
+	c.counts[messageName(msg)]++
+}
+
+func (c *msgCounter) snapshot() map[string]uint64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	counts := make(map[string]uint64, len(c.counts))
+	for name, count := range c.counts {
+		counts[name] = count
+	}
+	return counts
+}
+
+// messageName is the type name of the message, the message wrapper for the
+// amino interface encoding is removed.
+func messageName(msg interface{}) string {
+	if wrapper, ok := msg.(struct{ BlockchainMessage }); ok {
+		msg = wrapper.BlockchainMessage
+	}
+
+	t := reflect.TypeOf(msg)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
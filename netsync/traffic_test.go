@@ -0,0 +1,45 @@
+package netsync
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clarenous/go-capsule/p2p/connection"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+type trafficPeer struct {
+	windowPeer
+	status []connection.ChannelStatus
+}
+
+func (p *trafficPeer) ChannelStatus() []connection.ChannelStatus { return p.status }
+
+func TestMessageCounters(t *testing.T) {
+	basePeer := &trafficPeer{windowPeer: windowPeer{id: "traffic"}}
+	p := newPeer(0, nil, basePeer)
+
+	p.getAddrs()
+	p.getAddrs()
+	p.getHeaders(nil, &types.Hash{})
+	p.msgsReceived.add(&GetAddrMessage{})
+
+	wantSent := map[string]uint64{"GetAddrMessage": 2, "GetHeadersMessage": 1}
+	if got := p.msgsSent.snapshot(); !reflect.DeepEqual(got, wantSent) {
+		t.Errorf("got sent counts %v, want %v", got, wantSent)
+	}
+	wantReceived := map[string]uint64{"GetAddrMessage": 1}
+	if got := p.msgsReceived.snapshot(); !reflect.DeepEqual(got, wantReceived) {
+		t.Errorf("got received counts %v, want %v", got, wantReceived)
+	}
+}
+
+func TestNewChannelInfos(t *testing.T) {
+	infos := newChannelInfos([]connection.ChannelStatus{
+		{ID: 0x40, SentBytes: 100, RecvBytes: 200, SentMessages: 1, RecvMessages: 2},
+	})
+	want := []*ChannelInfo{{ID: 0x40, SentBytes: 100, ReceivedBytes: 200, SentMessages: 1, ReceivedMessages: 2}}
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("got channel infos %v, want %v", infos, want)
+	}
+}
@@ -2,6 +2,7 @@ package netsync
 
 import (
 	"math/rand"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -10,9 +11,18 @@ import (
 )
 
 const (
-	// This is the target size for the packs of transactions sent by txSyncLoop.
-	// A pack can get larger than this if a single transactions exceeds this size.
+	// maxTxInvSize is the maximum number of tx hashes in an announcement or a
+	// request, larger ones are punished as oversized.
+	maxTxInvSize = 1000
+	// maxTxMsgSize is the maximum size of a raw tx sent in response to a request
+	maxTxMsgSize = maxBlockchainResponseSize / 4
+
+	// txSyncPackSize is the target size for the packs of transactions pushed
+	// to the legacy peers by txSyncLoop.
 	txSyncPackSize = 100 * 1024
+
+	txInvInterval    = 500 * time.Millisecond
+	txRequestTimeout = 30 * time.Second
 )
 
 type txSyncMsg struct {
@@ -33,9 +43,18 @@ func (sm *SyncManager) syncTransactions(peerID string) {
 	sm.txSyncCh <- &txSyncMsg{peerID, txs}
 }
 
+// txBroadcastLoop queues the new txs of the mempool to be announced, the
+// queues are flushed every txInvInterval in batches.
 func (sm *SyncManager) txBroadcastLoop() {
+	ticker := time.NewTicker(txInvInterval)
+	defer ticker.Stop()
+
 	for {
 		select {
+		case <-ticker.C:
+			sm.peers.flushTxInvs()
+			sm.retryTxRequests()
+
 		case obj, ok := <-sm.txMsgSub.Chan():
 			if !ok {
 				log.WithFields(log.Fields{"module": logModule}).Warning("mempool tx msg subscription channel closed")
@@ -60,10 +79,89 @@ func (sm *SyncManager) txBroadcastLoop() {
 	}
 }
 
+// handleTxInvMsg requests the announced txs which are neither in the mempool
+// nor requested from other peers yet.
+func (sm *SyncManager) handleTxInvMsg(peer *peer, msg *TxInvMessage) {
+	if len(msg.RawHashes) > maxTxInvSize {
+		sm.peers.addBanScore(peer.ID(), 20, 0, "oversized tx inventory")
+		return
+	}
+
+	unknown := []*types.Hash{}
+	for _, hash := range msg.GetHashes() {
+		peer.markTransaction(hash)
+		if sm.txPool.HaveTransaction(hash) || sm.peers.isTxRequested(hash) {
+			continue
+		}
+		unknown = append(unknown, hash)
+	}
+
+	if len(unknown) == 0 {
+		return
+	}
+	if ok := peer.getTxs(unknown); !ok {
+		sm.peers.removePeer(peer.ID())
+	}
+}
+
+// handleGetTxsMsg sends the requested txs of the mempool to the peer
+func (sm *SyncManager) handleGetTxsMsg(peer *peer, msg *GetTxsMessage) {
+	if len(msg.RawHashes) > maxTxInvSize {
+		sm.peers.addBanScore(peer.ID(), 20, 0, "oversized tx request")
+		return
+	}
+
+	txs := []*types.Tx{}
+	for _, hash := range msg.GetHashes() {
+		txDesc, err := sm.txPool.GetTransaction(hash)
+		if err != nil {
+			continue
+		}
+		txs = append(txs, txDesc.Tx)
+	}
+
+	if len(txs) == 0 {
+		return
+	}
+	ok, err := peer.sendTransactions(txs)
+	if !ok {
+		sm.peers.removePeer(peer.ID())
+	}
+	if err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on handleGetTxsMsg sendTransactions")
+	}
+}
+
+// retryTxRequests drops the tx requests timed out and requests the txs again
+// from other peers which have announced them.
+func (sm *SyncManager) retryTxRequests() {
+	deadline := time.Now().Add(-txRequestTimeout)
+	for _, peer := range sm.peers.getPeers() {
+		expired := peer.expiredTxRequests(deadline)
+		if len(expired) == 0 {
+			continue
+		}
+
+		log.WithFields(log.Fields{"module": logModule, "peer": peer.Addr(), "count": len(expired)}).Debug("tx requests timed out")
+		sm.peers.addBanScore(peer.ID(), 0, uint64(len(expired)), "tx request timeout")
+		for _, hash := range expired {
+			if sm.txPool.HaveTransaction(hash) || sm.peers.isTxRequested(hash) {
+				continue
+			}
+
+			for _, other := range sm.peers.peersWithTx(hash) {
+				if other.ID() != peer.ID() && other.getTxs([]*types.Hash{hash}) {
+					break
+				}
+			}
+		}
+	}
+}
+
 // txSyncLoop takes care of the initial transaction sync for each new
 // connection. When a new peer appears, we relay all currently pending
 // transactions. In order to minimise egress bandwidth usage, we send
-// the transactions in small packs to one peer at a time.
+// the announcements or the transactions in small packs to one peer at a time.
 func (sm *SyncManager) txSyncLoop() {
 	pending := make(map[string]*txSyncMsg)
 	sending := false            // whether a send is active
@@ -77,29 +175,45 @@ func (sm *SyncManager) txSyncLoop() {
 			return
 		}
 
+		// the legacy peers are pushed the txs in packs of txSyncPackSize bytes
+		relay := peer.isTxRelayNode()
+		txs := []*types.Tx{}
 		totalSize := uint64(0)
-		sendTxs := []*types.Tx{}
-		for i := 0; i < len(msg.txs) && totalSize < txSyncPackSize; i++ {
-			sendTxs = append(sendTxs, msg.txs[i])
-			totalSize += msg.txs[i].SerializedSize()
+		sent := 0
+		for ; sent < len(msg.txs) && len(txs) < maxTxInvSize && (relay || totalSize < txSyncPackSize); sent++ {
+			if peer.isSPVNode() && !peer.isRelatedTx(msg.txs[sent]) {
+				continue
+			}
+			txs = append(txs, msg.txs[sent])
+			totalSize += msg.txs[sent].SerializedSize()
 		}
 
-		if len(msg.txs) == len(sendTxs) {
+		if len(msg.txs) == sent {
 			delete(pending, msg.peerID)
 		} else {
-			msg.txs = msg.txs[len(sendTxs):]
+			msg.txs = msg.txs[sent:]
 		}
 
 		// Send the pack in the background.
 		log.WithFields(log.Fields{
 			"module": logModule,
-			"count":  len(sendTxs),
-			"bytes":  totalSize,
+			"count":  len(txs),
+			"relay":  relay,
 			"peer":   msg.peerID,
 		}).Debug("txSyncLoop sending transactions")
 		sending = true
 		go func() {
-			ok, err := peer.sendTransactions(sendTxs)
+			var ok bool
+			var err error
+			if relay {
+				hashes := make([]*types.Hash, len(txs))
+				for i, tx := range txs {
+					hashes[i] = tx.Hash().Ptr()
+				}
+				ok = peer.sendTxInv(hashes)
+			} else {
+				ok, err = peer.sendTransactions(txs)
+			}
 			if !ok {
 				sm.peers.removePeer(msg.peerID)
 			}
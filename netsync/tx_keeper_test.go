@@ -0,0 +1,66 @@
+package netsync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clarenous/go-capsule/consensus"
+	"github.com/clarenous/go-capsule/protocol/types"
+	"github.com/clarenous/go-capsule/test/mock"
+)
+
+func TestBroadcastTx(t *testing.T) {
+	legacyServices := consensus.SFFullNode | consensus.SFFastSync
+	cases := []struct {
+		services consensus.ServiceFlag
+		wantSent map[string]uint64
+	}{
+		{services: consensus.DefaultServices, wantSent: map[string]uint64{"TxInvMessage": 1, "TransactionMessage": 1}},
+		{services: legacyServices, wantSent: map[string]uint64{"TxInvMessage": 0, "TransactionMessage": 1}},
+	}
+
+	for i, c := range cases {
+		blocks := mockBlocks(nil, 1)
+		a := mockSync(blocks)
+		b := mockSync(blocks)
+		tx := types.MockTx()
+		if _, err := a.txPool.ProcessTransaction(tx, 1); err != nil {
+			t.Fatal(err)
+		}
+
+		netWork := NewNetWork()
+		netWork.Register(a, "192.168.0.1", "test node A", c.services)
+		netWork.Register(b, "192.168.0.2", "test node B", c.services)
+		B2A, A2B, err := netWork.HandsShake(a, b)
+		if err != nil {
+			t.Fatalf("fail on peer hands shake %v", err)
+		}
+		go B2A.postMan()
+		go A2B.postMan()
+
+		if err := a.peers.broadcastTx(tx); err != nil {
+			t.Fatal(err)
+		}
+		a.peers.flushTxInvs()
+
+		chain := b.chain.(*mock.Chain)
+		for start := time.Now(); len(chain.ValidatedTxs()) == 0; time.Sleep(10 * time.Millisecond) {
+			if time.Since(start) > 5*time.Second {
+				t.Fatalf("case %d: tx is not relayed", i)
+			}
+		}
+
+		if got := chain.ValidatedTxs()[0]; got.Hash() != tx.Hash() {
+			t.Errorf("case %d: got tx %v", i, got.Hash())
+		}
+		sent := a.peers.getPeer("test node B").msgsSent.snapshot()
+		for name, count := range c.wantSent {
+			if sent[name] != count {
+				t.Errorf("case %d: got sent messages %v, want %v", i, sent, c.wantSent)
+			}
+		}
+		if score := b.peers.getPeer("test node A").banScore.Int(); score != 0 {
+			t.Errorf("case %d: got ban score %d", i, score)
+		}
+	}
+}
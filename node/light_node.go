@@ -0,0 +1,64 @@
+package node
+
+import (
+	"encoding/hex"
+	"strings"
+
+	cmn "github.com/tendermint/tmlibs/common"
+	dbm "github.com/tendermint/tmlibs/db"
+
+	cfg "github.com/clarenous/go-capsule/config"
+	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/light"
+	"github.com/clarenous/go-capsule/netsync"
+)
+
+// newLightNode creates the node syncing only the headers and the merkle
+// proofs of the watched txs, the api is served by the header chain.
+func newLightNode(config *cfg.Config) *Node {
+	if config.Mining || config.Stratum.Enable || config.VaultMode {
+		cmn.Exit("Error: mining, stratum and vault mode are not available in light mode")
+	}
+
+	watched, err := parseLightWatch(config.LightWatch)
+	if err != nil {
+		cmn.Exit(cmn.Fmt("Failed to parse light_watch: %v", err))
+	}
+
+	lightDB := dbm.NewDB("light", dbm.LevelDBBackend, config.DBDir())
+	chain, err := light.NewChain(light.NewStore(lightDB))
+	if err != nil {
+		cmn.Exit(cmn.Fmt("Failed to create light chain structure: %v", err))
+	}
+
+	lightSync, err := netsync.NewLightSyncManager(config, chain, watched)
+	if err != nil {
+		cmn.Exit(cmn.Fmt("Failed to create light sync manager: %v", err))
+	}
+
+	node := &Node{
+		config:     config,
+		lightChain: chain,
+		lightSync:  lightSync,
+	}
+	node.BaseService = *cmn.NewBaseService(nil, "Node", node)
+	return node
+}
+
+// parseLightWatch decodes the comma delimited hex script hashes and evidence
+// digests watched by the light client.
+func parseLightWatch(watch string) ([][]byte, error) {
+	watched := [][]byte{}
+	for _, item := range strings.Split(watch, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		data, err := hex.DecodeString(item)
+		if err != nil {
+			return nil, errors.Wrap(err, item)
+		}
+		watched = append(watched, data)
+	}
+	return watched, nil
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"encoding/hex"
 	"errors"
 	"github.com/clarenous/go-capsule/event"
 	"github.com/clarenous/go-capsule/p2p"
@@ -18,9 +19,13 @@ import (
 	"github.com/clarenous/go-capsule/api"
 	cfg "github.com/clarenous/go-capsule/config"
 	"github.com/clarenous/go-capsule/consensus"
+	ca "github.com/clarenous/go-capsule/consensus/algorithm"
+	"github.com/clarenous/go-capsule/crypto/ed25519"
 	"github.com/clarenous/go-capsule/database/leveldb"
+	"github.com/clarenous/go-capsule/light"
 	"github.com/clarenous/go-capsule/mining/cpuminer"
 	"github.com/clarenous/go-capsule/mining/miningpool"
+	"github.com/clarenous/go-capsule/mining/stratum"
 	"github.com/clarenous/go-capsule/netsync"
 	"github.com/clarenous/go-capsule/protocol"
 )
@@ -38,11 +43,14 @@ type Node struct {
 	eventDispatcher *event.Dispatcher
 	syncManager     *netsync.SyncManager
 
-	api          *api.API
-	chain        *protocol.Chain
-	cpuMiner     *cpuminer.CPUMiner
-	miningPool   *miningpool.MiningPool
-	miningEnable bool
+	api           *api.API
+	chain         *protocol.Chain
+	lightChain    *light.Chain
+	lightSync     *netsync.LightSyncManager
+	cpuMiner      *cpuminer.CPUMiner
+	miningPool    *miningpool.MiningPool
+	stratumServer *stratum.Server
+	miningEnable  bool
 }
 
 // NewNode create bytom node
@@ -53,6 +61,9 @@ func NewNode(config *cfg.Config) *Node {
 	initLogFile(config)
 	initActiveNetParams(config)
 	initCommonConfig(config)
+	if config.Light {
+		return newLightNode(config)
+	}
 
 	// Get store
 	if config.DBBackend != "memdb" && config.DBBackend != "leveldb" {
@@ -63,7 +74,7 @@ func NewNode(config *cfg.Config) *Node {
 
 	dispatcher := event.NewDispatcher()
 	txPool := protocol.NewTxPool(store, dispatcher)
-	chain, err := protocol.NewChain(store, txPool)
+	chain, err := protocol.NewChain(store, txPool, dispatcher)
 	if err != nil {
 		cmn.Exit(cmn.Fmt("Failed to create chain structure: %v", err))
 	}
@@ -90,12 +101,21 @@ func NewNode(config *cfg.Config) *Node {
 		config:          config,
 		syncManager:     syncManager,
 		chain:           chain,
-		//miningEnable:    config.Mining,
-		miningEnable: true,
+		miningEnable:    config.Mining,
 	}
 
 	node.cpuMiner = cpuminer.NewCPUMiner(chain, txPool, dispatcher)
+	if config.AuthorityKey != "" {
+		key, err := hex.DecodeString(config.AuthorityKey)
+		if err != nil || len(key) != ed25519.PrivateKeySize {
+			cmn.Exit(cmn.Fmt("invalid authority key"))
+		}
+		node.cpuMiner.SetAuthorityKey(ed25519.PrivateKey(key))
+	}
 	node.miningPool = miningpool.NewMiningPool(chain, txPool, dispatcher)
+	if config.Stratum.Enable {
+		node.stratumServer = stratum.NewServer(chain, node.miningPool, config.Stratum.ShareDifficulty)
+	}
 
 	node.BaseService = *cmn.NewBaseService(nil, "Node", node)
 
@@ -112,11 +132,30 @@ func lockDataDirectory(config *cfg.Config) error {
 }
 
 func initActiveNetParams(config *cfg.Config) {
+	if config.ChainSpec != "" {
+		spec, err := cfg.LoadChainSpec(config.ChainSpecFile())
+		if err != nil {
+			cmn.Exit(cmn.Fmt("Failed to load chain spec: %v", err))
+		}
+		if config.ChainID == "" {
+			config.ChainID = spec.ChainID
+		}
+		if spec.ChainID != config.ChainID {
+			cmn.Exit(cmn.Fmt("chain_id[%v] doesn't match the chain spec [%v]", config.ChainID, spec.ChainID))
+		}
+		if err := cfg.RegisterChainSpec(spec); err != nil {
+			cmn.Exit(cmn.Fmt("Failed to register chain spec: %v", err))
+		}
+	}
+
 	var exist bool
 	consensus.ActiveNetParams, exist = consensus.NetParams[config.ChainID]
 	if !exist {
 		cmn.Exit(cmn.Fmt("chain_id[%v] don't exist", config.ChainID))
 	}
+	if err := ca.SetProofForks(consensus.ActiveNetParams.ProofForks); err != nil {
+		cmn.Exit(cmn.Fmt("chain_id[%v] has invalid consensus forks: %v", config.ChainID, err))
+	}
 }
 
 func initLogFile(config *cfg.Config) {
@@ -148,11 +187,22 @@ func launchWebBrowser(port string) {
 }
 
 func (n *Node) initAndstartAPIServer() error {
-	n.api = api.NewAPI(n.chain, n.cpuMiner, n.syncManager)
+	if n.lightChain != nil {
+		n.api = api.NewLightAPI(n.lightChain, n.config)
+	} else {
+		n.api = api.NewAPI(n.chain, n.cpuMiner, n.miningPool, n.syncManager, n.eventDispatcher, n.config)
+	}
 	return n.api.Start()
 }
 
 func (n *Node) OnStart() error {
+	if n.lightChain != nil {
+		if err := n.lightSync.Start(); err != nil {
+			return err
+		}
+		return n.initAndstartAPIServer()
+	}
+
 	if n.miningEnable {
 		//if _, err := n.wallet.AccountMgr.GetMiningAddress(); err != nil {
 		//	n.miningEnable = false
@@ -173,6 +223,12 @@ func (n *Node) OnStart() error {
 		return err
 	}
 
+	if n.stratumServer != nil {
+		if err := n.stratumServer.Start(n.config.Stratum.ListenAddress); err != nil {
+			return err
+		}
+	}
+
 	if !n.config.Web.Closed {
 		_, port, err := net.SplitHostPort(n.config.ApiAddress)
 		if err != nil {
@@ -186,8 +242,16 @@ func (n *Node) OnStart() error {
 
 func (n *Node) OnStop() {
 	n.BaseService.OnStop()
-	if n.miningEnable {
-		n.cpuMiner.Stop()
+	if n.lightChain != nil {
+		n.api.Stop()
+		n.lightSync.Stop()
+		return
+	}
+
+	// mining may be started through the api even if it is disabled on start
+	n.cpuMiner.Stop()
+	if n.stratumServer != nil {
+		n.stratumServer.Stop()
 	}
 	if !n.config.VaultMode {
 		n.syncManager.Stop()
@@ -203,6 +267,9 @@ func (n *Node) RunForever() {
 }
 
 func (n *Node) NodeInfo() *p2p.NodeInfo {
+	if n.lightChain != nil {
+		return n.lightSync.NodeInfo()
+	}
 	return n.syncManager.NodeInfo()
 }
 
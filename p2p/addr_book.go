@@ -0,0 +1,365 @@
+package p2p
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	mrand "math/rand"
+	"net"
+	"sync"
+	"time"
+
+	dbm "github.com/tendermint/tmlibs/db"
+
+	"github.com/clarenous/go-capsule/errors"
+)
+
+const (
+	addrBookKey = "AddrBook"
+
+	newBucketCount   = 64
+	newBucketSize    = 64
+	triedBucketCount = 16
+	triedBucketSize  = 64
+
+	// tried addresses are this many times more likely to be picked for dialing
+	triedBias = 4
+	// addresses never connected are bad after this many attempts
+	numRetries = 3
+	// addresses connected before are bad after this many attempts
+	maxFailures = 10
+	// addresses not connected for this long are not trusted anymore
+	minBadDuration = 7 * 24 * time.Hour
+	// recently attempted addresses are rarely picked again
+	recentAttempt = 10 * time.Minute
+)
+
+var ErrInvalidAddress = errors.New("invalid address")
+
+// knownAddress is an address of the book with its connection history.
+type knownAddress struct {
+	Addr        *NetAddress `json:"addr"`
+	Src         *NetAddress `json:"src"`
+	Added       time.Time   `json:"added"`
+	Attempts    int         `json:"attempts"`
+	LastAttempt time.Time   `json:"last_attempt"`
+	LastSuccess time.Time   `json:"last_success"`
+	Tried       bool        `json:"tried"`
+
+	bucket int
+}
+
+// chance is the relative probability of the address to be picked for dialing.
+func (ka *knownAddress) chance() float64 {
+	c := 1.0
+	if time.Since(ka.LastAttempt) < recentAttempt {
+		c *= 0.01
+	}
+	for i := 0; i < ka.Attempts && i < 8; i++ {
+		c *= 0.66
+	}
+	if ka.Tried {
+		c *= triedBias
+	}
+	return c
+}
+
+// isBad tells whether the address failed too often to be worth keeping.
+func (ka *knownAddress) isBad() bool {
+	if ka.LastSuccess.IsZero() {
+		return ka.Attempts >= numRetries
+	}
+	return ka.Attempts >= maxFailures && time.Since(ka.LastSuccess) > minBadDuration
+}
+
+// AddrBook keeps the known addresses of the network in the db, addresses
+// never connected are bucketed by their source group and network group,
+// connected addresses are moved to the tried buckets of their network group.
+type AddrBook struct {
+	mtx          sync.Mutex
+	db           dbm.DB
+	key          []byte
+	addrs        map[string]*knownAddress
+	newBuckets   [newBucketCount]map[string]*knownAddress
+	triedBuckets [triedBucketCount]map[string]*knownAddress
+}
+
+type addrBookJSON struct {
+	Key   []byte          `json:"key"`
+	Addrs []*knownAddress `json:"addrs"`
+}
+
+// NewAddrBook creates the address book and loads the addresses saved in the db.
+func NewAddrBook(db dbm.DB) (*AddrBook, error) {
+	a := &AddrBook{
+		db:    db,
+		addrs: make(map[string]*knownAddress),
+	}
+	for i := range a.newBuckets {
+		a.newBuckets[i] = make(map[string]*knownAddress)
+	}
+	for i := range a.triedBuckets {
+		a.triedBuckets[i] = make(map[string]*knownAddress)
+	}
+	return a, a.load()
+}
+
+// AddAddress adds the addr learned from src to the book, known addresses are
+// left untouched. The addresses gossiped by the peers must be routable.
+func (a *AddrBook) AddAddress(addr, src *NetAddress) error {
+	if addr != nil && !addr.Equals(src) && !addr.Routable() {
+		return ErrInvalidAddress
+	}
+	return a.addAddress(addr, src)
+}
+
+// addAddress adds the addr learned from src to the book without the routable
+// check, for the addresses verified by the caller.
+func (a *AddrBook) addAddress(addr, src *NetAddress) error {
+	if addr == nil || !addr.Valid() || addr.Port == 0 {
+		return ErrInvalidAddress
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if _, ok := a.addrs[addr.String()]; ok {
+		return nil
+	}
+	a.addNew(&knownAddress{Addr: addr, Src: src, Added: time.Now()})
+	return nil
+}
+
+// GetSelection returns up to n random addresses which are not bad, for
+// gossiping to the peers.
+func (a *AddrBook) GetSelection(n int) []*NetAddress {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	result := []*NetAddress{}
+	for _, ka := range a.addrs {
+		if !ka.isBad() {
+			result = append(result, ka.Addr)
+		}
+	}
+	mrand.Shuffle(len(result), func(i, j int) { result[i], result[j] = result[j], result[i] })
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// MarkAttempt records a dial attempt to the addr.
+func (a *AddrBook) MarkAttempt(addr *NetAddress) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if ka, ok := a.addrs[addr.String()]; ok {
+		ka.Attempts++
+		ka.LastAttempt = time.Now()
+	}
+}
+
+// MarkGood records a successful connection to the addr and moves it to the
+// tried buckets.
+func (a *AddrBook) MarkGood(addr *NetAddress) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka, ok := a.addrs[addr.String()]
+	if !ok {
+		ka = &knownAddress{Addr: addr, Src: addr, Added: time.Now()}
+	} else if !ka.Tried {
+		a.remove(ka)
+	}
+
+	now := time.Now()
+	ka.Attempts = 0
+	ka.LastAttempt = now
+	ka.LastSuccess = now
+	if !ka.Tried {
+		a.addTried(ka)
+	}
+}
+
+// PickAddresses picks up to n addresses to dial, the addresses with good
+// history are preferred and the addresses rejected by skip are ignored.
+func (a *AddrBook) PickAddresses(n int, skip func(*NetAddress) bool) []*NetAddress {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	candidates := []*knownAddress{}
+	weights := []float64{}
+	var total float64
+	for _, ka := range a.addrs {
+		if ka.isBad() || (skip != nil && skip(ka.Addr)) {
+			continue
+		}
+		candidates = append(candidates, ka)
+		weights = append(weights, ka.chance())
+		total += ka.chance()
+	}
+
+	result := []*NetAddress{}
+	for len(result) < n && len(candidates) > 0 {
+		i, r := 0, mrand.Float64()*total
+		for ; i < len(candidates)-1 && r >= weights[i]; i++ {
+			r -= weights[i]
+		}
+
+		result = append(result, candidates[i].Addr)
+		total -= weights[i]
+		candidates = append(candidates[:i], candidates[i+1:]...)
+		weights = append(weights[:i], weights[i+1:]...)
+	}
+	return result
+}
+
+// Save writes the addresses of the book to the db.
+func (a *AddrBook) Save() error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	data := &addrBookJSON{Key: a.key, Addrs: make([]*knownAddress, 0, len(a.addrs))}
+	for _, ka := range a.addrs {
+		data.Addrs = append(data.Addrs, ka)
+	}
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	a.db.Set([]byte(addrBookKey), dataJSON)
+	return nil
+}
+
+// Size returns the count of the known addresses.
+func (a *AddrBook) Size() int {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	return len(a.addrs)
+}
+
+func (a *AddrBook) addNew(ka *knownAddress) {
+	ka.Tried = false
+	ka.bucket = a.newBucketIndex(ka.Addr, ka.Src)
+	bucket := a.newBuckets[ka.bucket]
+	if len(bucket) >= newBucketSize {
+		a.remove(a.evictionCandidate(bucket))
+	}
+
+	bucket[ka.Addr.String()] = ka
+	a.addrs[ka.Addr.String()] = ka
+}
+
+func (a *AddrBook) addTried(ka *knownAddress) {
+	ka.Tried = true
+	ka.bucket = a.triedBucketIndex(ka.Addr)
+	bucket := a.triedBuckets[ka.bucket]
+	if len(bucket) >= triedBucketSize {
+		// the tried address connected least recently goes back to the new buckets
+		var oldest *knownAddress
+		for _, old := range bucket {
+			if oldest == nil || old.LastSuccess.Before(oldest.LastSuccess) {
+				oldest = old
+			}
+		}
+		a.remove(oldest)
+		a.addNew(oldest)
+	}
+
+	bucket[ka.Addr.String()] = ka
+	a.addrs[ka.Addr.String()] = ka
+}
+
+// evictionCandidate returns a bad address of the bucket or else the oldest one.
+func (a *AddrBook) evictionCandidate(bucket map[string]*knownAddress) *knownAddress {
+	var oldest *knownAddress
+	for _, ka := range bucket {
+		if ka.isBad() {
+			return ka
+		}
+		if oldest == nil || ka.Added.Before(oldest.Added) {
+			oldest = ka
+		}
+	}
+	return oldest
+}
+
+func (a *AddrBook) load() error {
+	dataJSON := a.db.Get([]byte(addrBookKey))
+	if dataJSON == nil {
+		a.key = make([]byte, 32)
+		_, err := rand.Read(a.key)
+		return err
+	}
+
+	data := &addrBookJSON{}
+	if err := json.Unmarshal(dataJSON, data); err != nil {
+		return err
+	}
+
+	a.key = data.Key
+	for _, ka := range data.Addrs {
+		if ka.Addr == nil || ka.Src == nil {
+			continue
+		}
+		if ka.Tried {
+			a.addTried(ka)
+		} else {
+			a.addNew(ka)
+		}
+	}
+	return nil
+}
+
+func (a *AddrBook) newBucketIndex(addr, src *NetAddress) int {
+	return a.bucketIndex(newBucketCount, groupKey(src), groupKey(addr))
+}
+
+func (a *AddrBook) remove(ka *knownAddress) {
+	if ka.Tried {
+		delete(a.triedBuckets[ka.bucket], ka.Addr.String())
+	} else {
+		delete(a.newBuckets[ka.bucket], ka.Addr.String())
+	}
+	delete(a.addrs, ka.Addr.String())
+}
+
+func (a *AddrBook) triedBucketIndex(addr *NetAddress) int {
+	return a.bucketIndex(triedBucketCount, groupKey(addr))
+}
+
+// bucketIndex hashes the groups with the secret key of the book, so remote
+// peers can't predict the buckets their addresses fall into.
+func (a *AddrBook) bucketIndex(count int, groups ...string) int {
+	h := sha256.New()
+	h.Write(a.key)
+	for _, group := range groups {
+		h.Write([]byte(group))
+		h.Write([]byte{0})
+	}
+	return int(binary.LittleEndian.Uint64(h.Sum(nil)) % uint64(count))
+}
+
+// groupKey returns the network group of the address, /16 for ipv4, /32 for
+// ipv6 and the first 5 bits for onion, the addresses not routable share their
+// own groups.
+func groupKey(na *NetAddress) string {
+	if na.IsOnion() {
+		return "onion:" + na.Onion[:1]
+	}
+	if na.Local() {
+		return "local"
+	}
+	if !na.Routable() {
+		return "unroutable"
+	}
+	if ip := na.IP.To4(); ip != nil {
+		return ip.Mask(net.CIDRMask(16, 32)).String()
+	}
+	return na.IP.Mask(net.CIDRMask(32, 128)).String()
+}
@@ -0,0 +1,135 @@
+package p2p
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	dbm "github.com/tendermint/tmlibs/db"
+)
+
+func mustNetAddress(t *testing.T, addr string) *NetAddress {
+	na, err := NewNetAddressString(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return na
+}
+
+func TestAddrBookAddAndPick(t *testing.T) {
+	book, err := NewAddrBook(dbm.NewMemDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := mustNetAddress(t, "8.8.8.8:46656")
+	for i := 1; i <= 10; i++ {
+		if err := book.AddAddress(mustNetAddress(t, fmt.Sprintf("1.2.%d.4:46656", i)), src); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := book.AddAddress(mustNetAddress(t, "1.2.1.4:46656"), src); err != nil {
+		t.Fatal(err)
+	}
+	if err := book.AddAddress(&NetAddress{}, src); err != ErrInvalidAddress {
+		t.Fatal(err)
+	}
+	for _, addr := range []string{"127.0.0.1:46656", "192.168.0.1:46656", "10.0.0.1:46656"} {
+		if err := book.AddAddress(mustNetAddress(t, addr), src); err != ErrInvalidAddress {
+			t.Fatalf("add unroutable address %s from peer: %v", addr, err)
+		}
+	}
+	if book.Size() != 10 {
+		t.Fatalf("got %d addresses, want 10", book.Size())
+	}
+
+	skipped := mustNetAddress(t, "1.2.5.4:46656")
+	addrs := book.PickAddresses(20, func(addr *NetAddress) bool { return addr.Equals(skipped) })
+	if len(addrs) != 9 {
+		t.Fatalf("got %d picked addresses, want 9", len(addrs))
+	}
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		if addr.Equals(skipped) || seen[addr.String()] {
+			t.Fatalf("unexpected picked address %v", addr)
+		}
+		seen[addr.String()] = true
+	}
+}
+
+func TestAddrBookPreferGoodHistory(t *testing.T) {
+	book, err := NewAddrBook(dbm.NewMemDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	good := mustNetAddress(t, "1.2.3.4:46656")
+	bad := mustNetAddress(t, "5.6.7.8:46656")
+	book.AddAddress(good, good)
+	book.AddAddress(bad, bad)
+	book.MarkGood(good)
+	for i := 0; i < numRetries; i++ {
+		book.MarkAttempt(bad)
+	}
+
+	for i := 0; i < 10; i++ {
+		addrs := book.PickAddresses(2, nil)
+		if len(addrs) != 1 || !addrs[0].Equals(good) {
+			t.Fatalf("got picked addresses %v, want %v", addrs, good)
+		}
+	}
+	if addrs := book.GetSelection(10); len(addrs) != 1 || !addrs[0].Equals(good) {
+		t.Fatalf("got selection %v, want %v", addrs, good)
+	}
+}
+
+func TestAddrBookSaveAndLoad(t *testing.T) {
+	db := dbm.NewMemDB()
+	book, err := NewAddrBook(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tried := mustNetAddress(t, "1.2.3.4:46656")
+	fresh := mustNetAddress(t, "5.6.7.8:46656")
+	book.AddAddress(fresh, tried)
+	book.MarkGood(tried)
+	if err := book.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := NewAddrBook(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Size() != 2 {
+		t.Fatalf("got %d addresses, want 2", loaded.Size())
+	}
+
+	ka := loaded.addrs[tried.String()]
+	if !ka.Tried || time.Since(ka.LastSuccess) > time.Minute {
+		t.Fatalf("tried address is not restored: %+v", ka)
+	}
+	if _, ok := loaded.triedBuckets[loaded.triedBucketIndex(tried)][tried.String()]; !ok {
+		t.Fatal("tried address is not in its bucket")
+	}
+	if _, ok := loaded.newBuckets[loaded.newBucketIndex(fresh, tried)][fresh.String()]; !ok {
+		t.Fatal("new address is not in its bucket")
+	}
+}
+
+func TestAddrBookBucketEviction(t *testing.T) {
+	book, err := NewAddrBook(dbm.NewMemDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a single source and network group share a single new bucket
+	src := mustNetAddress(t, "8.8.8.8:46656")
+	for i := 0; i < newBucketSize*2; i++ {
+		book.AddAddress(mustNetAddress(t, fmt.Sprintf("1.2.%d.%d:46656", i/200, i%200+1)), src)
+	}
+	if book.Size() != newBucketSize {
+		t.Fatalf("got %d addresses, want %d", book.Size(), newBucketSize)
+	}
+}
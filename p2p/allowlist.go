@@ -0,0 +1,114 @@
+package p2p
+
+import (
+	"bufio"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tendermint/go-crypto"
+
+	"github.com/clarenous/go-capsule/errors"
+)
+
+const allowlistReloadInterval = 10 * time.Second
+
+var (
+	ErrConnectNotAllowed = errors.New("Connect peer not in allowlist")
+	ErrPubKeyMismatch    = errors.New("Node info pubkey mismatch the secret connection")
+	errInvalidAllowlist  = errors.New("invalid allowlist")
+)
+
+// Allowlist decides the node public keys allowed to connect in permissioned
+// mode, Reload tells whether the list changed since the last call.
+type Allowlist interface {
+	IsAllowed(crypto.PubKeyEd25519) bool
+	Reload() (bool, error)
+}
+
+// FileAllowlist is the allowlist read from a file of hex encoded node public
+// keys, one per line, the text after a '#' is a comment.
+type FileAllowlist struct {
+	mtx     sync.RWMutex
+	path    string
+	modTime time.Time
+	keys    map[crypto.PubKeyEd25519]bool
+}
+
+// NewFileAllowlist loads the allowlist from the file.
+func NewFileAllowlist(path string) (*FileAllowlist, error) {
+	a := &FileAllowlist{path: path}
+	if _, err := a.Reload(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// IsAllowed returns whether the node public key is in the allowlist.
+func (a *FileAllowlist) IsAllowed(pubKey crypto.PubKeyEd25519) bool {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	return a.keys[pubKey]
+}
+
+// Reload reads the file again when it is modified, the current list is kept
+// when the file is invalid.
+func (a *FileAllowlist) Reload() (bool, error) {
+	info, err := os.Stat(a.path)
+	if err != nil {
+		return false, err
+	}
+
+	a.mtx.RLock()
+	modTime := a.modTime
+	a.mtx.RUnlock()
+	if info.ModTime().Equal(modTime) {
+		return false, nil
+	}
+
+	file, err := os.Open(a.path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	keys, err := parseAllowlist(file)
+	if err != nil {
+		return false, err
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.keys = keys
+	a.modTime = info.ModTime()
+	return true, nil
+}
+
+func parseAllowlist(r io.Reader) (map[crypto.PubKeyEd25519]bool, error) {
+	keys := make(map[crypto.PubKeyEd25519]bool)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.Index(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+		if text = strings.TrimSpace(text); text == "" {
+			continue
+		}
+
+		var pubKey crypto.PubKeyEd25519
+		b, err := hex.DecodeString(text)
+		if err != nil || len(b) != len(pubKey) {
+			return nil, errors.WithDetailf(errInvalidAllowlist, "line %d: %s", line, text)
+		}
+
+		copy(pubKey[:], b)
+		keys[pubKey] = true
+	}
+	return keys, scanner.Err()
+}
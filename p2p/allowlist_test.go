@@ -0,0 +1,137 @@
+package p2p
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tendermint/go-crypto"
+	dbm "github.com/tendermint/tmlibs/db"
+
+	"github.com/clarenous/go-capsule/errors"
+)
+
+func TestFileAllowlist(t *testing.T) {
+	dirPath, err := ioutil.TempDir(".", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dirPath)
+
+	alice := crypto.GenPrivKeyEd25519().PubKey().Unwrap().(crypto.PubKeyEd25519)
+	bob := crypto.GenPrivKeyEd25519().PubKey().Unwrap().(crypto.PubKeyEd25519)
+	path := filepath.Join(dirPath, "allowlist")
+	content := "# consortium nodes\n" + hex.EncodeToString(alice[:]) + " # alice\n\n"
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	allowlist, err := NewFileAllowlist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !allowlist.IsAllowed(alice) || allowlist.IsAllowed(bob) {
+		t.Fatal("unexpected allowlist before reload")
+	}
+	if changed, err := allowlist.Reload(); changed || err != nil {
+		t.Fatalf("reload unmodified file: changed %v, err %v", changed, err)
+	}
+
+	content = hex.EncodeToString(bob[:]) + "\n"
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	if changed, err := allowlist.Reload(); !changed || err != nil {
+		t.Fatalf("reload modified file: changed %v, err %v", changed, err)
+	}
+	if allowlist.IsAllowed(alice) || !allowlist.IsAllowed(bob) {
+		t.Fatal("unexpected allowlist after reload")
+	}
+
+	// an invalid file keeps the current allowlist
+	if err := ioutil.WriteFile(path, []byte("not a key\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	modTime = modTime.Add(time.Second)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := allowlist.Reload(); errors.Root(err) != errInvalidAllowlist {
+		t.Fatal(err)
+	}
+	if !allowlist.IsAllowed(bob) {
+		t.Fatal("allowlist is lost on invalid reload")
+	}
+}
+
+// dialSwitch connects the node of privKey claiming the pubkey of nodeKey to
+// the switch and returns the error of the switch adding the inbound peer.
+func dialSwitch(sw *Switch, privKey crypto.PrivKeyEd25519, nodeKey crypto.PubKeyEd25519) error {
+	return sw.addPeerWithConnection(pipePeer(privKey, nodeKey))
+}
+
+// pipePeer returns the inbound end of a pipe, the node of privKey claiming the
+// pubkey of nodeKey handshakes on the other end.
+func pipePeer(privKey crypto.PrivKeyEd25519, nodeKey crypto.PubKeyEd25519) net.Conn {
+	c1, c2 := net.Pipe()
+	go func() {
+		pc, err := newPeerConn(c2, true, privKey, DefaultPeerConfig(testCfg.P2P))
+		if err != nil {
+			return
+		}
+		pc.HandshakeTimeout(NewNodeInfo(testCfg, nodeKey, "127.0.0.1:46657"), 5*time.Second)
+	}()
+	return c1
+}
+
+func TestAllowlistAddPeer(t *testing.T) {
+	dirPath, err := ioutil.TempDir(".", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dirPath)
+
+	alice, bob := crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519()
+	alicePub := alice.PubKey().Unwrap().(crypto.PubKeyEd25519)
+	bobPub := bob.PubKey().Unwrap().(crypto.PubKeyEd25519)
+	path := filepath.Join(dirPath, "allowlist")
+	if err := ioutil.WriteFile(path, []byte(hex.EncodeToString(alicePub[:])+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	allowlist, err := NewFileAllowlist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testDB := dbm.NewDB("testdb", "leveldb", dirPath)
+	s1 := MakeSwitch(testCfg, testDB, initSwitchFunc)
+	s1.SetAllowlist(allowlist)
+	s1.Start()
+	defer s1.Stop()
+
+	if err := dialSwitch(s1, bob, bobPub); errors.Root(err) != ErrConnectNotAllowed {
+		t.Fatalf("got err %v, want %v", err, ErrConnectNotAllowed)
+	}
+	// bob can't claim the allowlisted key of alice in the node info
+	if err := dialSwitch(s1, bob, alicePub); errors.Root(err) != ErrPubKeyMismatch {
+		t.Fatalf("got err %v, want %v", err, ErrPubKeyMismatch)
+	}
+	if err := dialSwitch(s1, alice, alicePub); err != nil {
+		t.Fatal(err)
+	}
+
+	if outbound, inbound, dialing := s1.NumPeers(); outbound != 0 || inbound != 1 || dialing != 0 {
+		t.Fatalf("got peers outbound %d, inbound %d, dialing %d", outbound, inbound, dialing)
+	}
+	if !s1.Peers().Has(alicePub.KeyString()) {
+		t.Fatal("allowlisted peer is not added")
+	}
+}
@@ -32,6 +32,16 @@ func (chDesc *ChannelDescriptor) FillDefaults() {
 	}
 }
 
+// ChannelStatus is the traffic of a channel, the bytes include the packet
+// overhead.
+type ChannelStatus struct {
+	ID           byte
+	SentBytes    uint64
+	RecvBytes    uint64
+	SentMessages uint64
+	RecvMessages uint64
+}
+
 type channel struct {
 	conn          *MConnection
 	desc          *ChannelDescriptor
@@ -42,6 +52,11 @@ type channel struct {
 	sending       []byte
 	priority      int
 	recentlySent  int64 // exponential moving average
+
+	sentBytes    uint64 // atomic.
+	recvBytes    uint64 // atomic.
+	sentMessages uint64 // atomic.
+	recvMessages uint64 // atomic.
 }
 
 func newChannel(conn *MConnection, desc *ChannelDescriptor) *channel {
@@ -94,6 +109,7 @@ func (ch *channel) nextMsgPacket() msgPacket {
 		packet.EOF = byte(0x01)
 		ch.sending = nil
 		atomic.AddInt32(&ch.sendQueueSize, -1) // decrement sendQueueSize
+		atomic.AddUint64(&ch.sentMessages, 1)
 	} else {
 		packet.EOF = byte(0x00)
 		ch.sending = ch.sending[cmn.MinInt(maxMsgPacketPayloadSize, len(ch.sending)):]
@@ -112,6 +128,7 @@ func (ch *channel) recvMsgPacket(packet msgPacket) ([]byte, error) {
 	if packet.EOF == byte(0x01) {
 		msgBytes := ch.recving
 		ch.recving = ch.recving[:0] // make([]byte, 0, ch.desc.RecvBufferCapacity)
+		atomic.AddUint64(&ch.recvMessages, 1)
 		return msgBytes, nil
 	}
 	return nil, nil
@@ -154,9 +171,21 @@ func (ch *channel) writeMsgPacketTo(w io.Writer) (n int, err error) {
 		ch.recentlySent += int64(n)
 	}
 	n += int(m)
+	atomic.AddUint64(&ch.sentBytes, uint64(n))
 	return
 }
 
+// Goroutine-safe
+func (ch *channel) status() ChannelStatus {
+	return ChannelStatus{
+		ID:           ch.id,
+		SentBytes:    atomic.LoadUint64(&ch.sentBytes),
+		RecvBytes:    atomic.LoadUint64(&ch.recvBytes),
+		SentMessages: atomic.LoadUint64(&ch.sentMessages),
+		RecvMessages: atomic.LoadUint64(&ch.recvMessages),
+	}
+}
+
 // Call this periodically to update stats for throttling purposes.
 // Not goroutine-safe
 func (ch *channel) updateStats() {
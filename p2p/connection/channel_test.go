@@ -0,0 +1,36 @@
+package connection
+
+import (
+	"bytes"
+	"testing"
+
+	cmn "github.com/tendermint/tmlibs/common"
+)
+
+func TestChannelStatus(t *testing.T) {
+	ch := newChannel(nil, &ChannelDescriptor{ID: 0x01, Priority: 1})
+	if !ch.trySendBytes(cmn.RandBytes(maxMsgPacketPayloadSize + 10)) {
+		t.Fatal("fail to queue the message")
+	}
+
+	buf := new(bytes.Buffer)
+	for ch.isSendPending() {
+		if _, err := ch.writeMsgPacketTo(buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	status := ch.status()
+	if status.SentMessages != 1 || status.SentBytes != uint64(buf.Len()) {
+		t.Fatalf("got sent status %+v, want 1 message of %d bytes", status, buf.Len())
+	}
+
+	for _, packet := range []msgPacket{{ChannelID: 0x01, Bytes: []byte("he")}, {ChannelID: 0x01, EOF: 0x01, Bytes: []byte("llo")}} {
+		if _, err := ch.recvMsgPacket(packet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if status = ch.status(); status.RecvMessages != 1 {
+		t.Fatalf("got %d received messages, want 1", status.RecvMessages)
+	}
+}
@@ -90,6 +90,8 @@ type MConnection struct {
 	onError     errorCbFunc
 	errored     uint32
 	config      *MConnConfig
+	pingSent    int64 // unix nano of the first ping waiting for the pong
+	latency     int64 // round trip time of the last ping in nanoseconds
 
 	quit         chan struct{}
 	flushTimer   *cmn.ThrottleTimer // flush writes as necessary but throttled.
@@ -99,8 +101,59 @@ type MConnection struct {
 
 // MConnConfig is a MConnection configuration.
 type MConnConfig struct {
-	SendRate int64 `mapstructure:"send_rate"`
-	RecvRate int64 `mapstructure:"recv_rate"`
+	SendRate  int64      `mapstructure:"send_rate"`
+	RecvRate  int64      `mapstructure:"recv_rate"`
+	Bandwidth *Bandwidth `mapstructure:"-"` // shared by all the connections
+}
+
+// Bandwidth is the traffic of all the connections sharing it, the rates are
+// the global limits in bytes per second and 0 means unlimited.
+type Bandwidth struct {
+	SendRate    int64
+	RecvRate    int64
+	sendMonitor *flowrate.Monitor
+	recvMonitor *flowrate.Monitor
+}
+
+// NewBandwidth creates the shared traffic monitor with the global limits.
+func NewBandwidth(sendRate, recvRate int64) *Bandwidth {
+	return &Bandwidth{
+		SendRate:    sendRate,
+		RecvRate:    recvRate,
+		sendMonitor: flowrate.New(0, 0),
+		recvMonitor: flowrate.New(0, 0),
+	}
+}
+
+// TrafficStatus return the in and out traffic status of all the connections
+func (b *Bandwidth) TrafficStatus() (*flowrate.Status, *flowrate.Status) {
+	sentStatus := b.sendMonitor.Status()
+	receivedStatus := b.recvMonitor.Status()
+	return &sentStatus, &receivedStatus
+}
+
+func (b *Bandwidth) limitSend() {
+	if b != nil {
+		b.sendMonitor.Limit(maxMsgPacketTotalSize, atomic.LoadInt64(&b.SendRate), true)
+	}
+}
+
+func (b *Bandwidth) limitRecv() {
+	if b != nil {
+		b.recvMonitor.Limit(maxMsgPacketTotalSize, atomic.LoadInt64(&b.RecvRate), true)
+	}
+}
+
+func (b *Bandwidth) updateSend(n int) {
+	if b != nil {
+		b.sendMonitor.Update(n)
+	}
+}
+
+func (b *Bandwidth) updateRecv(n int) {
+	if b != nil {
+		b.recvMonitor.Update(n)
+	}
 }
 
 // DefaultMConnConfig returns the default config.
@@ -200,6 +253,21 @@ func (c *MConnection) Send(chID byte, msg interface{}) bool {
 	return true
 }
 
+// ChannelStatus returns the traffic of the channels
+func (c *MConnection) ChannelStatus() []ChannelStatus {
+	status := make([]ChannelStatus, 0, len(c.channels))
+	for _, channel := range c.channels {
+		status = append(status, channel.status())
+	}
+	return status
+}
+
+// Latency returns the round trip time of the last ping, it is zero until the
+// first pong is received.
+func (c *MConnection) Latency() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.latency))
+}
+
 // TrafficStatus return the in and out traffic status
 func (c *MConnection) TrafficStatus() (*flowrate.Status, *flowrate.Status) {
 	sentStatus := c.sendMonitor.Status()
@@ -258,13 +326,14 @@ func (c *MConnection) recvRoutine() {
 	for {
 		// Block until .recvMonitor says we can read.
 		c.recvMonitor.Limit(maxMsgPacketTotalSize, atomic.LoadInt64(&c.config.RecvRate), true)
+		c.config.Bandwidth.limitRecv()
 
 		// Read packet type
 		var n int
 		var err error
 		pktType, err := c.bufReader.ReadByte()
 		n += 1
-		c.recvMonitor.Update(int(n))
+		c.updateRecv(n)
 		if err != nil {
 			if c.IsRunning() {
 				log.WithFields(log.Fields{"module": logModule, "conn": c, "error": err}).Error("Connection failed @ recvRoutine (reading byte)")
@@ -285,11 +354,14 @@ func (c *MConnection) recvRoutine() {
 
 		case packetTypePong:
 			log.WithFields(log.Fields{"module": logModule, "conn": c}).Debug("receive Pong")
+			if sent := atomic.SwapInt64(&c.pingSent, 0); sent != 0 {
+				atomic.StoreInt64(&c.latency, time.Now().UnixNano()-sent)
+			}
 
 		case packetTypeMsg:
 			pkt, n, err := msgPacket{}, int64(0), error(nil)
 			n, err = amino.UnmarshalBinaryLengthPrefixedReader(c.bufReader, &pkt, maxMsgPacketTotalSize)
-			c.recvMonitor.Update(int(n))
+			c.updateRecv(int(n))
 			if err != nil {
 				if c.IsRunning() {
 					log.WithFields(log.Fields{"module": logModule, "conn": c, "error": err}).Error("failed on recvRoutine")
@@ -302,6 +374,7 @@ func (c *MConnection) recvRoutine() {
 			if !ok || channel == nil {
 				cmn.PanicQ(cmn.Fmt("Unknown channel %X", pkt.ChannelID))
 			}
+			atomic.AddUint64(&channel.recvBytes, uint64(n)+1)
 
 			msgBytes, err := channel.recvMsgPacket(pkt)
 			if err != nil {
@@ -345,7 +418,7 @@ func (c *MConnection) sendMsgPacket() bool {
 		c.stopForError(err)
 		return true
 	}
-	c.sendMonitor.Update(int(n))
+	c.updateSend(n)
 	c.flushTimer.Set()
 	return false
 }
@@ -366,15 +439,16 @@ func (c *MConnection) sendRoutine() {
 			}
 		case <-c.pingTimer.C:
 			log.WithFields(log.Fields{"module": logModule, "conn": c}).Debug("send Ping")
+			atomic.CompareAndSwapInt64(&c.pingSent, 0, time.Now().UnixNano())
 			err = c.bufWriter.WriteByte(packetTypePing)
 			n = n + 1
-			c.sendMonitor.Update(int(n))
+			c.updateSend(n)
 			c.flush()
 		case <-c.pong:
 			log.WithFields(log.Fields{"module": logModule, "conn": c}).Debug("send Pong")
 			err = c.bufWriter.WriteByte(packetTypePong)
 			n = n + 1
-			c.sendMonitor.Update(int(n))
+			c.updateSend(n)
 			c.flush()
 		case <-c.quit:
 			return
@@ -404,6 +478,7 @@ func (c *MConnection) sendSomeMsgPackets() bool {
 	// Once we're ready we send more than we asked for,
 	// but amortized it should even out.
 	c.sendMonitor.Limit(maxMsgPacketTotalSize, atomic.LoadInt64(&c.config.SendRate), true)
+	c.config.Bandwidth.limitSend()
 	for i := 0; i < numBatchMsgPackets; i++ {
 		if c.sendMsgPacket() {
 			return true
@@ -412,6 +487,16 @@ func (c *MConnection) sendSomeMsgPackets() bool {
 	return false
 }
 
+func (c *MConnection) updateRecv(n int) {
+	c.recvMonitor.Update(n)
+	c.config.Bandwidth.updateRecv(n)
+}
+
+func (c *MConnection) updateSend(n int) {
+	c.sendMonitor.Update(n)
+	c.config.Bandwidth.updateSend(n)
+}
+
 func (c *MConnection) stopForError(r interface{}) {
 	c.Stop()
 	if atomic.CompareAndSwapUint32(&c.errored, 0, 1) && c.onError != nil {
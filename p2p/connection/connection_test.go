@@ -32,7 +32,7 @@ func TestMConnectionSend(t *testing.T) {
 	defer client.Close()
 
 	mconn := createMConnection(client)
-	_, err := mconn.Start()
+	err := mconn.Start()
 	require.Nil(err)
 	defer mconn.Stop()
 
@@ -67,12 +67,12 @@ func TestMConnectionReceive(t *testing.T) {
 		errorsCh <- r
 	}
 	mconn1 := createMConnectionWithCallbacks(client, onReceive, onError)
-	_, err := mconn1.Start()
+	err := mconn1.Start()
 	require.Nil(err)
 	defer mconn1.Stop()
 
 	mconn2 := createMConnection(server)
-	_, err = mconn2.Start()
+	err = mconn2.Start()
 	require.Nil(err)
 	defer mconn2.Stop()
 
@@ -105,7 +105,7 @@ func TestMConnectionStopsAndReturnsError(t *testing.T) {
 		errorsCh <- r
 	}
 	mconn := createMConnectionWithCallbacks(client, onReceive, onError)
-	_, err := mconn.Start()
+	err := mconn.Start()
 	require.Nil(err)
 	defer mconn.Stop()
 
@@ -121,3 +121,32 @@ func TestMConnectionStopsAndReturnsError(t *testing.T) {
 		t.Fatal("Did not receive error in 500ms")
 	}
 }
+
+func TestMConnectionLatency(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	mconn := createMConnection(client)
+	mconn.pingTimer = time.NewTicker(10 * time.Millisecond)
+	err := mconn.Start()
+	require.Nil(err)
+	defer mconn.Stop()
+	assert.Zero(mconn.Latency())
+
+	// the remote answers the ping after a delay
+	ping := make([]byte, 1)
+	_, err = server.Read(ping)
+	require.Nil(err)
+	require.Equal(packetTypePing, ping[0])
+	time.Sleep(50 * time.Millisecond)
+	_, err = server.Write([]byte{packetTypePong})
+	require.Nil(err)
+
+	for start := time.Now(); mconn.Latency() == 0 && time.Since(start) < time.Second; {
+		time.Sleep(time.Millisecond)
+	}
+	assert.True(mconn.Latency() >= 50*time.Millisecond, "got latency %v", mconn.Latency())
+}
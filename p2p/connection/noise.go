@@ -0,0 +1,471 @@
+package connection
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/tendermint/go-crypto"
+)
+
+const (
+	// NoiseTransportName is the name of the noise transport in the offers
+	NoiseTransportName = "noise"
+
+	noiseProtocolName = "Noise_XX_25519_AESGCM_SHA256"
+	noiseLenSize      = 2 // uint16 to describe the length of the message
+	noiseMaxMsgSize   = 65535
+	noiseTagSize      = 16
+	noiseMaxPayload   = noiseMaxMsgSize - noiseTagSize
+	noiseIdentitySize = 32 + 64 // node pubkey and the signature of the static key
+)
+
+var (
+	// noiseRekeyInterval is the number of frames sent or received with a key
+	// before both peers rekey it
+	noiseRekeyInterval uint64 = 1 << 16
+
+	noisePrologue     = []byte("capsule-noise")
+	noiseStaticPrefix = []byte("noise-static-key:")
+
+	errNoiseMessage  = errors.New("Invalid noise handshake message")
+	errNoiseIdentity = errors.New("Noise static key verification failed")
+)
+
+// NoiseTransport secures the connection with the Noise XX handshake, the
+// static key is derived from the node key and signed by it in the handshake
+// payload.
+type NoiseTransport struct{}
+
+// NewNoiseTransport returns the noise transport.
+func NewNoiseTransport() *NoiseTransport {
+	return &NoiseTransport{}
+}
+
+// Name implements Transport.
+func (t *NoiseTransport) Name() string {
+	return NoiseTransportName
+}
+
+// Handshake implements Transport.
+func (t *NoiseTransport) Handshake(conn io.ReadWriteCloser, locPrivKey crypto.PrivKeyEd25519, initiator bool) (SecureConn, error) {
+	return MakeNoiseConnection(conn, locPrivKey, initiator)
+}
+
+// NoiseConnection implements net.Conn, the frames are up to 64KB and the keys
+// of both directions are rekeyed every noiseRekeyInterval frames.
+type NoiseConnection struct {
+	conn      io.ReadWriteCloser
+	remPubKey crypto.PubKeyEd25519
+
+	recvMtx    sync.Mutex
+	recvBuffer []byte
+	recvCipher *noiseCipher
+
+	sendMtx    sync.Mutex
+	sendCipher *noiseCipher
+}
+
+// MakeNoiseConnection performs the Noise XX handshake:
+//
+//	-> e
+//	<- e, ee, s, es, identity
+//	-> s, se, identity
+func MakeNoiseConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKeyEd25519, initiator bool) (*NoiseConnection, error) {
+	hs := newNoiseHandshake(locPrivKey)
+
+	var remIdentity []byte
+	var err error
+	if initiator {
+		remIdentity, err = hs.initiate(conn)
+	} else {
+		remIdentity, err = hs.respond(conn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remPubKey, err := verifyNoiseIdentity(remIdentity, hs.rs)
+	if err != nil {
+		return nil, err
+	}
+
+	c1, c2 := hs.split()
+	nc := &NoiseConnection{conn: conn, remPubKey: remPubKey, sendCipher: c1, recvCipher: c2}
+	if !initiator {
+		nc.sendCipher, nc.recvCipher = c2, c1
+	}
+	return nc, nil
+}
+
+// Read implements net.Conn.
+func (nc *NoiseConnection) Read(data []byte) (int, error) {
+	nc.recvMtx.Lock()
+	defer nc.recvMtx.Unlock()
+
+	if len(nc.recvBuffer) == 0 {
+		msg, err := readNoiseMessage(nc.conn)
+		if err != nil {
+			return 0, err
+		}
+
+		if nc.recvBuffer, err = nc.recvCipher.decrypt(nil, msg); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(data, nc.recvBuffer)
+	nc.recvBuffer = nc.recvBuffer[n:]
+	return n, nil
+}
+
+// Write implements net.Conn.
+func (nc *NoiseConnection) Write(data []byte) (n int, err error) {
+	nc.sendMtx.Lock()
+	defer nc.sendMtx.Unlock()
+
+	for 0 < len(data) {
+		chunk := data
+		if noiseMaxPayload < len(chunk) {
+			chunk = data[:noiseMaxPayload]
+		}
+		data = data[len(chunk):]
+
+		if err := writeNoiseMessage(nc.conn, nc.sendCipher.encrypt(nil, chunk)); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+	}
+	return
+}
+
+// RemotePubKey returns authenticated remote pubkey
+func (nc *NoiseConnection) RemotePubKey() crypto.PubKeyEd25519 {
+	return nc.remPubKey
+}
+
+// Close implements net.Conn
+func (nc *NoiseConnection) Close() error { return nc.conn.Close() }
+
+// LocalAddr implements net.Conn
+func (nc *NoiseConnection) LocalAddr() net.Addr { return nc.conn.(net.Conn).LocalAddr() }
+
+// RemoteAddr implements net.Conn
+func (nc *NoiseConnection) RemoteAddr() net.Addr { return nc.conn.(net.Conn).RemoteAddr() }
+
+// SetDeadline implements net.Conn
+func (nc *NoiseConnection) SetDeadline(t time.Time) error { return nc.conn.(net.Conn).SetDeadline(t) }
+
+// SetReadDeadline implements net.Conn
+func (nc *NoiseConnection) SetReadDeadline(t time.Time) error {
+	return nc.conn.(net.Conn).SetReadDeadline(t)
+}
+
+// SetWriteDeadline implements net.Conn
+func (nc *NoiseConnection) SetWriteDeadline(t time.Time) error {
+	return nc.conn.(net.Conn).SetWriteDeadline(t)
+}
+
+// noiseHandshake is the handshake state of the Noise XX pattern.
+type noiseHandshake struct {
+	ck     [32]byte
+	h      [32]byte
+	cipher *noiseCipher
+
+	sPub, sPriv    *[32]byte
+	ePub, ePriv    *[32]byte
+	rs, re         *[32]byte
+	locIdentityMsg []byte
+}
+
+func newNoiseHandshake(privKey crypto.PrivKeyEd25519) *noiseHandshake {
+	hs := &noiseHandshake{}
+	copy(hs.h[:], noiseProtocolName)
+	hs.ck = hs.h
+	hs.mixHash(noisePrologue)
+
+	hs.sPub, hs.sPriv = noiseStaticKey(privKey)
+	hs.ePub, hs.ePriv = genEphKeys()
+	hs.locIdentityMsg = noiseIdentity(privKey, hs.sPub)
+	return hs
+}
+
+func (hs *noiseHandshake) initiate(conn io.ReadWriter) ([]byte, error) {
+	// -> e
+	hs.mixHash(hs.ePub[:])
+	if err := writeNoiseMessage(conn, append(hs.ePub[:], hs.encryptAndHash(nil)...)); err != nil {
+		return nil, err
+	}
+
+	// <- e, ee, s, es
+	msg, err := readNoiseMessage(conn)
+	if err != nil {
+		return nil, err
+	}
+	if msg, err = hs.readEphemeral(msg); err != nil {
+		return nil, err
+	}
+	hs.mixKey(noiseDH(hs.ePriv, hs.re))
+	if msg, err = hs.readStatic(msg); err != nil {
+		return nil, err
+	}
+	hs.mixKey(noiseDH(hs.ePriv, hs.rs))
+	remIdentity, err := hs.decryptAndHash(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// -> s, se
+	out := hs.encryptAndHash(hs.sPub[:])
+	hs.mixKey(noiseDH(hs.sPriv, hs.re))
+	out = append(out, hs.encryptAndHash(hs.locIdentityMsg)...)
+	return remIdentity, writeNoiseMessage(conn, out)
+}
+
+func (hs *noiseHandshake) respond(conn io.ReadWriter) ([]byte, error) {
+	// -> e
+	msg, err := readNoiseMessage(conn)
+	if err != nil {
+		return nil, err
+	}
+	if msg, err = hs.readEphemeral(msg); err != nil {
+		return nil, err
+	}
+	if _, err = hs.decryptAndHash(msg); err != nil {
+		return nil, err
+	}
+
+	// <- e, ee, s, es
+	hs.mixHash(hs.ePub[:])
+	out := append([]byte{}, hs.ePub[:]...)
+	hs.mixKey(noiseDH(hs.ePriv, hs.re))
+	out = append(out, hs.encryptAndHash(hs.sPub[:])...)
+	hs.mixKey(noiseDH(hs.sPriv, hs.re))
+	out = append(out, hs.encryptAndHash(hs.locIdentityMsg)...)
+	if err := writeNoiseMessage(conn, out); err != nil {
+		return nil, err
+	}
+
+	// -> s, se
+	if msg, err = readNoiseMessage(conn); err != nil {
+		return nil, err
+	}
+	if msg, err = hs.readStatic(msg); err != nil {
+		return nil, err
+	}
+	hs.mixKey(noiseDH(hs.ePriv, hs.rs))
+	return hs.decryptAndHash(msg)
+}
+
+func (hs *noiseHandshake) readEphemeral(msg []byte) ([]byte, error) {
+	if len(msg) < 32 {
+		return nil, errNoiseMessage
+	}
+
+	hs.re = new([32]byte)
+	copy(hs.re[:], msg[:32])
+	hs.mixHash(hs.re[:])
+	return msg[32:], nil
+}
+
+func (hs *noiseHandshake) readStatic(msg []byte) ([]byte, error) {
+	if len(msg) < 32+noiseTagSize {
+		return nil, errNoiseMessage
+	}
+
+	rs, err := hs.decryptAndHash(msg[:32+noiseTagSize])
+	if err != nil {
+		return nil, err
+	}
+	hs.rs = new([32]byte)
+	copy(hs.rs[:], rs)
+	return msg[32+noiseTagSize:], nil
+}
+
+func (hs *noiseHandshake) mixHash(data []byte) {
+	hasher := sha256.New()
+	hasher.Write(hs.h[:])
+	hasher.Write(data)
+	hasher.Sum(hs.h[:0])
+}
+
+func (hs *noiseHandshake) mixKey(ikm []byte) {
+	var key [32]byte
+	hs.ck, key = noiseHKDF(hs.ck[:], ikm)
+	hs.cipher = newNoiseCipher(key)
+}
+
+func (hs *noiseHandshake) encryptAndHash(plaintext []byte) []byte {
+	ciphertext := plaintext
+	if hs.cipher != nil {
+		ciphertext = hs.cipher.encrypt(hs.h[:], plaintext)
+	}
+	hs.mixHash(ciphertext)
+	return ciphertext
+}
+
+func (hs *noiseHandshake) decryptAndHash(ciphertext []byte) ([]byte, error) {
+	plaintext := ciphertext
+	if hs.cipher != nil {
+		var err error
+		if plaintext, err = hs.cipher.decrypt(hs.h[:], ciphertext); err != nil {
+			return nil, err
+		}
+	}
+	hs.mixHash(ciphertext)
+	return plaintext, nil
+}
+
+// split returns the ciphers of the initiator to responder and the responder
+// to initiator directions.
+func (hs *noiseHandshake) split() (*noiseCipher, *noiseCipher) {
+	k1, k2 := noiseHKDF(hs.ck[:], nil)
+	return newNoiseCipher(k1), newNoiseCipher(k2)
+}
+
+// noiseCipher is the AES-GCM cipher state, the 96 bits nonce is 32 zero bits
+// followed by the big-endian counter.
+type noiseCipher struct {
+	key   [32]byte
+	aead  cipher.AEAD
+	nonce uint64
+}
+
+func newNoiseCipher(key [32]byte) *noiseCipher {
+	c := &noiseCipher{}
+	c.setKey(key)
+	return c
+}
+
+func (c *noiseCipher) setKey(key [32]byte) {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		panic(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	c.key, c.aead = key, aead
+}
+
+func (c *noiseCipher) encrypt(ad, plaintext []byte) []byte {
+	ciphertext := c.aead.Seal(nil, c.nextNonce(), plaintext, ad)
+	c.maybeRekey()
+	return ciphertext
+}
+
+func (c *noiseCipher) decrypt(ad, ciphertext []byte) ([]byte, error) {
+	plaintext, err := c.aead.Open(nil, c.nextNonce(), ciphertext, ad)
+	if err != nil {
+		return nil, errors.New("Failed to decrypt NoiseConnection")
+	}
+	c.maybeRekey()
+	return plaintext, nil
+}
+
+func (c *noiseCipher) nextNonce() []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce[4:], c.nonce)
+	c.nonce++
+	return nonce
+}
+
+// maybeRekey replaces the key with the encryption of zeros under the maximum
+// nonce as the noise Rekey function does.
+func (c *noiseCipher) maybeRekey() {
+	if c.nonce%noiseRekeyInterval != 0 {
+		return
+	}
+
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce[4:], ^uint64(0))
+	var key [32]byte
+	copy(key[:], c.aead.Seal(nil, nonce, make([]byte, 32), nil))
+	c.setKey(key)
+}
+
+// noiseStaticKey derives the Curve25519 static key from the node key.
+func noiseStaticKey(privKey crypto.PrivKeyEd25519) (pub, priv *[32]byte) {
+	digest := sha512.Sum512(privKey[:32])
+	priv, pub = new([32]byte), new([32]byte)
+	copy(priv[:], digest[:32])
+	priv[0] &= 248
+	priv[31] &= 127
+	priv[31] |= 64
+	curve25519.ScalarBaseMult(pub, priv)
+	return
+}
+
+// noiseIdentity is the node pubkey and its signature of the static key.
+func noiseIdentity(privKey crypto.PrivKeyEd25519, staticPub *[32]byte) []byte {
+	pubKey := privKey.PubKey().Unwrap().(crypto.PubKeyEd25519)
+	signature := privKey.Sign(noiseStaticMessage(staticPub)).Unwrap().(crypto.SignatureEd25519)
+	return append(pubKey[:], signature[:]...)
+}
+
+func verifyNoiseIdentity(identity []byte, staticPub *[32]byte) (crypto.PubKeyEd25519, error) {
+	var pubKey crypto.PubKeyEd25519
+	if len(identity) != noiseIdentitySize {
+		return pubKey, errNoiseIdentity
+	}
+
+	copy(pubKey[:], identity[:32])
+	if !pubKey.VerifyBytes(noiseStaticMessage(staticPub), crypto.SignatureEd25519FromBytes(identity[32:])) {
+		return pubKey, errNoiseIdentity
+	}
+	return pubKey, nil
+}
+
+func noiseStaticMessage(staticPub *[32]byte) []byte {
+	return append(append([]byte{}, noiseStaticPrefix...), staticPub[:]...)
+}
+
+func noiseDH(priv, pub *[32]byte) []byte {
+	var shared [32]byte
+	curve25519.ScalarMult(&shared, priv, pub)
+	return shared[:]
+}
+
+func noiseHKDF(ck, ikm []byte) (out1, out2 [32]byte) {
+	tempKey := hmacSHA256(ck, ikm)
+	copy(out1[:], hmacSHA256(tempKey, []byte{0x01}))
+	copy(out2[:], hmacSHA256(tempKey, append(out1[:], 0x02)))
+	return
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func readNoiseMessage(r io.Reader) ([]byte, error) {
+	var lenBuf [noiseLenSize]byte
+	if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+		return nil, err
+	}
+
+	msg := make([]byte, binary.BigEndian.Uint16(lenBuf[:]))
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func writeNoiseMessage(w io.Writer, msg []byte) error {
+	buf := make([]byte, noiseLenSize, noiseLenSize+len(msg))
+	binary.BigEndian.PutUint16(buf, uint16(len(msg)))
+	_, err := w.Write(append(buf, msg...))
+	return err
+}
@@ -0,0 +1,152 @@
+package connection
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/tendermint/go-crypto"
+	cmn "github.com/tendermint/tmlibs/common"
+)
+
+func makeNoiseConnPair(t *testing.T) (fooNoiseConn, barNoiseConn *NoiseConnection) {
+	fooConn, barConn := makeDummyConnPair()
+	fooPrvKey, barPrvKey := crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519()
+
+	var fooErr, barErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		fooNoiseConn, fooErr = MakeNoiseConnection(fooConn, fooPrvKey, true)
+	}()
+	go func() {
+		defer wg.Done()
+		barNoiseConn, barErr = MakeNoiseConnection(barConn, barPrvKey, false)
+	}()
+	wg.Wait()
+
+	if fooErr != nil || barErr != nil {
+		t.Fatalf("fail on noise handshake: %v, %v", fooErr, barErr)
+	}
+	if fooNoiseConn.RemotePubKey() != barPrvKey.PubKey().Unwrap().(crypto.PubKeyEd25519) {
+		t.Fatal("foo got wrong remote pubkey")
+	}
+	if barNoiseConn.RemotePubKey() != fooPrvKey.PubKey().Unwrap().(crypto.PubKeyEd25519) {
+		t.Fatal("bar got wrong remote pubkey")
+	}
+	return
+}
+
+func checkTransfer(t *testing.T, writer io.Writer, reader io.Reader, data []byte) {
+	go func() {
+		if _, err := writer.Write(data); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	got := make([]byte, len(data))
+	if _, err := io.ReadFull(reader, got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("transferred data mismatch")
+	}
+}
+
+func TestNoiseConnectionReadWrite(t *testing.T) {
+	fooNoiseConn, barNoiseConn := makeNoiseConnPair(t)
+	defer fooNoiseConn.Close()
+	defer barNoiseConn.Close()
+
+	// larger than a frame
+	block := cmn.RandBytes(3*noiseMaxPayload + 100)
+	checkTransfer(t, fooNoiseConn, barNoiseConn, block)
+	checkTransfer(t, barNoiseConn, fooNoiseConn, block)
+	checkTransfer(t, fooNoiseConn, barNoiseConn, []byte("hello"))
+}
+
+func TestNoiseRekey(t *testing.T) {
+	defer func(interval uint64) { noiseRekeyInterval = interval }(noiseRekeyInterval)
+	noiseRekeyInterval = 2
+
+	fooNoiseConn, barNoiseConn := makeNoiseConnPair(t)
+	defer fooNoiseConn.Close()
+	defer barNoiseConn.Close()
+
+	sendKey := fooNoiseConn.sendCipher.key
+	for i := 0; i < 5; i++ {
+		checkTransfer(t, fooNoiseConn, barNoiseConn, cmn.RandBytes(100))
+		checkTransfer(t, barNoiseConn, fooNoiseConn, cmn.RandBytes(100))
+	}
+	if fooNoiseConn.sendCipher.key == sendKey {
+		t.Fatal("send key is not rekeyed")
+	}
+	if fooNoiseConn.sendCipher.key != barNoiseConn.recvCipher.key {
+		t.Fatal("rekeyed keys mismatch")
+	}
+}
+
+func TestNoiseIdentity(t *testing.T) {
+	privKey := crypto.GenPrivKeyEd25519()
+	staticPub, _ := noiseStaticKey(privKey)
+	identity := noiseIdentity(privKey, staticPub)
+
+	pubKey, err := verifyNoiseIdentity(identity, staticPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pubKey != privKey.PubKey().Unwrap().(crypto.PubKeyEd25519) {
+		t.Fatal("identity pubkey mismatch")
+	}
+
+	otherPub, _ := noiseStaticKey(crypto.GenPrivKeyEd25519())
+	if _, err := verifyNoiseIdentity(identity, otherPub); err != errNoiseIdentity {
+		t.Fatalf("got %v, want %v", err, errNoiseIdentity)
+	}
+}
+
+type namedTransport struct {
+	NoiseTransport
+	name string
+}
+
+func (t *namedTransport) Name() string {
+	return t.name
+}
+
+func TestSelectTransport(t *testing.T) {
+	a, b := &namedTransport{name: "a"}, &namedTransport{name: "b"}
+	cases := []struct {
+		transports []Transport
+		remNames   []string
+		initiator  bool
+		want       Transport
+	}{
+		{transports: []Transport{a, b}, remNames: []string{"b", "a"}, initiator: true, want: a},
+		{transports: []Transport{a, b}, remNames: []string{"b", "a"}, initiator: false, want: b},
+		{transports: []Transport{a}, remNames: []string{"b"}, initiator: true, want: nil},
+		{transports: []Transport{a, b}, remNames: nil, initiator: false, want: nil},
+		{transports: nil, remNames: []string{"a"}, initiator: true, want: nil},
+	}
+
+	for i, c := range cases {
+		if got := selectTransport(c.transports, c.remNames, c.initiator); got != c.want {
+			t.Errorf("case %d: got %v, want %v", i, got, c.want)
+		}
+	}
+}
+
+func TestTransportOffer(t *testing.T) {
+	offer := make([]byte, dataMaxSize-authSigMsgSize)
+	copy(offer, encodeTransportOffer([]string{"noise", "other"}))
+	if names := decodeTransportOffer(offer); len(names) != 2 || names[0] != "noise" || names[1] != "other" {
+		t.Fatalf("got offer %v", names)
+	}
+
+	// the padding of the legacy peers
+	if names := decodeTransportOffer(make([]byte, dataMaxSize-authSigMsgSize)); names != nil {
+		t.Fatalf("got offer %v from zero padding", names)
+	}
+}
@@ -24,12 +24,14 @@ const (
 	dataMaxSize     = 1024
 	totalFrameSize  = dataMaxSize + dataLenSize
 	sealedFrameSize = totalFrameSize + secretbox.Overhead
-	authSigMsgSize  = (32 + 1) + (64 + 1) // fixed size (length prefixed) byte arrays
+	authSigMsgSize  = 1 + (1 + 32 + 1) + (1 + 64 + 1) // length prefixed fields of fixed size byte arrays
 )
 
+// authSigMessage holds the concrete ed25519 types, amino can't encode the
+// go-crypto interface wrappers.
 type authSigMessage struct {
-	Key crypto.PubKey
-	Sig crypto.Signature
+	Key crypto.PubKeyEd25519
+	Sig crypto.SignatureEd25519
 }
 
 // SecretConnection implements net.Conn
@@ -44,6 +46,13 @@ type SecretConnection struct {
 
 // MakeSecretConnection performs handshake and returns a new authenticated SecretConnection.
 func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKeyEd25519) (*SecretConnection, error) {
+	sc, _, err := makeSecretConnection(conn, locPrivKey, nil)
+	return sc, err
+}
+
+// makeSecretConnection also shares the transport offers, the offers are sent
+// in the padding of the auth frame which the legacy peers ignore.
+func makeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKeyEd25519, locOffer []byte) (*SecretConnection, []byte, error) {
 	locPubKey := locPrivKey.PubKey().Unwrap().(crypto.PubKeyEd25519)
 
 	// Generate ephemeral keys for perfect forward secrecy.
@@ -54,7 +63,7 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKeyEd25
 	// (see DJB's Curve25519 paper: http://cr.yp.to/ecdh/curve25519-20060209.pdf)
 	remEphPub, err := shareEphPubKey(conn, locEphPub)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Compute common shared secret.
@@ -82,17 +91,17 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKeyEd25
 	locSignature := signChallenge(challenge, locPrivKey)
 
 	// Share (in secret) each other's pubkey & challenge signature
-	authSigMsg, err := shareAuthSignature(sc, locPubKey, locSignature)
+	authSigMsg, remOffer, err := shareAuthSignature(sc, locPubKey, locSignature, locOffer)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	remPubKey, remSignature := authSigMsg.Key, authSigMsg.Sig
-	if !remPubKey.VerifyBytes(challenge[:], remSignature) {
-		return nil, errors.New("Challenge verification failed")
+	if !remPubKey.VerifyBytes(challenge[:], remSignature.Wrap()) {
+		return nil, nil, errors.New("Challenge verification failed")
 	}
 
-	sc.remPubKey = remPubKey.Unwrap().(crypto.PubKeyEd25519)
-	return sc, nil
+	sc.remPubKey = remPubKey
+	return sc, remOffer, nil
 }
 
 // CONTRACT: data smaller than dataMaxSize is read atomically.
@@ -103,6 +112,18 @@ func (sc *SecretConnection) Read(data []byte) (n int, err error) {
 		return
 	}
 
+	chunk, _, err := sc.readFrame()
+	if err != nil {
+		return
+	}
+
+	n = copy(data, chunk)
+	sc.recvBuffer = chunk[n:]
+	return
+}
+
+// readFrame reads and decrypts a frame, extra is the padding after the chunk.
+func (sc *SecretConnection) readFrame() (chunk []byte, extra []byte, err error) {
 	sealedFrame := make([]byte, sealedFrameSize)
 	if _, err = io.ReadFull(sc.conn, sealedFrame); err != nil {
 		return
@@ -111,19 +132,15 @@ func (sc *SecretConnection) Read(data []byte) (n int, err error) {
 	// decrypt the frame
 	frame := make([]byte, totalFrameSize)
 	if _, ok := secretbox.Open(frame[:0], sealedFrame, sc.recvNonce, sc.shrSecret); !ok {
-		return n, errors.New("Failed to decrypt SecretConnection")
+		return nil, nil, errors.New("Failed to decrypt SecretConnection")
 	}
 
 	incr2Nonce(sc.recvNonce)
 	chunkLength := binary.BigEndian.Uint16(frame) // read the first two bytes
 	if chunkLength > dataMaxSize {
-		return 0, errors.New("chunkLength is greater than dataMaxSize")
+		return nil, nil, errors.New("chunkLength is greater than dataMaxSize")
 	}
-
-	chunk := frame[dataLenSize : dataLenSize+chunkLength]
-	n = copy(data, chunk)
-	sc.recvBuffer = chunk[n:]
-	return
+	return frame[dataLenSize : dataLenSize+chunkLength], frame[dataLenSize+chunkLength:], nil
 }
 
 // RemotePubKey returns authenticated remote pubkey
@@ -136,7 +153,6 @@ func (sc *SecretConnection) RemotePubKey() crypto.PubKeyEd25519 {
 func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 	for 0 < len(data) {
 		var chunk []byte
-		frame := make([]byte, totalFrameSize)
 		if dataMaxSize < len(data) {
 			chunk = data[:dataMaxSize]
 			data = data[dataMaxSize:]
@@ -144,15 +160,8 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 			chunk = data
 			data = nil
 		}
-		binary.BigEndian.PutUint16(frame, uint16(len(chunk)))
-		copy(frame[dataLenSize:], chunk)
 
-		// encrypt the frame
-		sealedFrame := make([]byte, sealedFrameSize)
-		secretbox.Seal(sealedFrame[:0], frame, sc.sendNonce, sc.shrSecret)
-		incr2Nonce(sc.sendNonce)
-
-		if _, err := sc.conn.Write(sealedFrame); err != nil {
+		if err := sc.writeFrame(chunk, nil); err != nil {
 			return n, err
 		}
 
@@ -161,6 +170,23 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 	return
 }
 
+// writeFrame encrypts and writes a frame, extra is put in the padding after
+// the chunk.
+func (sc *SecretConnection) writeFrame(chunk []byte, extra []byte) error {
+	frame := make([]byte, totalFrameSize)
+	binary.BigEndian.PutUint16(frame, uint16(len(chunk)))
+	copy(frame[dataLenSize:], chunk)
+	copy(frame[dataLenSize+len(chunk):], extra)
+
+	// encrypt the frame
+	sealedFrame := make([]byte, sealedFrameSize)
+	secretbox.Seal(sealedFrame[:0], frame, sc.sendNonce, sc.shrSecret)
+	incr2Nonce(sc.sendNonce)
+
+	_, err := sc.conn.Write(sealedFrame)
+	return err
+}
+
 // Close implements net.Conn
 func (sc *SecretConnection) Close() error { return sc.conn.Close() }
 
@@ -234,34 +260,35 @@ func signChallenge(challenge *[32]byte, locPrivKey crypto.PrivKeyEd25519) (signa
 	return
 }
 
-func shareAuthSignature(sc *SecretConnection, pubKey crypto.PubKeyEd25519, signature crypto.SignatureEd25519) (*authSigMessage, error) {
+func shareAuthSignature(sc *SecretConnection, pubKey crypto.PubKeyEd25519, signature crypto.SignatureEd25519, offer []byte) (*authSigMessage, []byte, error) {
 	var recvMsg authSigMessage
+	var remOffer []byte
 	var err1, err2 error
 
 	cmn.Parallel(
 		func(i int) (interface{}, error, bool) {
-			msgBytes := amino.MustMarshalBinaryLengthPrefixed(authSigMessage{pubKey.Wrap(), signature.Wrap()})
-			_, err1 = sc.Write(msgBytes)
+			msgBytes := amino.MustMarshalBinaryLengthPrefixed(authSigMessage{pubKey, signature})
+			err1 = sc.writeFrame(msgBytes, offer)
 			return nil, err1, false
 		},
 		func(i int) (interface{}, error, bool) {
-			readBuffer := make([]byte, authSigMsgSize)
-			_, err2 = io.ReadFull(sc, readBuffer)
+			var chunk []byte
+			chunk, remOffer, err2 = sc.readFrame()
 			if err2 != nil {
 				return nil, err2, false
 			}
-			_, err2 = amino.UnmarshalBinaryLengthPrefixedReader(bytes.NewBuffer(readBuffer), &recvMsg, authSigMsgSize)
+			_, err2 = amino.UnmarshalBinaryLengthPrefixedReader(bytes.NewBuffer(chunk), &recvMsg, authSigMsgSize)
 			return nil, err2, false
 		},
 	)
 
 	if err1 != nil {
-		return nil, err1
+		return nil, nil, err1
 	}
 	if err2 != nil {
-		return nil, err2
+		return nil, nil, err2
 	}
-	return &recvMsg, nil
+	return &recvMsg, remOffer, nil
 }
 
 func shareEphPubKey(conn io.ReadWriteCloser, locEphPub *[32]byte) (remEphPub *[32]byte, err error) {
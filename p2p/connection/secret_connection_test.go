@@ -30,6 +30,14 @@ func makeDummyConnPair() (fooConn, barConn dummyConn) {
 	return dummyConn{fooReader, fooWriter}, dummyConn{barReader, barWriter}
 }
 
+// parallelTask runs f as a task of cmn.Parallel
+func parallelTask(f func()) cmn.Task {
+	return func(int) (interface{}, error, bool) {
+		f()
+		return nil, nil, false
+	}
+}
+
 func makeSecretConnPair(tb testing.TB) (fooSecConn, barSecConn *SecretConnection) {
 	fooConn, barConn := makeDummyConnPair()
 	fooPrvKey := crypto.GenPrivKeyEd25519()
@@ -38,7 +46,7 @@ func makeSecretConnPair(tb testing.TB) (fooSecConn, barSecConn *SecretConnection
 	barPubKey := barPrvKey.PubKey().Unwrap().(crypto.PubKeyEd25519)
 
 	cmn.Parallel(
-		func() {
+		parallelTask(func() {
 			var err error
 			fooSecConn, err = MakeSecretConnection(fooConn, fooPrvKey)
 			if err != nil {
@@ -50,8 +58,8 @@ func makeSecretConnPair(tb testing.TB) (fooSecConn, barSecConn *SecretConnection
 				tb.Errorf("Unexpected fooSecConn.RemotePubKey.  Expected %v, got %v",
 					barPubKey, fooSecConn.RemotePubKey())
 			}
-		},
-		func() {
+		}),
+		parallelTask(func() {
 			var err error
 			barSecConn, err = MakeSecretConnection(barConn, barPrvKey)
 			if barSecConn == nil {
@@ -63,7 +71,7 @@ func makeSecretConnPair(tb testing.TB) (fooSecConn, barSecConn *SecretConnection
 				tb.Errorf("Unexpected barSecConn.RemotePubKey.  Expected %v, got %v",
 					fooPubKey, barSecConn.RemotePubKey())
 			}
-		})
+		}))
 
 	return
 }
@@ -97,7 +105,7 @@ func TestSecretConnectionReadWrite(t *testing.T) {
 			}
 			// In parallel, handle reads and writes
 			cmn.Parallel(
-				func() {
+				parallelTask(func() {
 					// Node writes
 					for _, nodeWrite := range nodeWrites {
 						n, err := nodeSecretConn.Write([]byte(nodeWrite))
@@ -111,13 +119,14 @@ func TestSecretConnectionReadWrite(t *testing.T) {
 						}
 					}
 					nodeConn.PipeWriter.Close()
-				},
-				func() {
+				}),
+				parallelTask(func() {
 					// Node reads
 					readBuffer := make([]byte, dataMaxSize)
 					for {
 						n, err := nodeSecretConn.Read(readBuffer)
 						if err == io.EOF {
+							nodeConn.PipeReader.Close()
 							return
 						} else if err != nil {
 							t.Errorf("Failed to read from nodeSecretConn: %v", err)
@@ -125,15 +134,14 @@ func TestSecretConnectionReadWrite(t *testing.T) {
 						}
 						*nodeReads = append(*nodeReads, string(readBuffer[:n]))
 					}
-					nodeConn.PipeReader.Close()
-				})
+				}))
 		}
 	}
 
 	// Run foo & bar in parallel
 	cmn.Parallel(
-		genNodeRunner(fooConn, fooWrites, &fooReads),
-		genNodeRunner(barConn, barWrites, &barReads),
+		parallelTask(genNodeRunner(fooConn, fooWrites, &fooReads)),
+		parallelTask(genNodeRunner(barConn, barWrites, &barReads)),
 	)
 
 	// A helper to ensure that the writes and reads match.
@@ -183,7 +191,8 @@ func BenchmarkSecretConnection(b *testing.B) {
 			if err == io.EOF {
 				return
 			} else if err != nil {
-				b.Fatalf("Failed to read from barSecConn: %v", err)
+				b.Errorf("Failed to read from barSecConn: %v", err)
+				return
 			}
 		}
 	}()
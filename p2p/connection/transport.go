@@ -0,0 +1,108 @@
+package connection
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/tendermint/go-crypto"
+)
+
+// transportOfferPrefix starts the transport offer in the auth frame padding,
+// the padding of the legacy peers is zero.
+const transportOfferPrefix = "transports:"
+
+var ErrTransportPubKeyMismatch = errors.New("Transport pubkey mismatch the secret connection")
+
+// SecureConn is an authenticated and encrypted connection to the peer.
+type SecureConn interface {
+	net.Conn
+	RemotePubKey() crypto.PubKeyEd25519
+}
+
+// Transport secures the raw connection in place of the SecretConnection once
+// both peers offer it, initiator is true on the dialing side.
+type Transport interface {
+	Name() string
+	Handshake(conn io.ReadWriteCloser, locPrivKey crypto.PrivKeyEd25519, initiator bool) (SecureConn, error)
+}
+
+// MakeSecureConnection performs the SecretConnection handshake every peer
+// speaks, then switches to the first transport of the initiator which both
+// peers offer. The SecretConnection is kept when there is none.
+func MakeSecureConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKeyEd25519, transports []Transport, initiator bool) (SecureConn, error) {
+	names := make([]string, 0, len(transports))
+	for _, transport := range transports {
+		names = append(names, transport.Name())
+	}
+
+	sc, remOffer, err := makeSecretConnection(conn, locPrivKey, encodeTransportOffer(names))
+	if err != nil {
+		return nil, err
+	}
+
+	transport := selectTransport(transports, decodeTransportOffer(remOffer), initiator)
+	if transport == nil {
+		return sc, nil
+	}
+
+	secureConn, err := transport.Handshake(conn, locPrivKey, initiator)
+	if err != nil {
+		return nil, err
+	}
+	if secureConn.RemotePubKey() != sc.RemotePubKey() {
+		return nil, ErrTransportPubKeyMismatch
+	}
+	return secureConn, nil
+}
+
+func encodeTransportOffer(names []string) []byte {
+	if len(names) == 0 {
+		return nil
+	}
+	return []byte(transportOfferPrefix + strings.Join(names, ","))
+}
+
+func decodeTransportOffer(offer []byte) []string {
+	if !bytes.HasPrefix(offer, []byte(transportOfferPrefix)) {
+		return nil
+	}
+
+	offer = offer[len(transportOfferPrefix):]
+	if i := bytes.IndexByte(offer, 0); i >= 0 {
+		offer = offer[:i]
+	}
+	if len(offer) == 0 {
+		return nil
+	}
+	return strings.Split(string(offer), ",")
+}
+
+// selectTransport picks by the preference of the initiator so both peers make
+// the same choice.
+func selectTransport(transports []Transport, remNames []string, initiator bool) Transport {
+	offered := make(map[string]bool)
+	for _, name := range remNames {
+		offered[name] = true
+	}
+
+	if initiator {
+		for _, transport := range transports {
+			if offered[transport.Name()] {
+				return transport
+			}
+		}
+		return nil
+	}
+
+	for _, name := range remNames {
+		for _, transport := range transports {
+			if transport.Name() == name {
+				return transport
+			}
+		}
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -12,7 +13,7 @@ import (
 
 	cfg "github.com/clarenous/go-capsule/config"
 	"github.com/clarenous/go-capsule/errors"
-	"github.com/clarenous/go-capsule/p2p/upnp"
+	"github.com/clarenous/go-capsule/p2p/nat"
 )
 
 const (
@@ -26,6 +27,7 @@ type Listener interface {
 	Connections() <-chan net.Conn
 	InternalAddress() *NetAddress
 	ExternalAddress() *NetAddress
+	ExternalAddressUpdates() <-chan *NetAddress
 	String() string
 	Stop() error
 }
@@ -55,30 +57,24 @@ func GetListener(config *cfg.P2PConfig) (Listener, string) {
 	return l, cmn.Fmt("%v:%v", l.InternalAddress().IP.String(), l.InternalAddress().Port)
 }
 
-//getUPNPExternalAddress UPNP external address discovery & port mapping
-func getUPNPExternalAddress(externalPort, internalPort int) (*NetAddress, error) {
-	nat, err := upnp.Discover()
+//mapExternalAddress maps the listening port on the gateway with PCP, NAT-PMP
+//or UPnP and returns the mapped external address
+func mapExternalAddress(externalPort, internalPort int) (*NetAddress, *nat.Mapping, error) {
+	gateway, err := nat.Discover()
 	if err != nil {
-		return nil, errors.Wrap(err, "could not perform UPNP discover")
-	}
-
-	ext, err := nat.GetExternalAddress()
-	if err != nil {
-		return nil, errors.Wrap(err, "could not perform UPNP external address")
+		return nil, nil, errors.Wrap(err, "could not perform NAT discover")
 	}
 
 	if externalPort == 0 {
 		externalPort = defaultExternalPort
 	}
-	externalPort, err = nat.AddPortMapping("tcp", externalPort, internalPort, "capsuled tcp", 0)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not add tcp UPNP port mapping")
-	}
-	externalPort, err = nat.AddPortMapping("udp", externalPort, internalPort, "capsuled udp", 0)
+	mapping, err := nat.NewMapping(gateway, externalPort, internalPort, "capsuled", nat.MappingLifetime)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not add udp UPNP port mapping")
+		return nil, nil, errors.Wrap(err, "could not add "+gateway.String()+" port mapping")
 	}
-	return NewNetAddressIPPort(ext, uint16(externalPort)), nil
+
+	ip, port := mapping.ExternalAddress()
+	return NewNetAddressIPPort(ip, uint16(port)), mapping, nil
 }
 
 func getNaiveExternalAddress(port int, settleForLocal bool) *NetAddress {
@@ -120,8 +116,12 @@ type DefaultListener struct {
 
 	listener    net.Listener
 	intAddr     *NetAddress
-	extAddr     *NetAddress
 	connections chan net.Conn
+	mapping     *nat.Mapping
+	extUpdates  chan *NetAddress
+
+	mtx     sync.RWMutex
+	extAddr *NetAddress
 }
 
 //NewDefaultListener create a default listener
@@ -149,11 +149,14 @@ func NewDefaultListener(protocol string, lAddr string, skipUPNP bool) (Listener,
 
 	// Determine external address...
 	var extAddr *NetAddress
-	var upnpMap bool
+	var mapping *nat.Mapping
 	if !skipUPNP && (lAddrIP == "" || lAddrIP == "0.0.0.0") {
-		extAddr, err = getUPNPExternalAddress(lAddrPort, listenerPort)
-		upnpMap = err == nil
-		log.WithFields(log.Fields{"module": logModule, "err": err}).Info("get UPNP external address")
+		extAddr, mapping, err = mapExternalAddress(lAddrPort, listenerPort)
+		if mapping != nil {
+			log.WithFields(log.Fields{"module": logModule, "nat": mapping.NAT(), "address": extAddr}).Info("map external address")
+		} else {
+			log.WithFields(log.Fields{"module": logModule, "err": err}).Info("fail on map external address")
+		}
 	}
 
 	if extAddr == nil {
@@ -173,10 +176,13 @@ func NewDefaultListener(protocol string, lAddr string, skipUPNP bool) (Listener,
 		intAddr:     intAddr,
 		extAddr:     extAddr,
 		connections: make(chan net.Conn, numBufferedConnections),
+		mapping:     mapping,
+		extUpdates:  make(chan *NetAddress, 1),
 	}
 	dl.BaseService = *cmn.NewBaseService(nil, "DefaultListener", dl)
 	dl.Start() // Started upon construction
-	if upnpMap {
+	if mapping != nil {
+		mapping.Start(dl.updateExternalAddress)
 		return dl, true
 	}
 
@@ -199,6 +205,9 @@ func (l *DefaultListener) OnStart() error {
 func (l *DefaultListener) OnStop() {
 	l.BaseService.OnStop()
 	l.listener.Close()
+	if l.mapping != nil {
+		l.mapping.Stop()
+	}
 }
 
 //listenRoutine Accept connections and pass on the channel
@@ -231,9 +240,31 @@ func (l *DefaultListener) InternalAddress() *NetAddress {
 
 //ExternalAddress listener external address for remote peer dial
 func (l *DefaultListener) ExternalAddress() *NetAddress {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+
 	return l.extAddr
 }
 
+//ExternalAddressUpdates the channel of the new external address when the gateway changes it
+func (l *DefaultListener) ExternalAddressUpdates() <-chan *NetAddress {
+	return l.extUpdates
+}
+
+// updateExternalAddress keeps only the latest address in the updates channel.
+func (l *DefaultListener) updateExternalAddress(ip net.IP, port int) {
+	extAddr := NewNetAddressIPPort(ip, uint16(port))
+	l.mtx.Lock()
+	l.extAddr = extAddr
+	l.mtx.Unlock()
+
+	select {
+	case <-l.extUpdates:
+	default:
+	}
+	l.extUpdates <- extAddr
+}
+
 // NetListener the returned listener is already Accept()'ing. So it's not suitable to pass into http.Serve().
 func (l *DefaultListener) NetListener() net.Listener {
 	return l.listener
@@ -241,5 +272,5 @@ func (l *DefaultListener) NetListener() net.Listener {
 
 //String string of default listener
 func (l *DefaultListener) String() string {
-	return fmt.Sprintf("Listener(@%v)", l.extAddr)
+	return fmt.Sprintf("Listener(@%v)", l.ExternalAddress())
 }
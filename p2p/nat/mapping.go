@@ -0,0 +1,142 @@
+package nat
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	logModule = "nat"
+
+	// MappingLifetime is the lease of the port mappings, they are renewed at
+	// the half of the lease
+	MappingLifetime = 20 * time.Minute
+)
+
+// Mapping keeps the internal port mapped on the gateway for both tcp and udp,
+// the mappings are renewed before the lease ends and the changes of the
+// external address are reported to onChange.
+type Mapping struct {
+	nat          NAT
+	internalPort int
+	description  string
+	lifetime     time.Duration
+	onChange     func(net.IP, int)
+
+	mtx     sync.Mutex
+	extIP   net.IP
+	extPort int
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewMapping maps the internal port on the gateway, the external port is the
+// suggested one which the gateway may not grant.
+func NewMapping(nat NAT, externalPort, internalPort int, description string, lifetime time.Duration) (*Mapping, error) {
+	m := &Mapping{
+		nat:          nat,
+		internalPort: internalPort,
+		description:  description,
+		lifetime:     lifetime,
+		extPort:      externalPort,
+		quit:         make(chan struct{}),
+	}
+	if _, err := m.renew(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ExternalAddress returns the external ip and port of the mapping.
+func (m *Mapping) ExternalAddress() (net.IP, int) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.extIP, m.extPort
+}
+
+// NAT returns the gateway of the mapping.
+func (m *Mapping) NAT() NAT {
+	return m.nat
+}
+
+// Start renews the mappings until Stop, onChange is called when the external
+// address changes.
+func (m *Mapping) Start(onChange func(net.IP, int)) {
+	m.onChange = onChange
+	m.wg.Add(1)
+	go m.renewRoutine()
+}
+
+// Stop stops the renewal and deletes the mappings from the gateway.
+func (m *Mapping) Stop() {
+	close(m.quit)
+	m.wg.Wait()
+
+	_, extPort := m.ExternalAddress()
+	for _, protocol := range []string{"tcp", "udp"} {
+		if err := m.nat.DeletePortMapping(protocol, extPort, m.internalPort); err != nil {
+			log.WithFields(log.Fields{"module": logModule, "nat": m.nat, "protocol": protocol, "err": err}).Debug("fail on delete port mapping")
+		}
+	}
+}
+
+// renew maps the ports again and tells whether the external address changed.
+func (m *Mapping) renew() (bool, error) {
+	m.mtx.Lock()
+	extPort := m.extPort
+	m.mtx.Unlock()
+
+	timeout := int(m.lifetime / time.Second)
+	mappedPort, err := m.nat.AddPortMapping("tcp", extPort, m.internalPort, m.description+" tcp", timeout)
+	if err != nil {
+		return false, err
+	}
+	if _, err := m.nat.AddPortMapping("udp", mappedPort, m.internalPort, m.description+" udp", timeout); err != nil {
+		return false, err
+	}
+
+	extIP, err := m.nat.GetExternalAddress()
+	if err != nil {
+		return false, err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	changed := !extIP.Equal(m.extIP) || mappedPort != m.extPort
+	m.extIP, m.extPort = extIP, mappedPort
+	return changed, nil
+}
+
+func (m *Mapping) renewRoutine() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.lifetime / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			changed, err := m.renew()
+			if err != nil {
+				log.WithFields(log.Fields{"module": logModule, "nat": m.nat, "err": err}).Warn("fail on renew port mapping")
+				continue
+			}
+			if !changed {
+				continue
+			}
+
+			extIP, extPort := m.ExternalAddress()
+			log.WithFields(log.Fields{"module": logModule, "nat": m.nat, "ip": extIP, "port": extPort}).Info("external address changed")
+			if m.onChange != nil {
+				m.onChange(extIP, extPort)
+			}
+		case <-m.quit:
+			return
+		}
+	}
+}
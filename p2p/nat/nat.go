@@ -0,0 +1,157 @@
+// Package nat maps the listening port of the node on the gateway with PCP,
+// NAT-PMP or UPnP, whichever the gateway speaks.
+package nat
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/p2p/upnp"
+)
+
+const (
+	// gatewayPort is the port PCP and NAT-PMP servers listen on
+	gatewayPort = 5351
+
+	initialTimeout = 250 * time.Millisecond
+	maxTries       = 3
+)
+
+var (
+	errNoGateway         = errors.New("no default gateway")
+	errNoResponse        = errors.New("no response from gateway")
+	errNoExternalAddress = errors.New("external address is unknown before mapping")
+)
+
+// NAT is a gateway mapping the ports of the node, protocol is either "udp"
+// or "tcp" and timeout is the lifetime of the mapping in seconds.
+type NAT interface {
+	GetExternalAddress() (net.IP, error)
+	AddPortMapping(protocol string, externalPort, internalPort int, description string, timeout int) (int, error)
+	DeletePortMapping(protocol string, externalPort, internalPort int) error
+	String() string
+}
+
+// Discover finds the NAT of the default gateway, PCP and NAT-PMP are asked
+// first for they are cheap to probe, UPnP is the fallback.
+func Discover() (NAT, error) {
+	if gateway, err := defaultGateway(); err == nil {
+		if nat, err := discoverGateway(&net.UDPAddr{IP: gateway, Port: gatewayPort}); err == nil {
+			return nat, nil
+		}
+	}
+
+	nat, err := upnp.Discover()
+	if err != nil {
+		return nil, err
+	}
+	return &upnpNAT{nat}, nil
+}
+
+func discoverGateway(gateway *net.UDPAddr) (NAT, error) {
+	if nat, err := newPCP(gateway); err == nil {
+		return nat, nil
+	}
+	return newNATPMP(gateway)
+}
+
+// upnpNAT names the UPnP gateway.
+type upnpNAT struct {
+	upnp.NAT
+}
+
+func (n *upnpNAT) String() string {
+	return "UPnP"
+}
+
+// defaultGateway reads the gateway of the default route, the gateway is
+// guessed as the first host of the local network when the route table is not
+// readable.
+func defaultGateway() (net.IP, error) {
+	if ip, err := routeGateway("/proc/net/route"); err == nil {
+		return ip, nil
+	}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		ipnet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		if v4 := ipnet.IP.To4(); v4 != nil && !v4.IsLoopback() {
+			gateway := v4.Mask(ipnet.Mask)
+			gateway[3] |= 1
+			return gateway, nil
+		}
+	}
+	return nil, errNoGateway
+}
+
+func routeGateway(path string) (net.IP, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+
+		b, err := hex.DecodeString(fields[2])
+		if err != nil || len(b) != net.IPv4len {
+			continue
+		}
+		// the route table is in host byte order
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, binary.LittleEndian.Uint32(b))
+		return ip, nil
+	}
+	return nil, errNoGateway
+}
+
+// roundTrip sends the request to the gateway until a response accepted by
+// check is received, the timeout doubles on every try.
+func roundTrip(gateway *net.UDPAddr, req []byte, check func([]byte) bool) ([]byte, error) {
+	conn, err := net.DialUDP("udp", nil, gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	buf := make([]byte, 1100)
+	timeout := initialTimeout
+	for i := 0; i < maxTries; i++ {
+		if _, err := conn.Write(req); err != nil {
+			return nil, err
+		}
+
+		deadline := time.Now().Add(timeout)
+		for {
+			if err := conn.SetReadDeadline(deadline); err != nil {
+				return nil, err
+			}
+			n, err := conn.Read(buf)
+			if err != nil {
+				break
+			}
+			if check(buf[:n]) {
+				return buf[:n], nil
+			}
+		}
+		timeout *= 2
+	}
+	return nil, errNoResponse
+}
@@ -0,0 +1,314 @@
+package nat
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeGateway answers NAT-PMP and, unless pmpOnly, PCP requests on localhost.
+type fakeGateway struct {
+	conn    *net.UDPConn
+	pmpOnly bool
+
+	mtx      sync.Mutex
+	extIP    net.IP
+	mappings map[byte]map[int]int
+}
+
+func newFakeGateway(t *testing.T, pmpOnly bool) *fakeGateway {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := &fakeGateway{
+		conn:     conn,
+		pmpOnly:  pmpOnly,
+		extIP:    net.IPv4(203, 0, 113, 1).To4(),
+		mappings: make(map[byte]map[int]int),
+	}
+	go g.serve()
+	return g
+}
+
+func (g *fakeGateway) addr() *net.UDPAddr {
+	return g.conn.LocalAddr().(*net.UDPAddr)
+}
+
+func (g *fakeGateway) close() {
+	g.conn.Close()
+}
+
+func (g *fakeGateway) setExternalIP(ip net.IP) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.extIP = ip.To4()
+}
+
+func (g *fakeGateway) mapped(protocol byte, internalPort int) (int, bool) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	port, ok := g.mappings[protocol][internalPort]
+	return port, ok
+}
+
+// mapPort grants the suggested port, or internal port + 1000 when none.
+func (g *fakeGateway) mapPort(protocol byte, internalPort, externalPort int, lifetime uint32) int {
+	if g.mappings[protocol] == nil {
+		g.mappings[protocol] = make(map[int]int)
+	}
+	if lifetime == 0 {
+		delete(g.mappings[protocol], internalPort)
+		return 0
+	}
+	if externalPort == 0 {
+		externalPort = internalPort + 1000
+	}
+	g.mappings[protocol][internalPort] = externalPort
+	return externalPort
+}
+
+func (g *fakeGateway) serve() {
+	buf := make([]byte, 1100)
+	for {
+		n, addr, err := g.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+
+		g.mtx.Lock()
+		resp := g.handle(buf[:n])
+		g.mtx.Unlock()
+		if resp != nil {
+			g.conn.WriteToUDP(resp, addr)
+		}
+	}
+}
+
+func (g *fakeGateway) handle(req []byte) []byte {
+	if len(req) < 2 {
+		return nil
+	}
+	if req[0] == pcpVersion {
+		if g.pmpOnly {
+			// unsupported version
+			return []byte{pmpVersion, req[1] | pmpResponseFlag, 0, 1, 0, 0, 0, 0}
+		}
+		return g.handlePCP(req)
+	}
+	return g.handlePMP(req)
+}
+
+func (g *fakeGateway) handlePMP(req []byte) []byte {
+	switch req[1] {
+	case pmpOpExternal:
+		resp := make([]byte, pmpExternalSize)
+		resp[1] = pmpOpExternal | pmpResponseFlag
+		copy(resp[8:12], g.extIP)
+		return resp
+
+	case pmpOpMapUDP, pmpOpMapTCP:
+		if len(req) < 12 {
+			return nil
+		}
+		internalPort := int(binary.BigEndian.Uint16(req[4:6]))
+		lifetime := binary.BigEndian.Uint32(req[8:12])
+		extPort := g.mapPort(req[1], internalPort, int(binary.BigEndian.Uint16(req[6:8])), lifetime)
+
+		resp := make([]byte, pmpMapSize)
+		resp[1] = req[1] | pmpResponseFlag
+		copy(resp[8:10], req[4:6])
+		binary.BigEndian.PutUint16(resp[10:12], uint16(extPort))
+		binary.BigEndian.PutUint32(resp[12:16], lifetime)
+		return resp
+	}
+	return nil
+}
+
+func (g *fakeGateway) handlePCP(req []byte) []byte {
+	if len(req) < pcpHeaderSize {
+		return nil
+	}
+
+	resp := make([]byte, pcpHeaderSize, pcpHeaderSize+pcpMapSize)
+	resp[0], resp[1] = pcpVersion, req[1]|pcpResponseFlag
+	copy(resp[4:8], req[4:8])
+	if req[1] != pcpOpMap {
+		return resp
+	}
+	if len(req) < pcpHeaderSize+pcpMapSize {
+		return nil
+	}
+
+	payload := append([]byte{}, req[pcpHeaderSize:pcpHeaderSize+pcpMapSize]...)
+	lifetime := binary.BigEndian.Uint32(req[4:8])
+	extPort := g.mapPort(payload[12], int(binary.BigEndian.Uint16(payload[16:18])), int(binary.BigEndian.Uint16(payload[18:20])), lifetime)
+	binary.BigEndian.PutUint16(payload[18:20], uint16(extPort))
+	copy(payload[20:36], g.extIP.To16())
+	return append(resp, payload...)
+}
+
+func TestDiscoverGateway(t *testing.T) {
+	cases := []struct {
+		pmpOnly bool
+		want    string
+	}{
+		{pmpOnly: false, want: "PCP"},
+		{pmpOnly: true, want: "NAT-PMP"},
+	}
+
+	for i, c := range cases {
+		gateway := newFakeGateway(t, c.pmpOnly)
+		nat, err := discoverGateway(gateway.addr())
+		gateway.close()
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if nat.String() != c.want {
+			t.Errorf("case %d: got %s, want %s", i, nat, c.want)
+		}
+	}
+}
+
+func TestDiscoverNoGateway(t *testing.T) {
+	gateway := newFakeGateway(t, false)
+	addr := gateway.addr()
+	gateway.close()
+
+	if _, err := discoverGateway(addr); err == nil {
+		t.Fatal("discover a closed gateway")
+	}
+}
+
+func TestPortMapping(t *testing.T) {
+	for _, pmpOnly := range []bool{false, true} {
+		gateway := newFakeGateway(t, pmpOnly)
+		nat, err := discoverGateway(gateway.addr())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		extPort, err := nat.AddPortMapping("tcp", 0, 8770, "test", 60)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if extPort != 9770 {
+			t.Errorf("%s: got external port %d, want 9770", nat, extPort)
+		}
+
+		extIP, err := nat.GetExternalAddress()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !extIP.Equal(net.IPv4(203, 0, 113, 1)) {
+			t.Errorf("%s: got external ip %v", nat, extIP)
+		}
+
+		if err := nat.DeletePortMapping("tcp", extPort, 8770); err != nil {
+			t.Fatal(err)
+		}
+		protocol := byte(pcpProtocolTCP)
+		if pmpOnly {
+			protocol = pmpOpMapTCP
+		}
+		if _, ok := gateway.mapped(protocol, 8770); ok {
+			t.Errorf("%s: mapping is not deleted", nat)
+		}
+		gateway.close()
+	}
+}
+
+func TestPCPExternalAddressBeforeMapping(t *testing.T) {
+	gateway := newFakeGateway(t, false)
+	defer gateway.close()
+
+	nat, err := newPCP(gateway.addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nat.GetExternalAddress(); err != errNoExternalAddress {
+		t.Fatalf("got %v, want %v", err, errNoExternalAddress)
+	}
+}
+
+func TestMappingRenew(t *testing.T) {
+	gateway := newFakeGateway(t, false)
+	defer gateway.close()
+
+	nat, err := discoverGateway(gateway.addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewMapping(nat, 8770, 8771, "test", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip, port := m.ExternalAddress(); !ip.Equal(net.IPv4(203, 0, 113, 1)) || port != 8770 {
+		t.Fatalf("got external address %v:%d", ip, port)
+	}
+	for _, protocol := range []byte{pcpProtocolTCP, pcpProtocolUDP} {
+		if port, ok := gateway.mapped(protocol, 8771); !ok || port != 8770 {
+			t.Fatalf("protocol %d: got mapped port %d, %v", protocol, port, ok)
+		}
+	}
+
+	changed, err := m.renew()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Fatal("external address changed without gateway change")
+	}
+
+	gateway.setExternalIP(net.IPv4(203, 0, 113, 2))
+	if changed, err = m.renew(); err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("external address change is not detected")
+	}
+	if ip, _ := m.ExternalAddress(); !ip.Equal(net.IPv4(203, 0, 113, 2)) {
+		t.Fatalf("got external ip %v", ip)
+	}
+
+	m.Start(nil)
+	m.Stop()
+	for _, protocol := range []byte{pcpProtocolTCP, pcpProtocolUDP} {
+		if _, ok := gateway.mapped(protocol, 8771); ok {
+			t.Fatalf("protocol %d: mapping is not deleted", protocol)
+		}
+	}
+}
+
+func TestRouteGateway(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "route")
+	table := "Iface\tDestination\tGateway \tFlags\n" +
+		"eth0\t0000FEA9\t00000000\t0001\n" +
+		"eth0\t00000000\t0101A8C0\t0003\n"
+	if err := ioutil.WriteFile(file, []byte(table), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ip, err := routeGateway(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ip.Equal(net.IPv4(192, 168, 1, 1)) {
+		t.Fatalf("got gateway %v", ip)
+	}
+}
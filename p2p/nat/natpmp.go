@@ -0,0 +1,89 @@
+package nat
+
+import (
+	"encoding/binary"
+	"net"
+
+	"github.com/clarenous/go-capsule/errors"
+)
+
+// NAT-PMP (RFC 6886) opcodes
+const (
+	pmpVersion       = 0
+	pmpOpExternal    = 0
+	pmpOpMapUDP      = 1
+	pmpOpMapTCP      = 2
+	pmpResponseFlag  = 128
+	pmpExternalSize  = 12
+	pmpMapSize       = 16
+	pmpResultSuccess = 0
+)
+
+var errPMPResult = errors.New("nat-pmp request failed")
+
+// natPMP is the NAT-PMP client of the gateway.
+type natPMP struct {
+	gateway *net.UDPAddr
+}
+
+// newNATPMP probes the gateway with an external address request.
+func newNATPMP(gateway *net.UDPAddr) (*natPMP, error) {
+	n := &natPMP{gateway: gateway}
+	if _, err := n.GetExternalAddress(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *natPMP) GetExternalAddress() (net.IP, error) {
+	resp, err := n.request([]byte{pmpVersion, pmpOpExternal}, pmpExternalSize)
+	if err != nil {
+		return nil, err
+	}
+	return net.IP(append([]byte{}, resp[8:12]...)), nil
+}
+
+func (n *natPMP) AddPortMapping(protocol string, externalPort, internalPort int, description string, timeout int) (int, error) {
+	resp, err := n.mapPort(protocol, externalPort, internalPort, timeout)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(resp[10:12])), nil
+}
+
+func (n *natPMP) DeletePortMapping(protocol string, externalPort, internalPort int) error {
+	_, err := n.mapPort(protocol, 0, internalPort, 0)
+	return err
+}
+
+func (n *natPMP) String() string {
+	return "NAT-PMP"
+}
+
+func (n *natPMP) mapPort(protocol string, externalPort, internalPort int, timeout int) ([]byte, error) {
+	op := byte(pmpOpMapTCP)
+	if protocol == "udp" {
+		op = pmpOpMapUDP
+	}
+
+	req := make([]byte, 12)
+	req[0], req[1] = pmpVersion, op
+	binary.BigEndian.PutUint16(req[4:6], uint16(internalPort))
+	binary.BigEndian.PutUint16(req[6:8], uint16(externalPort))
+	binary.BigEndian.PutUint32(req[8:12], uint32(timeout))
+	return n.request(req, pmpMapSize)
+}
+
+func (n *natPMP) request(req []byte, size int) ([]byte, error) {
+	resp, err := roundTrip(n.gateway, req, func(resp []byte) bool {
+		return len(resp) >= size && resp[0] == pmpVersion && resp[1] == req[1]|pmpResponseFlag
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result := binary.BigEndian.Uint16(resp[2:4]); result != pmpResultSuccess {
+		return nil, errors.WithDetailf(errPMPResult, "result code %d", result)
+	}
+	return resp, nil
+}
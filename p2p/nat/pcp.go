@@ -0,0 +1,152 @@
+package nat
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"sync"
+
+	"github.com/clarenous/go-capsule/errors"
+)
+
+// PCP (RFC 6887) opcodes and sizes
+const (
+	pcpVersion       = 2
+	pcpOpAnnounce    = 0
+	pcpOpMap         = 1
+	pcpResponseFlag  = 0x80
+	pcpHeaderSize    = 24
+	pcpMapSize       = 36
+	pcpResultSuccess = 0
+	pcpProtocolTCP   = 6
+	pcpProtocolUDP   = 17
+)
+
+var (
+	errPCPResult      = errors.New("pcp request failed")
+	errPCPUnsupported = errors.New("gateway doesn't support pcp")
+)
+
+type pcpMappingKey struct {
+	protocol     byte
+	internalPort int
+}
+
+// pcp is the PCP client of the gateway, the external address is learned
+// from the mappings since PCP has no request for it.
+type pcp struct {
+	gateway *net.UDPAddr
+
+	mtx    sync.Mutex
+	extIP  net.IP
+	nonces map[pcpMappingKey][]byte
+}
+
+// newPCP probes the gateway with an announce request.
+func newPCP(gateway *net.UDPAddr) (*pcp, error) {
+	n := &pcp{gateway: gateway, nonces: make(map[pcpMappingKey][]byte)}
+	if _, err := n.request(pcpOpAnnounce, 0, nil); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *pcp) GetExternalAddress() (net.IP, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if n.extIP == nil {
+		return nil, errNoExternalAddress
+	}
+	return n.extIP, nil
+}
+
+func (n *pcp) AddPortMapping(protocol string, externalPort, internalPort int, description string, timeout int) (int, error) {
+	resp, err := n.mapPort(protocol, externalPort, internalPort, timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	n.mtx.Lock()
+	n.extIP = net.IP(append([]byte{}, resp[pcpHeaderSize+20:pcpHeaderSize+36]...)).To4()
+	n.mtx.Unlock()
+	return int(binary.BigEndian.Uint16(resp[pcpHeaderSize+18 : pcpHeaderSize+20])), nil
+}
+
+func (n *pcp) DeletePortMapping(protocol string, externalPort, internalPort int) error {
+	_, err := n.mapPort(protocol, 0, internalPort, 0)
+	return err
+}
+
+func (n *pcp) String() string {
+	return "PCP"
+}
+
+// mapPort sends a map request, the nonce of the mapping is kept for the
+// renewals and the deletion must come with the same nonce.
+func (n *pcp) mapPort(protocol string, externalPort, internalPort int, timeout int) ([]byte, error) {
+	key := pcpMappingKey{protocol: pcpProtocolTCP, internalPort: internalPort}
+	if protocol == "udp" {
+		key.protocol = pcpProtocolUDP
+	}
+
+	n.mtx.Lock()
+	nonce, ok := n.nonces[key]
+	if !ok {
+		nonce = make([]byte, 12)
+		if _, err := rand.Read(nonce); err != nil {
+			n.mtx.Unlock()
+			return nil, err
+		}
+		n.nonces[key] = nonce
+	}
+	n.mtx.Unlock()
+
+	payload := make([]byte, pcpMapSize)
+	copy(payload[0:12], nonce)
+	payload[12] = key.protocol
+	binary.BigEndian.PutUint16(payload[16:18], uint16(internalPort))
+	binary.BigEndian.PutUint16(payload[18:20], uint16(externalPort))
+	copy(payload[20:36], net.IPv4zero.To16())
+
+	resp, err := n.request(pcpOpMap, uint32(timeout), payload)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) < pcpHeaderSize+pcpMapSize || string(resp[pcpHeaderSize:pcpHeaderSize+12]) != string(nonce) {
+		return nil, errors.WithDetail(errPCPResult, "mismatched map response")
+	}
+	return resp, nil
+}
+
+func (n *pcp) request(op byte, lifetime uint32, payload []byte) ([]byte, error) {
+	// the client address is filled once the local address toward the gateway is known
+	conn, err := net.DialUDP("udp", nil, n.gateway)
+	if err != nil {
+		return nil, err
+	}
+	clientIP := conn.LocalAddr().(*net.UDPAddr).IP
+	conn.Close()
+
+	req := make([]byte, pcpHeaderSize, pcpHeaderSize+len(payload))
+	req[0], req[1] = pcpVersion, op
+	binary.BigEndian.PutUint32(req[4:8], lifetime)
+	copy(req[8:24], clientIP.To16())
+	req = append(req, payload...)
+
+	resp, err := roundTrip(n.gateway, req, func(resp []byte) bool {
+		// a NAT-PMP gateway answers with its own version
+		return len(resp) >= 4 && (resp[0] != pcpVersion || (len(resp) >= pcpHeaderSize && resp[1] == op|pcpResponseFlag))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp[0] != pcpVersion {
+		return nil, errPCPUnsupported
+	}
+	if result := resp[3]; result != pcpResultSuccess {
+		return nil, errors.WithDetailf(errPCPResult, "result code %d", result)
+	}
+	return resp, nil
+}
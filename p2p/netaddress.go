@@ -9,10 +9,13 @@ import (
 	"flag"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/btcsuite/go-socks/socks"
 	cmn "github.com/tendermint/tmlibs/common"
+
+	"github.com/clarenous/go-capsule/p2p/tor"
 )
 
 // NetAddress defines information about a peer on the network
@@ -21,14 +24,21 @@ type NetAddress struct {
 	IP   net.IP
 	Port uint16
 	str  string
+
+	Onion string // onion v3 host of the onion address, the IP is nil
 }
 
 // NewNetAddress returns a new NetAddress using the provided TCP
-// address. When testing, other net.Addr (except TCP) will result in
-// using 0.0.0.0:0. When normal run, other net.Addr (except TCP) will
+// address or the socks proxied address. When testing, other net.Addr
+// will result in using 0.0.0.0:0. When normal run, other net.Addr will
 // panic.
-// TODO: socks proxies?
 func NewNetAddress(addr net.Addr) *NetAddress {
+	if proxiedAddr, ok := addr.(*socks.ProxiedAddr); ok {
+		if na, err := NewNetAddressString(proxiedAddr.String()); err == nil {
+			return na
+		}
+	}
+
 	tcpAddr, ok := addr.(*net.TCPAddr)
 	if !ok {
 		if flag.Lookup("test.v") == nil { // normal run
@@ -44,13 +54,21 @@ func NewNetAddress(addr net.Addr) *NetAddress {
 
 // NewNetAddressString returns a new NetAddress using the provided
 // address in the form of "IP:Port". Also resolves the host if host
-// is not an IP.
+// is not an IP, the onion host is never resolved.
 func NewNetAddressString(addr string) (*NetAddress, error) {
 	host, portStr, err := net.SplitHostPort(addr)
 	if err != nil {
 		return nil, err
 	}
 
+	if tor.IsOnion(host) {
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil {
+			return nil, err
+		}
+		return NewOnionNetAddress(host, uint16(port))
+	}
+
 	ip := net.ParseIP(host)
 	if ip == nil {
 		if len(host) > 0 {
@@ -98,6 +116,20 @@ func NewNetAddressIPPort(ip net.IP, port uint16) *NetAddress {
 	}
 }
 
+// NewOnionNetAddress returns a new NetAddress of the onion v3 host and port.
+func NewOnionNetAddress(onion string, port uint16) (*NetAddress, error) {
+	if _, err := tor.ParseOnion(onion); err != nil {
+		return nil, err
+	}
+
+	onion = strings.ToLower(onion)
+	return &NetAddress{
+		Port:  port,
+		Onion: onion,
+		str:   net.JoinHostPort(onion, strconv.FormatUint(uint64(port), 10)),
+	}, nil
+}
+
 // Equals reports whether na and other are the same addresses.
 func (na *NetAddress) Equals(other interface{}) bool {
 	if o, ok := other.(*NetAddress); ok {
@@ -106,11 +138,24 @@ func (na *NetAddress) Equals(other interface{}) bool {
 	return false
 }
 
+// Host returns the onion host of the onion address, the IP otherwise.
+func (na *NetAddress) Host() string {
+	if na.IsOnion() {
+		return na.Onion
+	}
+	return na.IP.String()
+}
+
+// IsOnion returns true if it is an onion address.
+func (na *NetAddress) IsOnion() bool {
+	return na.Onion != ""
+}
+
 // String representation.
 func (na *NetAddress) String() string {
 	if na.str == "" {
 		na.str = net.JoinHostPort(
-			na.IP.String(),
+			na.Host(),
 			strconv.FormatUint(uint64(na.Port), 10),
 		)
 	}
@@ -120,7 +165,7 @@ func (na *NetAddress) String() string {
 //DialString dial address string representation
 func (na *NetAddress) DialString() string {
 	return net.JoinHostPort(
-		na.IP.String(),
+		na.Host(),
 		strconv.FormatUint(uint64(na.Port), 10),
 	)
 }
@@ -160,8 +205,12 @@ func (na *NetAddress) Routable() bool {
 }
 
 // Valid For IPv4 these are either a 0 or all bits set address. For IPv6 a zero
-// address or one that matches the RFC3849 documentation address format.
+// address or one that matches the RFC3849 documentation address format. The
+// onion address is checked on creation.
 func (na *NetAddress) Valid() bool {
+	if na.IsOnion() {
+		return true
+	}
 	return na.IP != nil && !(na.IP.IsUnspecified() || na.RFC3849() ||
 		na.IP.Equal(net.IPv4bcast))
 }
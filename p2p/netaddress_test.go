@@ -3,9 +3,11 @@
 package p2p
 
 import (
+	"encoding/json"
 	"net"
 	"testing"
 
+	"github.com/btcsuite/go-socks/socks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -38,6 +40,9 @@ func TestNewNetAddressString(t *testing.T) {
 		{"a:8080", false},
 		{"8082", false},
 		{"127.0.0:8080000", false},
+		{"duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion:8770", true},
+		{"duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczae.onion:8770", false},
+		{"3g2upl4pq6kufc4m.onion:8770", false},
 	}
 
 	for _, t := range tests {
@@ -67,6 +72,35 @@ func TestNewNetAddressIPPort(t *testing.T) {
 	assert.Equal("127.0.0.1:8080", addr.String())
 }
 
+func TestOnionNetAddress(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	onion := "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion"
+	addr := NewNetAddress(&socks.ProxiedAddr{Net: "tcp", Host: onion, Port: 8770})
+	require.True(addr.IsOnion())
+	assert.Nil(addr.IP)
+	assert.Equal(onion, addr.Host())
+	assert.Equal(onion+":8770", addr.DialString())
+
+	// the address book persists the addresses in json
+	data, err := json.Marshal(addr)
+	require.Nil(err)
+	loaded := &NetAddress{}
+	require.Nil(json.Unmarshal(data, loaded))
+	assert.True(addr.Equals(loaded))
+}
+
+// TestOnionNetAddressProperties needs no dns, the onion hosts are not resolved
+func TestOnionNetAddressProperties(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	addr, err := NewNetAddressString("duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion:8770")
+	require.Nil(err)
+	assert.True(addr.Valid())
+	assert.False(addr.Local())
+	assert.True(addr.Routable())
+}
+
 func TestNetAddressProperties(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 
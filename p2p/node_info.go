@@ -12,7 +12,15 @@ import (
 	"github.com/clarenous/go-capsule/version"
 )
 
-const maxNodeInfoSize = 10240 // 10Kb
+const (
+	maxNodeInfoSize = 10240 // 10Kb
+
+	// ProtocolVersion is the p2p protocol version of the node, version 2
+	// negotiates the services in the handshake
+	ProtocolVersion = uint32(2)
+	// legacyProtocolVersion is the version of the peers not sending one
+	legacyProtocolVersion = uint32(1)
+)
 
 //NodeInfo peer node info
 type NodeInfo struct {
@@ -23,16 +31,26 @@ type NodeInfo struct {
 	ListenAddr string               `json:"listen_addr"`
 	Version    string               `json:"version"` // major.minor.revision
 	Other      []string             `json:"other"`   // other application specific data
+	Protocol   uint32               `json:"protocol"`
+	Services   uint64               `json:"services"`
 }
 
 func NewNodeInfo(config *cfg.Config, pubkey crypto.PubKeyEd25519, listenAddr string) *NodeInfo {
+	// the light client serves nothing, full nodes relay it the filtered data
+	services := consensus.DefaultServices
+	if config.Light {
+		services = consensus.ServiceFlag(0)
+	}
+
 	return &NodeInfo{
 		PubKey:     pubkey,
 		Moniker:    config.Moniker,
 		Network:    config.ChainID,
 		ListenAddr: listenAddr,
 		Version:    version.Version,
-		Other:      []string{strconv.FormatUint(uint64(consensus.DefaultServices), 10)},
+		Other:      []string{strconv.FormatUint(uint64(services), 10)},
+		Protocol:   ProtocolVersion,
+		Services:   uint64(services),
 	}
 }
 
@@ -53,6 +71,32 @@ func (info *NodeInfo) CompatibleWith(other *NodeInfo) error {
 	return nil
 }
 
+// GetProtocol returns the p2p protocol version of the node.
+func (info *NodeInfo) GetProtocol() uint32 {
+	if info.Protocol == 0 {
+		return legacyProtocolVersion
+	}
+	return info.Protocol
+}
+
+// ServiceFlag returns the services of the node, the legacy peers advertise
+// them as a decimal string in Other.
+func (info *NodeInfo) ServiceFlag() consensus.ServiceFlag {
+	if info.Protocol >= ProtocolVersion {
+		return consensus.ServiceFlag(info.Services)
+	}
+
+	services := consensus.SFFullNode
+	if len(info.Other) == 0 {
+		return services
+	}
+
+	if serviceFlag, err := strconv.ParseUint(info.Other[0], 10, 64); err == nil {
+		services = consensus.ServiceFlag(serviceFlag)
+	}
+	return services
+}
+
 func (info *NodeInfo) getPubkey() crypto.PubKeyEd25519 {
 	return info.PubKey
 }
@@ -76,5 +120,5 @@ func (info *NodeInfo) GetNetwork() string {
 
 //String representation
 func (info NodeInfo) String() string {
-	return fmt.Sprintf("NodeInfo{pk: %v, moniker: %v, network: %v [listen %v], version: %v (%v)}", info.PubKey, info.Moniker, info.Network, info.ListenAddr, info.Version, info.Other)
+	return fmt.Sprintf("NodeInfo{pk: %v, moniker: %v, network: %v [listen %v], version: %v (%v), protocol: %v, services: %v}", info.PubKey, info.Moniker, info.Network, info.ListenAddr, info.Version, info.Other, info.GetProtocol(), info.ServiceFlag())
 }
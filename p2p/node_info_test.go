@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/tendermint/go-amino"
+
+	"github.com/clarenous/go-capsule/consensus"
+)
+
+func TestNodeInfoServiceFlag(t *testing.T) {
+	cases := []struct {
+		info         *NodeInfo
+		wantProtocol uint32
+		wantServices consensus.ServiceFlag
+	}{
+		{
+			info:         &NodeInfo{},
+			wantProtocol: legacyProtocolVersion,
+			wantServices: consensus.SFFullNode,
+		},
+		{
+			info:         &NodeInfo{Other: []string{"7"}},
+			wantProtocol: legacyProtocolVersion,
+			wantServices: consensus.SFFullNode | consensus.SFFastSync | consensus.SFSPV,
+		},
+		{
+			info:         &NodeInfo{Other: []string{"7"}, Protocol: ProtocolVersion, Services: uint64(consensus.DefaultServices)},
+			wantProtocol: ProtocolVersion,
+			wantServices: consensus.DefaultServices,
+		},
+	}
+
+	for i, c := range cases {
+		bz, err := amino.MarshalBinaryLengthPrefixed(c.info)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		info := new(NodeInfo)
+		if err := amino.UnmarshalBinaryLengthPrefixed(bz, info); err != nil {
+			t.Fatal(err)
+		}
+		if protocol := info.GetProtocol(); protocol != c.wantProtocol {
+			t.Errorf("case %d: got protocol %d, want %d", i, protocol, c.wantProtocol)
+		}
+		if services := info.ServiceFlag(); services != c.wantServices {
+			t.Errorf("case %d: got services %d, want %d", i, services, c.wantServices)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package p2p
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	cmn "github.com/tendermint/tmlibs/common"
+
+	cfg "github.com/clarenous/go-capsule/config"
+	"github.com/clarenous/go-capsule/p2p/tor"
+)
+
+// TorController publishes the onion services of the node, it is the tor
+// control port out of the tests.
+type TorController interface {
+	AddOnion(key string, virtPort int, target string) (string, string, error)
+	DelOnion(serviceID string) error
+	Close() error
+}
+
+// GetOnionListener publishes the onion service on the port of the p2p listen
+// address through the tor control port.
+func GetOnionListener(config *cfg.Config) (Listener, error) {
+	control, err := tor.Dial(config.P2P.TorControl, config.P2P.TorPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	_, address := protocolAndAddress(config.P2P.ListenAddress)
+	_, port := splitHostPort(address)
+	l, err := NewOnionListener(control, config.OnionKeyFile(), port)
+	if err != nil {
+		control.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// OnionListener accepts the connections of the onion service, tor forwards
+// them to the listener on the loopback
+type OnionListener struct {
+	cmn.BaseService
+
+	listener    net.Listener
+	intAddr     *NetAddress
+	extAddr     *NetAddress
+	connections chan net.Conn
+	control     TorController
+	serviceID   string
+}
+
+// NewOnionListener publishes the onion service of the virtual port, the key of
+// the service is saved in the key file to keep the onion address on restart
+func NewOnionListener(control TorController, keyFile string, virtPort int) (*OnionListener, error) {
+	key, err := loadOnionKey(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	serviceID, newKey, err := control.AddOnion(key, virtPort, listener.Addr().String())
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	extAddr, err := NewOnionNetAddress(serviceID+".onion", uint16(virtPort))
+	if err == nil && newKey != "" {
+		err = ioutil.WriteFile(keyFile, []byte(newKey), 0600)
+	}
+	if err != nil {
+		control.DelOnion(serviceID)
+		listener.Close()
+		return nil, err
+	}
+
+	ol := &OnionListener{
+		listener:    listener,
+		intAddr:     NewNetAddress(listener.Addr()),
+		extAddr:     extAddr,
+		connections: make(chan net.Conn, numBufferedConnections),
+		control:     control,
+		serviceID:   serviceID,
+	}
+	ol.BaseService = *cmn.NewBaseService(nil, "OnionListener", ol)
+	ol.Start() // Started upon construction
+	log.WithFields(log.Fields{"module": logModule, "address": extAddr}).Info("publish onion service")
+	return ol, nil
+}
+
+// loadOnionKey returns the saved key of the onion service, or asks tor for a
+// new one when there is none.
+func loadOnionKey(keyFile string) (string, error) {
+	key, err := ioutil.ReadFile(keyFile)
+	if os.IsNotExist(err) {
+		return tor.NewOnionKey, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(key)), nil
+}
+
+// OnStart start listener
+func (l *OnionListener) OnStart() error {
+	l.BaseService.OnStart()
+	go l.listenRoutine()
+	return nil
+}
+
+// OnStop removes the onion service and stops listener
+func (l *OnionListener) OnStop() {
+	l.BaseService.OnStop()
+	if err := l.control.DelOnion(l.serviceID); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Warn("fail on remove onion service")
+	}
+	l.control.Close()
+	l.listener.Close()
+}
+
+// onionConn marks the connections accepted through the onion service, they all
+// come from the loopback
+type onionConn struct {
+	net.Conn
+}
+
+// listenRoutine Accept connections and pass on the channel
+func (l *OnionListener) listenRoutine() {
+	for {
+		conn, err := l.listener.Accept()
+		if !l.IsRunning() {
+			break // Go to cleanup
+		}
+		// listener wasn't stopped,
+		// yet we encountered an error.
+		if err != nil {
+			cmn.PanicCrisis(err)
+		}
+		l.connections <- &onionConn{conn}
+	}
+	// Cleanup
+	close(l.connections)
+}
+
+// Connections a channel of inbound connections. It gets closed when the listener closes.
+func (l *OnionListener) Connections() <-chan net.Conn {
+	return l.connections
+}
+
+// InternalAddress the loopback address tor forwards the connections to
+func (l *OnionListener) InternalAddress() *NetAddress {
+	return l.intAddr
+}
+
+// ExternalAddress the onion address for remote peer dial
+func (l *OnionListener) ExternalAddress() *NetAddress {
+	return l.extAddr
+}
+
+// ExternalAddressUpdates the onion address never changes, nothing is sent
+func (l *OnionListener) ExternalAddressUpdates() <-chan *NetAddress {
+	return nil
+}
+
+// String string of onion listener
+func (l *OnionListener) String() string {
+	return fmt.Sprintf("OnionListener(@%v)", l.extAddr)
+}
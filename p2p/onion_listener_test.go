@@ -0,0 +1,130 @@
+// +build !network
+
+package p2p
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/clarenous/go-capsule/p2p/tor"
+)
+
+// fakeTorController keeps the onion services in memory.
+type fakeTorController struct {
+	services  map[string]string // private key -> service id
+	published map[string]string // service id -> target
+	closed    bool
+}
+
+func newFakeTorController() *fakeTorController {
+	return &fakeTorController{
+		services:  make(map[string]string),
+		published: make(map[string]string),
+	}
+}
+
+func (c *fakeTorController) AddOnion(key string, virtPort int, target string) (string, string, error) {
+	if serviceID, ok := c.services[key]; ok {
+		c.published[serviceID] = target
+		return serviceID, "", nil
+	}
+
+	pubKey := make([]byte, 32)
+	rand.Read(pubKey)
+	serviceID := strings.TrimSuffix(tor.OnionAddress(pubKey), ".onion")
+	newKey := "ED25519-V3:" + serviceID
+	c.services[newKey] = serviceID
+	c.published[serviceID] = target
+	return serviceID, newKey, nil
+}
+
+func (c *fakeTorController) DelOnion(serviceID string) error {
+	delete(c.published, serviceID)
+	return nil
+}
+
+func (c *fakeTorController) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestOnionListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "onion")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	control := newFakeTorController()
+	keyFile := filepath.Join(dir, "onion_key")
+	l, err := NewOnionListener(control, keyFile, 8770)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extAddr := l.ExternalAddress()
+	if !extAddr.IsOnion() || extAddr.Port != 8770 {
+		t.Fatalf("got external address %v", extAddr)
+	}
+	serviceID := strings.TrimSuffix(extAddr.Onion, ".onion")
+	if control.published[serviceID] != l.InternalAddress().String() {
+		t.Fatalf("onion service forwards to %s, want %s", control.published[serviceID], l.InternalAddress())
+	}
+
+	// tor forwards the onion connections to the internal address
+	connOut, err := l.InternalAddress().Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer connOut.Close()
+	if _, ok := <-l.Connections(); !ok {
+		t.Fatal("Could not get inbound connection from listener")
+	}
+
+	l.Stop()
+	if _, ok := control.published[serviceID]; ok || !control.closed {
+		t.Fatal("onion service is not removed on stop")
+	}
+
+	// the saved key keeps the onion address
+	control.closed = false
+	l, err = NewOnionListener(control, keyFile, 8770)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Stop()
+	if !l.ExternalAddress().Equals(extAddr) {
+		t.Fatalf("got external address %v after restart, want %v", l.ExternalAddress(), extAddr)
+	}
+}
+
+func TestOnionOnlyDial(t *testing.T) {
+	onionAddr, err := NewNetAddressString("duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion:8770")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clearnetAddr, err := NewNetAddressString("127.0.0.1:8770")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		addr      *NetAddress
+		onionOnly bool
+		want      error
+	}{
+		{addr: clearnetAddr, onionOnly: true, want: ErrClearnetDial},
+		{addr: onionAddr, onionOnly: true, want: ErrOnionNoProxy},
+		{addr: onionAddr, onionOnly: false, want: ErrOnionNoProxy},
+	}
+
+	for i, c := range cases {
+		if _, err := dial(c.addr, &PeerConfig{OnionOnly: c.onionOnly}); err != c.want {
+			t.Errorf("case %d: got %v, want %v", i, err, c.want)
+		}
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"github.com/tendermint/go-amino"
 	"net"
 	"reflect"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/btcsuite/go-socks/socks"
@@ -16,13 +16,13 @@ import (
 	"github.com/tendermint/tmlibs/flowrate"
 
 	cfg "github.com/clarenous/go-capsule/config"
-	"github.com/clarenous/go-capsule/consensus"
 	"github.com/clarenous/go-capsule/p2p/connection"
 )
 
 // peerConn contains the raw connection and its config.
 type peerConn struct {
 	outbound bool
+	onion    bool // inbound through the onion service
 	config   *PeerConfig
 	conn     net.Conn // source connection
 }
@@ -35,6 +35,8 @@ type PeerConfig struct {
 	ProxyUsername    string                  `mapstructure:"proxy_username"`
 	ProxyPassword    string                  `mapstructure:"proxy_password"`
 	MConfig          *connection.MConnConfig `mapstructure:"connection"`
+	Transports       []connection.Transport  `mapstructure:"transports"`
+	OnionOnly        bool                    `mapstructure:"onion_only"`
 }
 
 // DefaultPeerConfig returns the default config.
@@ -45,8 +47,29 @@ func DefaultPeerConfig(config *cfg.P2PConfig) *PeerConfig {
 		ProxyAddress:     config.ProxyAddress,
 		ProxyUsername:    config.ProxyUsername,
 		ProxyPassword:    config.ProxyPassword,
-		MConfig:          connection.DefaultMConnConfig(),
+		MConfig: &connection.MConnConfig{
+			SendRate:  config.PeerSendRate,
+			RecvRate:  config.PeerRecvRate,
+			Bandwidth: connection.NewBandwidth(config.TotalSendRate, config.TotalRecvRate),
+		},
+		Transports: parseTransports(config.Transports),
+		OnionOnly:  config.OnionOnly,
+	}
+}
+
+// parseTransports returns the transports in the order of preference.
+func parseTransports(names string) []connection.Transport {
+	var transports []connection.Transport
+	for _, name := range strings.Split(names, ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+		case connection.NoiseTransportName:
+			transports = append(transports, connection.NewNoiseTransport())
+		default:
+			log.WithFields(log.Fields{"module": logModule, "transport": name}).Warn("ignore unknown transport")
+		}
 	}
+	return transports
 }
 
 // Peer represent a bytom network node
@@ -97,20 +120,22 @@ func newOutboundPeerConn(addr *NetAddress, ourNodePrivKey crypto.PrivKeyEd25519,
 	return pc, nil
 }
 
-func newInboundPeerConn(conn net.Conn, ourNodePrivKey crypto.PrivKeyEd25519, config *cfg.P2PConfig) (*peerConn, error) {
-	return newPeerConn(conn, false, ourNodePrivKey, DefaultPeerConfig(config))
+func newInboundPeerConn(conn net.Conn, ourNodePrivKey crypto.PrivKeyEd25519, config *PeerConfig) (*peerConn, error) {
+	return newPeerConn(conn, false, ourNodePrivKey, config)
 }
 
 func newPeerConn(rawConn net.Conn, outbound bool, ourNodePrivKey crypto.PrivKeyEd25519, config *PeerConfig) (*peerConn, error) {
 	rawConn.SetDeadline(time.Now().Add(config.HandshakeTimeout))
-	conn, err := connection.MakeSecretConnection(rawConn, ourNodePrivKey)
+	conn, err := connection.MakeSecureConnection(rawConn, ourNodePrivKey, config.Transports, outbound)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error creating peer")
 	}
 
+	_, onion := rawConn.(*onionConn)
 	return &peerConn{
 		config:   config,
 		outbound: outbound,
+		onion:    onion,
 		conn:     conn,
 	}, nil
 }
@@ -120,6 +145,16 @@ func (p *Peer) Addr() net.Addr {
 	return p.conn.RemoteAddr()
 }
 
+// BanKey returns the key the bans of the peer are recorded by. The peers of
+// the onion service all connect from the loopback, they are banned by the node
+// public key instead of the ip.
+func (p *Peer) BanKey() string {
+	if p.onion {
+		return p.PubKey().KeyString()
+	}
+	return p.remoteAddrHost()
+}
+
 // CanSend returns true if the send queue is not full, false otherwise.
 func (p *Peer) CanSend(chID byte) bool {
 	if !p.IsRunning() {
@@ -128,6 +163,11 @@ func (p *Peer) CanSend(chID byte) bool {
 	return p.mconn.CanSend(chID)
 }
 
+// ChannelStatus returns the traffic of the channels.
+func (p *Peer) ChannelStatus() []connection.ChannelStatus {
+	return p.mconn.ChannelStatus()
+}
+
 // CloseConn should be used when the peer was created, but never started.
 func (pc *peerConn) CloseConn() {
 	pc.conn.Close()
@@ -154,7 +194,7 @@ func (pc *peerConn) HandshakeTimeout(ourNodeInfo *NodeInfo, timeout time.Duratio
 			return nil, err1, false
 		},
 		func(i int) (interface{}, error, bool) {
-			_, err2 = amino.MarshalBinaryLengthPrefixedWriter(pc.conn, peerNodeInfo)
+			_, err2 = amino.UnmarshalBinaryLengthPrefixedReader(pc.conn, peerNodeInfo, maxNodeInfoSize)
 			log.WithFields(log.Fields{"module": logModule, "address": peerNodeInfo.ListenAddr}).Info("Peer handshake")
 			return nil, err2, false
 		})
@@ -183,9 +223,14 @@ func (p *Peer) IsOutbound() bool {
 	return p.outbound
 }
 
+// Latency returns the round trip time of the last ping to the peer.
+func (p *Peer) Latency() time.Duration {
+	return p.mconn.Latency()
+}
+
 // PubKey returns peer's public key.
 func (p *Peer) PubKey() crypto.PubKeyEd25519 {
-	return p.conn.(*connection.SecretConnection).RemotePubKey()
+	return p.conn.(connection.SecureConn).RemotePubKey()
 }
 
 // Send msg to the channel identified by chID byte. Returns false if the send
@@ -197,19 +242,6 @@ func (p *Peer) Send(chID byte, msg interface{}) bool {
 	return p.mconn.Send(chID, msg)
 }
 
-// ServiceFlag return the ServiceFlag of this peer
-func (p *Peer) ServiceFlag() consensus.ServiceFlag {
-	services := consensus.SFFullNode
-	if len(p.Other) == 0 {
-		return services
-	}
-
-	if serviceFlag, err := strconv.ParseUint(p.Other[0], 10, 64); err == nil {
-		services = consensus.ServiceFlag(serviceFlag)
-	}
-	return services
-}
-
 // String representation.
 func (p *Peer) String() string {
 	if p.outbound {
@@ -255,6 +287,13 @@ func createMConnection(conn net.Conn, p *Peer, reactorsByCh map[byte]Reactor, ch
 }
 
 func dial(addr *NetAddress, config *PeerConfig) (net.Conn, error) {
+	if config.OnionOnly && !addr.IsOnion() {
+		return nil, ErrClearnetDial
+	}
+	if addr.IsOnion() && config.ProxyAddress == "" {
+		return nil, ErrOnionNoProxy
+	}
+
 	var conn net.Conn
 	var err error
 	if config.ProxyAddress == "" {
@@ -9,6 +9,7 @@ import (
 	"github.com/tendermint/go-crypto"
 
 	cfg "github.com/clarenous/go-capsule/config"
+	"github.com/clarenous/go-capsule/consensus"
 	conn "github.com/clarenous/go-capsule/p2p/connection"
 	"github.com/clarenous/go-capsule/version"
 )
@@ -25,7 +26,7 @@ func TestPeerBasic(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = p.Start()
+	err = p.Start()
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -45,7 +46,7 @@ func TestPeerSend(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	_, err = p.Start()
+	err = p.Start()
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -60,6 +61,54 @@ func TestPeerSend(t *testing.T) {
 	}
 }
 
+func TestPeerHandshake(t *testing.T) {
+	lightCfg := *testCfg
+	lightCfg.Light = true
+	fullKey, lightKey := crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519()
+	fullInfo := NewNodeInfo(testCfg, fullKey.PubKey().Unwrap().(crypto.PubKeyEd25519), "127.0.0.1:46656")
+	lightInfo := NewNodeInfo(&lightCfg, lightKey.PubKey().Unwrap().(crypto.PubKeyEd25519), "127.0.0.1:46657")
+
+	// the full node dials the light node
+	c1, c2 := net.Pipe()
+	var fullPeerInfo, lightPeerInfo *NodeInfo
+	var fullErr, lightErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pc, err := newPeerConn(c2, false, lightKey, DefaultPeerConfig(lightCfg.P2P))
+		if err != nil {
+			lightErr = err
+			return
+		}
+		lightPeerInfo, lightErr = pc.HandshakeTimeout(lightInfo, 5*time.Second)
+	}()
+
+	pc, err := newPeerConn(c1, true, fullKey, DefaultPeerConfig(testCfg.P2P))
+	if err == nil {
+		fullPeerInfo, fullErr = pc.HandshakeTimeout(fullInfo, 5*time.Second)
+	}
+	<-done
+	if err != nil || fullErr != nil || lightErr != nil {
+		t.Fatal(err, fullErr, lightErr)
+	}
+
+	if !fullPeerInfo.PubKey.Equals(lightInfo.PubKey.Wrap()) || !lightPeerInfo.PubKey.Equals(fullInfo.PubKey.Wrap()) {
+		t.Fatalf("got pubkeys %v, %v", fullPeerInfo.PubKey, lightPeerInfo.PubKey)
+	}
+	if protocol := fullPeerInfo.GetProtocol(); protocol != ProtocolVersion {
+		t.Errorf("full node got protocol %d", protocol)
+	}
+	if protocol := lightPeerInfo.GetProtocol(); protocol != ProtocolVersion {
+		t.Errorf("light node got protocol %d", protocol)
+	}
+	if services := fullPeerInfo.ServiceFlag(); services != consensus.ServiceFlag(0) {
+		t.Errorf("full node got services %d", services)
+	}
+	if services := lightPeerInfo.ServiceFlag(); services != consensus.DefaultServices {
+		t.Errorf("light node got services %d", services)
+	}
+}
+
 func createOutboundPeerAndPerformHandshake(
 	addr *NetAddress,
 	config *cfg.P2PConfig,
@@ -126,7 +175,7 @@ func (rp *remotePeer) accept(l net.Listener) {
 			fmt.Println("Failed to accept conn:", err)
 		}
 
-		pc, err := newInboundPeerConn(conn, rp.PrivKey, rp.Config.P2P)
+		pc, err := newInboundPeerConn(conn, rp.PrivKey, DefaultPeerConfig(rp.Config.P2P))
 		if err != nil {
 			fmt.Println("Failed to create a peer:", err)
 		}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/clarenous/go-capsule/errors"
 	"github.com/clarenous/go-capsule/p2p/connection"
 	"github.com/clarenous/go-capsule/p2p/discover"
+	"github.com/clarenous/go-capsule/p2p/tor"
 	"github.com/clarenous/go-capsule/p2p/trust"
 	"github.com/clarenous/go-capsule/version"
 )
@@ -28,7 +30,8 @@ const (
 	defaultBanDuration = time.Hour * 1
 	logModule          = "p2p"
 
-	minNumOutboundPeers = 4
+	minNumOutboundPeers  = 4
+	addrBookSaveInterval = 2 * time.Minute
 )
 
 //pre-define errors for connecting fail
@@ -37,6 +40,10 @@ var (
 	ErrConnectSelf       = errors.New("Connect self")
 	ErrConnectBannedPeer = errors.New("Connect banned peer")
 	ErrConnectSpvPeer    = errors.New("Outbound connect spv peer")
+	ErrPeerNotBanned     = errors.New("Peer is not banned")
+	ErrOldPeerProtocol   = errors.New("Peer protocol version too old")
+	ErrClearnetDial      = errors.New("Dial clearnet address in onion only mode")
+	ErrOnionNoProxy      = errors.New("Dial onion address without proxy")
 )
 
 type discv interface {
@@ -62,6 +69,8 @@ type Switch struct {
 	nodePrivKey  crypto.PrivKeyEd25519 // our node privkey
 	discv        discv
 	bannedPeer   map[string]time.Time
+	addrBook     *AddrBook
+	allowlist    Allowlist
 	db           dbm.DB
 	mtx          sync.Mutex
 }
@@ -69,9 +78,13 @@ type Switch struct {
 // NewSwitch create a new Switch and set discover.
 func NewSwitch(config *cfg.Config) (*Switch, error) {
 	var err error
-	var l Listener
+	var l, onionListener Listener
 	var listenAddr string
-	var discv *discover.Network
+	var network discv
+
+	if config.P2P.OnionOnly && config.P2P.ProxyAddress == "" {
+		return nil, ErrOnionNoProxy
+	}
 
 	blacklistDB := dbm.NewDB("trusthistory", dbm.DBBackendType(config.DBBackend), config.DBDir())
 	config.P2P.PrivateKey, err = config.NodeKey()
@@ -88,15 +101,44 @@ func NewSwitch(config *cfg.Config) (*Switch, error) {
 	copy(newKey[:], bytes)
 	privKey := crypto.PrivKeyEd25519(newKey)
 	if !config.VaultMode {
-		// Create listener
-		l, listenAddr = GetListener(config.P2P)
-		discv, err = discover.NewDiscover(config, ed25519.PrivateKey(bytes), l.ExternalAddress().Port)
+		// Create listener, the clearnet listener and the discovery reveal the
+		// node so there are none in onion only mode
+		if !config.P2P.OnionOnly {
+			l, listenAddr = GetListener(config.P2P)
+			network, err = discover.NewDiscover(config, ed25519.PrivateKey(bytes), l.ExternalAddress().Port)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if config.P2P.TorControl != "" {
+			if onionListener, err = GetOnionListener(config); err != nil {
+				return nil, err
+			}
+			if config.P2P.OnionOnly {
+				listenAddr = onionListener.ExternalAddress().String()
+			}
+		}
+	}
+
+	sw, err := newSwitch(config, network, blacklistDB, l, privKey, listenAddr)
+	if err != nil {
+		return nil, err
+	}
+	if onionListener != nil {
+		sw.AddListener(onionListener)
+	}
+	if config.P2P.OnionOnly {
+		sw.addOnionSeeds(config.P2P.Seeds)
+	}
+
+	if path := config.AllowlistFile(); path != "" {
+		allowlist, err := NewFileAllowlist(path)
 		if err != nil {
 			return nil, err
 		}
+		sw.SetAllowlist(allowlist)
 	}
-
-	return newSwitch(config, discv, blacklistDB, l, privKey, listenAddr)
+	return sw, nil
 }
 
 // newSwitch creates a new Switch with the given config.
@@ -119,7 +161,15 @@ func newSwitch(config *cfg.Config, discv discv, blacklistDB dbm.DB, l Listener,
 		return nil, err
 	}
 
-	sw.AddListener(l)
+	addrBook, err := NewAddrBook(blacklistDB)
+	if err != nil {
+		return nil, err
+	}
+	sw.addrBook = addrBook
+
+	if l != nil {
+		sw.AddListener(l)
+	}
 	sw.BaseService = *cmn.NewBaseService(nil, "P2P Switch", sw)
 	trust.Init()
 	return sw, nil
@@ -134,8 +184,13 @@ func (sw *Switch) OnStart() error {
 	}
 	for _, listener := range sw.listeners {
 		go sw.listenerRoutine(listener)
+		go sw.externalAddressRoutine(listener)
 	}
 	go sw.ensureOutboundPeersRoutine()
+	go sw.saveAddrBookRoutine()
+	if sw.allowlist != nil {
+		go sw.reloadAllowlistRoutine()
+	}
 	return nil
 }
 
@@ -154,21 +209,15 @@ func (sw *Switch) OnStop() {
 	for _, reactor := range sw.reactors {
 		reactor.Stop()
 	}
+
+	if err := sw.addrBook.Save(); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on save address book")
+	}
 }
 
 //AddBannedPeer add peer to blacklist
 func (sw *Switch) AddBannedPeer(ip string) error {
-	sw.mtx.Lock()
-	defer sw.mtx.Unlock()
-
-	sw.bannedPeer[ip] = time.Now().Add(defaultBanDuration)
-	dataJSON, err := json.Marshal(sw.bannedPeer)
-	if err != nil {
-		return err
-	}
-
-	sw.db.Set([]byte(bannedPeerKey), dataJSON)
-	return nil
+	return sw.BanPeer(ip, defaultBanDuration)
 }
 
 // AddPeer performs the P2P handshake with a peer
@@ -177,17 +226,22 @@ func (sw *Switch) AddBannedPeer(ip string) error {
 // NOTE: This performs a blocking handshake before the peer is added.
 // CONTRACT: If error is returned, peer is nil, and conn is immediately closed.
 func (sw *Switch) AddPeer(pc *peerConn) error {
-	peerNodeInfo, err := pc.HandshakeTimeout(sw.nodeInfo, sw.peerConfig.HandshakeTimeout)
+	nodeInfo := sw.NodeInfo()
+	peerNodeInfo, err := pc.HandshakeTimeout(nodeInfo, sw.peerConfig.HandshakeTimeout)
 	if err != nil {
 		return err
 	}
 
-	if err := version.Status.CheckUpdate(sw.nodeInfo.Version, peerNodeInfo.Version, peerNodeInfo.RemoteAddr); err != nil {
+	if err := version.Status.CheckUpdate(nodeInfo.Version, peerNodeInfo.Version, peerNodeInfo.RemoteAddr); err != nil {
 		return err
 	}
-	if err := sw.nodeInfo.CompatibleWith(peerNodeInfo); err != nil {
+	if err := nodeInfo.CompatibleWith(peerNodeInfo); err != nil {
 		return err
 	}
+	if protocol := peerNodeInfo.GetProtocol(); protocol < uint32(sw.Config.P2P.MinProtocol) {
+		log.WithFields(log.Fields{"module": logModule, "address": peerNodeInfo.RemoteAddr, "protocol": protocol}).Warn("reject peer on old protocol version")
+		return ErrOldPeerProtocol
+	}
 
 	peer := newPeer(pc, peerNodeInfo, sw.reactorsByCh, sw.chDescs, sw.StopPeerForError)
 	if err := sw.filterConnByPeer(peer); err != nil {
@@ -205,7 +259,14 @@ func (sw *Switch) AddPeer(pc *peerConn) error {
 		}
 	}
 
-	return sw.peers.Add(peer)
+	if err := sw.peers.Add(peer); err != nil {
+		return err
+	}
+
+	if !pc.outbound {
+		sw.addListenAddress(peer)
+	}
+	return nil
 }
 
 // AddReactor adds the given reactor to the switch.
@@ -226,21 +287,61 @@ func (sw *Switch) AddReactor(name string, reactor Reactor) Reactor {
 	return reactor
 }
 
+// AddrBook returns the address book of the switch.
+func (sw *Switch) AddrBook() *AddrBook {
+	return sw.addrBook
+}
+
 // AddListener adds the given listener to the switch for listening to incoming peer connections.
 // NOTE: Not goroutine safe.
 func (sw *Switch) AddListener(l Listener) {
 	sw.listeners = append(sw.listeners, l)
 }
 
+// Bandwidth returns the traffic and the rate limits of all the peers.
+func (sw *Switch) Bandwidth() *connection.Bandwidth {
+	return sw.peerConfig.MConfig.Bandwidth
+}
+
+// BanPeer adds the ip to the blacklist for the duration, the default ban
+// duration is used when the duration is not positive.
+func (sw *Switch) BanPeer(ip string, duration time.Duration) error {
+	if duration <= 0 {
+		duration = defaultBanDuration
+	}
+
+	sw.mtx.Lock()
+	defer sw.mtx.Unlock()
+
+	sw.bannedPeer[ip] = time.Now().Add(duration)
+	return sw.saveBannedPeers()
+}
+
+// BannedPeers returns the banned ips and the time their bans end.
+func (sw *Switch) BannedPeers() map[string]time.Time {
+	sw.mtx.Lock()
+	defer sw.mtx.Unlock()
+
+	now := time.Now()
+	result := make(map[string]time.Time, len(sw.bannedPeer))
+	for ip, banEnd := range sw.bannedPeer {
+		if now.Before(banEnd) {
+			result[ip] = banEnd
+		}
+	}
+	return result
+}
+
 //DialPeerWithAddress dial node from net address
 func (sw *Switch) DialPeerWithAddress(addr *NetAddress) error {
 	log.WithFields(log.Fields{"module": logModule, "address": addr}).Debug("Dialing peer")
-	sw.dialing.Set(addr.IP.String(), addr)
-	defer sw.dialing.Delete(addr.IP.String())
-	if err := sw.filterConnByIP(addr.IP.String()); err != nil {
+	sw.dialing.Set(addr.Host(), addr)
+	defer sw.dialing.Delete(addr.Host())
+	if err := sw.filterConnByIP(addr.Host()); err != nil {
 		return err
 	}
 
+	sw.addrBook.MarkAttempt(addr)
 	pc, err := newOutboundPeerConn(addr, sw.nodePrivKey, sw.peerConfig)
 	if err != nil {
 		log.WithFields(log.Fields{"module": logModule, "address": addr, " err": err}).Error("DialPeer fail on newOutboundPeerConn")
@@ -252,13 +353,14 @@ func (sw *Switch) DialPeerWithAddress(addr *NetAddress) error {
 		pc.CloseConn()
 		return err
 	}
+	sw.addrBook.MarkGood(addr)
 	log.WithFields(log.Fields{"module": logModule, "address": addr, "peer num": sw.peers.Size()}).Debug("DialPeer added peer")
 	return nil
 }
 
 //IsDialing prevent duplicate dialing
 func (sw *Switch) IsDialing(addr *NetAddress) bool {
-	return sw.dialing.Has(addr.IP.String())
+	return sw.dialing.Has(addr.Host())
 }
 
 // IsListening returns true if the switch has at least one listener.
@@ -298,12 +400,22 @@ func (sw *Switch) NumPeers() (outbound, inbound, dialing int) {
 	return
 }
 
-// NodeInfo returns the switch's NodeInfo.
-// NOTE: Not goroutine safe.
+// NodeInfo returns the switch's NodeInfo, it is replaced instead of modified
+// when the listen address changes.
 func (sw *Switch) NodeInfo() *NodeInfo {
+	sw.mtx.Lock()
+	defer sw.mtx.Unlock()
+
 	return sw.nodeInfo
 }
 
+// SetAllowlist turns on the permissioned mode, only the peers with node public
+// keys in the allowlist can connect.
+// NOTE: Not goroutine safe.
+func (sw *Switch) SetAllowlist(allowlist Allowlist) {
+	sw.allowlist = allowlist
+}
+
 //Peers return switch peerset
 func (sw *Switch) Peers() *PeerSet {
 	return sw.peers
@@ -322,8 +434,57 @@ func (sw *Switch) StopPeerGracefully(peerID string) {
 	}
 }
 
+// UnbanPeer removes the ip from the blacklist.
+func (sw *Switch) UnbanPeer(ip string) error {
+	sw.mtx.Lock()
+	defer sw.mtx.Unlock()
+
+	if _, ok := sw.bannedPeer[ip]; !ok {
+		return ErrPeerNotBanned
+	}
+	return sw.delBannedPeer(ip)
+}
+
+// addListenAddress adds the listen address of the inbound peer to the
+// address book, the address is only trusted when it is on the remote host.
+// The onion address can't be checked for tor hides the remote host.
+func (sw *Switch) addListenAddress(peer *Peer) {
+	addr, err := NewNetAddressString(peer.ListenAddr)
+	if err != nil || (!addr.IsOnion() && addr.IP.String() != peer.remoteAddrHost()) {
+		return
+	}
+
+	if err := sw.addrBook.addAddress(addr, NewNetAddress(peer.Addr())); err != nil {
+		log.WithFields(log.Fields{"module": logModule, "address": addr, "err": err}).Debug("fail on add peer listen address")
+	}
+}
+
+// addOnionSeeds adds the onion seeds to the address book in onion only mode,
+// the clearnet seeds are skipped without resolving the host.
+func (sw *Switch) addOnionSeeds(seeds string) {
+	for _, seed := range strings.Split(seeds, ",") {
+		if seed = strings.TrimSpace(seed); seed == "" {
+			continue
+		}
+
+		host, _, err := net.SplitHostPort(seed)
+		if err != nil || !tor.IsOnion(host) {
+			log.WithFields(log.Fields{"module": logModule, "seed": seed}).Warn("skip clearnet seed in onion only mode")
+			continue
+		}
+
+		addr, err := NewNetAddressString(seed)
+		if err == nil {
+			err = sw.addrBook.AddAddress(addr, addr)
+		}
+		if err != nil {
+			log.WithFields(log.Fields{"module": logModule, "seed": seed, "err": err}).Warn("fail on add onion seed")
+		}
+	}
+}
+
 func (sw *Switch) addPeerWithConnection(conn net.Conn) error {
-	peerConn, err := newInboundPeerConn(conn, sw.nodePrivKey, sw.Config.P2P)
+	peerConn, err := newInboundPeerConn(conn, sw.nodePrivKey, sw.peerConfig)
 	if err != nil {
 		if err := conn.Close(); err != nil {
 			log.WithFields(log.Fields{"module": logModule, "remote peer:": conn.RemoteAddr().String(), " err:": err}).Error("closes connection err")
@@ -358,36 +519,55 @@ func (sw *Switch) checkBannedPeer(peer string) error {
 	return nil
 }
 
+// delBannedPeer removes the addr from the blacklist, the caller must hold sw.mtx.
 func (sw *Switch) delBannedPeer(addr string) error {
-	sw.mtx.Lock()
-	defer sw.mtx.Unlock()
-
 	delete(sw.bannedPeer, addr)
-	datajson, err := json.Marshal(sw.bannedPeer)
-	if err != nil {
-		return err
-	}
-
-	sw.db.Set([]byte(bannedPeerKey), datajson)
-	return nil
+	return sw.saveBannedPeers()
 }
 
 func (sw *Switch) filterConnByIP(ip string) error {
-	if ip == sw.nodeInfo.listenHost() {
+	if ip == sw.NodeInfo().listenHost() {
 		return ErrConnectSelf
 	}
 	return sw.checkBannedPeer(ip)
 }
 
+// filterConnByAllowlist checks the node public key verified by the secret
+// connection against the allowlist in permissioned mode.
+func (sw *Switch) filterConnByAllowlist(peer *Peer) error {
+	if sw.allowlist == nil {
+		return nil
+	}
+
+	err := ErrConnectNotAllowed
+	if !peer.NodeInfo.PubKey.Equals(peer.PubKey().Wrap()) {
+		err = ErrPubKeyMismatch
+	} else if sw.allowlist.IsAllowed(peer.PubKey()) {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"module":  logModule,
+		"address": peer.Addr().String(),
+		"pubkey":  peer.PubKey().KeyString(),
+		"reason":  err,
+	}).Warn("reject peer in permissioned mode")
+	return err
+}
+
 func (sw *Switch) filterConnByPeer(peer *Peer) error {
-	if err := sw.checkBannedPeer(peer.remoteAddrHost()); err != nil {
+	if err := sw.checkBannedPeer(peer.BanKey()); err != nil {
 		return err
 	}
 
-	if sw.nodeInfo.getPubkey().Equals(peer.PubKey().Wrap()) {
+	if sw.NodeInfo().getPubkey().Equals(peer.PubKey().Wrap()) {
 		return ErrConnectSelf
 	}
 
+	if err := sw.filterConnByAllowlist(peer); err != nil {
+		return err
+	}
+
 	if sw.peers.Has(peer.Key) {
 		return ErrDuplicatePeer
 	}
@@ -418,6 +598,29 @@ func (sw *Switch) listenerRoutine(l Listener) {
 	}
 }
 
+// externalAddressRoutine updates the listen address of the node info when the
+// gateway maps the listener to a new external address.
+func (sw *Switch) externalAddressRoutine(l Listener) {
+	for {
+		select {
+		case addr := <-l.ExternalAddressUpdates():
+			sw.setListenAddr(addr.String())
+		case <-sw.Quit():
+			return
+		}
+	}
+}
+
+func (sw *Switch) setListenAddr(listenAddr string) {
+	sw.mtx.Lock()
+	defer sw.mtx.Unlock()
+
+	nodeInfo := *sw.nodeInfo
+	nodeInfo.ListenAddr = listenAddr
+	sw.nodeInfo = &nodeInfo
+	log.WithFields(log.Fields{"module": logModule, "listen addr": listenAddr}).Info("update node info listen address")
+}
+
 func (sw *Switch) dialPeerWorker(a *NetAddress, wg *sync.WaitGroup) {
 	if err := sw.DialPeerWithAddress(a); err != nil {
 		log.WithFields(log.Fields{"module": logModule, "addr": a, "err": err}).Error("dialPeerWorker fail on dial peer")
@@ -438,21 +641,38 @@ func (sw *Switch) ensureOutboundPeers() {
 		connectedPeers[peer.remoteAddrHost()] = struct{}{}
 	}
 
-	var wg sync.WaitGroup
-	nodes := make([]*discover.Node, numToDial)
-	n := sw.discv.ReadRandomNodes(nodes)
-	for i := 0; i < n; i++ {
-		try := NewNetAddressIPPort(nodes[i].IP, nodes[i].TCP)
-		if sw.NodeInfo().ListenAddr == try.String() {
-			continue
+	skip := func(addr *NetAddress) bool {
+		if sw.NodeInfo().ListenAddr == addr.String() || sw.IsDialing(addr) {
+			return true
 		}
-		if dialling := sw.IsDialing(try); dialling {
-			continue
+		if sw.peerConfig.OnionOnly && !addr.IsOnion() {
+			return true
 		}
-		if _, ok := connectedPeers[try.IP.String()]; ok {
+		_, ok := connectedPeers[addr.Host()]
+		return ok
+	}
+
+	// the address book goes first, discovery fills the rest
+	addrs := sw.addrBook.PickAddresses(numToDial, skip)
+	if len(addrs) < numToDial && sw.discv != nil {
+		nodes := make([]*discover.Node, numToDial-len(addrs))
+		n := sw.discv.ReadRandomNodes(nodes)
+		for i := 0; i < n; i++ {
+			try := NewNetAddressIPPort(nodes[i].IP, nodes[i].TCP)
+			if err := sw.addrBook.AddAddress(try, try); err != nil {
+				continue
+			}
+			addrs = append(addrs, try)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, try := range addrs {
+		if skip(try) {
 			continue
 		}
 
+		connectedPeers[try.Host()] = struct{}{}
 		wg.Add(1)
 		go sw.dialPeerWorker(try, &wg)
 	}
@@ -475,6 +695,63 @@ func (sw *Switch) ensureOutboundPeersRoutine() {
 	}
 }
 
+// saveBannedPeers persists the blacklist, the caller must hold sw.mtx.
+func (sw *Switch) saveBannedPeers() error {
+	dataJSON, err := json.Marshal(sw.bannedPeer)
+	if err != nil {
+		return err
+	}
+
+	sw.db.Set([]byte(bannedPeerKey), dataJSON)
+	return nil
+}
+
+// reloadAllowlistRoutine reloads the allowlist and disconnects the peers not
+// allowed anymore.
+func (sw *Switch) reloadAllowlistRoutine() {
+	ticker := time.NewTicker(allowlistReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			changed, err := sw.allowlist.Reload()
+			if err != nil {
+				log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on reload allowlist")
+				continue
+			}
+			if !changed {
+				continue
+			}
+
+			log.WithFields(log.Fields{"module": logModule}).Info("allowlist reloaded")
+			for _, peer := range sw.peers.List() {
+				if err := sw.filterConnByAllowlist(peer); err != nil {
+					sw.StopPeerForError(peer, err)
+				}
+			}
+		case <-sw.Quit():
+			return
+		}
+	}
+}
+
+func (sw *Switch) saveAddrBookRoutine() {
+	ticker := time.NewTicker(addrBookSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := sw.addrBook.Save(); err != nil {
+				log.WithFields(log.Fields{"module": logModule, "err": err}).Error("fail on save address book")
+			}
+		case <-sw.Quit():
+			return
+		}
+	}
+}
+
 func (sw *Switch) startInitPeer(peer *Peer) error {
 	// spawn send/recv routines
 	if err := peer.Start(); err != nil {
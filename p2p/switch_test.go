@@ -7,10 +7,12 @@ import (
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	cfg "github.com/clarenous/go-capsule/config"
 	"github.com/clarenous/go-capsule/errors"
 	conn "github.com/clarenous/go-capsule/p2p/connection"
+	"github.com/clarenous/go-capsule/p2p/tor"
 )
 
 var (
@@ -172,12 +174,87 @@ func TestDialBannedPeer(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	s1.delBannedPeer(rp.addr.IP.String())
+	if err := s1.UnbanPeer(rp.addr.IP.String()); err != nil {
+		t.Fatal(err)
+	}
 	if err := s1.DialPeerWithAddress(rp.addr); err != nil {
 		t.Fatal(err)
 	}
 }
 
+func TestBanPeerExpires(t *testing.T) {
+	dirPath, err := ioutil.TempDir(".", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dirPath)
+
+	testDB := dbm.NewDB("testdb", "leveldb", dirPath)
+	s1 := MakeSwitch(testCfg, testDB, initSwitchFunc)
+	s1.Start()
+	defer s1.Stop()
+
+	ip := "192.0.2.10"
+	if err := s1.BanPeer(ip, 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s1.BannedPeers()[ip]; !ok {
+		t.Fatal("banned peer is not listed")
+	}
+	if err := s1.filterConnByIP(ip); err != ErrConnectBannedPeer {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if _, ok := s1.BannedPeers()[ip]; ok {
+		t.Fatal("expired ban is still listed")
+	}
+	if err := s1.filterConnByIP(ip); err != nil {
+		t.Fatal(err)
+	}
+	if err := s1.UnbanPeer(ip); err != ErrPeerNotBanned {
+		t.Fatal(err)
+	}
+}
+
+// TestBanOnionInboundPeer the onion peers all connect from the loopback,
+// banning one must not reject the others.
+func TestBanOnionInboundPeer(t *testing.T) {
+	dirPath, err := ioutil.TempDir(".", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dirPath)
+
+	testDB := dbm.NewDB("testdb", "leveldb", dirPath)
+	s1 := MakeSwitch(testCfg, testDB, initSwitchFunc)
+	s1.Start()
+	defer s1.Stop()
+
+	alice, bob := crypto.GenPrivKeyEd25519(), crypto.GenPrivKeyEd25519()
+	alicePub := alice.PubKey().Unwrap().(crypto.PubKeyEd25519)
+	bobPub := bob.PubKey().Unwrap().(crypto.PubKeyEd25519)
+	if err := s1.addPeerWithConnection(&onionConn{pipePeer(alice, alicePub)}); err != nil {
+		t.Fatal(err)
+	}
+
+	peer := s1.Peers().Get(alicePub.KeyString())
+	if peer == nil || peer.BanKey() != alicePub.KeyString() {
+		t.Fatal("onion peer is not banned by the node public key")
+	}
+	if err := s1.AddBannedPeer(peer.BanKey()); err != nil {
+		t.Fatal(err)
+	}
+	s1.StopPeerGracefully(peer.Key)
+
+	if err := s1.addPeerWithConnection(&onionConn{pipePeer(alice, alicePub)}); errors.Root(err) != ErrConnectBannedPeer {
+		t.Fatalf("got err %v, want %v", err, ErrConnectBannedPeer)
+	}
+	if err := s1.addPeerWithConnection(&onionConn{pipePeer(bob, bobPub)}); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestDuplicateOutBoundPeer(t *testing.T) {
 	dirPath, err := ioutil.TempDir(".", "")
 	if err != nil {
@@ -320,3 +397,39 @@ func TestStopPeer(t *testing.T) {
 		t.Fatal("TestStopPeer peer size error")
 	}
 }
+
+// TestEnsureOutboundOnionPeers dials an onion host once, the onion addresses
+// have no ip to tell them apart.
+func TestEnsureOutboundOnionPeers(t *testing.T) {
+	dirPath, err := ioutil.TempDir(".", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dirPath)
+
+	testDB := dbm.NewDB("testdb", "leveldb", dirPath)
+	s1 := MakeSwitch(testCfg, testDB, initSwitchFunc)
+
+	onions := []string{tor.OnionAddress(crypto.CRandBytes(32)), tor.OnionAddress(crypto.CRandBytes(32))}
+	hosts := []string{onions[0], onions[0], onions[1]}
+	for i, host := range hosts {
+		addr, err := NewOnionNetAddress(host, uint16(46656+i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s1.addrBook.AddAddress(addr, addr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s1.ensureOutboundPeers()
+	attempts := map[string]int{}
+	for _, ka := range s1.addrBook.addrs {
+		attempts[ka.Addr.Host()] += ka.Attempts
+	}
+	for _, onion := range onions {
+		if attempts[onion] != 1 {
+			t.Errorf("got %d dial attempts to %s", attempts[onion], onion)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package tor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/clarenous/go-capsule/errors"
+)
+
+const (
+	dialTimeout = 10 * time.Second
+
+	// NewOnionKey asks tor to generate the key of the new onion service
+	NewOnionKey = "NEW:ED25519-V3"
+
+	replyOK           = 250
+	safeCookieNonceSz = 32
+	serverHashKey     = "Tor safe cookie authentication server-to-controller hash"
+	clientHashKey     = "Tor safe cookie authentication controller-to-server hash"
+)
+
+var (
+	errAuthMethod     = errors.New("no supported tor control auth method")
+	errServerHash     = errors.New("tor control server hash mismatch")
+	errNoServiceID    = errors.New("no service id in the add onion reply")
+	errInvalidReplyKV = errors.New("invalid tor control reply")
+)
+
+// Controller talks to the tor control port, the onion services it adds are
+// removed by tor when the control connection closes.
+type Controller struct {
+	mtx  sync.Mutex
+	conn *textproto.Conn
+}
+
+// Dial connects and authenticates to the tor control port, the password is
+// only used when tor asks for the hashed password.
+func Dial(addr, password string) (*Controller, error) {
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Controller{conn: textproto.NewConn(conn)}
+	if err := c.authenticate(password); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// AddOnion publishes the onion service forwarding the virtual port to the
+// target address, key is either NewOnionKey or a key returned before. It
+// returns the service id and the private key of the new service, the private
+// key is empty for a key given.
+func (c *Controller) AddOnion(key string, virtPort int, target string) (string, string, error) {
+	lines, err := c.request("ADD_ONION %s Port=%d,%s", key, virtPort, target)
+	if err != nil {
+		return "", "", errors.Wrap(err, "add onion")
+	}
+
+	args := replyArgs(lines)
+	if args["ServiceID"] == "" {
+		return "", "", errNoServiceID
+	}
+	return args["ServiceID"], args["PrivateKey"], nil
+}
+
+// Close closes the control connection.
+func (c *Controller) Close() error {
+	return c.conn.Close()
+}
+
+// DelOnion removes the onion service of the service id.
+func (c *Controller) DelOnion(serviceID string) error {
+	_, err := c.request("DEL_ONION %s", serviceID)
+	return err
+}
+
+func (c *Controller) authenticate(password string) error {
+	lines, err := c.request("PROTOCOLINFO 1")
+	if err != nil {
+		return errors.Wrap(err, "protocol info")
+	}
+
+	args := replyArgs(lines)
+	methods := make(map[string]bool)
+	for _, method := range strings.Split(args["METHODS"], ",") {
+		methods[method] = true
+	}
+
+	switch {
+	case methods["NULL"]:
+		_, err = c.request("AUTHENTICATE")
+	case methods["HASHEDPASSWORD"] && password != "":
+		_, err = c.request("AUTHENTICATE %s", hex.EncodeToString([]byte(password)))
+	case methods["SAFECOOKIE"]:
+		err = c.authenticateSafeCookie(args["COOKIEFILE"])
+	case methods["COOKIE"]:
+		var cookie []byte
+		if cookie, err = ioutil.ReadFile(args["COOKIEFILE"]); err == nil {
+			_, err = c.request("AUTHENTICATE %s", hex.EncodeToString(cookie))
+		}
+	default:
+		return errAuthMethod
+	}
+	return errors.Wrap(err, "authenticate")
+}
+
+// authenticateSafeCookie proves the knowledge of the cookie without sending
+// it, and checks the server knows it as well.
+func (c *Controller) authenticateSafeCookie(cookieFile string) error {
+	cookie, err := ioutil.ReadFile(cookieFile)
+	if err != nil {
+		return err
+	}
+
+	clientNonce := make([]byte, safeCookieNonceSz)
+	if _, err := rand.Read(clientNonce); err != nil {
+		return err
+	}
+
+	lines, err := c.request("AUTHCHALLENGE SAFECOOKIE %s", hex.EncodeToString(clientNonce))
+	if err != nil {
+		return err
+	}
+
+	args := replyArgs(lines)
+	serverHash, err := hex.DecodeString(args["SERVERHASH"])
+	if err != nil {
+		return errors.Wrap(errInvalidReplyKV, err.Error())
+	}
+	serverNonce, err := hex.DecodeString(args["SERVERNONCE"])
+	if err != nil {
+		return errors.Wrap(errInvalidReplyKV, err.Error())
+	}
+
+	message := append(append(append([]byte{}, cookie...), clientNonce...), serverNonce...)
+	if !hmac.Equal(serverHash, safeCookieHash(serverHashKey, message)) {
+		return errServerHash
+	}
+
+	_, err = c.request("AUTHENTICATE %s", hex.EncodeToString(safeCookieHash(clientHashKey, message)))
+	return err
+}
+
+// request sends the command and returns the lines of the reply, a reply
+// other than 250 is returned as the *textproto.Error.
+func (c *Controller) request(format string, args ...interface{}) ([]string, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if err := c.conn.PrintfLine(format, args...); err != nil {
+		return nil, err
+	}
+
+	_, message, err := c.conn.ReadResponse(replyOK)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(message, "\n"), nil
+}
+
+func safeCookieHash(key string, message []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
+// replyArgs parses the KEY=VALUE arguments of the reply lines, the value may
+// be a quoted string. The words without value are skipped.
+func replyArgs(lines []string) map[string]string {
+	args := make(map[string]string)
+	for _, line := range lines {
+		for line = strings.TrimLeft(line, " "); line != ""; line = strings.TrimLeft(line, " ") {
+			i := strings.IndexAny(line, "= ")
+			if i < 0 {
+				break
+			}
+			if line[i] == ' ' {
+				line = line[i:]
+				continue
+			}
+
+			key, value := line[:i], ""
+			line = line[i+1:]
+			if strings.HasPrefix(line, "\"") {
+				end := quoteEnd(line)
+				value, _ = strconv.Unquote(line[:end])
+				line = line[end:]
+			} else {
+				if j := strings.IndexByte(line, ' '); j >= 0 {
+					value, line = line[:j], line[j:]
+				} else {
+					value, line = line, ""
+				}
+			}
+			args[key] = value
+		}
+	}
+	return args
+}
+
+// quoteEnd returns the index after the closing quote of the quoted string
+// at the start of s, or the length of s when it is not closed.
+func quoteEnd(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(s)
+}
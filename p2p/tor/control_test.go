@@ -0,0 +1,282 @@
+package tor
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeControl is the tor control port of one connection on localhost.
+type fakeControl struct {
+	listener   net.Listener
+	methods    string
+	password   string
+	cookie     []byte
+	cookieFile string
+
+	mtx           sync.Mutex
+	authenticated bool
+	clientHash    string
+	services      map[string]string // private key -> service id
+	published     map[string]bool
+}
+
+func newFakeControl(t *testing.T, methods string) *fakeControl {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "tor")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &fakeControl{
+		listener:   listener,
+		methods:    methods,
+		password:   "secret",
+		cookie:     make([]byte, 32),
+		cookieFile: filepath.Join(dir, "control auth cookie"),
+		services:   make(map[string]string),
+		published:  make(map[string]bool),
+	}
+	rand.Read(f.cookie)
+	if err := ioutil.WriteFile(f.cookieFile, f.cookie, 0600); err != nil {
+		t.Fatal(err)
+	}
+	go f.serve()
+	return f
+}
+
+func (f *fakeControl) addr() string {
+	return f.listener.Addr().String()
+}
+
+func (f *fakeControl) close() {
+	f.listener.Close()
+	os.RemoveAll(filepath.Dir(f.cookieFile))
+}
+
+func (f *fakeControl) isPublished(serviceID string) bool {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	return f.published[serviceID]
+}
+
+func (f *fakeControl) serve() {
+	conn, err := f.listener.Accept()
+	if err != nil {
+		return
+	}
+
+	tp := textproto.NewConn(conn)
+	defer tp.Close()
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		f.mtx.Lock()
+		reply := f.handle(strings.Fields(line))
+		f.mtx.Unlock()
+		for _, l := range reply {
+			tp.PrintfLine("%s", l)
+		}
+	}
+}
+
+func (f *fakeControl) handle(words []string) []string {
+	arg := func(i int) string {
+		if i < len(words) {
+			return words[i]
+		}
+		return ""
+	}
+
+	switch arg(0) {
+	case "PROTOCOLINFO":
+		return []string{
+			"250-PROTOCOLINFO 1",
+			"250-AUTH METHODS=" + f.methods + " COOKIEFILE=\"" + f.cookieFile + "\"",
+			"250-VERSION Tor=\"0.4.8.9\"",
+			"250 OK",
+		}
+
+	case "AUTHCHALLENGE":
+		clientNonce, _ := hex.DecodeString(arg(2))
+		serverNonce := make([]byte, safeCookieNonceSz)
+		rand.Read(serverNonce)
+		message := append(append(append([]byte{}, f.cookie...), clientNonce...), serverNonce...)
+		f.clientHash = hex.EncodeToString(safeCookieHash(clientHashKey, message))
+		return []string{"250 AUTHCHALLENGE SERVERHASH=" + hex.EncodeToString(safeCookieHash(serverHashKey, message)) + " SERVERNONCE=" + hex.EncodeToString(serverNonce)}
+
+	case "AUTHENTICATE":
+		switch {
+		case f.methods == "NULL",
+			f.methods == "HASHEDPASSWORD" && arg(1) == hex.EncodeToString([]byte(f.password)),
+			f.methods == "COOKIE" && arg(1) == hex.EncodeToString(f.cookie),
+			f.methods == "SAFECOOKIE" && f.clientHash != "" && arg(1) == f.clientHash:
+			f.authenticated = true
+			return []string{"250 OK"}
+		}
+		return []string{"515 Authentication failed"}
+
+	case "ADD_ONION":
+		if !f.authenticated {
+			return []string{"514 Authentication required."}
+		}
+		if arg(1) != NewOnionKey {
+			serviceID, ok := f.services[arg(1)]
+			if !ok {
+				return []string{"513 Invalid key"}
+			}
+			f.published[serviceID] = true
+			return []string{"250-ServiceID=" + serviceID, "250 OK"}
+		}
+
+		pubKey, privKey := make([]byte, 32), make([]byte, 64)
+		rand.Read(pubKey)
+		rand.Read(privKey)
+		serviceID := strings.TrimSuffix(OnionAddress(pubKey), onionSuffix)
+		key := "ED25519-V3:" + base64.StdEncoding.EncodeToString(privKey)
+		f.services[key] = serviceID
+		f.published[serviceID] = true
+		return []string{"250-ServiceID=" + serviceID, "250-PrivateKey=" + key, "250 OK"}
+
+	case "DEL_ONION":
+		if !f.published[arg(1)] {
+			return []string{"552 Unknown Onion Service id"}
+		}
+		delete(f.published, arg(1))
+		return []string{"250 OK"}
+	}
+	return []string{"510 Unrecognized command"}
+}
+
+func TestControllerAuthenticate(t *testing.T) {
+	cases := []struct {
+		methods  string
+		password string
+		wantErr  bool
+	}{
+		{methods: "NULL"},
+		{methods: "HASHEDPASSWORD", password: "secret"},
+		{methods: "HASHEDPASSWORD", password: "wrong", wantErr: true},
+		{methods: "HASHEDPASSWORD", wantErr: true},
+		{methods: "COOKIE"},
+		{methods: "SAFECOOKIE"},
+	}
+
+	for i, c := range cases {
+		control := newFakeControl(t, c.methods)
+		controller, err := Dial(control.addr(), c.password)
+		if (err != nil) != c.wantErr {
+			t.Errorf("case %d: got err %v, want err %v", i, err, c.wantErr)
+		}
+		if err == nil {
+			controller.Close()
+		}
+		control.close()
+	}
+}
+
+func TestControllerOnion(t *testing.T) {
+	control := newFakeControl(t, "SAFECOOKIE")
+	defer control.close()
+
+	controller, err := Dial(control.addr(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer controller.Close()
+
+	serviceID, key, err := controller.AddOnion(NewOnionKey, 8770, "127.0.0.1:18770")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseOnion(serviceID + onionSuffix); err != nil {
+		t.Fatalf("invalid service id %s: %v", serviceID, err)
+	}
+	if !strings.HasPrefix(key, "ED25519-V3:") {
+		t.Fatalf("got private key %s", key)
+	}
+
+	if err := controller.DelOnion(serviceID); err != nil {
+		t.Fatal(err)
+	}
+	if control.isPublished(serviceID) {
+		t.Fatal("onion service is not removed")
+	}
+
+	// the saved key publishes the same service again
+	againID, againKey, err := controller.AddOnion(key, 8770, "127.0.0.1:18770")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if againID != serviceID || againKey != "" {
+		t.Fatalf("got service %s and key %s", againID, againKey)
+	}
+
+	if _, _, err := controller.AddOnion("ED25519-V3:unknown", 8770, "127.0.0.1:18770"); err == nil {
+		t.Fatal("add onion of unknown key")
+	}
+}
+
+func TestReplyArgs(t *testing.T) {
+	args := replyArgs([]string{
+		"PROTOCOLINFO 1",
+		`AUTH METHODS=COOKIE,SAFECOOKIE COOKIEFILE="/var/run/tor/control \"auth\" cookie"`,
+		`VERSION Tor="0.4.8.9"`,
+		"OK",
+	})
+
+	want := map[string]string{
+		"METHODS":    "COOKIE,SAFECOOKIE",
+		"COOKIEFILE": `/var/run/tor/control "auth" cookie`,
+		"Tor":        "0.4.8.9",
+	}
+	if len(args) != len(want) {
+		t.Fatalf("got args %v, want %v", args, want)
+	}
+	for key, value := range want {
+		if args[key] != value {
+			t.Errorf("arg %s: got %q, want %q", key, args[key], value)
+		}
+	}
+}
+
+func TestOnionAddress(t *testing.T) {
+	host := "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion"
+	pubKey, err := ParseOnion(host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := OnionAddress(pubKey); got != host {
+		t.Fatalf("got onion address %s, want %s", got, host)
+	}
+	if _, err := ParseOnion(strings.ToUpper(host)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, invalid := range []string{
+		"duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczae.onion", // checksum
+		"duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.com",   // domain
+		"duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzcza1.onion", // base32
+		"3g2upl4pq6kufc4m.onion", // v2
+	} {
+		if _, err := ParseOnion(invalid); err == nil {
+			t.Errorf("parse invalid onion address %s", invalid)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Package tor publishes the onion service of the node through the tor
+// control port and handles the onion v3 addresses.
+package tor
+
+import (
+	"encoding/base32"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/clarenous/go-capsule/errors"
+)
+
+const (
+	onionSuffix      = ".onion"
+	onionV3Version   = 0x03
+	onionV3Len       = 56
+	onionChecksumLen = 2
+	onionPubKeyLen   = 32
+)
+
+var (
+	errInvalidOnion = errors.New("invalid onion v3 address")
+
+	onionEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// IsOnion returns true if the host is in the onion domain, the address is
+// not checked to be a valid onion v3 address.
+func IsOnion(host string) bool {
+	return strings.HasSuffix(strings.ToLower(host), onionSuffix)
+}
+
+// OnionAddress returns the onion v3 host of the ed25519 public key of the
+// onion service.
+func OnionAddress(pubKey []byte) string {
+	data := make([]byte, 0, onionPubKeyLen+onionChecksumLen+1)
+	data = append(data, pubKey...)
+	data = append(data, onionChecksum(pubKey)...)
+	data = append(data, onionV3Version)
+	return strings.ToLower(onionEncoding.EncodeToString(data)) + onionSuffix
+}
+
+// ParseOnion returns the ed25519 public key of the onion v3 host, the legacy
+// v2 addresses are refused.
+func ParseOnion(host string) ([]byte, error) {
+	host = strings.ToLower(host)
+	if !strings.HasSuffix(host, onionSuffix) || len(host) != onionV3Len+len(onionSuffix) {
+		return nil, errInvalidOnion
+	}
+
+	data, err := onionEncoding.DecodeString(strings.ToUpper(strings.TrimSuffix(host, onionSuffix)))
+	if err != nil {
+		return nil, errors.Wrap(errInvalidOnion, err.Error())
+	}
+
+	pubKey, checksum, version := data[:onionPubKeyLen], data[onionPubKeyLen:onionPubKeyLen+onionChecksumLen], data[len(data)-1]
+	if version != onionV3Version || string(checksum) != string(onionChecksum(pubKey)) {
+		return nil, errInvalidOnion
+	}
+	return pubKey, nil
+}
+
+func onionChecksum(pubKey []byte) []byte {
+	h := sha3.New256()
+	h.Write([]byte(".onion checksum"))
+	h.Write(pubKey)
+	h.Write([]byte{onionV3Version})
+	return h.Sum(nil)[:onionChecksumLen]
+}
@@ -18,6 +18,13 @@ var (
 	ErrBadStateRoot = errors.New("invalid state merkle root")
 )
 
+// BlockConnectedEvent is posted when the block is connected to the main chain
+type BlockConnectedEvent struct{ Block *types.Block }
+
+// BlockDisconnectedEvent is posted when the block is detached from the main
+// chain by a reorganization
+type BlockDisconnectedEvent struct{ Block *types.Block }
+
 // BlockExist check is a block in chain or orphan
 func (c *Chain) BlockExist(hash *types.Hash) bool {
 	return c.index.BlockExist(hash) || c.orphanManage.BlockExist(hash)
@@ -28,11 +35,21 @@ func (c *Chain) GetBlockByHash(hash *types.Hash) (*types.Block, error) {
 	return c.store.GetBlock(hash)
 }
 
+// GetBlockFilter return the golomb-coded set filter of the block by given hash
+func (c *Chain) GetBlockFilter(hash *types.Hash) ([]byte, error) {
+	return c.store.GetBlockFilter(hash)
+}
+
+// GetFilterHeader return the filter header of the block by given hash
+func (c *Chain) GetFilterHeader(hash *types.Hash) (*types.Hash, error) {
+	return c.store.GetFilterHeader(hash)
+}
+
 // GetBlockByHeight return a block header by given height
 func (c *Chain) GetBlockByHeight(height uint64) (*types.Block, error) {
 	node := c.index.NodeByHeight(height)
 	if node == nil {
-		return nil, errors.New("can't find block in given height")
+		return nil, errors.WithDetailf(ErrBlockNotFound, "no block at height %d", height)
 	}
 	return c.store.GetBlock(&node.Hash)
 }
@@ -41,16 +58,25 @@ func (c *Chain) GetBlockByHeight(height uint64) (*types.Block, error) {
 func (c *Chain) GetHeaderByHash(hash *types.Hash) (*types.BlockHeader, error) {
 	node := c.index.GetNode(hash)
 	if node == nil {
-		return nil, errors.New("can't find block header in given hash")
+		return nil, errors.WithDetailf(ErrBlockNotFound, "no block of hash %s", hash.String())
 	}
 	return node.BlockHeader(), nil
 }
 
+// GetBlockNode return the block index node by given hash
+func (c *Chain) GetBlockNode(hash *types.Hash) (*state.BlockNode, error) {
+	node := c.index.GetNode(hash)
+	if node == nil {
+		return nil, errors.WithDetailf(ErrBlockNotFound, "no block of hash %s", hash.String())
+	}
+	return node, nil
+}
+
 // GetHeaderByHeight return a block header by given height
 func (c *Chain) GetHeaderByHeight(height uint64) (*types.BlockHeader, error) {
 	node := c.index.NodeByHeight(height)
 	if node == nil {
-		return nil, errors.New("can't find block header in given height")
+		return nil, errors.WithDetailf(ErrBlockNotFound, "no block at height %d", height)
 	}
 	return node.BlockHeader(), nil
 }
@@ -90,12 +116,15 @@ func (c *Chain) connectBlock(block *types.Block) (err error) {
 	for _, tx := range block.Transactions {
 		c.txPool.RemoveTransaction(tx.Hash().Ptr())
 	}
+	c.eventDispatcher.Post(BlockConnectedEvent{Block: block})
 	return nil
 }
 
 func (c *Chain) reorganizeChain(node *state.BlockNode) error {
 	attachNodes, detachNodes := c.calcReorganizeNodes(node)
 	utxoView := state.NewUtxoViewpoint()
+	detachBlocks := make([]*types.Block, 0, len(detachNodes))
+	attachBlocks := make([]*types.Block, 0, len(attachNodes))
 
 	for _, detachNode := range detachNodes {
 		b, err := c.store.GetBlock(&detachNode.Hash)
@@ -110,6 +139,7 @@ func (c *Chain) reorganizeChain(node *state.BlockNode) error {
 		if err := utxoView.DetachBlock(detachBlock); err != nil {
 			return err
 		}
+		detachBlocks = append(detachBlocks, detachBlock)
 
 		log.WithFields(log.Fields{"module": logModule, "height": node.Height, "hash": node.Hash.String()}).Debug("detach from mainchain")
 	}
@@ -127,11 +157,22 @@ func (c *Chain) reorganizeChain(node *state.BlockNode) error {
 		if err := utxoView.ApplyBlock(attachBlock); err != nil {
 			return err
 		}
+		attachBlocks = append(attachBlocks, attachBlock)
 
 		log.WithFields(log.Fields{"module": logModule, "height": node.Height, "hash": node.Hash.String()}).Debug("attach from mainchain")
 	}
 
-	return c.setState(node, utxoView)
+	if err := c.setState(node, utxoView); err != nil {
+		return err
+	}
+
+	for _, block := range detachBlocks {
+		c.eventDispatcher.Post(BlockDisconnectedEvent{Block: block})
+	}
+	for _, block := range attachBlocks {
+		c.eventDispatcher.Post(BlockConnectedEvent{Block: block})
+	}
+	return nil
 }
 
 // SaveBlock will validate and save block into storage
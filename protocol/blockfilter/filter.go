@@ -0,0 +1,69 @@
+package blockfilter
+
+import (
+	"github.com/clarenous/go-capsule/crypto/sha3pool"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+// Key returns the siphash key of the block filter, the first bytes of the
+// block hash so that the collisions can't be made in advance.
+func Key(blockHash *types.Hash) [KeySize]byte {
+	var key [KeySize]byte
+	copy(key[:], blockHash[:KeySize])
+	return key
+}
+
+// Items returns the distinct output script hashes and evidence digests and
+// sources of the block.
+func Items(block *types.Block) [][]byte {
+	seen := make(map[string]bool)
+	items := [][]byte{}
+	add := func(item []byte) {
+		if len(item) == 0 || seen[string(item)] {
+			return
+		}
+		seen[string(item)] = true
+		items = append(items, item)
+	}
+
+	for _, tx := range block.Transactions {
+		for _, out := range tx.Outputs {
+			add(out.ScriptHash[:])
+		}
+		for _, evid := range tx.Evidences {
+			add(evid.Digest)
+			add(evid.Source)
+		}
+	}
+	return items
+}
+
+// BuildBlockFilter returns the golomb-coded set filter of the block
+func BuildBlockFilter(block *types.Block) []byte {
+	blockHash := block.Hash()
+	return BuildGCS(Key(&blockHash), Items(block))
+}
+
+// MatchBlockFilter reports whether any of the items is probably in the block
+func MatchBlockFilter(blockHash *types.Hash, filter []byte, items [][]byte) (bool, error) {
+	return MatchAny(Key(blockHash), filter, items)
+}
+
+// FilterHash returns the hash of the filter
+func FilterHash(filter []byte) types.Hash {
+	var hash types.Hash
+	sha3pool.Sum256(hash[:], filter)
+	return hash
+}
+
+// FilterHeader chains the filter hash to the filter header of the previous
+// block, the previous header of the genesis block is the zero hash.
+func FilterHeader(filterHash, prevHeader *types.Hash) types.Hash {
+	var buf [64]byte
+	copy(buf[:32], filterHash[:])
+	copy(buf[32:], prevHeader[:])
+
+	var header types.Hash
+	sha3pool.Sum256(header[:], buf[:])
+	return header
+}
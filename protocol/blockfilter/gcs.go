@@ -0,0 +1,215 @@
+package blockfilter
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"sort"
+)
+
+const (
+	// P is the bits of the golomb-rice remainder of the filter
+	P = 19
+	// M is the inverse false positive rate of the filter
+	M = 784931
+	// KeySize is the bytes of the siphash key of the filter
+	KeySize = 16
+)
+
+var errInvalidFilter = errors.New("invalid golomb-coded set filter")
+
+// siphash is the SipHash-2-4 of the data
+func siphash(k0, k1 uint64, data []byte) uint64 {
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	round := func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13)
+		v1 ^= v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16)
+		v3 ^= v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21)
+		v3 ^= v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17)
+		v1 ^= v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+
+	length := len(data)
+	for ; len(data) >= 8; data = data[8:] {
+		m := binary.LittleEndian.Uint64(data)
+		v3 ^= m
+		round()
+		round()
+		v0 ^= m
+	}
+
+	var last [8]byte
+	copy(last[:], data)
+	last[7] = byte(length)
+	m := binary.LittleEndian.Uint64(last[:])
+	v3 ^= m
+	round()
+	round()
+	v0 ^= m
+
+	v2 ^= 0xff
+	for i := 0; i < 4; i++ {
+		round()
+	}
+	return v0 ^ v1 ^ v2 ^ v3
+}
+
+// hashToRange maps the item uniformly to [0, n*M)
+func hashToRange(key [KeySize]byte, item []byte, n uint64) uint64 {
+	k0 := binary.LittleEndian.Uint64(key[:8])
+	k1 := binary.LittleEndian.Uint64(key[8:])
+	hi, _ := bits.Mul64(siphash(k0, k1, item), n*M)
+	return hi
+}
+
+func hashedSet(key [KeySize]byte, items [][]byte, n uint64) []uint64 {
+	values := make([]uint64, 0, len(items))
+	for _, item := range items {
+		values = append(values, hashToRange(key, item, n))
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+type bitWriter struct {
+	bytes []byte
+	used  uint8
+}
+
+func (w *bitWriter) writeBit(bit bool) {
+	if w.used == 0 {
+		w.bytes = append(w.bytes, 0)
+		w.used = 8
+	}
+	w.used--
+	if bit {
+		w.bytes[len(w.bytes)-1] |= 1 << w.used
+	}
+}
+
+func (w *bitWriter) writeBits(value uint64, count uint) {
+	for i := count; i > 0; i-- {
+		w.writeBit(value&(1<<(i-1)) != 0)
+	}
+}
+
+type bitReader struct {
+	bytes []byte
+	pos   uint64
+}
+
+func (r *bitReader) readBit() (bool, error) {
+	if r.pos >= uint64(len(r.bytes))*8 {
+		return false, errInvalidFilter
+	}
+	bit := r.bytes[r.pos/8]&(1<<(7-r.pos%8)) != 0
+	r.pos++
+	return bit, nil
+}
+
+func (r *bitReader) readBits(count uint) (uint64, error) {
+	value := uint64(0)
+	for i := uint(0); i < count; i++ {
+		bit, err := r.readBit()
+		if err != nil {
+			return 0, err
+		}
+		value <<= 1
+		if bit {
+			value |= 1
+		}
+	}
+	return value, nil
+}
+
+// readValue decodes the next golomb-rice coded delta
+func (r *bitReader) readValue() (uint64, error) {
+	quotient := uint64(0)
+	for {
+		bit, err := r.readBit()
+		if err != nil {
+			return 0, err
+		}
+		if !bit {
+			break
+		}
+		quotient++
+	}
+
+	remainder, err := r.readBits(P)
+	if err != nil {
+		return 0, err
+	}
+	return quotient<<P | remainder, nil
+}
+
+// BuildGCS returns the golomb-coded set of the items keyed by key, it is the
+// varint count of the items followed by the coded deltas of the sorted hashes.
+func BuildGCS(key [KeySize]byte, items [][]byte) []byte {
+	n := uint64(len(items))
+	buf := make([]byte, binary.MaxVarintLen64)
+	filter := buf[:binary.PutUvarint(buf, n)]
+	if n == 0 {
+		return filter
+	}
+
+	w := &bitWriter{}
+	last := uint64(0)
+	for _, value := range hashedSet(key, items, n) {
+		delta := value - last
+		last = value
+		for q := delta >> P; q > 0; q-- {
+			w.writeBit(true)
+		}
+		w.writeBit(false)
+		w.writeBits(delta, P)
+	}
+	return append(filter, w.bytes...)
+}
+
+// MatchAny reports whether any of the items is probably in the filter, the
+// false positive rate is 1/M.
+func MatchAny(key [KeySize]byte, filter []byte, items [][]byte) (bool, error) {
+	n, read := binary.Uvarint(filter)
+	if read <= 0 {
+		return false, errInvalidFilter
+	}
+	if n == 0 || len(items) == 0 {
+		return false, nil
+	}
+
+	queries := hashedSet(key, items, n)
+	r := &bitReader{bytes: filter[read:]}
+	value := uint64(0)
+	for i := uint64(0); i < n; i++ {
+		delta, err := r.readValue()
+		if err != nil {
+			return false, err
+		}
+		value += delta
+
+		for len(queries) > 0 && queries[0] < value {
+			queries = queries[1:]
+		}
+		if len(queries) == 0 {
+			return false, nil
+		}
+		if queries[0] == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,86 @@
+package blockfilter
+
+import (
+	"encoding/binary"
+	"testing"
+
+	_ "github.com/clarenous/go-capsule/consensus/algorithm/pow"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+func TestSiphash(t *testing.T) {
+	var key [KeySize]byte
+	data := make([]byte, 15)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	k0 := binary.LittleEndian.Uint64(key[:8])
+	k1 := binary.LittleEndian.Uint64(key[8:])
+	if got := siphash(k0, k1, data); got != 0xa129ca6149be45e5 {
+		t.Errorf("got siphash %x", got)
+	}
+	if got := siphash(k0, k1, nil); got != 0x726fdb47dd0e0e31 {
+		t.Errorf("got siphash %x", got)
+	}
+}
+
+func TestGCS(t *testing.T) {
+	key := [KeySize]byte{1, 2, 3}
+	items := [][]byte{}
+	for i := 0; i < 500; i++ {
+		items = append(items, types.MockLenBytes(32))
+	}
+	filter := BuildGCS(key, items)
+
+	for i, item := range items {
+		if ok, err := MatchAny(key, filter, [][]byte{item}); err != nil || !ok {
+			t.Fatalf("item %d: got %v %v", i, ok, err)
+		}
+	}
+
+	falsePositives := 0
+	for i := 0; i < 1000; i++ {
+		if ok, _ := MatchAny(key, filter, [][]byte{types.MockLenBytes(32)}); ok {
+			falsePositives++
+		}
+	}
+	if falsePositives > 1 {
+		t.Errorf("got %d false positives", falsePositives)
+	}
+
+	if ok, err := MatchAny(key, BuildGCS(key, nil), items); err != nil || ok {
+		t.Errorf("empty filter: got %v %v", ok, err)
+	}
+	if _, err := MatchAny(key, filter[:len(filter)/2], [][]byte{{0}}); err != errInvalidFilter {
+		t.Errorf("got err %v want %v", err, errInvalidFilter)
+	}
+}
+
+func TestBlockFilter(t *testing.T) {
+	tx := types.MockTx()
+	tx.Evidences = []types.Evidence{*types.MockEvidence()}
+	block := &types.Block{BlockHeader: *types.MockBlockHeader(), Transactions: []*types.Tx{tx, tx}}
+
+	items := Items(block)
+	if len(items) != len(tx.Outputs)+2 {
+		t.Fatalf("got %d items", len(items))
+	}
+
+	blockHash := block.Hash()
+	filter := BuildBlockFilter(block)
+	for _, item := range [][]byte{tx.Outputs[0].ScriptHash[:], tx.Evidences[0].Digest, tx.Evidences[0].Source} {
+		if ok, err := MatchBlockFilter(&blockHash, filter, [][]byte{item}); err != nil || !ok {
+			t.Errorf("got %v %v", ok, err)
+		}
+	}
+
+	prev := types.Hash{}
+	filterHash := FilterHash(filter)
+	if FilterHeader(&filterHash, &prev) == FilterHeader(&filterHash, &filterHash) {
+		t.Errorf("filter header doesn't commit to the previous header")
+	}
+}
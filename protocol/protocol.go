@@ -8,6 +8,7 @@ import (
 
 	"github.com/clarenous/go-capsule/config"
 	"github.com/clarenous/go-capsule/errors"
+	"github.com/clarenous/go-capsule/event"
 	"github.com/clarenous/go-capsule/protocol/types"
 
 	"github.com/clarenous/go-capsule/protocol/state"
@@ -23,17 +24,20 @@ type Chain struct {
 	store          Store
 	processBlockCh chan *processBlockMsg
 
+	eventDispatcher *event.Dispatcher
+
 	cond     sync.Cond
 	bestNode *state.BlockNode
 }
 
 // NewChain returns a new Chain using store as the underlying storage.
-func NewChain(store Store, txPool *TxPool) (*Chain, error) {
+func NewChain(store Store, txPool *TxPool, dispatcher *event.Dispatcher) (*Chain, error) {
 	c := &Chain{
-		orphanManage:   NewOrphanManage(),
-		txPool:         txPool,
-		store:          store,
-		processBlockCh: make(chan *processBlockMsg, maxProcessBlockChSize),
+		orphanManage:    NewOrphanManage(),
+		txPool:          txPool,
+		store:           store,
+		processBlockCh:  make(chan *processBlockMsg, maxProcessBlockChSize),
+		eventDispatcher: dispatcher,
 	}
 	c.cond.L = new(sync.Mutex)
 
@@ -86,10 +86,14 @@ func (node *BlockNode) CalcPastMedianTime() uint64 {
 	return timestamps[len(timestamps)/2]
 }
 
-// HintNextProof calculate the bits for next block
+// HintNextProof calculate the proof for next block with the consensus
+// algorithm active at the next height
 func (node *BlockNode) HintNextProof() (ca.Proof, error) {
-	proof, _ := ca.NewProof(consensus.ProofType)
-	err := proof.HintNextProof([]interface{}{node})
+	proof, err := ca.NewProofAt(node.Height + 1)
+	if err != nil {
+		return nil, err
+	}
+	err = proof.HintNextProof([]interface{}{node})
 	return proof, err
 }
 
@@ -2,11 +2,21 @@ package protocol
 
 import (
 	"github.com/clarenous/go-capsule/database/storage"
+	"github.com/clarenous/go-capsule/errors"
 	"github.com/clarenous/go-capsule/protocol/types"
 
 	"github.com/clarenous/go-capsule/protocol/state"
 )
 
+// The errors of the missing data are wrapped with the hash or the height
+// looked up as the detail.
+var (
+	ErrBlockNotFound       = errors.New("block not found")
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrEvidenceNotFound    = errors.New("evidence not found")
+	ErrFilterNotFound      = errors.New("block filter not found")
+)
+
 // Store provides storage interface for blockchain data
 type Store interface {
 	BlockExist(*types.Hash) bool
@@ -22,6 +32,9 @@ type Store interface {
 
 	GetTransaction(hash *types.Hash) (*types.Tx, error)
 	GetEvidence(hash *types.Hash) (*types.Evidence, *types.Tx, int, error)
+
+	GetBlockFilter(*types.Hash) ([]byte, error)
+	GetFilterHeader(*types.Hash) (*types.Hash, error)
 }
 
 // BlockStoreState represents the core's db status
@@ -32,15 +32,35 @@ func (c *Chain) ValidateTx(tx *types.Tx) (bool, error) {
 	}
 
 	bh := c.BestBlockHeader()
-	err := validation.ValidateTx(tx, &types.Block{BlockHeader: *bh})
-
-	if err != nil {
+	if err := validation.ValidateTx(tx, &types.Block{BlockHeader: *bh}); err != nil {
 		log.WithFields(log.Fields{"module": logModule, "tx_id": tx.Hash().String(), "error": err}).Info("transaction status fail")
+		err = errors.WithDetail(ErrBadTx, err.Error())
+		c.txPool.AddErrCache(tx.Hash().Ptr(), err)
+		return false, err
 	}
 
 	return c.txPool.ProcessTransaction(tx, bh.Height)
 }
 
+// CheckTx runs the checks of ValidateTx without adding the tx to the pool, it
+// returns whether the tx is an orphan or the reason the tx is rejected.
+func (c *Chain) CheckTx(tx *types.Tx) (bool, error) {
+	if err := c.txPool.GetErrCache(tx.Hash().Ptr()); err != nil {
+		return false, err
+	}
+
+	if c.txPool.IsDust(tx) {
+		return false, ErrDustTx
+	}
+
+	bh := c.BestBlockHeader()
+	if err := validation.ValidateTx(tx, &types.Block{BlockHeader: *bh}); err != nil {
+		return false, errors.WithDetail(ErrBadTx, err.Error())
+	}
+
+	return c.txPool.CheckTransaction(tx)
+}
+
 func (c *Chain) GetTransaction(hash *types.Hash) (*types.Tx, error) {
 	return c.store.GetTransaction(hash)
 }
@@ -33,6 +33,8 @@ var (
 
 	// ErrTransactionNotExist is the pre-defined error message
 	ErrTransactionNotExist = errors.New("transaction are not existed in the mempool")
+	// ErrTransactionExist indicates the transaction is already in the mempool
+	ErrTransactionExist = errors.New("transaction is already in the mempool")
 	// ErrPoolIsFull indicates the pool is full
 	ErrPoolIsFull = errors.New("transaction pool reach the max number")
 	// ErrDustTx indicates transaction is dust tx
@@ -95,7 +97,7 @@ func (tp *TxPool) AddErrCache(txHash *types.Hash, err error) {
 	tp.mtx.Lock()
 	defer tp.mtx.Unlock()
 
-	tp.errCache.Add(txHash, err)
+	tp.errCache.Add(*txHash, err)
 }
 
 // ExpireOrphan expire all the orphans that before the input time range
@@ -115,7 +117,7 @@ func (tp *TxPool) GetErrCache(txHash *types.Hash) error {
 	tp.mtx.Lock()
 	defer tp.mtx.Unlock()
 
-	v, ok := tp.errCache.Get(txHash)
+	v, ok := tp.errCache.Get(*txHash)
 	if !ok {
 		return nil
 	}
@@ -167,6 +169,19 @@ func (tp *TxPool) GetTransactions() []*TxDesc {
 	return txDs
 }
 
+// Stats returns the number and the serialized bytes of the transactions in
+// the pool
+func (tp *TxPool) Stats() (int, uint64) {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+
+	var bytes uint64
+	for _, desc := range tp.pool {
+		bytes += desc.Weight
+	}
+	return len(tp.pool), bytes
+}
+
 // IsTransactionInPool check wheather a transaction in pool or not
 func (tp *TxPool) IsTransactionInPool(txHash *types.Hash) bool {
 	tp.mtx.RLock()
@@ -181,7 +196,7 @@ func (tp *TxPool) IsTransactionInErrCache(txHash *types.Hash) bool {
 	tp.mtx.RLock()
 	defer tp.mtx.RUnlock()
 
-	_, ok := tp.errCache.Get(txHash)
+	_, ok := tp.errCache.Get(*txHash)
 	return ok
 }
 
@@ -195,6 +210,29 @@ func (tp *TxPool) IsDust(tx *types.Tx) bool {
 	return false
 }
 
+// CheckTransaction reports whether the pool would accept the tx as an orphan
+// or the reason it would reject the tx, the pool is not changed.
+func (tp *TxPool) CheckTransaction(tx *types.Tx) (bool, error) {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+
+	if _, ok := tp.pool[tx.Hash()]; ok {
+		return false, ErrTransactionExist
+	}
+
+	requireParents, err := tp.checkOrphanUtxos(tx)
+	if err != nil {
+		return false, err
+	}
+	if len(requireParents) > 0 {
+		return true, nil
+	}
+	if len(tp.pool) >= maxNewTxNum {
+		return false, ErrPoolIsFull
+	}
+	return false, nil
+}
+
 func (tp *TxPool) processTransaction(tx *types.Tx, height uint64) (bool, error) {
 	tp.mtx.Lock()
 	defer tp.mtx.Unlock()
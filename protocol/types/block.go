@@ -54,19 +54,19 @@ func (bh *BlockHeader) ToProto() (*typespb.BlockHeader, error) {
 }
 
 func (bh *BlockHeader) FromProto(pb *typespb.BlockHeader) error {
-	bh.ChainID = NewHashFromProto(pb.ChainId).Value()
-	bh.Version = pb.Version
-	bh.Height = pb.Height
-	bh.Timestamp = pb.Timestamp
-	bh.Previous = NewHashFromProto(pb.Previous).Value()
-	bh.TransactionRoot = NewHashFromProto(pb.TransactionRoot).Value()
-	bh.WitnessRoot = NewHashFromProto(pb.WitnessRoot).Value()
-
-	proof, err := ca.NewProof("pow")
+	bh.ChainID = NewHashFromProto(pb.GetChainId()).Value()
+	bh.Version = pb.GetVersion()
+	bh.Height = pb.GetHeight()
+	bh.Timestamp = pb.GetTimestamp()
+	bh.Previous = NewHashFromProto(pb.GetPrevious()).Value()
+	bh.TransactionRoot = NewHashFromProto(pb.GetTransactionRoot()).Value()
+	bh.WitnessRoot = NewHashFromProto(pb.GetWitnessRoot()).Value()
+
+	proof, err := ca.NewProofAt(bh.Height)
 	if err != nil {
 		return err
 	}
-	if err := proof.FromBytes(pb.Proof); err != nil {
+	if err := proof.FromBytes(pb.GetProof()); err != nil {
 		return err
 	}
 	bh.Proof = proof
@@ -93,7 +93,7 @@ func (blk *Block) ToProto() (*typespb.Block, error) {
 
 func (blk *Block) FromProto(pb *typespb.Block) error {
 	bh := new(BlockHeader)
-	if err := bh.FromProto(pb.BlockHeader); err != nil {
+	if err := bh.FromProto(pb.GetBlockHeader()); err != nil {
 		return err
 	}
 	blk.BlockHeader = *bh
@@ -122,6 +122,10 @@ func (h *Hash) ToProto() *typespb.Hash {
 }
 
 func (h *Hash) FromProto(pb *typespb.Hash) error {
+	if pb == nil {
+		*h = Hash{}
+		return nil
+	}
 	*h = pb.Byte32()
 	return nil
 }
@@ -124,6 +124,10 @@ func (h *Hash160) ToProto() *typespb.Hash160 {
 }
 
 func (h *Hash160) FromProto(pb *typespb.Hash160) error {
+	if pb == nil {
+		*h = Hash160{}
+		return nil
+	}
 	*h = pb.Byte20()
 	return nil
 }
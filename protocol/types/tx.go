@@ -148,8 +148,8 @@ func (tx *Tx) FromProto(pb *typespb.Tx) error {
 	for i, inPb := range pb.Inputs {
 		tx.Inputs[i] = TxIn{
 			ValueSource: ValueSource{
-				TxID:  NewHashFromProto(inPb.ValueSource.Txid).Value(),
-				Index: inPb.ValueSource.Index,
+				TxID:  NewHashFromProto(inPb.GetValueSource().GetTxid()).Value(),
+				Index: inPb.GetValueSource().GetIndex(),
 			},
 			RedeemScript: append([]byte{}, inPb.RedeemScript...),
 			UnlockScript: append([]byte{}, inPb.UnlockScript...),
@@ -0,0 +1,218 @@
+package mock
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/clarenous/go-capsule/protocol/state"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+// Chain is an in memory chain serving the blocks set by the tests, the txs
+// validated by it are recorded.
+type Chain struct {
+	mtx             sync.RWMutex
+	bestBlockHeader *types.BlockHeader
+	heightMap       map[uint64]*types.Block
+	blockMap        map[types.Hash]*types.Block
+	filterMap       map[types.Hash][]byte
+	prevOrphans     map[types.Hash]*types.Block
+	validatedTxs    []*types.Tx
+}
+
+func NewChain() *Chain {
+	return &Chain{
+		heightMap:   map[uint64]*types.Block{},
+		blockMap:    map[types.Hash]*types.Block{},
+		filterMap:   map[types.Hash][]byte{},
+		prevOrphans: map[types.Hash]*types.Block{},
+	}
+}
+
+func (c *Chain) BestBlockHeader() *types.BlockHeader {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.bestBlockHeader
+}
+
+func (c *Chain) BestBlockHeight() uint64 {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.bestBlockHeader.Height
+}
+
+func (c *Chain) GetBlockByHash(hash *types.Hash) (*types.Block, error) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	block, ok := c.blockMap[*hash]
+	if !ok {
+		return nil, errors.New("can't find block")
+	}
+	return block, nil
+}
+
+func (c *Chain) GetBlockByHeight(height uint64) (*types.Block, error) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	block, ok := c.heightMap[height]
+	if !ok {
+		return nil, errors.New("can't find block")
+	}
+	return block, nil
+}
+
+func (c *Chain) GetBlockFilter(hash *types.Hash) ([]byte, error) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	filter, ok := c.filterMap[*hash]
+	if !ok {
+		return nil, errors.New("can't find block filter")
+	}
+	return filter, nil
+}
+
+func (c *Chain) GetBlockNode(hash *types.Hash) (*state.BlockNode, error) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.blockNode(hash)
+}
+
+// blockNode returns the node of the block linked to the parent node, the
+// parent is nil when it is not in the chain.
+func (c *Chain) blockNode(hash *types.Hash) (*state.BlockNode, error) {
+	block, ok := c.blockMap[*hash]
+	if !ok {
+		return nil, errors.New("can't find block")
+	}
+
+	var parent *state.BlockNode
+	if block.Height > 0 {
+		parent, _ = c.blockNode(&block.Previous)
+	}
+	return state.NewBlockNode(&block.BlockHeader, parent)
+}
+
+func (c *Chain) GetFilterHeader(hash *types.Hash) (*types.Hash, error) {
+	return nil, errors.New("can't find filter header")
+}
+
+func (c *Chain) GetHeaderByHash(hash *types.Hash) (*types.BlockHeader, error) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	block, ok := c.blockMap[*hash]
+	if !ok {
+		return nil, errors.New("can't find block")
+	}
+	return &block.BlockHeader, nil
+}
+
+func (c *Chain) GetHeaderByHeight(height uint64) (*types.BlockHeader, error) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	block, ok := c.heightMap[height]
+	if !ok {
+		return nil, errors.New("can't find block")
+	}
+	return &block.BlockHeader, nil
+}
+
+func (c *Chain) InMainChain(hash types.Hash) bool {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.inMainChain(hash)
+}
+
+func (c *Chain) inMainChain(hash types.Hash) bool {
+	block, ok := c.blockMap[hash]
+	if !ok {
+		return false
+	}
+	return c.heightMap[block.Height] == block
+}
+
+// ProcessBlock connects the block to the chain, the blocks of an unknown
+// parent are kept as orphans and the chain reorganizes to the longer branch.
+func (c *Chain) ProcessBlock(block *types.Block) (bool, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.bestBlockHeader.Hash() == block.Previous {
+		c.heightMap[block.Height] = block
+		c.blockMap[block.Hash()] = block
+		c.bestBlockHeader = &block.BlockHeader
+		return false, nil
+	}
+
+	if _, ok := c.blockMap[block.Previous]; !ok {
+		c.prevOrphans[block.Previous] = block
+		return true, nil
+	}
+
+	c.blockMap[block.Hash()] = block
+	for orphan, ok := c.prevOrphans[block.Hash()]; ok; orphan, ok = c.prevOrphans[block.Hash()] {
+		delete(c.prevOrphans, block.Hash())
+		block = orphan
+		c.blockMap[block.Hash()] = block
+	}
+
+	if block.Height <= c.bestBlockHeader.Height {
+		return false, nil
+	}
+
+	for height := block.Height + 1; c.heightMap[height] != nil; height++ {
+		delete(c.heightMap, height)
+	}
+	c.bestBlockHeader = &block.BlockHeader
+	for !c.inMainChain(block.Hash()) {
+		c.heightMap[block.Height] = block
+		block = c.blockMap[block.Previous]
+	}
+	return false, nil
+}
+
+func (c *Chain) SetBestBlockHeader(header *types.BlockHeader) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.bestBlockHeader = header
+}
+
+func (c *Chain) SetBlockByHeight(height uint64, block *types.Block) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.heightMap[height] = block
+	c.blockMap[block.Hash()] = block
+}
+
+func (c *Chain) SetBlockFilter(hash *types.Hash, filter []byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.filterMap[*hash] = filter
+}
+
+func (c *Chain) ValidateTx(tx *types.Tx) (bool, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.validatedTxs = append(c.validatedTxs, tx)
+	return false, nil
+}
+
+// ValidatedTxs returns the txs validated by the chain in order
+func (c *Chain) ValidatedTxs() []*types.Tx {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.validatedTxs
+}
@@ -0,0 +1,49 @@
+package mock
+
+import (
+	"github.com/clarenous/go-capsule/database/storage"
+	"github.com/clarenous/go-capsule/protocol"
+	"github.com/clarenous/go-capsule/protocol/state"
+	"github.com/clarenous/go-capsule/protocol/types"
+)
+
+// Store is an empty store for the tx pool, all the inputs of the txs are
+// unspent outputs so that no tx is an orphan.
+type Store struct{}
+
+func (s *Store) BlockExist(*types.Hash) bool { return false }
+
+func (s *Store) GetBlock(*types.Hash) (*types.Block, error) { return nil, protocol.ErrBlockNotFound }
+
+func (s *Store) GetStoreStatus() *protocol.BlockStoreState { return nil }
+
+func (s *Store) GetTransactionsUtxo(view *state.UtxoViewpoint, txs []*types.Tx) error {
+	for _, tx := range txs {
+		for _, in := range tx.Inputs {
+			view.Entries[in.ValueSource.Hash()] = storage.NewUtxoEntry(false, 0, false)
+		}
+	}
+	return nil
+}
+
+func (s *Store) GetUtxo(*types.Hash) (*storage.UtxoEntry, error) { return nil, nil }
+
+func (s *Store) LoadBlockIndex(uint64) (*state.BlockIndex, error) { return state.NewBlockIndex(), nil }
+
+func (s *Store) SaveBlock(*types.Block) error { return nil }
+
+func (s *Store) SaveChainStatus(*state.BlockNode, *state.UtxoViewpoint) error { return nil }
+
+func (s *Store) GetTransaction(*types.Hash) (*types.Tx, error) {
+	return nil, protocol.ErrTransactionNotFound
+}
+
+func (s *Store) GetEvidence(*types.Hash) (*types.Evidence, *types.Tx, int, error) {
+	return nil, nil, 0, protocol.ErrEvidenceNotFound
+}
+
+func (s *Store) GetBlockFilter(*types.Hash) ([]byte, error) { return nil, protocol.ErrFilterNotFound }
+
+func (s *Store) GetFilterHeader(*types.Hash) (*types.Hash, error) {
+	return nil, protocol.ErrFilterNotFound
+}
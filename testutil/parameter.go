@@ -5,6 +5,6 @@ import (
 )
 
 var (
-	MaxHash = &types.Hash{S0: 1<<64 - 1, S1: 1<<64 - 1, S2: 1<<64 - 1, S3: 1<<64 - 1}
+	MaxHash = &types.Hash{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
 	MinHash = &types.Hash{}
 )